@@ -45,4 +45,4 @@ GREATER_THAN_EQUAL  : '>=' ;
 LESS_THAN_EQUAL     : '<=' ;
 
 // Identifiers
-ID                 : [_]*[a-z][A-Za-z0-9_]* ;
+ID                 : [_]*[a-z][A-Za-z0-9_.\-]* ;
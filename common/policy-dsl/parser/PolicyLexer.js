@@ -25,7 +25,7 @@ const serializedATN = ["\u0003\u608b\ua72a\u8133\ub9ed\u417c\u3be7\u7786",
     "\f\u0002\u0002\r\u0003\u0003\u0005\u0004\u0007\u0005\t\u0006\u000b\u0007",
     "\r\b\u000f\t\u0011\n\u0013\u000b\u0015\f\u0017\r\u0003\u0002\b\u0004",
     "\u0002\"\"vv\u0003\u00023;\u0003\u00022;\u0003\u0002aa\u0003\u0002c",
-    "|\u0006\u00022;C\\aac|\u0002T\u0002\u0003\u0003\u0002\u0002\u0002\u0002",
+    "|\u0007\u0002/02;C\\aac|\u0002T\u0002\u0003\u0003\u0002\u0002\u0002\u0002",
     "\u0005\u0003\u0002\u0002\u0002\u0002\u0007\u0003\u0002\u0002\u0002\u0002",
     "\t\u0003\u0002\u0002\u0002\u0002\u000b\u0003\u0002\u0002\u0002\u0002",
     "\r\u0003\u0002\u0002\u0002\u0002\u000f\u0003\u0002\u0002\u0002\u0002",
@@ -24,6 +24,12 @@ test("Lexer: Chained expressions", t => {
     t.deepEqual(res.tokens, [ "ID", "AND", "ID", "OR", "ID", "EOF"])
 })
 
+test("Lexer: Identifiers with dots and dashes", t => {
+    const res = tokenize('org1.example.com && org-2')
+    t.deepEqual(res.tokens, [ "ID", "AND", "ID", "EOF"])
+    t.true(res.errors.length === 0)
+})
+
 test("Lexer: Invalid token", t => {
     const res = tokenize('org1 & org2 || org3')
     t.true(res.errors.length === 1)
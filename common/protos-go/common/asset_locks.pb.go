@@ -23,16 +23,19 @@ const (
 type LockMechanism int32
 
 const (
-	LockMechanism_HTLC LockMechanism = 0
+	LockMechanism_HTLC   LockMechanism = 0
+	LockMechanism_ESCROW LockMechanism = 1
 )
 
 // Enum value maps for LockMechanism.
 var (
 	LockMechanism_name = map[int32]string{
 		0: "HTLC",
+		1: "ESCROW",
 	}
 	LockMechanism_value = map[string]int32{
-		"HTLC": 0,
+		"HTLC":   0,
+		"ESCROW": 1,
 	}
 )
 
@@ -63,6 +66,58 @@ func (LockMechanism) EnumDescriptor() ([]byte, []int) {
 	return file_common_asset_locks_proto_rawDescGZIP(), []int{0}
 }
 
+type HashMechanism int32
+
+const (
+	HashMechanism_SHA256        HashMechanism = 0
+	HashMechanism_SHA512        HashMechanism = 1
+	HashMechanism_KECCAK256     HashMechanism = 2
+	HashMechanism_DOUBLE_SHA256 HashMechanism = 3
+)
+
+// Enum value maps for HashMechanism.
+var (
+	HashMechanism_name = map[int32]string{
+		0: "SHA256",
+		1: "SHA512",
+		2: "KECCAK256",
+		3: "DOUBLE_SHA256",
+	}
+	HashMechanism_value = map[string]int32{
+		"SHA256":        0,
+		"SHA512":        1,
+		"KECCAK256":     2,
+		"DOUBLE_SHA256": 3,
+	}
+)
+
+func (x HashMechanism) Enum() *HashMechanism {
+	p := new(HashMechanism)
+	*p = x
+	return p
+}
+
+func (x HashMechanism) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HashMechanism) Descriptor() protoreflect.EnumDescriptor {
+	return file_common_asset_locks_proto_enumTypes[1].Descriptor()
+}
+
+func (HashMechanism) Type() protoreflect.EnumType {
+	return &file_common_asset_locks_proto_enumTypes[1]
+}
+
+func (x HashMechanism) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HashMechanism.Descriptor instead.
+func (HashMechanism) EnumDescriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{1}
+}
+
 type AssetLockHTLC_TimeSpec int32
 
 const (
@@ -93,11 +148,11 @@ func (x AssetLockHTLC_TimeSpec) String() string {
 }
 
 func (AssetLockHTLC_TimeSpec) Descriptor() protoreflect.EnumDescriptor {
-	return file_common_asset_locks_proto_enumTypes[1].Descriptor()
+	return file_common_asset_locks_proto_enumTypes[2].Descriptor()
 }
 
 func (AssetLockHTLC_TimeSpec) Type() protoreflect.EnumType {
-	return &file_common_asset_locks_proto_enumTypes[1]
+	return &file_common_asset_locks_proto_enumTypes[2]
 }
 
 func (x AssetLockHTLC_TimeSpec) Number() protoreflect.EnumNumber {
@@ -106,7 +161,59 @@ func (x AssetLockHTLC_TimeSpec) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use AssetLockHTLC_TimeSpec.Descriptor instead.
 func (AssetLockHTLC_TimeSpec) EnumDescriptor() ([]byte, []int) {
-	return file_common_asset_locks_proto_rawDescGZIP(), []int{2, 0}
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{3, 0}
+}
+
+type AssetLockEvent_Action int32
+
+const (
+	AssetLockEvent_LOCK   AssetLockEvent_Action = 0
+	AssetLockEvent_CLAIM  AssetLockEvent_Action = 1
+	AssetLockEvent_UNLOCK AssetLockEvent_Action = 2
+	AssetLockEvent_EXTEND AssetLockEvent_Action = 3
+)
+
+// Enum value maps for AssetLockEvent_Action.
+var (
+	AssetLockEvent_Action_name = map[int32]string{
+		0: "LOCK",
+		1: "CLAIM",
+		2: "UNLOCK",
+		3: "EXTEND",
+	}
+	AssetLockEvent_Action_value = map[string]int32{
+		"LOCK":   0,
+		"CLAIM":  1,
+		"UNLOCK": 2,
+		"EXTEND": 3,
+	}
+)
+
+func (x AssetLockEvent_Action) Enum() *AssetLockEvent_Action {
+	p := new(AssetLockEvent_Action)
+	*p = x
+	return p
+}
+
+func (x AssetLockEvent_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AssetLockEvent_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_common_asset_locks_proto_enumTypes[3].Descriptor()
+}
+
+func (AssetLockEvent_Action) Type() protoreflect.EnumType {
+	return &file_common_asset_locks_proto_enumTypes[3]
+}
+
+func (x AssetLockEvent_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AssetLockEvent_Action.Descriptor instead.
+func (AssetLockEvent_Action) EnumDescriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{17, 0}
 }
 
 type AssetLock struct {
@@ -169,8 +276,9 @@ type AssetClaim struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LockMechanism LockMechanism `protobuf:"varint,1,opt,name=lockMechanism,proto3,enum=common.asset_locks.LockMechanism" json:"lockMechanism,omitempty"`
-	ClaimInfo     []byte        `protobuf:"bytes,2,opt,name=claimInfo,proto3" json:"claimInfo,omitempty"`
+	LockMechanism      LockMechanism        `protobuf:"varint,1,opt,name=lockMechanism,proto3,enum=common.asset_locks.LockMechanism" json:"lockMechanism,omitempty"`
+	ClaimInfo          []byte               `protobuf:"bytes,2,opt,name=claimInfo,proto3" json:"claimInfo,omitempty"`
+	RecipientApprovals []*RecipientApproval `protobuf:"bytes,3,rep,name=recipientApprovals,proto3" json:"recipientApprovals,omitempty"` // approvals of the other recipients, for a lock with a recipient threshold
 }
 
 func (x *AssetClaim) Reset() {
@@ -219,6 +327,72 @@ func (x *AssetClaim) GetClaimInfo() []byte {
 	return nil
 }
 
+func (x *AssetClaim) GetRecipientApprovals() []*RecipientApproval {
+	if x != nil {
+		return x.RecipientApprovals
+	}
+	return nil
+}
+
+// Approval of a claim by a recipient of a lock that requires a threshold of recipient approvals: a signature, using the
+// key in the recipient's certificate, over the message "ClaimApproval:<contractId>:<hashBase64>:<expiryTimeSecs>:<claimant>"
+// (hashBase64 and expiryTimeSecs being those of the lock, with hashBase64 empty for a lock other than an HTLC, and claimant
+// being the certificate, in base64 form, of the recipient submitting the claim)
+type RecipientApproval struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Recipient string `protobuf:"bytes,1,opt,name=recipient,proto3" json:"recipient,omitempty"` // base64 encoded PEM certificate of the approving recipient
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *RecipientApproval) Reset() {
+	*x = RecipientApproval{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_locks_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecipientApproval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipientApproval) ProtoMessage() {}
+
+func (x *RecipientApproval) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_locks_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipientApproval.ProtoReflect.Descriptor instead.
+func (*RecipientApproval) Descriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RecipientApproval) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *RecipientApproval) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
 type AssetLockHTLC struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -227,12 +401,13 @@ type AssetLockHTLC struct {
 	HashBase64     []byte                 `protobuf:"bytes,1,opt,name=hashBase64,proto3" json:"hashBase64,omitempty"`
 	ExpiryTimeSecs uint64                 `protobuf:"varint,2,opt,name=expiryTimeSecs,proto3" json:"expiryTimeSecs,omitempty"`
 	TimeSpec       AssetLockHTLC_TimeSpec `protobuf:"varint,3,opt,name=timeSpec,proto3,enum=common.asset_locks.AssetLockHTLC_TimeSpec" json:"timeSpec,omitempty"`
+	HashMechanism  HashMechanism          `protobuf:"varint,4,opt,name=hashMechanism,proto3,enum=common.asset_locks.HashMechanism" json:"hashMechanism,omitempty"`
 }
 
 func (x *AssetLockHTLC) Reset() {
 	*x = AssetLockHTLC{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_common_asset_locks_proto_msgTypes[2]
+		mi := &file_common_asset_locks_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -245,7 +420,7 @@ func (x *AssetLockHTLC) String() string {
 func (*AssetLockHTLC) ProtoMessage() {}
 
 func (x *AssetLockHTLC) ProtoReflect() protoreflect.Message {
-	mi := &file_common_asset_locks_proto_msgTypes[2]
+	mi := &file_common_asset_locks_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -258,7 +433,7 @@ func (x *AssetLockHTLC) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssetLockHTLC.ProtoReflect.Descriptor instead.
 func (*AssetLockHTLC) Descriptor() ([]byte, []int) {
-	return file_common_asset_locks_proto_rawDescGZIP(), []int{2}
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *AssetLockHTLC) GetHashBase64() []byte {
@@ -282,18 +457,26 @@ func (x *AssetLockHTLC) GetTimeSpec() AssetLockHTLC_TimeSpec {
 	return AssetLockHTLC_EPOCH
 }
 
+func (x *AssetLockHTLC) GetHashMechanism() HashMechanism {
+	if x != nil {
+		return x.HashMechanism
+	}
+	return HashMechanism_SHA256
+}
+
 type AssetClaimHTLC struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	HashPreimageBase64 []byte `protobuf:"bytes,1,opt,name=hashPreimageBase64,proto3" json:"hashPreimageBase64,omitempty"`
+	NumUnits           uint64 `protobuf:"varint,2,opt,name=numUnits,proto3" json:"numUnits,omitempty"` // units to claim from a fungible asset lock; all the units still locked if 0 (unused for non-fungible assets)
 }
 
 func (x *AssetClaimHTLC) Reset() {
 	*x = AssetClaimHTLC{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_common_asset_locks_proto_msgTypes[3]
+		mi := &file_common_asset_locks_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -306,7 +489,7 @@ func (x *AssetClaimHTLC) String() string {
 func (*AssetClaimHTLC) ProtoMessage() {}
 
 func (x *AssetClaimHTLC) ProtoReflect() protoreflect.Message {
-	mi := &file_common_asset_locks_proto_msgTypes[3]
+	mi := &file_common_asset_locks_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -319,7 +502,7 @@ func (x *AssetClaimHTLC) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssetClaimHTLC.ProtoReflect.Descriptor instead.
 func (*AssetClaimHTLC) Descriptor() ([]byte, []int) {
-	return file_common_asset_locks_proto_rawDescGZIP(), []int{3}
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *AssetClaimHTLC) GetHashPreimageBase64() []byte {
@@ -329,21 +512,152 @@ func (x *AssetClaimHTLC) GetHashPreimageBase64() []byte {
 	return nil
 }
 
+func (x *AssetClaimHTLC) GetNumUnits() uint64 {
+	if x != nil {
+		return x.NumUnits
+	}
+	return 0
+}
+
+// Asset locked for a recipient, released by the signed decision of a nominated arbiter or returned to the locker after expiry
+type AssetLockEscrow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Arbiter        string                 `protobuf:"bytes,1,opt,name=arbiter,proto3" json:"arbiter,omitempty"` // base64 encoded PEM certificate of the arbiter
+	ExpiryTimeSecs uint64                 `protobuf:"varint,2,opt,name=expiryTimeSecs,proto3" json:"expiryTimeSecs,omitempty"`
+	TimeSpec       AssetLockHTLC_TimeSpec `protobuf:"varint,3,opt,name=timeSpec,proto3,enum=common.asset_locks.AssetLockHTLC_TimeSpec" json:"timeSpec,omitempty"`
+}
+
+func (x *AssetLockEscrow) Reset() {
+	*x = AssetLockEscrow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_locks_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetLockEscrow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetLockEscrow) ProtoMessage() {}
+
+func (x *AssetLockEscrow) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_locks_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetLockEscrow.ProtoReflect.Descriptor instead.
+func (*AssetLockEscrow) Descriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AssetLockEscrow) GetArbiter() string {
+	if x != nil {
+		return x.Arbiter
+	}
+	return ""
+}
+
+func (x *AssetLockEscrow) GetExpiryTimeSecs() uint64 {
+	if x != nil {
+		return x.ExpiryTimeSecs
+	}
+	return 0
+}
+
+func (x *AssetLockEscrow) GetTimeSpec() AssetLockHTLC_TimeSpec {
+	if x != nil {
+		return x.TimeSpec
+	}
+	return AssetLockHTLC_EPOCH
+}
+
+// Arbiter's decision to release the asset to the recipient: a signature, using the key in the arbiter's certificate,
+// over the message "EscrowRelease:<contractId>:<expiryTimeSecs>" (expiryTimeSecs being the absolute expiry time of the lock)
+type AssetClaimEscrow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ArbiterSignature []byte `protobuf:"bytes,1,opt,name=arbiterSignature,proto3" json:"arbiterSignature,omitempty"`
+}
+
+func (x *AssetClaimEscrow) Reset() {
+	*x = AssetClaimEscrow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_locks_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetClaimEscrow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetClaimEscrow) ProtoMessage() {}
+
+func (x *AssetClaimEscrow) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_locks_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetClaimEscrow.ProtoReflect.Descriptor instead.
+func (*AssetClaimEscrow) Descriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AssetClaimEscrow) GetArbiterSignature() []byte {
+	if x != nil {
+		return x.ArbiterSignature
+	}
+	return nil
+}
+
+// Agreement to lock a non-fungible asset for a recipient. A lock for several recipients lists them in recipients
+// instead (leaving recipient empty): any one of them can claim the asset, unless recipientThreshold is set to k > 1,
+// in which case a claim needs the approval of k of them (the claimant counting as one).
+// The contractId of a new lock can be chosen by the client, either directly (contractId) or through a nonce
+// (contractIdNonce), in which case it is the base64 encoded SHA-256 hash of "ContractIdNonce:<locker>:<nonce>";
+// either way it needs to be unique on the ledger. A contractId chosen directly cannot have the form of a derived one
+// (a base64 encoded SHA-256 hash). Otherwise it is derived by the interop chaincode.
 type AssetExchangeAgreement struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type      string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Id        string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	Locker    string `protobuf:"bytes,3,opt,name=locker,proto3" json:"locker,omitempty"`
-	Recipient string `protobuf:"bytes,4,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Type               string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Id                 string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Locker             string   `protobuf:"bytes,3,opt,name=locker,proto3" json:"locker,omitempty"`
+	Recipient          string   `protobuf:"bytes,4,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Recipients         []string `protobuf:"bytes,5,rep,name=recipients,proto3" json:"recipients,omitempty"`
+	RecipientThreshold uint32   `protobuf:"varint,6,opt,name=recipientThreshold,proto3" json:"recipientThreshold,omitempty"`
+	ContractId         string   `protobuf:"bytes,7,opt,name=contractId,proto3" json:"contractId,omitempty"`
+	ContractIdNonce    string   `protobuf:"bytes,8,opt,name=contractIdNonce,proto3" json:"contractIdNonce,omitempty"`
 }
 
 func (x *AssetExchangeAgreement) Reset() {
 	*x = AssetExchangeAgreement{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_common_asset_locks_proto_msgTypes[4]
+		mi := &file_common_asset_locks_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -356,7 +670,7 @@ func (x *AssetExchangeAgreement) String() string {
 func (*AssetExchangeAgreement) ProtoMessage() {}
 
 func (x *AssetExchangeAgreement) ProtoReflect() protoreflect.Message {
-	mi := &file_common_asset_locks_proto_msgTypes[4]
+	mi := &file_common_asset_locks_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -369,7 +683,7 @@ func (x *AssetExchangeAgreement) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssetExchangeAgreement.ProtoReflect.Descriptor instead.
 func (*AssetExchangeAgreement) Descriptor() ([]byte, []int) {
-	return file_common_asset_locks_proto_rawDescGZIP(), []int{4}
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *AssetExchangeAgreement) GetType() string {
@@ -400,21 +714,53 @@ func (x *AssetExchangeAgreement) GetRecipient() string {
 	return ""
 }
 
+func (x *AssetExchangeAgreement) GetRecipients() []string {
+	if x != nil {
+		return x.Recipients
+	}
+	return nil
+}
+
+func (x *AssetExchangeAgreement) GetRecipientThreshold() uint32 {
+	if x != nil {
+		return x.RecipientThreshold
+	}
+	return 0
+}
+
+func (x *AssetExchangeAgreement) GetContractId() string {
+	if x != nil {
+		return x.ContractId
+	}
+	return ""
+}
+
+func (x *AssetExchangeAgreement) GetContractIdNonce() string {
+	if x != nil {
+		return x.ContractIdNonce
+	}
+	return ""
+}
+
+// Agreement to lock units of a fungible asset for a recipient; contractId and contractIdNonce are as in
+// AssetExchangeAgreement
 type FungibleAssetExchangeAgreement struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type      string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	NumUnits  uint64 `protobuf:"varint,2,opt,name=numUnits,proto3" json:"numUnits,omitempty"`
-	Locker    string `protobuf:"bytes,3,opt,name=locker,proto3" json:"locker,omitempty"`
-	Recipient string `protobuf:"bytes,4,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Type            string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	NumUnits        uint64 `protobuf:"varint,2,opt,name=numUnits,proto3" json:"numUnits,omitempty"`
+	Locker          string `protobuf:"bytes,3,opt,name=locker,proto3" json:"locker,omitempty"`
+	Recipient       string `protobuf:"bytes,4,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	ContractId      string `protobuf:"bytes,5,opt,name=contractId,proto3" json:"contractId,omitempty"`
+	ContractIdNonce string `protobuf:"bytes,6,opt,name=contractIdNonce,proto3" json:"contractIdNonce,omitempty"`
 }
 
 func (x *FungibleAssetExchangeAgreement) Reset() {
 	*x = FungibleAssetExchangeAgreement{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_common_asset_locks_proto_msgTypes[5]
+		mi := &file_common_asset_locks_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -427,7 +773,7 @@ func (x *FungibleAssetExchangeAgreement) String() string {
 func (*FungibleAssetExchangeAgreement) ProtoMessage() {}
 
 func (x *FungibleAssetExchangeAgreement) ProtoReflect() protoreflect.Message {
-	mi := &file_common_asset_locks_proto_msgTypes[5]
+	mi := &file_common_asset_locks_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -440,7 +786,7 @@ func (x *FungibleAssetExchangeAgreement) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FungibleAssetExchangeAgreement.ProtoReflect.Descriptor instead.
 func (*FungibleAssetExchangeAgreement) Descriptor() ([]byte, []int) {
-	return file_common_asset_locks_proto_rawDescGZIP(), []int{5}
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *FungibleAssetExchangeAgreement) GetType() string {
@@ -471,6 +817,20 @@ func (x *FungibleAssetExchangeAgreement) GetRecipient() string {
 	return ""
 }
 
+func (x *FungibleAssetExchangeAgreement) GetContractId() string {
+	if x != nil {
+		return x.ContractId
+	}
+	return ""
+}
+
+func (x *FungibleAssetExchangeAgreement) GetContractIdNonce() string {
+	if x != nil {
+		return x.ContractIdNonce
+	}
+	return ""
+}
+
 type AssetContractHTLC struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -485,7 +845,7 @@ type AssetContractHTLC struct {
 func (x *AssetContractHTLC) Reset() {
 	*x = AssetContractHTLC{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_common_asset_locks_proto_msgTypes[6]
+		mi := &file_common_asset_locks_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -498,7 +858,7 @@ func (x *AssetContractHTLC) String() string {
 func (*AssetContractHTLC) ProtoMessage() {}
 
 func (x *AssetContractHTLC) ProtoReflect() protoreflect.Message {
-	mi := &file_common_asset_locks_proto_msgTypes[6]
+	mi := &file_common_asset_locks_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -511,7 +871,7 @@ func (x *AssetContractHTLC) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssetContractHTLC.ProtoReflect.Descriptor instead.
 func (*AssetContractHTLC) Descriptor() ([]byte, []int) {
-	return file_common_asset_locks_proto_rawDescGZIP(), []int{6}
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AssetContractHTLC) GetContractId() string {
@@ -556,7 +916,7 @@ type FungibleAssetContractHTLC struct {
 func (x *FungibleAssetContractHTLC) Reset() {
 	*x = FungibleAssetContractHTLC{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_common_asset_locks_proto_msgTypes[7]
+		mi := &file_common_asset_locks_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -569,7 +929,1071 @@ func (x *FungibleAssetContractHTLC) String() string {
 func (*FungibleAssetContractHTLC) ProtoMessage() {}
 
 func (x *FungibleAssetContractHTLC) ProtoReflect() protoreflect.Message {
-	mi := &file_common_asset_locks_proto_msgTypes[7]
+	mi := &file_common_asset_locks_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FungibleAssetContractHTLC.ProtoReflect.Descriptor instead.
+func (*FungibleAssetContractHTLC) Descriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FungibleAssetContractHTLC) GetContractId() string {
+	if x != nil {
+		return x.ContractId
+	}
+	return ""
+}
+
+func (x *FungibleAssetContractHTLC) GetAgreement() *FungibleAssetExchangeAgreement {
+	if x != nil {
+		return x.Agreement
+	}
+	return nil
+}
+
+func (x *FungibleAssetContractHTLC) GetLock() *AssetLockHTLC {
+	if x != nil {
+		return x.Lock
+	}
+	return nil
+}
+
+func (x *FungibleAssetContractHTLC) GetClaim() *AssetClaimHTLC {
+	if x != nil {
+		return x.Claim
+	}
+	return nil
+}
+
+type AssetContractEscrow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ContractId string                  `protobuf:"bytes,1,opt,name=contractId,proto3" json:"contractId,omitempty"`
+	Agreement  *AssetExchangeAgreement `protobuf:"bytes,2,opt,name=agreement,proto3" json:"agreement,omitempty"`
+	Lock       *AssetLockEscrow        `protobuf:"bytes,3,opt,name=lock,proto3" json:"lock,omitempty"`
+	Claim      *AssetClaimEscrow       `protobuf:"bytes,4,opt,name=claim,proto3" json:"claim,omitempty"`
+}
+
+func (x *AssetContractEscrow) Reset() {
+	*x = AssetContractEscrow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_locks_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetContractEscrow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetContractEscrow) ProtoMessage() {}
+
+func (x *AssetContractEscrow) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_locks_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetContractEscrow.ProtoReflect.Descriptor instead.
+func (*AssetContractEscrow) Descriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AssetContractEscrow) GetContractId() string {
+	if x != nil {
+		return x.ContractId
+	}
+	return ""
+}
+
+func (x *AssetContractEscrow) GetAgreement() *AssetExchangeAgreement {
+	if x != nil {
+		return x.Agreement
+	}
+	return nil
+}
+
+func (x *AssetContractEscrow) GetLock() *AssetLockEscrow {
+	if x != nil {
+		return x.Lock
+	}
+	return nil
+}
+
+func (x *AssetContractEscrow) GetClaim() *AssetClaimEscrow {
+	if x != nil {
+		return x.Claim
+	}
+	return nil
+}
+
+type FungibleAssetContractEscrow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ContractId string                          `protobuf:"bytes,1,opt,name=contractId,proto3" json:"contractId,omitempty"`
+	Agreement  *FungibleAssetExchangeAgreement `protobuf:"bytes,2,opt,name=agreement,proto3" json:"agreement,omitempty"`
+	Lock       *AssetLockEscrow                `protobuf:"bytes,3,opt,name=lock,proto3" json:"lock,omitempty"`
+	Claim      *AssetClaimEscrow               `protobuf:"bytes,4,opt,name=claim,proto3" json:"claim,omitempty"`
+}
+
+func (x *FungibleAssetContractEscrow) Reset() {
+	*x = FungibleAssetContractEscrow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_locks_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FungibleAssetContractEscrow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FungibleAssetContractEscrow) ProtoMessage() {}
+
+func (x *FungibleAssetContractEscrow) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_locks_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FungibleAssetContractEscrow.ProtoReflect.Descriptor instead.
+func (*FungibleAssetContractEscrow) Descriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FungibleAssetContractEscrow) GetContractId() string {
+	if x != nil {
+		return x.ContractId
+	}
+	return ""
+}
+
+func (x *FungibleAssetContractEscrow) GetAgreement() *FungibleAssetExchangeAgreement {
+	if x != nil {
+		return x.Agreement
+	}
+	return nil
+}
+
+func (x *FungibleAssetContractEscrow) GetLock() *AssetLockEscrow {
+	if x != nil {
+		return x.Lock
+	}
+	return nil
+}
+
+func (x *FungibleAssetContractEscrow) GetClaim() *AssetClaimEscrow {
+	if x != nil {
+		return x.Claim
+	}
+	return nil
+}
+
+// An asset in a bundle: either a non-fungible asset (identified by id) or a number of units of a fungible asset
+type AssetBundleItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type     string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Id       string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	NumUnits uint64 `protobuf:"varint,3,opt,name=numUnits,proto3" json:"numUnits,omitempty"`
+}
+
+func (x *AssetBundleItem) Reset() {
+	*x = AssetBundleItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_locks_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetBundleItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetBundleItem) ProtoMessage() {}
+
+func (x *AssetBundleItem) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_locks_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetBundleItem.ProtoReflect.Descriptor instead.
+func (*AssetBundleItem) Descriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AssetBundleItem) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AssetBundleItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AssetBundleItem) GetNumUnits() uint64 {
+	if x != nil {
+		return x.NumUnits
+	}
+	return 0
+}
+
+// A basket of assets locked under a single contract, claimed or unlocked together
+type AssetBundleExchangeAgreement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Assets    []*AssetBundleItem `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets,omitempty"`
+	Locker    string             `protobuf:"bytes,2,opt,name=locker,proto3" json:"locker,omitempty"`
+	Recipient string             `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient,omitempty"`
+}
+
+func (x *AssetBundleExchangeAgreement) Reset() {
+	*x = AssetBundleExchangeAgreement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_locks_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetBundleExchangeAgreement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetBundleExchangeAgreement) ProtoMessage() {}
+
+func (x *AssetBundleExchangeAgreement) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_locks_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetBundleExchangeAgreement.ProtoReflect.Descriptor instead.
+func (*AssetBundleExchangeAgreement) Descriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AssetBundleExchangeAgreement) GetAssets() []*AssetBundleItem {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+func (x *AssetBundleExchangeAgreement) GetLocker() string {
+	if x != nil {
+		return x.Locker
+	}
+	return ""
+}
+
+func (x *AssetBundleExchangeAgreement) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+type AssetBundleContractHTLC struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ContractId string                        `protobuf:"bytes,1,opt,name=contractId,proto3" json:"contractId,omitempty"`
+	Agreement  *AssetBundleExchangeAgreement `protobuf:"bytes,2,opt,name=agreement,proto3" json:"agreement,omitempty"`
+	Lock       *AssetLockHTLC                `protobuf:"bytes,3,opt,name=lock,proto3" json:"lock,omitempty"`
+	Claim      *AssetClaimHTLC               `protobuf:"bytes,4,opt,name=claim,proto3" json:"claim,omitempty"`
+}
+
+func (x *AssetBundleContractHTLC) Reset() {
+	*x = AssetBundleContractHTLC{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_locks_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetBundleContractHTLC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetBundleContractHTLC) ProtoMessage() {}
+
+func (x *AssetBundleContractHTLC) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_locks_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetBundleContractHTLC.ProtoReflect.Descriptor instead.
+func (*AssetBundleContractHTLC) Descriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AssetBundleContractHTLC) GetContractId() string {
+	if x != nil {
+		return x.ContractId
+	}
+	return ""
+}
+
+func (x *AssetBundleContractHTLC) GetAgreement() *AssetBundleExchangeAgreement {
+	if x != nil {
+		return x.Agreement
+	}
+	return nil
+}
+
+func (x *AssetBundleContractHTLC) GetLock() *AssetLockHTLC {
+	if x != nil {
+		return x.Lock
+	}
+	return nil
+}
+
+func (x *AssetBundleContractHTLC) GetClaim() *AssetClaimHTLC {
+	if x != nil {
+		return x.Claim
+	}
+	return nil
+}
+
+// Extension of the expiry time of an existing lock, proposed by the locker and consented to by the recipient through a
+// signature, using the key in the recipient's certificate, over the message
+// "LockExtension:<contractId>:<expiryTimeSecs>:<newExpiryTimeSecs>" (expiryTimeSecs being the current absolute expiry time)
+type AssetLockExtension struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ContractId         string `protobuf:"bytes,1,opt,name=contractId,proto3" json:"contractId,omitempty"`
+	NewExpiryTimeSecs  uint64 `protobuf:"varint,2,opt,name=newExpiryTimeSecs,proto3" json:"newExpiryTimeSecs,omitempty"`
+	RecipientSignature []byte `protobuf:"bytes,3,opt,name=recipientSignature,proto3" json:"recipientSignature,omitempty"`
+}
+
+func (x *AssetLockExtension) Reset() {
+	*x = AssetLockExtension{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_locks_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetLockExtension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetLockExtension) ProtoMessage() {}
+
+func (x *AssetLockExtension) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_locks_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetLockExtension.ProtoReflect.Descriptor instead.
+func (*AssetLockExtension) Descriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AssetLockExtension) GetContractId() string {
+	if x != nil {
+		return x.ContractId
+	}
+	return ""
+}
+
+func (x *AssetLockExtension) GetNewExpiryTimeSecs() uint64 {
+	if x != nil {
+		return x.NewExpiryTimeSecs
+	}
+	return 0
+}
+
+func (x *AssetLockExtension) GetRecipientSignature() []byte {
+	if x != nil {
+		return x.RecipientSignature
+	}
+	return nil
+}
+
+// A lock lifecycle notification from the interop chaincode; for a claim of a fungible asset,
+// numUnits is the number of units claimed
+type AssetLockEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Action         AssetLockEvent_Action `protobuf:"varint,1,opt,name=action,proto3,enum=common.asset_locks.AssetLockEvent_Action" json:"action,omitempty"`
+	ContractId     string                `protobuf:"bytes,2,opt,name=contractId,proto3" json:"contractId,omitempty"`
+	LockMechanism  LockMechanism         `protobuf:"varint,3,opt,name=lockMechanism,proto3,enum=common.asset_locks.LockMechanism" json:"lockMechanism,omitempty"`
+	Type           string                `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Id             string                `protobuf:"bytes,5,opt,name=id,proto3" json:"id,omitempty"`
+	NumUnits       uint64                `protobuf:"varint,6,opt,name=numUnits,proto3" json:"numUnits,omitempty"`
+	Locker         string                `protobuf:"bytes,7,opt,name=locker,proto3" json:"locker,omitempty"`
+	Recipient      string                `protobuf:"bytes,8,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	ExpiryTimeSecs uint64                `protobuf:"varint,9,opt,name=expiryTimeSecs,proto3" json:"expiryTimeSecs,omitempty"`
+	BundleAssets   []*AssetBundleItem    `protobuf:"bytes,10,rep,name=bundleAssets,proto3" json:"bundleAssets,omitempty"`
+}
+
+func (x *AssetLockEvent) Reset() {
+	*x = AssetLockEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_locks_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetLockEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetLockEvent) ProtoMessage() {}
+
+func (x *AssetLockEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_locks_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetLockEvent.ProtoReflect.Descriptor instead.
+func (*AssetLockEvent) Descriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AssetLockEvent) GetAction() AssetLockEvent_Action {
+	if x != nil {
+		return x.Action
+	}
+	return AssetLockEvent_LOCK
+}
+
+func (x *AssetLockEvent) GetContractId() string {
+	if x != nil {
+		return x.ContractId
+	}
+	return ""
+}
+
+func (x *AssetLockEvent) GetLockMechanism() LockMechanism {
+	if x != nil {
+		return x.LockMechanism
+	}
+	return LockMechanism_HTLC
+}
+
+func (x *AssetLockEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AssetLockEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AssetLockEvent) GetNumUnits() uint64 {
+	if x != nil {
+		return x.NumUnits
+	}
+	return 0
+}
+
+func (x *AssetLockEvent) GetLocker() string {
+	if x != nil {
+		return x.Locker
+	}
+	return ""
+}
+
+func (x *AssetLockEvent) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *AssetLockEvent) GetExpiryTimeSecs() uint64 {
+	if x != nil {
+		return x.ExpiryTimeSecs
+	}
+	return 0
+}
+
+func (x *AssetLockEvent) GetBundleAssets() []*AssetBundleItem {
+	if x != nil {
+		return x.BundleAssets
+	}
+	return nil
+}
+
+// All the lock lifecycle notifications of a transaction, set by the interop chaincode as a single event
+type AssetLockEventEnvelope struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxId   string            `protobuf:"bytes,1,opt,name=txId,proto3" json:"txId,omitempty"`
+	Events []*AssetLockEvent `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *AssetLockEventEnvelope) Reset() {
+	*x = AssetLockEventEnvelope{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_locks_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetLockEventEnvelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetLockEventEnvelope) ProtoMessage() {}
+
+func (x *AssetLockEventEnvelope) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_locks_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetLockEventEnvelope.ProtoReflect.Descriptor instead.
+func (*AssetLockEventEnvelope) Descriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AssetLockEventEnvelope) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *AssetLockEventEnvelope) GetEvents() []*AssetLockEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// Outcome of the release of one of the expired locks unlocked in a batch; error is set if the lock was not released
+type AssetUnlockOutcome struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ContractId string `protobuf:"bytes,1,opt,name=contractId,proto3" json:"contractId,omitempty"`
+	Unlocked   bool   `protobuf:"varint,2,opt,name=unlocked,proto3" json:"unlocked,omitempty"`
+	Error      string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *AssetUnlockOutcome) Reset() {
+	*x = AssetUnlockOutcome{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_locks_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetUnlockOutcome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetUnlockOutcome) ProtoMessage() {}
+
+func (x *AssetUnlockOutcome) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_locks_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetUnlockOutcome.ProtoReflect.Descriptor instead.
+func (*AssetUnlockOutcome) Descriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AssetUnlockOutcome) GetContractId() string {
+	if x != nil {
+		return x.ContractId
+	}
+	return ""
+}
+
+func (x *AssetUnlockOutcome) GetUnlocked() bool {
+	if x != nil {
+		return x.Unlocked
+	}
+	return false
+}
+
+func (x *AssetUnlockOutcome) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type AssetBatchUnlockOutcomes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Outcomes []*AssetUnlockOutcome `protobuf:"bytes,1,rep,name=outcomes,proto3" json:"outcomes,omitempty"`
+}
+
+func (x *AssetBatchUnlockOutcomes) Reset() {
+	*x = AssetBatchUnlockOutcomes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_locks_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetBatchUnlockOutcomes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetBatchUnlockOutcomes) ProtoMessage() {}
+
+func (x *AssetBatchUnlockOutcomes) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_locks_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetBatchUnlockOutcomes.ProtoReflect.Descriptor instead.
+func (*AssetBatchUnlockOutcomes) Descriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AssetBatchUnlockOutcomes) GetOutcomes() []*AssetUnlockOutcome {
+	if x != nil {
+		return x.Outcomes
+	}
+	return nil
+}
+
+// Atomic claim of several HTLCs, on the same ledger, that are locked with the same hash
+type AssetBatchContractHTLC struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ContractIds []string        `protobuf:"bytes,1,rep,name=contractIds,proto3" json:"contractIds,omitempty"`
+	Claim       *AssetClaimHTLC `protobuf:"bytes,2,opt,name=claim,proto3" json:"claim,omitempty"`
+}
+
+func (x *AssetBatchContractHTLC) Reset() {
+	*x = AssetBatchContractHTLC{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_locks_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetBatchContractHTLC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetBatchContractHTLC) ProtoMessage() {}
+
+func (x *AssetBatchContractHTLC) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_locks_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetBatchContractHTLC.ProtoReflect.Descriptor instead.
+func (*AssetBatchContractHTLC) Descriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AssetBatchContractHTLC) GetContractIds() []string {
+	if x != nil {
+		return x.ContractIds
+	}
+	return nil
+}
+
+func (x *AssetBatchContractHTLC) GetClaim() *AssetClaimHTLC {
+	if x != nil {
+		return x.Claim
+	}
+	return nil
+}
+
+// Authorization by the owner of an asset (the locker in the asset agreement) for a delegate to lock the asset on
+// the owner's behalf; the owner signs "LockDelegation:<delegate>:<asset agreement>:<lock info>", with the agreement
+// and the lock info in the serialized base64 form that is submitted to the interop chaincode
+type AssetLockDelegation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Delegate       string `protobuf:"bytes,1,opt,name=delegate,proto3" json:"delegate,omitempty"`
+	OwnerSignature []byte `protobuf:"bytes,2,opt,name=ownerSignature,proto3" json:"ownerSignature,omitempty"`
+}
+
+func (x *AssetLockDelegation) Reset() {
+	*x = AssetLockDelegation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_locks_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetLockDelegation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetLockDelegation) ProtoMessage() {}
+
+func (x *AssetLockDelegation) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_locks_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetLockDelegation.ProtoReflect.Descriptor instead.
+func (*AssetLockDelegation) Descriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AssetLockDelegation) GetDelegate() string {
+	if x != nil {
+		return x.Delegate
+	}
+	return ""
+}
+
+func (x *AssetLockDelegation) GetOwnerSignature() []byte {
+	if x != nil {
+		return x.OwnerSignature
+	}
+	return nil
+}
+
+// Hash lock of an HTLC, as recorded in a lock record
+type HashLockRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	HashBase64    string        `protobuf:"bytes,1,opt,name=hashBase64,proto3" json:"hashBase64,omitempty"`
+	HashMechanism HashMechanism `protobuf:"varint,2,opt,name=hashMechanism,proto3,enum=common.asset_locks.HashMechanism" json:"hashMechanism,omitempty"`
+}
+
+func (x *HashLockRecord) Reset() {
+	*x = HashLockRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_locks_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HashLockRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashLockRecord) ProtoMessage() {}
+
+func (x *HashLockRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_locks_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashLockRecord.ProtoReflect.Descriptor instead.
+func (*HashLockRecord) Descriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *HashLockRecord) GetHashBase64() string {
+	if x != nil {
+		return x.HashBase64
+	}
+	return ""
+}
+
+func (x *HashLockRecord) GetHashMechanism() HashMechanism {
+	if x != nil {
+		return x.HashMechanism
+	}
+	return HashMechanism_SHA256
+}
+
+// Arbiter of an escrow lock, as recorded in a lock record
+type EscrowLockRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Arbiter string `protobuf:"bytes,1,opt,name=arbiter,proto3" json:"arbiter,omitempty"`
+}
+
+func (x *EscrowLockRecord) Reset() {
+	*x = EscrowLockRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_locks_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EscrowLockRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EscrowLockRecord) ProtoMessage() {}
+
+func (x *EscrowLockRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_locks_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EscrowLockRecord.ProtoReflect.Descriptor instead.
+func (*EscrowLockRecord) Descriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *EscrowLockRecord) GetArbiter() string {
+	if x != nil {
+		return x.Arbiter
+	}
+	return ""
+}
+
+// Asset of a bundle, as recorded in a lock record: a non-fungible asset if id is set, units of a fungible asset otherwise
+type AssetBundleItemRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type     string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Id       string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	NumUnits uint64 `protobuf:"varint,3,opt,name=numUnits,proto3" json:"numUnits,omitempty"`
+}
+
+func (x *AssetBundleItemRecord) Reset() {
+	*x = AssetBundleItemRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_locks_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetBundleItemRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetBundleItemRecord) ProtoMessage() {}
+
+func (x *AssetBundleItemRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_locks_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetBundleItemRecord.ProtoReflect.Descriptor instead.
+func (*AssetBundleItemRecord) Descriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AssetBundleItemRecord) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AssetBundleItemRecord) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AssetBundleItemRecord) GetNumUnits() uint64 {
+	if x != nil {
+		return x.NumUnits
+	}
+	return 0
+}
+
+// Record of an asset lock kept on the ledger by the interop chaincode; schemaVersion identifies the layout of the
+// record, and lockInfo the lock mechanism (an HTLC or an escrow). Type and numUnits are set for fungible asset locks,
+// bundleContractId for non-fungible assets locked in a bundle, and assets for asset bundle locks; recipients and
+// recipientThreshold are set, instead of recipient, for non-fungible asset locks with several recipients, and
+// contractId for non-fungible asset locks with a contractId chosen by the client.
+type AssetLockRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SchemaVersion uint32 `protobuf:"varint,1,opt,name=schemaVersion,proto3" json:"schemaVersion,omitempty"`
+	Locker        string `protobuf:"bytes,2,opt,name=locker,proto3" json:"locker,omitempty"`
+	Recipient     string `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	// Types that are assignable to LockInfo:
+	//	*AssetLockRecord_HashLock
+	//	*AssetLockRecord_EscrowLock
+	LockInfo           isAssetLockRecord_LockInfo `protobuf_oneof:"lockInfo"`
+	ExpiryTimeSecs     uint64                     `protobuf:"varint,6,opt,name=expiryTimeSecs,proto3" json:"expiryTimeSecs,omitempty"`
+	BundleContractId   string                     `protobuf:"bytes,7,opt,name=bundleContractId,proto3" json:"bundleContractId,omitempty"`
+	Type               string                     `protobuf:"bytes,8,opt,name=type,proto3" json:"type,omitempty"`
+	NumUnits           uint64                     `protobuf:"varint,9,opt,name=numUnits,proto3" json:"numUnits,omitempty"`
+	Assets             []*AssetBundleItemRecord   `protobuf:"bytes,10,rep,name=assets,proto3" json:"assets,omitempty"`
+	Recipients         []string                   `protobuf:"bytes,11,rep,name=recipients,proto3" json:"recipients,omitempty"`
+	RecipientThreshold uint32                     `protobuf:"varint,12,opt,name=recipientThreshold,proto3" json:"recipientThreshold,omitempty"`
+	ContractId         string                     `protobuf:"bytes,13,opt,name=contractId,proto3" json:"contractId,omitempty"`
+}
+
+func (x *AssetLockRecord) Reset() {
+	*x = AssetLockRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_locks_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetLockRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetLockRecord) ProtoMessage() {}
+
+func (x *AssetLockRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_locks_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -580,39 +2004,125 @@ func (x *FungibleAssetContractHTLC) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FungibleAssetContractHTLC.ProtoReflect.Descriptor instead.
-func (*FungibleAssetContractHTLC) Descriptor() ([]byte, []int) {
-	return file_common_asset_locks_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use AssetLockRecord.ProtoReflect.Descriptor instead.
+func (*AssetLockRecord) Descriptor() ([]byte, []int) {
+	return file_common_asset_locks_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AssetLockRecord) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *AssetLockRecord) GetLocker() string {
+	if x != nil {
+		return x.Locker
+	}
+	return ""
+}
+
+func (x *AssetLockRecord) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (m *AssetLockRecord) GetLockInfo() isAssetLockRecord_LockInfo {
+	if m != nil {
+		return m.LockInfo
+	}
+	return nil
+}
+
+func (x *AssetLockRecord) GetHashLock() *HashLockRecord {
+	if x, ok := x.GetLockInfo().(*AssetLockRecord_HashLock); ok {
+		return x.HashLock
+	}
+	return nil
+}
+
+func (x *AssetLockRecord) GetEscrowLock() *EscrowLockRecord {
+	if x, ok := x.GetLockInfo().(*AssetLockRecord_EscrowLock); ok {
+		return x.EscrowLock
+	}
+	return nil
+}
+
+func (x *AssetLockRecord) GetExpiryTimeSecs() uint64 {
+	if x != nil {
+		return x.ExpiryTimeSecs
+	}
+	return 0
+}
+
+func (x *AssetLockRecord) GetBundleContractId() string {
+	if x != nil {
+		return x.BundleContractId
+	}
+	return ""
 }
 
-func (x *FungibleAssetContractHTLC) GetContractId() string {
+func (x *AssetLockRecord) GetType() string {
 	if x != nil {
-		return x.ContractId
+		return x.Type
 	}
 	return ""
 }
 
-func (x *FungibleAssetContractHTLC) GetAgreement() *FungibleAssetExchangeAgreement {
+func (x *AssetLockRecord) GetNumUnits() uint64 {
 	if x != nil {
-		return x.Agreement
+		return x.NumUnits
 	}
-	return nil
+	return 0
 }
 
-func (x *FungibleAssetContractHTLC) GetLock() *AssetLockHTLC {
+func (x *AssetLockRecord) GetAssets() []*AssetBundleItemRecord {
 	if x != nil {
-		return x.Lock
+		return x.Assets
 	}
 	return nil
 }
 
-func (x *FungibleAssetContractHTLC) GetClaim() *AssetClaimHTLC {
+func (x *AssetLockRecord) GetRecipients() []string {
 	if x != nil {
-		return x.Claim
+		return x.Recipients
 	}
 	return nil
 }
 
+func (x *AssetLockRecord) GetRecipientThreshold() uint32 {
+	if x != nil {
+		return x.RecipientThreshold
+	}
+	return 0
+}
+
+func (x *AssetLockRecord) GetContractId() string {
+	if x != nil {
+		return x.ContractId
+	}
+	return ""
+}
+
+type isAssetLockRecord_LockInfo interface {
+	isAssetLockRecord_LockInfo()
+}
+
+type AssetLockRecord_HashLock struct {
+	HashLock *HashLockRecord `protobuf:"bytes,4,opt,name=hashLock,proto3,oneof"`
+}
+
+type AssetLockRecord_EscrowLock struct {
+	EscrowLock *EscrowLockRecord `protobuf:"bytes,5,opt,name=escrowLock,proto3,oneof"`
+}
+
+func (*AssetLockRecord_HashLock) isAssetLockRecord_LockInfo() {}
+
+func (*AssetLockRecord_EscrowLock) isAssetLockRecord_LockInfo() {}
+
 var File_common_asset_locks_proto protoreflect.FileDescriptor
 
 var file_common_asset_locks_proto_rawDesc = []byte{
@@ -626,68 +2136,97 @@ var file_common_asset_locks_proto_rawDesc = []byte{
 	0x61, 0x6e, 0x69, 0x73, 0x6d, 0x52, 0x0d, 0x6c, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x63, 0x68, 0x61,
 	0x6e, 0x69, 0x73, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f,
-	0x22, 0x73, 0x0a, 0x0a, 0x41, 0x73, 0x73, 0x65, 0x74, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x12, 0x47,
-	0x0a, 0x0d, 0x6c, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61,
-	0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x4c, 0x6f, 0x63, 0x6b, 0x4d,
-	0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x52, 0x0d, 0x6c, 0x6f, 0x63, 0x6b, 0x4d, 0x65,
-	0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6c, 0x61, 0x69, 0x6d,
-	0x49, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x63, 0x6c, 0x61, 0x69,
-	0x6d, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0xc4, 0x01, 0x0a, 0x0d, 0x41, 0x73, 0x73, 0x65, 0x74, 0x4c,
-	0x6f, 0x63, 0x6b, 0x48, 0x54, 0x4c, 0x43, 0x12, 0x1e, 0x0a, 0x0a, 0x68, 0x61, 0x73, 0x68, 0x42,
-	0x61, 0x73, 0x65, 0x36, 0x34, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x68, 0x61, 0x73,
-	0x68, 0x42, 0x61, 0x73, 0x65, 0x36, 0x34, 0x12, 0x26, 0x0a, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72,
-	0x79, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x0e, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x73, 0x12,
-	0x46, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x70, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74,
-	0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x4c, 0x6f, 0x63, 0x6b,
-	0x48, 0x54, 0x4c, 0x43, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x70, 0x65, 0x63, 0x52, 0x08, 0x74,
-	0x69, 0x6d, 0x65, 0x53, 0x70, 0x65, 0x63, 0x22, 0x23, 0x0a, 0x08, 0x54, 0x69, 0x6d, 0x65, 0x53,
-	0x70, 0x65, 0x63, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x50, 0x4f, 0x43, 0x48, 0x10, 0x00, 0x12, 0x0c,
-	0x0a, 0x08, 0x44, 0x55, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x22, 0x40, 0x0a, 0x0e,
-	0x41, 0x73, 0x73, 0x65, 0x74, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x48, 0x54, 0x4c, 0x43, 0x12, 0x2e,
-	0x0a, 0x12, 0x68, 0x61, 0x73, 0x68, 0x50, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x61,
-	0x73, 0x65, 0x36, 0x34, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x12, 0x68, 0x61, 0x73, 0x68,
-	0x50, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x61, 0x73, 0x65, 0x36, 0x34, 0x22, 0x72,
-	0x0a, 0x16, 0x41, 0x73, 0x73, 0x65, 0x74, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41,
-	0x67, 0x72, 0x65, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x22, 0xca, 0x01, 0x0a, 0x0a, 0x41, 0x73, 0x73, 0x65, 0x74, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x12,
+	0x47, 0x0a, 0x0d, 0x6c, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x4c, 0x6f, 0x63, 0x6b,
+	0x4d, 0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x52, 0x0d, 0x6c, 0x6f, 0x63, 0x6b, 0x4d,
+	0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6c, 0x61, 0x69,
+	0x6d, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x63, 0x6c, 0x61,
+	0x69, 0x6d, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x55, 0x0a, 0x12, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69,
+	0x65, 0x6e, 0x74, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65,
+	0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x52, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e,
+	0x74, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x52, 0x12, 0x72, 0x65, 0x63, 0x69, 0x70,
+	0x69, 0x65, 0x6e, 0x74, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x73, 0x22, 0x4f, 0x0a,
+	0x11, 0x52, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76,
+	0x61, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x8d,
+	0x02, 0x0a, 0x0d, 0x41, 0x73, 0x73, 0x65, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x48, 0x54, 0x4c, 0x43,
+	0x12, 0x1e, 0x0a, 0x0a, 0x68, 0x61, 0x73, 0x68, 0x42, 0x61, 0x73, 0x65, 0x36, 0x34, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x68, 0x61, 0x73, 0x68, 0x42, 0x61, 0x73, 0x65, 0x36, 0x34,
+	0x12, 0x26, 0x0a, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65,
+	0x63, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79,
+	0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x73, 0x12, 0x46, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65,
+	0x53, 0x70, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e,
+	0x41, 0x73, 0x73, 0x65, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x48, 0x54, 0x4c, 0x43, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x53, 0x70, 0x65, 0x63, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x70, 0x65, 0x63,
+	0x12, 0x47, 0x0a, 0x0d, 0x68, 0x61, 0x73, 0x68, 0x4d, 0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73,
+	0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x48, 0x61, 0x73,
+	0x68, 0x4d, 0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x52, 0x0d, 0x68, 0x61, 0x73, 0x68,
+	0x4d, 0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x22, 0x23, 0x0a, 0x08, 0x54, 0x69, 0x6d,
+	0x65, 0x53, 0x70, 0x65, 0x63, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x50, 0x4f, 0x43, 0x48, 0x10, 0x00,
+	0x12, 0x0c, 0x0a, 0x08, 0x44, 0x55, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x22, 0x5c,
+	0x0a, 0x0e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x48, 0x54, 0x4c, 0x43,
+	0x12, 0x2e, 0x0a, 0x12, 0x68, 0x61, 0x73, 0x68, 0x50, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x42, 0x61, 0x73, 0x65, 0x36, 0x34, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x12, 0x68, 0x61,
+	0x73, 0x68, 0x50, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x61, 0x73, 0x65, 0x36, 0x34,
+	0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x75, 0x6d, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x22, 0x9b, 0x01, 0x0a,
+	0x0f, 0x41, 0x73, 0x73, 0x65, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x45, 0x73, 0x63, 0x72, 0x6f, 0x77,
+	0x12, 0x18, 0x0a, 0x07, 0x61, 0x72, 0x62, 0x69, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x61, 0x72, 0x62, 0x69, 0x74, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0e, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65,
+	0x63, 0x73, 0x12, 0x46, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x70, 0x65, 0x63, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73,
+	0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x4c,
+	0x6f, 0x63, 0x6b, 0x48, 0x54, 0x4c, 0x43, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x70, 0x65, 0x63,
+	0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x70, 0x65, 0x63, 0x22, 0x3e, 0x0a, 0x10, 0x41, 0x73,
+	0x73, 0x65, 0x74, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x45, 0x73, 0x63, 0x72, 0x6f, 0x77, 0x12, 0x2a,
+	0x0a, 0x10, 0x61, 0x72, 0x62, 0x69, 0x74, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x61, 0x72, 0x62, 0x69, 0x74, 0x65,
+	0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x8c, 0x02, 0x0a, 0x16, 0x41,
+	0x73, 0x73, 0x65, 0x74, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x67, 0x72, 0x65,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63,
+	0x6b, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65,
+	0x72, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x12,
+	0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x2e, 0x0a, 0x12, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x72, 0x65, 0x63,
+	0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12,
+	0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64, 0x12,
+	0x28, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64, 0x4e, 0x6f, 0x6e,
+	0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61,
+	0x63, 0x74, 0x49, 0x64, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x22, 0xd0, 0x01, 0x0a, 0x1e, 0x46, 0x75,
+	0x6e, 0x67, 0x69, 0x62, 0x6c, 0x65, 0x41, 0x73, 0x73, 0x65, 0x74, 0x45, 0x78, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x41, 0x67, 0x72, 0x65, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x75, 0x6d, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06,
 	0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f,
 	0x63, 0x6b, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e,
 	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65,
-	0x6e, 0x74, 0x22, 0x86, 0x01, 0x0a, 0x1e, 0x46, 0x75, 0x6e, 0x67, 0x69, 0x62, 0x6c, 0x65, 0x41,
-	0x73, 0x73, 0x65, 0x74, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x67, 0x72, 0x65,
-	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x75, 0x6d,
-	0x55, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6e, 0x75, 0x6d,
-	0x55, 0x6e, 0x69, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x1c, 0x0a,
-	0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x22, 0xee, 0x01, 0x0a, 0x11,
-	0x41, 0x73, 0x73, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x48, 0x54, 0x4c,
-	0x43, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49,
-	0x64, 0x12, 0x48, 0x0a, 0x09, 0x61, 0x67, 0x72, 0x65, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73,
-	0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x45,
-	0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x67, 0x72, 0x65, 0x65, 0x6d, 0x65, 0x6e, 0x74,
-	0x52, 0x09, 0x61, 0x67, 0x72, 0x65, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x35, 0x0a, 0x04, 0x6c,
-	0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
-	0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41,
-	0x73, 0x73, 0x65, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x48, 0x54, 0x4c, 0x43, 0x52, 0x04, 0x6c, 0x6f,
-	0x63, 0x6b, 0x12, 0x38, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74,
-	0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x43, 0x6c, 0x61, 0x69,
-	0x6d, 0x48, 0x54, 0x4c, 0x43, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x22, 0xfe, 0x01, 0x0a,
-	0x19, 0x46, 0x75, 0x6e, 0x67, 0x69, 0x62, 0x6c, 0x65, 0x41, 0x73, 0x73, 0x65, 0x74, 0x43, 0x6f,
-	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x48, 0x54, 0x4c, 0x43, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f,
-	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64, 0x12, 0x50, 0x0a, 0x09, 0x61, 0x67,
-	0x72, 0x65, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e,
-	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63,
-	0x6b, 0x73, 0x2e, 0x46, 0x75, 0x6e, 0x67, 0x69, 0x62, 0x6c, 0x65, 0x41, 0x73, 0x73, 0x65, 0x74,
+	0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x49, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64,
+	0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x22, 0xee, 0x01, 0x0a,
+	0x11, 0x41, 0x73, 0x73, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x48, 0x54,
+	0x4c, 0x43, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x49, 0x64, 0x12, 0x48, 0x0a, 0x09, 0x61, 0x67, 0x72, 0x65, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74,
 	0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x67, 0x72, 0x65, 0x65, 0x6d, 0x65, 0x6e,
 	0x74, 0x52, 0x09, 0x61, 0x67, 0x72, 0x65, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x35, 0x0a, 0x04,
 	0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6d,
@@ -696,15 +2235,222 @@ var file_common_asset_locks_proto_rawDesc = []byte{
 	0x6f, 0x63, 0x6b, 0x12, 0x38, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x18, 0x04, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65,
 	0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x43, 0x6c, 0x61,
-	0x69, 0x6d, 0x48, 0x54, 0x4c, 0x43, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x2a, 0x19, 0x0a,
-	0x0d, 0x4c, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x12, 0x08,
-	0x0a, 0x04, 0x48, 0x54, 0x4c, 0x43, 0x10, 0x00, 0x42, 0x51, 0x5a, 0x4f, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70, 0x65, 0x72, 0x6c, 0x65, 0x64, 0x67,
-	0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x77, 0x65, 0x61, 0x76, 0x65, 0x72, 0x2d, 0x64,
-	0x6c, 0x74, 0x2d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x62, 0x69, 0x6c,
-	0x69, 0x74, 0x79, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x73, 0x2d, 0x67, 0x6f, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x69, 0x6d, 0x48, 0x54, 0x4c, 0x43, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x22, 0xfe, 0x01,
+	0x0a, 0x19, 0x46, 0x75, 0x6e, 0x67, 0x69, 0x62, 0x6c, 0x65, 0x41, 0x73, 0x73, 0x65, 0x74, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x48, 0x54, 0x4c, 0x43, 0x12, 0x1e, 0x0a, 0x0a, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64, 0x12, 0x50, 0x0a, 0x09, 0x61,
+	0x67, 0x72, 0x65, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32,
+	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f,
+	0x63, 0x6b, 0x73, 0x2e, 0x46, 0x75, 0x6e, 0x67, 0x69, 0x62, 0x6c, 0x65, 0x41, 0x73, 0x73, 0x65,
+	0x74, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x67, 0x72, 0x65, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x09, 0x61, 0x67, 0x72, 0x65, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x35, 0x0a,
+	0x04, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73,
+	0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x48, 0x54, 0x4c, 0x43, 0x52, 0x04,
+	0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x38, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73,
+	0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x43, 0x6c,
+	0x61, 0x69, 0x6d, 0x48, 0x54, 0x4c, 0x43, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x22, 0xf4,
+	0x01, 0x0a, 0x13, 0x41, 0x73, 0x73, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x45, 0x73, 0x63, 0x72, 0x6f, 0x77, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61,
+	0x63, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x49, 0x64, 0x12, 0x48, 0x0a, 0x09, 0x61, 0x67, 0x72, 0x65, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41,
+	0x73, 0x73, 0x65, 0x74, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x67, 0x72, 0x65,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x09, 0x61, 0x67, 0x72, 0x65, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x37, 0x0a, 0x04, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23,
+	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f,
+	0x63, 0x6b, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x45, 0x73, 0x63,
+	0x72, 0x6f, 0x77, 0x52, 0x04, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x3a, 0x0a, 0x05, 0x63, 0x6c, 0x61,
+	0x69, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41, 0x73,
+	0x73, 0x65, 0x74, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x45, 0x73, 0x63, 0x72, 0x6f, 0x77, 0x52, 0x05,
+	0x63, 0x6c, 0x61, 0x69, 0x6d, 0x22, 0x84, 0x02, 0x0a, 0x1b, 0x46, 0x75, 0x6e, 0x67, 0x69, 0x62,
+	0x6c, 0x65, 0x41, 0x73, 0x73, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x45,
+	0x73, 0x63, 0x72, 0x6f, 0x77, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63,
+	0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x49, 0x64, 0x12, 0x50, 0x0a, 0x09, 0x61, 0x67, 0x72, 0x65, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x46, 0x75,
+	0x6e, 0x67, 0x69, 0x62, 0x6c, 0x65, 0x41, 0x73, 0x73, 0x65, 0x74, 0x45, 0x78, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x41, 0x67, 0x72, 0x65, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x09, 0x61, 0x67,
+	0x72, 0x65, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x37, 0x0a, 0x04, 0x6c, 0x6f, 0x63, 0x6b, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74,
+	0x4c, 0x6f, 0x63, 0x6b, 0x45, 0x73, 0x63, 0x72, 0x6f, 0x77, 0x52, 0x04, 0x6c, 0x6f, 0x63, 0x6b,
+	0x12, 0x3a, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c,
+	0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x45,
+	0x73, 0x63, 0x72, 0x6f, 0x77, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x22, 0x51, 0x0a, 0x0f,
+	0x41, 0x73, 0x73, 0x65, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x75, 0x6d, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x22,
+	0x91, 0x01, 0x0a, 0x1c, 0x41, 0x73, 0x73, 0x65, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x45,
+	0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x67, 0x72, 0x65, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x3b, 0x0a, 0x06, 0x61, 0x73, 0x73, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f,
+	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c,
+	0x65, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x06, 0x61, 0x73, 0x73, 0x65, 0x74, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c,
+	0x6f, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69,
+	0x65, 0x6e, 0x74, 0x22, 0xfa, 0x01, 0x0a, 0x17, 0x41, 0x73, 0x73, 0x65, 0x74, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x48, 0x54, 0x4c, 0x43, 0x12,
+	0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64, 0x12,
+	0x4e, 0x0a, 0x09, 0x61, 0x67, 0x72, 0x65, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x30, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65,
+	0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x67, 0x72, 0x65, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x09, 0x61, 0x67, 0x72, 0x65, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x35, 0x0a, 0x04, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63,
+	0x6b, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x48, 0x54, 0x4c, 0x43,
+	0x52, 0x04, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x38, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74,
+	0x43, 0x6c, 0x61, 0x69, 0x6d, 0x48, 0x54, 0x4c, 0x43, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d,
+	0x22, 0x92, 0x01, 0x0a, 0x12, 0x41, 0x73, 0x73, 0x65, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x45, 0x78,
+	0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x11, 0x6e, 0x65, 0x77, 0x45, 0x78,
+	0x70, 0x69, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x11, 0x6e, 0x65, 0x77, 0x45, 0x78, 0x70, 0x69, 0x72, 0x79, 0x54, 0x69, 0x6d,
+	0x65, 0x53, 0x65, 0x63, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65,
+	0x6e, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x12, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0xda, 0x03, 0x0a, 0x0e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x4c,
+	0x6f, 0x63, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x41, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41, 0x73,
+	0x73, 0x65, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64, 0x12, 0x47, 0x0a, 0x0d, 0x6c,
+	0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65,
+	0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x4c, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x63, 0x68,
+	0x61, 0x6e, 0x69, 0x73, 0x6d, 0x52, 0x0d, 0x6c, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x63, 0x68, 0x61,
+	0x6e, 0x69, 0x73, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x75, 0x6d, 0x55,
+	0x6e, 0x69, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x55,
+	0x6e, 0x69, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09,
+	0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x73, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65,
+	0x63, 0x73, 0x12, 0x47, 0x0a, 0x0c, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x41, 0x73, 0x73, 0x65,
+	0x74, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41, 0x73,
+	0x73, 0x65, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x0c, 0x62,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x41, 0x73, 0x73, 0x65, 0x74, 0x73, 0x22, 0x35, 0x0a, 0x06, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x4f, 0x43, 0x4b, 0x10, 0x00, 0x12,
+	0x09, 0x0a, 0x05, 0x43, 0x4c, 0x41, 0x49, 0x4d, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x4e,
+	0x4c, 0x4f, 0x43, 0x4b, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x45, 0x58, 0x54, 0x45, 0x4e, 0x44,
+	0x10, 0x03, 0x22, 0x68, 0x0a, 0x16, 0x41, 0x73, 0x73, 0x65, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x78, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x49, 0x64,
+	0x12, 0x3a, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x22, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f,
+	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x66, 0x0a, 0x12,
+	0x41, 0x73, 0x73, 0x65, 0x74, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x4f, 0x75, 0x74, 0x63, 0x6f,
+	0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x75, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x22, 0x5e, 0x0a, 0x18, 0x41, 0x73, 0x73, 0x65, 0x74, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x73,
+	0x12, 0x42, 0x0a, 0x08, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65,
+	0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x55, 0x6e, 0x6c,
+	0x6f, 0x63, 0x6b, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x52, 0x08, 0x6f, 0x75, 0x74, 0x63,
+	0x6f, 0x6d, 0x65, 0x73, 0x22, 0x74, 0x0a, 0x16, 0x41, 0x73, 0x73, 0x65, 0x74, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x48, 0x54, 0x4c, 0x43, 0x12, 0x20,
+	0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64, 0x73,
+	0x12, 0x38, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c,
+	0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x48,
+	0x54, 0x4c, 0x43, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x22, 0x59, 0x0a, 0x13, 0x41, 0x73,
+	0x73, 0x65, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12, 0x26, 0x0a,
+	0x0e, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x79, 0x0a, 0x0e, 0x48, 0x61, 0x73, 0x68, 0x4c, 0x6f, 0x63,
+	0x6b, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x68, 0x61, 0x73, 0x68, 0x42,
+	0x61, 0x73, 0x65, 0x36, 0x34, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x68, 0x61, 0x73,
+	0x68, 0x42, 0x61, 0x73, 0x65, 0x36, 0x34, 0x12, 0x47, 0x0a, 0x0d, 0x68, 0x61, 0x73, 0x68, 0x4d,
+	0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21,
+	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f,
+	0x63, 0x6b, 0x73, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x4d, 0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73,
+	0x6d, 0x52, 0x0d, 0x68, 0x61, 0x73, 0x68, 0x4d, 0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d,
+	0x22, 0x2c, 0x0a, 0x10, 0x45, 0x73, 0x63, 0x72, 0x6f, 0x77, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x72, 0x62, 0x69, 0x74, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x72, 0x62, 0x69, 0x74, 0x65, 0x72, 0x22, 0x57,
+	0x0a, 0x15, 0x41, 0x73, 0x73, 0x65, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x49, 0x74, 0x65,
+	0x6d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6e,
+	0x75, 0x6d, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6e,
+	0x75, 0x6d, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x22, 0xba, 0x04, 0x0a, 0x0f, 0x41, 0x73, 0x73, 0x65,
+	0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63,
+	0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65,
+	0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x40, 0x0a, 0x08, 0x68, 0x61, 0x73, 0x68, 0x4c,
+	0x6f, 0x63, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x48,
+	0x61, 0x73, 0x68, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x48, 0x00, 0x52,
+	0x08, 0x68, 0x61, 0x73, 0x68, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x46, 0x0a, 0x0a, 0x65, 0x73, 0x63,
+	0x72, 0x6f, 0x77, 0x4c, 0x6f, 0x63, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63,
+	0x6b, 0x73, 0x2e, 0x45, 0x73, 0x63, 0x72, 0x6f, 0x77, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x48, 0x00, 0x52, 0x0a, 0x65, 0x73, 0x63, 0x72, 0x6f, 0x77, 0x4c, 0x6f, 0x63,
+	0x6b, 0x12, 0x26, 0x0a, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x53,
+	0x65, 0x63, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x79, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x62, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x10, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x75, 0x6d,
+	0x55, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6e, 0x75, 0x6d,
+	0x55, 0x6e, 0x69, 0x74, 0x73, 0x12, 0x41, 0x0a, 0x06, 0x61, 0x73, 0x73, 0x65, 0x74, 0x73, 0x18,
+	0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74,
+	0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x52, 0x06, 0x61, 0x73, 0x73, 0x65, 0x74, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x63, 0x69,
+	0x70, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65,
+	0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x72, 0x65, 0x63, 0x69,
+	0x70, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x54,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x49, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x49, 0x64, 0x42, 0x0a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x6b,
+	0x49, 0x6e, 0x66, 0x6f, 0x2a, 0x25, 0x0a, 0x0d, 0x4c, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x63, 0x68,
+	0x61, 0x6e, 0x69, 0x73, 0x6d, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x54, 0x4c, 0x43, 0x10, 0x00, 0x12,
+	0x0a, 0x0a, 0x06, 0x45, 0x53, 0x43, 0x52, 0x4f, 0x57, 0x10, 0x01, 0x2a, 0x49, 0x0a, 0x0d, 0x48,
+	0x61, 0x73, 0x68, 0x4d, 0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x12, 0x0a, 0x0a, 0x06,
+	0x53, 0x48, 0x41, 0x32, 0x35, 0x36, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x48, 0x41, 0x35,
+	0x31, 0x32, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4b, 0x45, 0x43, 0x43, 0x41, 0x4b, 0x32, 0x35,
+	0x36, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x44, 0x4f, 0x55, 0x42, 0x4c, 0x45, 0x5f, 0x53, 0x48,
+	0x41, 0x32, 0x35, 0x36, 0x10, 0x03, 0x42, 0x51, 0x5a, 0x4f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70, 0x65, 0x72, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72,
+	0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x77, 0x65, 0x61, 0x76, 0x65, 0x72, 0x2d, 0x64, 0x6c, 0x74,
+	0x2d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x79, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2d,
+	0x67, 0x6f, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -719,35 +2465,79 @@ func file_common_asset_locks_proto_rawDescGZIP() []byte {
 	return file_common_asset_locks_proto_rawDescData
 }
 
-var file_common_asset_locks_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_common_asset_locks_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_common_asset_locks_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_common_asset_locks_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_common_asset_locks_proto_goTypes = []interface{}{
 	(LockMechanism)(0),                     // 0: common.asset_locks.LockMechanism
-	(AssetLockHTLC_TimeSpec)(0),            // 1: common.asset_locks.AssetLockHTLC.TimeSpec
-	(*AssetLock)(nil),                      // 2: common.asset_locks.AssetLock
-	(*AssetClaim)(nil),                     // 3: common.asset_locks.AssetClaim
-	(*AssetLockHTLC)(nil),                  // 4: common.asset_locks.AssetLockHTLC
-	(*AssetClaimHTLC)(nil),                 // 5: common.asset_locks.AssetClaimHTLC
-	(*AssetExchangeAgreement)(nil),         // 6: common.asset_locks.AssetExchangeAgreement
-	(*FungibleAssetExchangeAgreement)(nil), // 7: common.asset_locks.FungibleAssetExchangeAgreement
-	(*AssetContractHTLC)(nil),              // 8: common.asset_locks.AssetContractHTLC
-	(*FungibleAssetContractHTLC)(nil),      // 9: common.asset_locks.FungibleAssetContractHTLC
+	(HashMechanism)(0),                     // 1: common.asset_locks.HashMechanism
+	(AssetLockHTLC_TimeSpec)(0),            // 2: common.asset_locks.AssetLockHTLC.TimeSpec
+	(AssetLockEvent_Action)(0),             // 3: common.asset_locks.AssetLockEvent.Action
+	(*AssetLock)(nil),                      // 4: common.asset_locks.AssetLock
+	(*AssetClaim)(nil),                     // 5: common.asset_locks.AssetClaim
+	(*RecipientApproval)(nil),              // 6: common.asset_locks.RecipientApproval
+	(*AssetLockHTLC)(nil),                  // 7: common.asset_locks.AssetLockHTLC
+	(*AssetClaimHTLC)(nil),                 // 8: common.asset_locks.AssetClaimHTLC
+	(*AssetLockEscrow)(nil),                // 9: common.asset_locks.AssetLockEscrow
+	(*AssetClaimEscrow)(nil),               // 10: common.asset_locks.AssetClaimEscrow
+	(*AssetExchangeAgreement)(nil),         // 11: common.asset_locks.AssetExchangeAgreement
+	(*FungibleAssetExchangeAgreement)(nil), // 12: common.asset_locks.FungibleAssetExchangeAgreement
+	(*AssetContractHTLC)(nil),              // 13: common.asset_locks.AssetContractHTLC
+	(*FungibleAssetContractHTLC)(nil),      // 14: common.asset_locks.FungibleAssetContractHTLC
+	(*AssetContractEscrow)(nil),            // 15: common.asset_locks.AssetContractEscrow
+	(*FungibleAssetContractEscrow)(nil),    // 16: common.asset_locks.FungibleAssetContractEscrow
+	(*AssetBundleItem)(nil),                // 17: common.asset_locks.AssetBundleItem
+	(*AssetBundleExchangeAgreement)(nil),   // 18: common.asset_locks.AssetBundleExchangeAgreement
+	(*AssetBundleContractHTLC)(nil),        // 19: common.asset_locks.AssetBundleContractHTLC
+	(*AssetLockExtension)(nil),             // 20: common.asset_locks.AssetLockExtension
+	(*AssetLockEvent)(nil),                 // 21: common.asset_locks.AssetLockEvent
+	(*AssetLockEventEnvelope)(nil),         // 22: common.asset_locks.AssetLockEventEnvelope
+	(*AssetUnlockOutcome)(nil),             // 23: common.asset_locks.AssetUnlockOutcome
+	(*AssetBatchUnlockOutcomes)(nil),       // 24: common.asset_locks.AssetBatchUnlockOutcomes
+	(*AssetBatchContractHTLC)(nil),         // 25: common.asset_locks.AssetBatchContractHTLC
+	(*AssetLockDelegation)(nil),            // 26: common.asset_locks.AssetLockDelegation
+	(*HashLockRecord)(nil),                 // 27: common.asset_locks.HashLockRecord
+	(*EscrowLockRecord)(nil),               // 28: common.asset_locks.EscrowLockRecord
+	(*AssetBundleItemRecord)(nil),          // 29: common.asset_locks.AssetBundleItemRecord
+	(*AssetLockRecord)(nil),                // 30: common.asset_locks.AssetLockRecord
 }
 var file_common_asset_locks_proto_depIdxs = []int32{
-	0, // 0: common.asset_locks.AssetLock.lockMechanism:type_name -> common.asset_locks.LockMechanism
-	0, // 1: common.asset_locks.AssetClaim.lockMechanism:type_name -> common.asset_locks.LockMechanism
-	1, // 2: common.asset_locks.AssetLockHTLC.timeSpec:type_name -> common.asset_locks.AssetLockHTLC.TimeSpec
-	6, // 3: common.asset_locks.AssetContractHTLC.agreement:type_name -> common.asset_locks.AssetExchangeAgreement
-	4, // 4: common.asset_locks.AssetContractHTLC.lock:type_name -> common.asset_locks.AssetLockHTLC
-	5, // 5: common.asset_locks.AssetContractHTLC.claim:type_name -> common.asset_locks.AssetClaimHTLC
-	7, // 6: common.asset_locks.FungibleAssetContractHTLC.agreement:type_name -> common.asset_locks.FungibleAssetExchangeAgreement
-	4, // 7: common.asset_locks.FungibleAssetContractHTLC.lock:type_name -> common.asset_locks.AssetLockHTLC
-	5, // 8: common.asset_locks.FungibleAssetContractHTLC.claim:type_name -> common.asset_locks.AssetClaimHTLC
-	9, // [9:9] is the sub-list for method output_type
-	9, // [9:9] is the sub-list for method input_type
-	9, // [9:9] is the sub-list for extension type_name
-	9, // [9:9] is the sub-list for extension extendee
-	0, // [0:9] is the sub-list for field type_name
+	0,  // 0: common.asset_locks.AssetLock.lockMechanism:type_name -> common.asset_locks.LockMechanism
+	0,  // 1: common.asset_locks.AssetClaim.lockMechanism:type_name -> common.asset_locks.LockMechanism
+	6,  // 2: common.asset_locks.AssetClaim.recipientApprovals:type_name -> common.asset_locks.RecipientApproval
+	2,  // 3: common.asset_locks.AssetLockHTLC.timeSpec:type_name -> common.asset_locks.AssetLockHTLC.TimeSpec
+	1,  // 4: common.asset_locks.AssetLockHTLC.hashMechanism:type_name -> common.asset_locks.HashMechanism
+	2,  // 5: common.asset_locks.AssetLockEscrow.timeSpec:type_name -> common.asset_locks.AssetLockHTLC.TimeSpec
+	11, // 6: common.asset_locks.AssetContractHTLC.agreement:type_name -> common.asset_locks.AssetExchangeAgreement
+	7,  // 7: common.asset_locks.AssetContractHTLC.lock:type_name -> common.asset_locks.AssetLockHTLC
+	8,  // 8: common.asset_locks.AssetContractHTLC.claim:type_name -> common.asset_locks.AssetClaimHTLC
+	12, // 9: common.asset_locks.FungibleAssetContractHTLC.agreement:type_name -> common.asset_locks.FungibleAssetExchangeAgreement
+	7,  // 10: common.asset_locks.FungibleAssetContractHTLC.lock:type_name -> common.asset_locks.AssetLockHTLC
+	8,  // 11: common.asset_locks.FungibleAssetContractHTLC.claim:type_name -> common.asset_locks.AssetClaimHTLC
+	11, // 12: common.asset_locks.AssetContractEscrow.agreement:type_name -> common.asset_locks.AssetExchangeAgreement
+	9,  // 13: common.asset_locks.AssetContractEscrow.lock:type_name -> common.asset_locks.AssetLockEscrow
+	10, // 14: common.asset_locks.AssetContractEscrow.claim:type_name -> common.asset_locks.AssetClaimEscrow
+	12, // 15: common.asset_locks.FungibleAssetContractEscrow.agreement:type_name -> common.asset_locks.FungibleAssetExchangeAgreement
+	9,  // 16: common.asset_locks.FungibleAssetContractEscrow.lock:type_name -> common.asset_locks.AssetLockEscrow
+	10, // 17: common.asset_locks.FungibleAssetContractEscrow.claim:type_name -> common.asset_locks.AssetClaimEscrow
+	17, // 18: common.asset_locks.AssetBundleExchangeAgreement.assets:type_name -> common.asset_locks.AssetBundleItem
+	18, // 19: common.asset_locks.AssetBundleContractHTLC.agreement:type_name -> common.asset_locks.AssetBundleExchangeAgreement
+	7,  // 20: common.asset_locks.AssetBundleContractHTLC.lock:type_name -> common.asset_locks.AssetLockHTLC
+	8,  // 21: common.asset_locks.AssetBundleContractHTLC.claim:type_name -> common.asset_locks.AssetClaimHTLC
+	3,  // 22: common.asset_locks.AssetLockEvent.action:type_name -> common.asset_locks.AssetLockEvent.Action
+	0,  // 23: common.asset_locks.AssetLockEvent.lockMechanism:type_name -> common.asset_locks.LockMechanism
+	17, // 24: common.asset_locks.AssetLockEvent.bundleAssets:type_name -> common.asset_locks.AssetBundleItem
+	21, // 25: common.asset_locks.AssetLockEventEnvelope.events:type_name -> common.asset_locks.AssetLockEvent
+	23, // 26: common.asset_locks.AssetBatchUnlockOutcomes.outcomes:type_name -> common.asset_locks.AssetUnlockOutcome
+	8,  // 27: common.asset_locks.AssetBatchContractHTLC.claim:type_name -> common.asset_locks.AssetClaimHTLC
+	1,  // 28: common.asset_locks.HashLockRecord.hashMechanism:type_name -> common.asset_locks.HashMechanism
+	27, // 29: common.asset_locks.AssetLockRecord.hashLock:type_name -> common.asset_locks.HashLockRecord
+	28, // 30: common.asset_locks.AssetLockRecord.escrowLock:type_name -> common.asset_locks.EscrowLockRecord
+	29, // 31: common.asset_locks.AssetLockRecord.assets:type_name -> common.asset_locks.AssetBundleItemRecord
+	32, // [32:32] is the sub-list for method output_type
+	32, // [32:32] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
 }
 
 func init() { file_common_asset_locks_proto_init() }
@@ -781,7 +2571,7 @@ func file_common_asset_locks_proto_init() {
 			}
 		}
 		file_common_asset_locks_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AssetLockHTLC); i {
+			switch v := v.(*RecipientApproval); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -793,7 +2583,7 @@ func file_common_asset_locks_proto_init() {
 			}
 		}
 		file_common_asset_locks_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AssetClaimHTLC); i {
+			switch v := v.(*AssetLockHTLC); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -805,7 +2595,7 @@ func file_common_asset_locks_proto_init() {
 			}
 		}
 		file_common_asset_locks_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AssetExchangeAgreement); i {
+			switch v := v.(*AssetClaimHTLC); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -817,7 +2607,7 @@ func file_common_asset_locks_proto_init() {
 			}
 		}
 		file_common_asset_locks_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FungibleAssetExchangeAgreement); i {
+			switch v := v.(*AssetLockEscrow); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -829,7 +2619,7 @@ func file_common_asset_locks_proto_init() {
 			}
 		}
 		file_common_asset_locks_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AssetContractHTLC); i {
+			switch v := v.(*AssetClaimEscrow); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -841,6 +2631,42 @@ func file_common_asset_locks_proto_init() {
 			}
 		}
 		file_common_asset_locks_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssetExchangeAgreement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_asset_locks_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FungibleAssetExchangeAgreement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_asset_locks_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssetContractHTLC); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_asset_locks_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FungibleAssetContractHTLC); i {
 			case 0:
 				return &v.state
@@ -852,14 +2678,210 @@ func file_common_asset_locks_proto_init() {
 				return nil
 			}
 		}
+		file_common_asset_locks_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssetContractEscrow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_asset_locks_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FungibleAssetContractEscrow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_asset_locks_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssetBundleItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_asset_locks_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssetBundleExchangeAgreement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_asset_locks_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssetBundleContractHTLC); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_asset_locks_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssetLockExtension); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_asset_locks_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssetLockEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_asset_locks_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssetLockEventEnvelope); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_asset_locks_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssetUnlockOutcome); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_asset_locks_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssetBatchUnlockOutcomes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_asset_locks_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssetBatchContractHTLC); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_asset_locks_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssetLockDelegation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_asset_locks_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HashLockRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_asset_locks_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EscrowLockRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_asset_locks_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssetBundleItemRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_asset_locks_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssetLockRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_common_asset_locks_proto_msgTypes[26].OneofWrappers = []interface{}{
+		(*AssetLockRecord_HashLock)(nil),
+		(*AssetLockRecord_EscrowLock)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_common_asset_locks_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   8,
+			NumEnums:      4,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
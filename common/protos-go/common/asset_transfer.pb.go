@@ -0,0 +1,441 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.15.8
+// source: common/asset_transfer.proto
+
+package common
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Pledge of an asset, recorded on the network where the asset resides (the local network), for its transfer to a
+// recipient on another network (the remote network); assetDetails is the serialized asset, in a form defined by the
+// application chaincode, that lets the remote network recreate the asset when the recipient claims it
+type AssetPledge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AssetType       string `protobuf:"bytes,1,opt,name=assetType,proto3" json:"assetType,omitempty"`
+	AssetDetails    []byte `protobuf:"bytes,2,opt,name=assetDetails,proto3" json:"assetDetails,omitempty"`
+	Pledger         string `protobuf:"bytes,3,opt,name=pledger,proto3" json:"pledger,omitempty"`
+	LocalNetworkID  string `protobuf:"bytes,4,opt,name=localNetworkID,proto3" json:"localNetworkID,omitempty"`
+	RemoteNetworkID string `protobuf:"bytes,5,opt,name=remoteNetworkID,proto3" json:"remoteNetworkID,omitempty"`
+	Recipient       string `protobuf:"bytes,6,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	ExpiryTimeSecs  uint64 `protobuf:"varint,7,opt,name=expiryTimeSecs,proto3" json:"expiryTimeSecs,omitempty"`
+}
+
+func (x *AssetPledge) Reset() {
+	*x = AssetPledge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_transfer_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetPledge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetPledge) ProtoMessage() {}
+
+func (x *AssetPledge) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_transfer_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetPledge.ProtoReflect.Descriptor instead.
+func (*AssetPledge) Descriptor() ([]byte, []int) {
+	return file_common_asset_transfer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AssetPledge) GetAssetType() string {
+	if x != nil {
+		return x.AssetType
+	}
+	return ""
+}
+
+func (x *AssetPledge) GetAssetDetails() []byte {
+	if x != nil {
+		return x.AssetDetails
+	}
+	return nil
+}
+
+func (x *AssetPledge) GetPledger() string {
+	if x != nil {
+		return x.Pledger
+	}
+	return ""
+}
+
+func (x *AssetPledge) GetLocalNetworkID() string {
+	if x != nil {
+		return x.LocalNetworkID
+	}
+	return ""
+}
+
+func (x *AssetPledge) GetRemoteNetworkID() string {
+	if x != nil {
+		return x.RemoteNetworkID
+	}
+	return ""
+}
+
+func (x *AssetPledge) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *AssetPledge) GetExpiryTimeSecs() uint64 {
+	if x != nil {
+		return x.ExpiryTimeSecs
+	}
+	return 0
+}
+
+// Status of the claim of a pledged asset, as reported by the network where the claim is made (the local network) for
+// a pledge made on another network (the remote network). The asset details, recipient and expiry time are those of the
+// claim, if one has been made, and expirationStatus tells whether the pledge has expired as per the local network;
+// statusTimeSecs is the latest pledge expiry time that has elapsed as per the local network when the status is
+// reported, so that a pledge expiring at or before it can no longer be claimed
+type AssetClaimStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AssetType        string `protobuf:"bytes,1,opt,name=assetType,proto3" json:"assetType,omitempty"`
+	AssetDetails     []byte `protobuf:"bytes,2,opt,name=assetDetails,proto3" json:"assetDetails,omitempty"`
+	LocalNetworkID   string `protobuf:"bytes,3,opt,name=localNetworkID,proto3" json:"localNetworkID,omitempty"`
+	RemoteNetworkID  string `protobuf:"bytes,4,opt,name=remoteNetworkID,proto3" json:"remoteNetworkID,omitempty"`
+	Recipient        string `protobuf:"bytes,5,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	ClaimStatus      bool   `protobuf:"varint,6,opt,name=claimStatus,proto3" json:"claimStatus,omitempty"`
+	ExpiryTimeSecs   uint64 `protobuf:"varint,7,opt,name=expiryTimeSecs,proto3" json:"expiryTimeSecs,omitempty"`
+	ExpirationStatus bool   `protobuf:"varint,8,opt,name=expirationStatus,proto3" json:"expirationStatus,omitempty"`
+	StatusTimeSecs   uint64 `protobuf:"varint,9,opt,name=statusTimeSecs,proto3" json:"statusTimeSecs,omitempty"`
+}
+
+func (x *AssetClaimStatus) Reset() {
+	*x = AssetClaimStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_transfer_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetClaimStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetClaimStatus) ProtoMessage() {}
+
+func (x *AssetClaimStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_transfer_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetClaimStatus.ProtoReflect.Descriptor instead.
+func (*AssetClaimStatus) Descriptor() ([]byte, []int) {
+	return file_common_asset_transfer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AssetClaimStatus) GetAssetType() string {
+	if x != nil {
+		return x.AssetType
+	}
+	return ""
+}
+
+func (x *AssetClaimStatus) GetAssetDetails() []byte {
+	if x != nil {
+		return x.AssetDetails
+	}
+	return nil
+}
+
+func (x *AssetClaimStatus) GetLocalNetworkID() string {
+	if x != nil {
+		return x.LocalNetworkID
+	}
+	return ""
+}
+
+func (x *AssetClaimStatus) GetRemoteNetworkID() string {
+	if x != nil {
+		return x.RemoteNetworkID
+	}
+	return ""
+}
+
+func (x *AssetClaimStatus) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *AssetClaimStatus) GetClaimStatus() bool {
+	if x != nil {
+		return x.ClaimStatus
+	}
+	return false
+}
+
+func (x *AssetClaimStatus) GetExpiryTimeSecs() uint64 {
+	if x != nil {
+		return x.ExpiryTimeSecs
+	}
+	return 0
+}
+
+func (x *AssetClaimStatus) GetExpirationStatus() bool {
+	if x != nil {
+		return x.ExpirationStatus
+	}
+	return false
+}
+
+func (x *AssetClaimStatus) GetStatusTimeSecs() uint64 {
+	if x != nil {
+		return x.StatusTimeSecs
+	}
+	return 0
+}
+
+// Event emitted when an asset is pledged, claimed or reclaimed, with the pledge that the event refers to
+type AssetTransferEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PledgeId string       `protobuf:"bytes,1,opt,name=pledgeId,proto3" json:"pledgeId,omitempty"`
+	Pledge   *AssetPledge `protobuf:"bytes,2,opt,name=pledge,proto3" json:"pledge,omitempty"`
+}
+
+func (x *AssetTransferEvent) Reset() {
+	*x = AssetTransferEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_asset_transfer_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssetTransferEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetTransferEvent) ProtoMessage() {}
+
+func (x *AssetTransferEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_common_asset_transfer_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetTransferEvent.ProtoReflect.Descriptor instead.
+func (*AssetTransferEvent) Descriptor() ([]byte, []int) {
+	return file_common_asset_transfer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AssetTransferEvent) GetPledgeId() string {
+	if x != nil {
+		return x.PledgeId
+	}
+	return ""
+}
+
+func (x *AssetTransferEvent) GetPledge() *AssetPledge {
+	if x != nil {
+		return x.Pledge
+	}
+	return nil
+}
+
+var File_common_asset_transfer_proto protoreflect.FileDescriptor
+
+var file_common_asset_transfer_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x15, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x22, 0x81, 0x02, 0x0a, 0x0b, 0x41, 0x73, 0x73, 0x65, 0x74, 0x50, 0x6c,
+	0x65, 0x64, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x73, 0x73, 0x65, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x73, 0x73, 0x65, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x73, 0x73, 0x65, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x61, 0x73, 0x73, 0x65, 0x74, 0x44,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6c, 0x65, 0x64, 0x67, 0x65,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72,
+	0x12, 0x26, 0x0a, 0x0e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x4e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x44, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x44, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74,
+	0x12, 0x26, 0x0a, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65,
+	0x63, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79,
+	0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x73, 0x22, 0xe2, 0x02, 0x0a, 0x10, 0x41, 0x73, 0x73,
+	0x65, 0x74, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x61, 0x73, 0x73, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x61, 0x73, 0x73, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0c, 0x61, 0x73, 0x73, 0x65, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12,
+	0x26, 0x0a, 0x0e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49,
+	0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x44, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49,
+	0x44, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x12,
+	0x20, 0x0a, 0x0b, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x26, 0x0a, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x53,
+	0x65, 0x63, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x79, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x10, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54,
+	0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x73, 0x22, 0x6c, 0x0a,
+	0x12, 0x41, 0x73, 0x73, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x49, 0x64, 0x12,
+	0x3a, 0x0a, 0x06, 0x70, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x50, 0x6c, 0x65,
+	0x64, 0x67, 0x65, 0x52, 0x06, 0x70, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x42, 0x51, 0x5a, 0x4f, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70, 0x65, 0x72, 0x6c,
+	0x65, 0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x77, 0x65, 0x61, 0x76, 0x65,
+	0x72, 0x2d, 0x64, 0x6c, 0x74, 0x2d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2d, 0x67, 0x6f, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_common_asset_transfer_proto_rawDescOnce sync.Once
+	file_common_asset_transfer_proto_rawDescData = file_common_asset_transfer_proto_rawDesc
+)
+
+func file_common_asset_transfer_proto_rawDescGZIP() []byte {
+	file_common_asset_transfer_proto_rawDescOnce.Do(func() {
+		file_common_asset_transfer_proto_rawDescData = protoimpl.X.CompressGZIP(file_common_asset_transfer_proto_rawDescData)
+	})
+	return file_common_asset_transfer_proto_rawDescData
+}
+
+var file_common_asset_transfer_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_common_asset_transfer_proto_goTypes = []interface{}{
+	(*AssetPledge)(nil),        // 0: common.asset_transfer.AssetPledge
+	(*AssetClaimStatus)(nil),   // 1: common.asset_transfer.AssetClaimStatus
+	(*AssetTransferEvent)(nil), // 2: common.asset_transfer.AssetTransferEvent
+}
+var file_common_asset_transfer_proto_depIdxs = []int32{
+	0, // 0: common.asset_transfer.AssetTransferEvent.pledge:type_name -> common.asset_transfer.AssetPledge
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_common_asset_transfer_proto_init() }
+func file_common_asset_transfer_proto_init() {
+	if File_common_asset_transfer_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_common_asset_transfer_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssetPledge); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_asset_transfer_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssetClaimStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_asset_transfer_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssetTransferEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_common_asset_transfer_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_common_asset_transfer_proto_goTypes,
+		DependencyIndexes: file_common_asset_transfer_proto_depIdxs,
+		MessageInfos:      file_common_asset_transfer_proto_msgTypes,
+	}.Build()
+	File_common_asset_transfer_proto = out.File
+	file_common_asset_transfer_proto_rawDesc = nil
+	file_common_asset_transfer_proto_goTypes = nil
+	file_common_asset_transfer_proto_depIdxs = nil
+}
@@ -0,0 +1,185 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.15.8
+// source: ethereum/validator_set.proto
+
+package ethereum
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ValidatorSet captures the validators of an external Ethereum network with BFT finality, whose committed seals
+// make the block headers of views from the network final
+type ValidatorSet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SecurityDomain string `protobuf:"bytes,1,opt,name=securityDomain,proto3" json:"securityDomain,omitempty"`
+	// BFT protocol of the network, which determines the encoding of the header extra data: "IBFT2" or "QBFT"
+	Consensus string `protobuf:"bytes,2,opt,name=consensus,proto3" json:"consensus,omitempty"`
+	// 20-byte addresses of the validators, in 0x-prefixed hex form
+	Validators []string `protobuf:"bytes,3,rep,name=validators,proto3" json:"validators,omitempty"`
+	// maximum age (in seconds, beyond the clock skew tolerance) of the block of a view from the network, so that stale
+	// state cannot be presented as current
+	MaxBlockAgeSecs uint64 `protobuf:"varint,4,opt,name=maxBlockAgeSecs,proto3" json:"maxBlockAgeSecs,omitempty"`
+}
+
+func (x *ValidatorSet) Reset() {
+	*x = ValidatorSet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ethereum_validator_set_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorSet) ProtoMessage() {}
+
+func (x *ValidatorSet) ProtoReflect() protoreflect.Message {
+	mi := &file_ethereum_validator_set_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorSet.ProtoReflect.Descriptor instead.
+func (*ValidatorSet) Descriptor() ([]byte, []int) {
+	return file_ethereum_validator_set_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ValidatorSet) GetSecurityDomain() string {
+	if x != nil {
+		return x.SecurityDomain
+	}
+	return ""
+}
+
+func (x *ValidatorSet) GetConsensus() string {
+	if x != nil {
+		return x.Consensus
+	}
+	return ""
+}
+
+func (x *ValidatorSet) GetValidators() []string {
+	if x != nil {
+		return x.Validators
+	}
+	return nil
+}
+
+func (x *ValidatorSet) GetMaxBlockAgeSecs() uint64 {
+	if x != nil {
+		return x.MaxBlockAgeSecs
+	}
+	return 0
+}
+
+var File_ethereum_validator_set_proto protoreflect.FileDescriptor
+
+var file_ethereum_validator_set_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x5f, 0x73, 0x65, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08,
+	0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x22, 0x9e, 0x01, 0x0a, 0x0c, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x73, 0x65, 0x63,
+	0x75, 0x72, 0x69, 0x74, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x12,
+	0x1e, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12,
+	0x28, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x41, 0x67, 0x65, 0x53, 0x65,
+	0x63, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x41, 0x67, 0x65, 0x53, 0x65, 0x63, 0x73, 0x42, 0x53, 0x5a, 0x51, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70, 0x65, 0x72, 0x6c, 0x65, 0x64,
+	0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x77, 0x65, 0x61, 0x76, 0x65, 0x72, 0x2d,
+	0x64, 0x6c, 0x74, 0x2d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x79, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x2d, 0x67, 0x6f, 0x2f, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_ethereum_validator_set_proto_rawDescOnce sync.Once
+	file_ethereum_validator_set_proto_rawDescData = file_ethereum_validator_set_proto_rawDesc
+)
+
+func file_ethereum_validator_set_proto_rawDescGZIP() []byte {
+	file_ethereum_validator_set_proto_rawDescOnce.Do(func() {
+		file_ethereum_validator_set_proto_rawDescData = protoimpl.X.CompressGZIP(file_ethereum_validator_set_proto_rawDescData)
+	})
+	return file_ethereum_validator_set_proto_rawDescData
+}
+
+var file_ethereum_validator_set_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_ethereum_validator_set_proto_goTypes = []interface{}{
+	(*ValidatorSet)(nil), // 0: ethereum.ValidatorSet
+}
+var file_ethereum_validator_set_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_ethereum_validator_set_proto_init() }
+func file_ethereum_validator_set_proto_init() {
+	if File_ethereum_validator_set_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_ethereum_validator_set_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidatorSet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_ethereum_validator_set_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_ethereum_validator_set_proto_goTypes,
+		DependencyIndexes: file_ethereum_validator_set_proto_depIdxs,
+		MessageInfos:      file_ethereum_validator_set_proto_msgTypes,
+	}.Build()
+	File_ethereum_validator_set_proto = out.File
+	file_ethereum_validator_set_proto_rawDesc = nil
+	file_ethereum_validator_set_proto_goTypes = nil
+	file_ethereum_validator_set_proto_depIdxs = nil
+}
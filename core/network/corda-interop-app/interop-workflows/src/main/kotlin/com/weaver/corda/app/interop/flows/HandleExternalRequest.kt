@@ -56,7 +56,14 @@ class HandleExternalRequest(val query: QueryOuterClass.Query) : FlowLogic<Either
         println("Received query from foreign network $query")
 
         // 1. Check validity of request signature
-        verifyNodeSignature(query.certificate, query.requestorSignature, (query.address + query.nonce).toByteArray()).flatMap {
+        verifyNodeSignature(query.certificate, query.requestorSignature, getSignedQueryMessage(query)).flatMap {
+            // Responses are not encrypted for the requestor, so confidential queries cannot be served
+            if (query.confidential) {
+                Left(Error("Confidential queries are not supported"))
+            } else {
+                Right(it)
+            }
+        }.flatMap {
             getCertificateFromString(query.certificate).flatMap {
                 // 2. Check that the certificate of the requester is valid according to the securityDomain's Membership
                 verifyMemberInSecurityDomain(it, query.requestingNetwork, query.requestingOrg, serviceHub)
@@ -224,4 +231,25 @@ fun createViewData(
 } catch (e: Exception) {
     println("Error creating data and proof object: ${e.message}\n")
     Left(Error("Error creating data and proof object: ${e.message}"))
-}
\ No newline at end of file
+}
+
+/**
+ * The getSignedQueryMessage function returns the message signed by the requestor of a query.
+ *
+ * A query that carries a timestamp or is confidential is signed over its address, nonce and timestamp (in epoch
+ * seconds, in decimal form), followed by the field "confidential" for a confidential query, each prefixed by its length
+ * in bytes and ':', so that no characters can be moved from one field to the next without invalidating the signature.
+ * A query with neither is signed over its address followed by its nonce.
+ *
+ * @param query The query that is sent by the requesting network.
+ */
+fun getSignedQueryMessage(query: QueryOuterClass.Query): ByteArray {
+    if (query.timestamp == 0L && !query.confidential) {
+        return (query.address + query.nonce).toByteArray()
+    }
+    val fields = mutableListOf(query.address, query.nonce, java.lang.Long.toUnsignedString(query.timestamp))
+    if (query.confidential) {
+        fields.add("confidential")
+    }
+    return fields.joinToString("") { "${it.toByteArray().size}:$it" }.toByteArray()
+}
@@ -0,0 +1,375 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// asset_bundles contains the code to lock a basket of assets (non-fungible assets and units of fungible assets)
+// under a single contract, which is then claimed or unlocked atomically
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	log "github.com/sirupsen/logrus"
+)
+
+// Object used in the map, contractId --> <assets, locker, recipient, ...> (for asset bundles)
+type AssetBundleLockValue struct {
+	Assets         []AssetBundleItem `json:"assets"`
+	Locker         string            `json:"locker"`
+	Recipient      string            `json:"recipient"`
+	LockInfo       interface{}       `json:"lockInfo"`
+	ExpiryTimeSecs uint64            `json:"expiryTimeSecs"`
+}
+
+// Object used to capture an asset of a bundle: a non-fungible asset if Id is set, units of a fungible asset otherwise
+type AssetBundleItem struct {
+	Type     string `json:"type"`
+	Id       string `json:"id,omitempty"`
+	NumUnits uint64 `json:"numUnits,omitempty"`
+}
+
+const (
+	bundleContractIdPrefix = "BundleContractId_" // prefix for the map, contractId --> asset-bundle-object
+)
+
+// function to return the key to fetch an asset bundle lock from the map using contractId
+func generateBundleContractIdMapKey(contractId string) string {
+	return bundleContractIdPrefix + contractId
+}
+
+// function to extract the asset bundle agreement and check that every asset in it is well formed
+func getAssetBundleAgreement(bundleAgreementBytesBase64 string) (*common.AssetBundleExchangeAgreement, error) {
+	bundleAgreementBytes, err := base64.StdEncoding.DecodeString(bundleAgreementBytesBase64)
+	if err != nil {
+		return nil, logThenErrorf("error in base64 decode of asset bundle agreement: %+v", err)
+	}
+	bundleAgreement := &common.AssetBundleExchangeAgreement{}
+	err = proto.Unmarshal(bundleAgreementBytes, bundleAgreement)
+	if err != nil {
+		return nil, logThenErrorf(err.Error())
+	}
+	//display the requested asset bundle agreement
+	log.Infof("assetBundleExchangeAgreement: %+v", bundleAgreement)
+
+	if len(bundleAgreement.Assets) == 0 {
+		return nil, logThenErrorf("empty asset bundle")
+	}
+	if len(bundleAgreement.Recipient) == 0 {
+		return nil, logThenErrorf("empty recipient in the asset bundle agreement")
+	}
+	nonFungibleAssets := map[string]bool{}
+	for _, asset := range bundleAgreement.Assets {
+		if len(asset.Type) == 0 {
+			return nil, logThenErrorf("empty asset type in the asset bundle")
+		}
+		if len(asset.Id) > 0 && asset.NumUnits > 0 {
+			return nil, logThenErrorf("asset of type %s in the asset bundle has both an ID and a number of units", asset.Type)
+		}
+		if len(asset.Id) == 0 && asset.NumUnits == 0 {
+			return nil, logThenErrorf("asset of type %s in the asset bundle has neither an ID nor a number of units", asset.Type)
+		}
+		if len(asset.Id) > 0 {
+			if nonFungibleAssets[asset.Type+assetKeyDelimiter+asset.Id] {
+				return nil, logThenErrorf("asset of type %s and ID %s is repeated in the asset bundle", asset.Type, asset.Id)
+			}
+			nonFungibleAssets[asset.Type+assetKeyDelimiter+asset.Id] = true
+		}
+	}
+	return bundleAgreement, nil
+}
+
+/*
+ * Function to generate contract-id for asset bundle locking on the ledger (which is
+ * a hash on the asset bundle agreement and the transaction id)
+ */
+func generateAssetBundleLockContractId(ctx contractapi.TransactionContextInterface, bundleAgreement *common.AssetBundleExchangeAgreement) (string, error) {
+	bundleAgreementBytes, err := proto.Marshal(bundleAgreement)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	contractId := generateSHA256HashInBase64Form(string(bundleAgreementBytes) + ctx.GetStub().GetTxID())
+	return contractId, nil
+}
+
+// function to fetch the asset bundle locked using contractId
+func fetchAssetBundleLocked(ctx contractapi.TransactionContextInterface, contractId string) (AssetBundleLockValue, error) {
+	bundleLockVal := AssetBundleLockValue{}
+	bundleLockValBytes, err := ctx.GetStub().GetState(generateBundleContractIdMapKey(contractId))
+	if err != nil {
+		return bundleLockVal, logThenErrorf("failed to retrieve from the world state: %+v", err)
+	}
+	if bundleLockValBytes == nil {
+		return bundleLockVal, logThenErrorf("contractId %s is not associated with any currently locked asset bundle", contractId)
+	}
+	bundleLockVal, err = unmarshalAssetBundleLockValue(bundleLockValBytes)
+	if err != nil {
+		return bundleLockVal, logThenErrorf("unmarshal error: %s", err)
+	}
+	return bundleLockVal, nil
+}
+
+// function to build the lock summary (used by the indexes and the listing queries) of an asset bundle lock
+func getAssetBundleLockedAssetInfo(contractId string, bundleLockVal AssetBundleLockValue) LockedAssetInfo {
+	return LockedAssetInfo{ContractId: contractId, Locker: bundleLockVal.Locker, Recipient: bundleLockVal.Recipient,
+		ExpiryTimeSecs: bundleLockVal.ExpiryTimeSecs, Assets: bundleLockVal.Assets}
+}
+
+// function to release the locks on all the non-fungible assets of a bundle and delete the bundle lock along with its index entries
+func deleteAssetBundleLock(ctx contractapi.TransactionContextInterface, contractId string, bundleLockVal AssetBundleLockValue) error {
+	for _, asset := range bundleLockVal.Assets {
+		if len(asset.Id) == 0 {
+			continue
+		}
+		assetLockKey, _, err := generateAssetLockKeyAndContractId(ctx, &common.AssetExchangeAgreement{Type: asset.Type, Id: asset.Id})
+		if err != nil {
+			return err
+		}
+		err = ctx.GetStub().DelState(assetLockKey)
+		if err != nil {
+			return logThenErrorf("failed to delete lock for asset of type %s and ID %s: %v", asset.Type, asset.Id, err)
+		}
+	}
+	err := ctx.GetStub().DelState(generateBundleContractIdMapKey(contractId))
+	if err != nil {
+		return logThenErrorf("failed to delete the asset bundle contractId %s: %v", contractId, err)
+	}
+	return deleteAssetLockIndexes(ctx, assetBundleLockKind, contractId, getAssetBundleLockedAssetInfo(contractId, bundleLockVal))
+}
+
+// LockAssetBundle cc is used to record locking of a bundle of assets on the ledger under a single contract;
+// every non-fungible asset of the bundle must not be locked already
+func (s *SmartContract) LockAssetBundle(ctx contractapi.TransactionContextInterface, bundleAgreementBytesBase64 string, lockInfoBytesBase64 string) (string, error) {
+
+	bundleAgreement, err := getAssetBundleAgreement(bundleAgreementBytesBase64)
+	if err != nil {
+		return "", err
+	}
+
+	txCreatorECertBase64, err := getECertOfTxCreatorBase64(ctx)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	if len(bundleAgreement.Locker) == 0 {
+		bundleAgreement.Locker = txCreatorECertBase64
+	} else if bundleAgreement.Locker != txCreatorECertBase64 {
+		return "", logThenErrorf("error in locker validation: locker %s in the asset bundle agreement is not same as the transaction creator %s", bundleAgreement.Locker, txCreatorECertBase64)
+	}
+
+	lockInfo, expiryTimeSecs, err := getLockInfoAndExpiryTimeSecs(ctx, lockInfoBytesBase64)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	// every asset of the bundle is subject to the lock policy, as it would be if it were locked on its own
+	for _, asset := range bundleAgreement.Assets {
+		err = enforceLockPolicy(ctx, asset.Type, asset.NumUnits, expiryTimeSecs)
+		if err != nil {
+			return "", logThenErrorf("lock policy violation: %+v", err)
+		}
+	}
+
+	contractId, err := generateAssetBundleLockContractId(ctx, bundleAgreement)
+	if err != nil {
+		return "", err
+	}
+
+	bundleLockVal := AssetBundleLockValue{Locker: bundleAgreement.Locker, Recipient: bundleAgreement.Recipient,
+		LockInfo: lockInfo, ExpiryTimeSecs: expiryTimeSecs}
+	for _, asset := range bundleAgreement.Assets {
+		bundleLockVal.Assets = append(bundleLockVal.Assets, AssetBundleItem{Type: asset.Type, Id: asset.Id, NumUnits: asset.NumUnits})
+		if len(asset.Id) == 0 {
+			continue
+		}
+		// mark the non-fungible asset as locked, so that it can neither be locked again nor settled outside the bundle
+		assetLockKey, _, err := generateAssetLockKeyAndContractId(ctx, &common.AssetExchangeAgreement{Type: asset.Type, Id: asset.Id})
+		if err != nil {
+			return "", err
+		}
+		assetLockValBytes, err := ctx.GetStub().GetState(assetLockKey)
+		if err != nil {
+			return "", logThenErrorf(err.Error())
+		}
+		if assetLockValBytes != nil {
+			return "", logThenErrorf("asset of type %s and ID %s is already locked", asset.Type, asset.Id)
+		}
+		assetLockVal := AssetLockValue{Locker: bundleLockVal.Locker, Recipient: bundleLockVal.Recipient, LockInfo: lockInfo,
+			ExpiryTimeSecs: expiryTimeSecs, BundleContractId: contractId}
+		assetLockValBytes, err = marshalAssetLockValue(assetLockVal)
+		if err != nil {
+			return "", logThenErrorf("marshal error: %+v", err)
+		}
+		err = ctx.GetStub().PutState(assetLockKey, assetLockValBytes)
+		if err != nil {
+			return "", logThenErrorf(err.Error())
+		}
+	}
+
+	bundleLockValBytes, err := marshalAssetBundleLockValue(bundleLockVal)
+	if err != nil {
+		return "", logThenErrorf("marshal error: %+v", err)
+	}
+	err = ctx.GetStub().PutState(generateBundleContractIdMapKey(contractId), bundleLockValBytes)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	err = addAssetLockIndexes(ctx, assetBundleLockKind, contractId, getAssetBundleLockedAssetInfo(contractId, bundleLockVal))
+	if err != nil {
+		return "", err
+	}
+	err = recordTxIdOfContractId(ctx, assetBundleLockKind, contractId)
+	if err != nil {
+		return "", err
+	}
+	emitAssetLockEvent(ctx, newAssetBundleLockEvent(common.AssetLockEvent_LOCK, contractId, bundleLockVal))
+
+	return contractId, nil
+}
+
+// IsAssetBundleLocked cc is used to query the ledger and find out if an asset bundle is locked using contractId
+func (s *SmartContract) IsAssetBundleLocked(ctx contractapi.TransactionContextInterface, contractId string) (bool, error) {
+
+	bundleLockVal, err := fetchAssetBundleLocked(ctx, contractId)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if expiry time is elapsed
+	isExpired, err := isLockExpired(ctx, bundleLockVal.ExpiryTimeSecs)
+	if err != nil {
+		return false, logThenErrorf(err.Error())
+	}
+	if isExpired {
+		log.Infof("expiry time for asset bundle associated with contractId %s is already elapsed", contractId)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// ClaimAssetBundle cc is used to record claim of all the assets of a bundle on the ledger
+func (s *SmartContract) ClaimAssetBundle(ctx contractapi.TransactionContextInterface, contractId string, claimInfoBytesBase64 string) error {
+
+	bundleLockVal, err := fetchAssetBundleLocked(ctx, contractId)
+	if err != nil {
+		return err
+	}
+
+	txCreatorECertBase64, err := getECertOfTxCreatorBase64(ctx)
+	if err != nil {
+		return logThenErrorf("unable to get the transaction creator information: %+v", err)
+	}
+
+	// transaction creator needs to be the recipient of the locked asset bundle
+	if bundleLockVal.Recipient != txCreatorECertBase64 {
+		return logThenErrorf("asset bundle is not locked for %s to claim", txCreatorECertBase64)
+	}
+
+	claimInfo, err := getClaimInfo(claimInfoBytesBase64)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+
+	// Check if expiry time is elapsed
+	isExpired, err := isLockExpired(ctx, bundleLockVal.ExpiryTimeSecs)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	if isExpired {
+		return logThenErrorf("cannot claim asset bundle associated with contractId %s as the expiry time is already elapsed", contractId)
+	}
+
+	if claimInfo.LockMechanism == common.LockMechanism_HTLC {
+		isCorrectPreimage, err := validateHashPreimage(claimInfo, bundleLockVal.LockInfo)
+		if err != nil {
+			return logThenErrorf("claim asset bundle associated with contractId %s failed with error: %v", contractId, err)
+		}
+		if !isCorrectPreimage {
+			return logThenErrorf("cannot claim asset bundle associated with contractId %s as the hash preimage is not matching", contractId)
+		}
+	} else if claimInfo.LockMechanism == common.LockMechanism_ESCROW {
+		isReleased, err := validateEscrowRelease(contractId, claimInfo, bundleLockVal.LockInfo, bundleLockVal.ExpiryTimeSecs)
+		if err != nil {
+			return logThenErrorf("claim asset bundle associated with contractId %s failed with error: %v", contractId, err)
+		}
+		if !isReleased {
+			return logThenErrorf("cannot claim asset bundle associated with contractId %s as it is not released by the escrow arbiter", contractId)
+		}
+	}
+
+	err = deleteAssetBundleLock(ctx, contractId, bundleLockVal)
+	if err != nil {
+		return err
+	}
+
+	err = recordClaimedContract(ctx, contractId, claimInfo, txCreatorECertBase64)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	emitAssetLockEvent(ctx, newAssetBundleLockEvent(common.AssetLockEvent_CLAIM, contractId, bundleLockVal))
+
+	return nil
+}
+
+// UnlockAssetBundle cc is used to record unlocking of all the assets of a bundle on the ledger
+func (s *SmartContract) UnlockAssetBundle(ctx contractapi.TransactionContextInterface, contractId string) error {
+
+	bundleLockVal, err := fetchAssetBundleLocked(ctx, contractId)
+	if err != nil {
+		return err
+	}
+
+	txCreatorECertBase64, err := getECertOfTxCreatorBase64(ctx)
+	if err != nil {
+		return logThenErrorf("unable to get the transaction creator information: %+v", err)
+	}
+
+	// transaction creator needs to be the locker of the locked asset bundle
+	if bundleLockVal.Locker != txCreatorECertBase64 {
+		return logThenErrorf("asset bundle is not locked by %s to unlock", txCreatorECertBase64)
+	}
+
+	// Check if expiry time is elapsed
+	isExpired, err := isLockExpired(ctx, bundleLockVal.ExpiryTimeSecs)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	if !isExpired {
+		return logThenErrorf("cannot unlock asset bundle associated with the contractId %s as the expiry time is not yet elapsed", contractId)
+	}
+
+	err = deleteAssetBundleLock(ctx, contractId, bundleLockVal)
+	if err != nil {
+		return err
+	}
+	emitAssetLockEvent(ctx, newAssetBundleLockEvent(common.AssetLockEvent_UNLOCK, contractId, bundleLockVal))
+
+	return nil
+}
+
+// GetAssetBundleUsingContractId cc is used to fetch (in JSON form) the assets of the bundle locked using contractId
+func (s *SmartContract) GetAssetBundleUsingContractId(ctx contractapi.TransactionContextInterface, contractId string) (string, error) {
+
+	bundleLockVal, err := fetchAssetBundleLocked(ctx, contractId)
+	if err != nil {
+		return "", err
+	}
+	assetsBytes, err := json.Marshal(bundleLockVal.Assets)
+	if err != nil {
+		return "", logThenErrorf("marshal error: %+v", err)
+	}
+	return string(assetsBytes), nil
+}
+
+// GetAssetBundleTimeToReleaseUsingContractId cc is used to query the expiry time (in epoch seconds) of the lock on an asset bundle using contractId
+func (s *SmartContract) GetAssetBundleTimeToReleaseUsingContractId(ctx contractapi.TransactionContextInterface, contractId string) (uint64, error) {
+	bundleLockVal, err := fetchAssetBundleLocked(ctx, contractId)
+	if err != nil {
+		return 0, err
+	}
+	return bundleLockVal.ExpiryTimeSecs, nil
+}
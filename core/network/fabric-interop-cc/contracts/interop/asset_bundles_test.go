@@ -0,0 +1,207 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/stretchr/testify/require"
+)
+
+// function that supplies the base64 encoded asset bundle agreement for the given assets
+func getAssetBundleAgreementBase64(assets []*common.AssetBundleItem, locker string, recipient string) string {
+	bundleAgreement := &common.AssetBundleExchangeAgreement{
+		Assets:    assets,
+		Locker:    locker,
+		Recipient: recipient,
+	}
+	bundleAgreementBytes, _ := proto.Marshal(bundleAgreement)
+	return base64.StdEncoding.EncodeToString(bundleAgreementBytes)
+}
+
+func TestLockAssetBundle(t *testing.T) {
+	ctx, mockStub, interopcc := prepShimMockStub()
+
+	locker := getTxCreatorECertBase64()
+	recipient := "Bob"
+	hashBase64 := generateSHA256HashInBase64Form("abcd")
+	expiryTimeSecs := uint64(time.Now().Unix()) + defaultTimeLockSecs
+	assets := []*common.AssetBundleItem{
+		{Type: "bond", Id: "A001"},
+		{Type: "bond", Id: "A002"},
+		{Type: "cbdc", NumUnits: 50},
+	}
+
+	// Test failure with an empty bundle
+	mockStub.MockTransactionStart("tx1")
+	_, err := interopcc.LockAssetBundle(ctx, getAssetBundleAgreementBase64(nil, locker, recipient), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+	require.EqualError(t, err, "empty asset bundle")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with an asset having both an ID and a number of units
+	_, err = interopcc.LockAssetBundle(ctx, getAssetBundleAgreementBase64([]*common.AssetBundleItem{{Type: "bond", Id: "A001", NumUnits: 1}}, locker, recipient), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+	require.EqualError(t, err, "asset of type bond in the asset bundle has both an ID and a number of units")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a non-fungible asset repeated in the bundle
+	_, err = interopcc.LockAssetBundle(ctx, getAssetBundleAgreementBase64([]*common.AssetBundleItem{{Type: "bond", Id: "A001"}, {Type: "bond", Id: "A001"}}, locker, recipient), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+	require.EqualError(t, err, "asset of type bond and ID A001 is repeated in the asset bundle")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with the locker not being the transaction creator
+	_, err = interopcc.LockAssetBundle(ctx, getAssetBundleAgreementBase64(assets, "Alice", recipient), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error in locker validation")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with a bundle of bonds and tokens
+	contractId, err := interopcc.LockAssetBundle(ctx, getAssetBundleAgreementBase64(assets, "", recipient), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+	require.NoError(t, err)
+	require.NotEmpty(t, contractId)
+	mockStub.MockTransactionEnd("tx1")
+	isLocked, err := interopcc.IsAssetBundleLocked(ctx, contractId)
+	require.NoError(t, err)
+	require.True(t, isLocked)
+	bundleAssetsJSON, err := interopcc.GetAssetBundleUsingContractId(ctx, contractId)
+	require.NoError(t, err)
+	bundleAssets := []AssetBundleItem{}
+	err = json.Unmarshal([]byte(bundleAssetsJSON), &bundleAssets)
+	require.NoError(t, err)
+	require.Equal(t, []AssetBundleItem{{Type: "bond", Id: "A001"}, {Type: "bond", Id: "A002"}, {Type: "cbdc", NumUnits: 50}}, bundleAssets)
+	bundleExpiryTimeSecs, err := interopcc.GetAssetBundleTimeToReleaseUsingContractId(ctx, contractId)
+	require.NoError(t, err)
+	require.Equal(t, expiryTimeSecs, bundleExpiryTimeSecs)
+
+	// Test success with the bundle listed as a single lock, whose tokens count towards the total of units locked
+	expectedLockedAssetInfo := LockedAssetInfo{ContractId: contractId, Locker: locker, Recipient: recipient, ExpiryTimeSecs: expiryTimeSecs, Assets: bundleAssets}
+	expectedLockedAssetInfoBytes, _ := json.Marshal(expectedLockedAssetInfo)
+	lockedAssets, err := interopcc.GetAllLockedAssets(ctx, recipient, locker)
+	require.NoError(t, err)
+	require.Equal(t, []string{string(expectedLockedAssetInfoBytes)}, lockedAssets)
+	lockedAssets, err = interopcc.GetAllAssetsLockedUntil(ctx, expiryTimeSecs)
+	require.NoError(t, err)
+	require.Equal(t, []string{string(expectedLockedAssetInfoBytes)}, lockedAssets)
+	lockedAssets, err = interopcc.GetAllNonFungibleLockedAssets(ctx, recipient, locker)
+	require.NoError(t, err)
+	require.Empty(t, lockedAssets)
+	numUnits, err := interopcc.GetTotalFungibleLockedAssets(ctx, "cbdc")
+	require.NoError(t, err)
+	require.Equal(t, uint64(50), numUnits)
+
+	// Test failure with a bond of the bundle locked again, on its own or in another bundle
+	bondAgreement := &common.AssetExchangeAgreement{
+		Type:      "bond",
+		Id:        "A002",
+		Recipient: recipient,
+		Locker:    locker,
+	}
+	bondAgreementBytes, _ := proto.Marshal(bondAgreement)
+	mockStub.MockTransactionStart("tx2")
+	_, err = interopcc.LockAsset(ctx, base64.StdEncoding.EncodeToString(bondAgreementBytes), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+	require.EqualError(t, err, "asset of type bond and ID A002 is already locked")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	_, err = interopcc.LockAssetBundle(ctx, getAssetBundleAgreementBase64([]*common.AssetBundleItem{{Type: "bond", Id: "A003"}, {Type: "bond", Id: "A002"}}, locker, recipient), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+	require.EqualError(t, err, "asset of type bond and ID A002 is already locked")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	mockStub.MockTransactionEnd("tx2")
+
+	// Test failure with a bond of the bundle unlocked outside the bundle
+	bondAgreementBytes, _ = proto.Marshal(bondAgreement)
+	err = interopcc.UnlockAsset(ctx, base64.StdEncoding.EncodeToString(bondAgreementBytes))
+	require.EqualError(t, err, "cannot unlock asset of type bond and ID A002 as it is locked as part of the asset bundle "+contractId)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+}
+
+func TestClaimAndUnlockAssetBundle(t *testing.T) {
+	ctx, mockStub, interopcc := prepShimMockStub()
+
+	// the transaction creator is both the locker and the recipient
+	party := getTxCreatorECertBase64()
+	preimage := "abcd"
+	hashBase64 := generateSHA256HashInBase64Form(preimage)
+	currentTimeSecs := uint64(time.Now().Unix())
+	assets := []*common.AssetBundleItem{
+		{Type: "bond", Id: "A001"},
+		{Type: "cbdc", NumUnits: 50},
+	}
+	getClaimInfoBase64 := func(preimage string) string {
+		claimInfoHTLC := &common.AssetClaimHTLC{
+			HashPreimageBase64: []byte(base64.StdEncoding.EncodeToString([]byte(preimage))),
+		}
+		claimInfoHTLCBytes, _ := proto.Marshal(claimInfoHTLC)
+		claimInfo := &common.AssetClaim{
+			LockMechanism: common.LockMechanism_HTLC,
+			ClaimInfo:     claimInfoHTLCBytes,
+		}
+		claimInfoBytes, _ := proto.Marshal(claimInfo)
+		return base64.StdEncoding.EncodeToString(claimInfoBytes)
+	}
+
+	mockStub.MockTransactionStart("tx1")
+	contractId, err := interopcc.LockAssetBundle(ctx, getAssetBundleAgreementBase64(assets, party, party), getHTLCLockInfoBase64(hashBase64, currentTimeSecs+defaultTimeLockSecs))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx1")
+
+	// Test failure with the bundle unlocked before its expiry
+	mockStub.MockTransactionStart("tx2")
+	err = interopcc.UnlockAssetBundle(ctx, contractId)
+	require.EqualError(t, err, "cannot unlock asset bundle associated with the contractId "+contractId+" as the expiry time is not yet elapsed")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a wrong preimage
+	err = interopcc.ClaimAssetBundle(ctx, contractId, getClaimInfoBase64("wxyz"))
+	require.EqualError(t, err, "cannot claim asset bundle associated with contractId "+contractId+" as the hash preimage is not matching")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with the whole bundle claimed at once
+	err = interopcc.ClaimAssetBundle(ctx, contractId, getClaimInfoBase64(preimage))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx2")
+	_, err = interopcc.IsAssetBundleLocked(ctx, contractId)
+	require.EqualError(t, err, "contractId "+contractId+" is not associated with any currently locked asset bundle")
+	assetLockKey, _, _ := generateAssetLockKeyAndContractId(ctx, &common.AssetExchangeAgreement{Type: "bond", Id: "A001"})
+	assetLockValBytes, _ := mockStub.GetState(assetLockKey)
+	require.Nil(t, assetLockValBytes)
+	hashPreimageBase64, err := interopcc.GetHTLCHashPreImage(ctx, contractId)
+	require.NoError(t, err)
+	require.Equal(t, base64.StdEncoding.EncodeToString([]byte(preimage)), hashPreimageBase64)
+	lockedAssets, err := interopcc.GetAllLockedAssets(ctx, party, party)
+	require.NoError(t, err)
+	require.Empty(t, lockedAssets)
+	numUnits, err := interopcc.GetTotalFungibleLockedAssets(ctx, "cbdc")
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), numUnits)
+
+	// Test success with the whole bundle unlocked at once after its expiry
+	mockStub.MockTransactionStart("tx3")
+	expiryTimeSecs := currentTimeSecs + defaultTimeLockSecs
+	contractId, err = interopcc.LockAssetBundle(ctx, getAssetBundleAgreementBase64(assets, party, party), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx3")
+	mockStub.MockTransactionStart("tx4")
+	mockStub.TxTimestamp = &timestamp.Timestamp{Seconds: int64(expiryTimeSecs + 1)}
+	err = interopcc.ClaimAssetBundle(ctx, contractId, getClaimInfoBase64(preimage))
+	require.EqualError(t, err, "cannot claim asset bundle associated with contractId "+contractId+" as the expiry time is already elapsed")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	err = interopcc.UnlockAssetBundle(ctx, contractId)
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx4")
+	assetLockValBytes, _ = mockStub.GetState(assetLockKey)
+	require.Nil(t, assetLockValBytes)
+	_, err = interopcc.IsAssetBundleLocked(ctx, contractId)
+	require.Error(t, err)
+	lockedAssets, err = interopcc.GetAllAssetsLockedUntil(ctx, expiryTimeSecs)
+	require.NoError(t, err)
+	require.Empty(t, lockedAssets)
+}
@@ -0,0 +1,459 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// asset_transfer contains the functions used to transfer an asset from one network to another: the network where the
+// asset resides (the source) records a pledge of the asset for a recipient on the other network (the destination); the
+// recipient claims the asset on the destination with a verified view of the pledge, and if the pledge expires without
+// being claimed, the pledger reclaims the asset on the source with a verified view of the claim status on the destination
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	localNetworkIdKey                 = "LocalNetworkId"               // ledger key for the id of the network this interop cc belongs to
+	remoteAssetTransferContractPrefix = "RemoteAssetTransferContract_" // prefix for the map, remote-network-id --> remote-asset-transfer-contract-object
+	assetPledgePrefix                 = "AssetPledge_"                 // prefix for the map, pledgeId --> asset-pledge-object
+	assetClaimStatusPrefix            = "AssetClaimStatus_"            // prefix for the map, remote-network-id + pledgeId --> asset-claim-status-object
+	assetPledgeStatusViewFunc         = "GetAssetPledgeStatus"         // function queried by a remote network for the view of a pledge
+	assetClaimStatusViewFunc          = "GetAssetClaimStatus"          // function queried by a remote network for the view of a claim status
+)
+
+// Object used to capture the chaincode, on a channel of a remote network, whose views of asset pledges and claim
+// statuses are accepted for the transfer of assets from and to that network
+type RemoteAssetTransferContract struct {
+	Channel  string `json:"channel"`
+	Contract string `json:"contract"`
+}
+
+// SetLocalNetworkId cc is used by an admin of the interop cc to record the id of the network this interop cc belongs
+// to, which asset pledges made on this network and claims of assets pledged on other networks are bound to
+func (s *SmartContract) SetLocalNetworkId(ctx contractapi.TransactionContextInterface, networkId string) error {
+	err := checkCallerIsAdmin(ctx)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	if networkId == "" {
+		return logThenErrorf("empty local network id")
+	}
+	err = ctx.GetStub().PutState(localNetworkIdKey, []byte(networkId))
+	if err != nil {
+		return logThenErrorf("failed to write to the world state: %+v", err)
+	}
+	return nil
+}
+
+// GetLocalNetworkId cc is used to query the id of the network this interop cc belongs to
+func (s *SmartContract) GetLocalNetworkId(ctx contractapi.TransactionContextInterface) (string, error) {
+	return getLocalNetworkId(ctx)
+}
+
+// function to fetch the id of the local network recorded on the ledger
+func getLocalNetworkId(ctx contractapi.TransactionContextInterface) (string, error) {
+	networkIdBytes, err := ctx.GetStub().GetState(localNetworkIdKey)
+	if err != nil {
+		return "", logThenErrorf("failed to retrieve from the world state: %+v", err)
+	}
+	if len(networkIdBytes) == 0 {
+		return "", logThenErrorf("no local network id is recorded on the ledger")
+	}
+	return string(networkIdBytes), nil
+}
+
+// SetRemoteAssetTransferContract cc is used by an admin of the interop cc to record the channel and chaincode of a
+// remote network that serve the views of asset pledges and claim statuses; claims of assets pledged on that network,
+// and reclaims of assets pledged to it, only accept views of that chaincode
+func (s *SmartContract) SetRemoteAssetTransferContract(ctx contractapi.TransactionContextInterface, remoteNetworkId string,
+	channel string, contract string) error {
+	err := checkCallerIsAdmin(ctx)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	if remoteNetworkId == "" || strings.Contains(remoteNetworkId, "/") {
+		return logThenErrorf("invalid remote network id %s", remoteNetworkId)
+	}
+	for _, segment := range []string{channel, contract} {
+		if segment == "" || strings.ContainsAny(segment, "/:") {
+			return logThenErrorf("invalid channel or contract %s of the remote network", segment)
+		}
+	}
+	remoteContractBytes, err := json.Marshal(&RemoteAssetTransferContract{Channel: channel, Contract: contract})
+	if err != nil {
+		return logThenErrorf("marshal error: %+v", err)
+	}
+	err = ctx.GetStub().PutState(remoteAssetTransferContractPrefix+remoteNetworkId, remoteContractBytes)
+	if err != nil {
+		return logThenErrorf("failed to write to the world state: %+v", err)
+	}
+	return nil
+}
+
+// GetRemoteAssetTransferContract cc is used to query the channel and chaincode of a remote network whose asset
+// transfer views are accepted
+func (s *SmartContract) GetRemoteAssetTransferContract(ctx contractapi.TransactionContextInterface, remoteNetworkId string) (*RemoteAssetTransferContract, error) {
+	return getRemoteAssetTransferContract(ctx, remoteNetworkId)
+}
+
+// function to fetch the channel and chaincode of a remote network whose asset transfer views are accepted
+func getRemoteAssetTransferContract(ctx contractapi.TransactionContextInterface, remoteNetworkId string) (*RemoteAssetTransferContract, error) {
+	remoteContractBytes, err := ctx.GetStub().GetState(remoteAssetTransferContractPrefix + remoteNetworkId)
+	if err != nil {
+		return nil, logThenErrorf("failed to retrieve from the world state: %+v", err)
+	}
+	if remoteContractBytes == nil {
+		return nil, logThenErrorf("no asset transfer contract is recorded for the network %s", remoteNetworkId)
+	}
+	remoteContract := &RemoteAssetTransferContract{}
+	err = json.Unmarshal(remoteContractBytes, remoteContract)
+	if err != nil {
+		return nil, logThenErrorf("unmarshal error: %+v", err)
+	}
+	return remoteContract, nil
+}
+
+// function to generate the pledge-id of an asset pledge; the id is hex encoded so that it can be used as an argument
+// in the address of a view (which cannot contain the '/' and ':' separators)
+func generateAssetPledgeId(ctx contractapi.TransactionContextInterface, assetPledgeBytes []byte) string {
+	pledgeIdHash := sha256.Sum256(append(assetPledgeBytes, []byte(ctx.GetStub().GetTxID())...))
+	return hex.EncodeToString(pledgeIdHash[:])
+}
+
+func generateAssetPledgeMapKey(pledgeId string) string {
+	return assetPledgePrefix + pledgeId
+}
+
+func generateAssetClaimStatusMapKey(remoteNetworkId string, pledgeId string) string {
+	return assetClaimStatusPrefix + remoteNetworkId + "_" + pledgeId
+}
+
+// function to check that a view is from the asset transfer contract recorded for the given remote network and that it
+// is the result of the given function queried for the given pledge, so that neither the view of a different query nor
+// that of another chaincode (with a function of the same name) can be passed off as that of the pledge
+func validateAssetTransferViewAddress(ctx contractapi.TransactionContextInterface, address string, remoteNetworkId string,
+	viewFunc string, pledgeId string) error {
+	remoteContract, err := getRemoteAssetTransferContract(ctx, remoteNetworkId)
+	if err != nil {
+		return err
+	}
+	addressStruct, err := parseAddress(address)
+	if err != nil {
+		return fmt.Errorf("unable to parse view address: %+v", err)
+	}
+	if addressStruct.LedgerSegment != remoteNetworkId {
+		return fmt.Errorf("view address %s does not refer to the network %s", address, remoteNetworkId)
+	}
+	viewAddress, err := parseFabricViewAddress(addressStruct.ViewSegment)
+	if err != nil {
+		return fmt.Errorf("unable to parse view address: %+v", err)
+	}
+	if viewAddress.Channel != remoteContract.Channel || viewAddress.Contract != remoteContract.Contract {
+		return fmt.Errorf("view address %s does not refer to the asset transfer contract %s:%s of the network %s", address,
+			remoteContract.Channel, remoteContract.Contract, remoteNetworkId)
+	}
+	if viewAddress.CCFunc != viewFunc || len(viewAddress.Args) == 0 || viewAddress.Args[0] != pledgeId {
+		return fmt.Errorf("view address %s does not refer to %s for the pledge %s", address, viewFunc, pledgeId)
+	}
+	return nil
+}
+
+// function to verify a view against its address, and to extract the (base64 encoded) serialized message it carries
+func (s *SmartContract) parseAndValidateAssetTransferView(ctx contractapi.TransactionContextInterface, address string,
+	b64ViewProto string, message proto.Message) error {
+	viewData, err := s.ParseAndValidateView(ctx, address, b64ViewProto)
+	if err != nil {
+		return err
+	}
+	messageBytes, err := base64.StdEncoding.DecodeString(string(viewData))
+	if err != nil {
+		return fmt.Errorf("error in base64 decode of view data: %+v", err)
+	}
+	err = proto.Unmarshal(messageBytes, message)
+	if err != nil {
+		return fmt.Errorf("unmarshal error: %s", err)
+	}
+	return nil
+}
+
+// function to fetch the asset pledge recorded on the ledger against a pledge-id
+func fetchAssetPledge(ctx contractapi.TransactionContextInterface, pledgeId string) (*common.AssetPledge, error) {
+	assetPledgeBytes, err := ctx.GetStub().GetState(generateAssetPledgeMapKey(pledgeId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve from the world state: %+v", err)
+	}
+	if assetPledgeBytes == nil {
+		return nil, fmt.Errorf("no asset is pledged with pledgeId %s", pledgeId)
+	}
+	assetPledge := &common.AssetPledge{}
+	err = proto.Unmarshal(assetPledgeBytes, assetPledge)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal error: %s", err)
+	}
+	return assetPledge, nil
+}
+
+// PledgeAsset cc is used to record the pledge of an asset on the local network for its transfer to a recipient on a
+// remote network; the pledger is the transaction creator, the local network id of the pledge defaults to the one
+// recorded on the ledger, and the pledge-id is returned
+func (s *SmartContract) PledgeAsset(ctx contractapi.TransactionContextInterface, assetPledgeBytesBase64 string) (string, error) {
+	assetPledgeBytes, err := base64.StdEncoding.DecodeString(assetPledgeBytesBase64)
+	if err != nil {
+		return "", logThenErrorf("error in base64 decode of asset pledge: %+v", err)
+	}
+	assetPledge := &common.AssetPledge{}
+	err = proto.Unmarshal(assetPledgeBytes, assetPledge)
+	if err != nil {
+		return "", logThenErrorf("unmarshal error: %s", err)
+	}
+	log.Infof("assetPledge: %+v", assetPledge)
+
+	if assetPledge.AssetType == "" || len(assetPledge.AssetDetails) == 0 {
+		return "", logThenErrorf("empty asset type or details in the asset pledge")
+	}
+	localNetworkId, err := getLocalNetworkId(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(assetPledge.LocalNetworkID) == 0 {
+		assetPledge.LocalNetworkID = localNetworkId
+	} else if assetPledge.LocalNetworkID != localNetworkId {
+		return "", logThenErrorf("local network id %s in the asset pledge is not the local network id %s recorded on the ledger", assetPledge.LocalNetworkID, localNetworkId)
+	}
+	if assetPledge.RemoteNetworkID == "" {
+		return "", logThenErrorf("empty remote network id in the asset pledge")
+	}
+	if assetPledge.LocalNetworkID == assetPledge.RemoteNetworkID {
+		return "", logThenErrorf("remote network id %s in the asset pledge is same as the local network id", assetPledge.RemoteNetworkID)
+	}
+	if assetPledge.Recipient == "" {
+		return "", logThenErrorf("empty recipient in the asset pledge")
+	}
+	txCreatorECertBase64, err := getECertOfTxCreatorBase64(ctx)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	if len(assetPledge.Pledger) == 0 {
+		assetPledge.Pledger = txCreatorECertBase64
+	} else if assetPledge.Pledger != txCreatorECertBase64 {
+		return "", logThenErrorf("pledger %s in the asset pledge is not same as the transaction creator %s", assetPledge.Pledger, txCreatorECertBase64)
+	}
+	currentTimeSecs, err := getTxTimeSecs(ctx)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	if assetPledge.ExpiryTimeSecs <= currentTimeSecs {
+		return "", logThenErrorf("pledge expiry time %d is not after the transaction time %d", assetPledge.ExpiryTimeSecs, currentTimeSecs)
+	}
+
+	assetPledgeBytes, err = proto.Marshal(assetPledge)
+	if err != nil {
+		return "", logThenErrorf("marshal error: %+v", err)
+	}
+	pledgeId := generateAssetPledgeId(ctx, assetPledgeBytes)
+	err = ctx.GetStub().PutState(generateAssetPledgeMapKey(pledgeId), assetPledgeBytes)
+	if err != nil {
+		return "", logThenErrorf("failed to write to the world state: %+v", err)
+	}
+	log.Infof("asset of type %s pledged for transfer to network %s with pledgeId %s", assetPledge.AssetType, assetPledge.RemoteNetworkID, pledgeId)
+
+	return pledgeId, nil
+}
+
+// GetAssetPledgeStatus cc is used (by a remote network, through a view) to query an asset pledge; it returns the
+// serialized pledge in base64 form, which is empty if no asset is pledged with the pledge-id (or if it was reclaimed)
+func (s *SmartContract) GetAssetPledgeStatus(ctx contractapi.TransactionContextInterface, pledgeId string) (string, error) {
+	assetPledgeBytes, err := ctx.GetStub().GetState(generateAssetPledgeMapKey(pledgeId))
+	if err != nil {
+		return "", logThenErrorf("failed to retrieve from the world state: %+v", err)
+	}
+	return base64.StdEncoding.EncodeToString(assetPledgeBytes), nil
+}
+
+/*
+ * ClaimRemoteAsset cc is used by the recipient of an asset pledged on a remote network to claim the asset on the local
+ * network. The pledge is read from a view (from the asset transfer contract recorded for the remote network) of
+ * GetAssetPledgeStatus queried for the pledge-id;
+ * the pledge needs to be meant for the transaction creator on the local network (whose id is recorded on the ledger),
+ * and it should not have expired. The
+ * claim is recorded so that the asset cannot be claimed again (nor reclaimed on the remote network), and the pledge is
+ * returned (as serialized base64 form) for the application to recreate the asset from its details.
+ */
+func (s *SmartContract) ClaimRemoteAsset(ctx contractapi.TransactionContextInterface, pledgeId string, remoteNetworkId string,
+	pledgeViewAddress string, pledgeViewB64 string) (string, error) {
+	localNetworkId, err := getLocalNetworkId(ctx)
+	if err != nil {
+		return "", err
+	}
+	claimStatusKey := generateAssetClaimStatusMapKey(remoteNetworkId, pledgeId)
+	claimStatusBytes, err := ctx.GetStub().GetState(claimStatusKey)
+	if err != nil {
+		return "", logThenErrorf("failed to retrieve from the world state: %+v", err)
+	}
+	if claimStatusBytes != nil {
+		return "", logThenErrorf("asset pledged with pledgeId %s on network %s has already been claimed", pledgeId, remoteNetworkId)
+	}
+
+	err = validateAssetTransferViewAddress(ctx, pledgeViewAddress, remoteNetworkId, assetPledgeStatusViewFunc, pledgeId)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	assetPledge := &common.AssetPledge{}
+	err = s.parseAndValidateAssetTransferView(ctx, pledgeViewAddress, pledgeViewB64, assetPledge)
+	if err != nil {
+		return "", logThenErrorf("error in validating the view of the asset pledge: %+v", err)
+	}
+	log.Infof("assetPledge: %+v", assetPledge)
+
+	if assetPledge.LocalNetworkID != remoteNetworkId || assetPledge.RemoteNetworkID != localNetworkId {
+		return "", logThenErrorf("asset pledged with pledgeId %s is not meant for transfer from network %s to network %s", pledgeId, remoteNetworkId, localNetworkId)
+	}
+	txCreatorECertBase64, err := getECertOfTxCreatorBase64(ctx)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	if assetPledge.Recipient != txCreatorECertBase64 {
+		return "", logThenErrorf("recipient %s in the asset pledge is not same as the transaction creator %s", assetPledge.Recipient, txCreatorECertBase64)
+	}
+	expired, err := isLockExpired(ctx, assetPledge.ExpiryTimeSecs)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	if expired {
+		return "", logThenErrorf("cannot claim asset pledged with pledgeId %s as the pledge has expired", pledgeId)
+	}
+
+	claimStatus := &common.AssetClaimStatus{
+		AssetType:       assetPledge.AssetType,
+		AssetDetails:    assetPledge.AssetDetails,
+		LocalNetworkID:  localNetworkId,
+		RemoteNetworkID: remoteNetworkId,
+		Recipient:       txCreatorECertBase64,
+		ClaimStatus:     true,
+		ExpiryTimeSecs:  assetPledge.ExpiryTimeSecs,
+	}
+	claimStatusBytes, err = proto.Marshal(claimStatus)
+	if err != nil {
+		return "", logThenErrorf("marshal error: %+v", err)
+	}
+	err = ctx.GetStub().PutState(claimStatusKey, claimStatusBytes)
+	if err != nil {
+		return "", logThenErrorf("failed to write to the world state: %+v", err)
+	}
+
+	assetPledgeBytes, err := proto.Marshal(assetPledge)
+	if err != nil {
+		return "", logThenErrorf("marshal error: %+v", err)
+	}
+	return base64.StdEncoding.EncodeToString(assetPledgeBytes), nil
+}
+
+// GetAssetClaimStatus cc is used (by a remote network, through a view) to query whether an asset pledged on that network
+// has been claimed on the local network; it returns the serialized claim status in base64 form. The status is built from
+// the ledger alone: the claim, if one has been made, and the latest pledge expiry time that has elapsed on this network.
+func (s *SmartContract) GetAssetClaimStatus(ctx contractapi.TransactionContextInterface, pledgeId string, remoteNetworkId string) (string, error) {
+	localNetworkId, err := getLocalNetworkId(ctx)
+	if err != nil {
+		return "", err
+	}
+	claimStatus := &common.AssetClaimStatus{
+		LocalNetworkID:  localNetworkId,
+		RemoteNetworkID: remoteNetworkId,
+	}
+	claimStatusBytes, err := ctx.GetStub().GetState(generateAssetClaimStatusMapKey(remoteNetworkId, pledgeId))
+	if err != nil {
+		return "", logThenErrorf("failed to retrieve from the world state: %+v", err)
+	}
+	if claimStatusBytes != nil {
+		err = proto.Unmarshal(claimStatusBytes, claimStatus)
+		if err != nil {
+			return "", logThenErrorf("unmarshal error: %s", err)
+		}
+		claimStatus.ExpirationStatus, err = isLockExpired(ctx, claimStatus.ExpiryTimeSecs)
+		if err != nil {
+			return "", logThenErrorf(err.Error())
+		}
+	}
+	claimStatus.StatusTimeSecs, err = getLatestExpiredTimeSecs(ctx)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	claimStatusBytes, err = proto.Marshal(claimStatus)
+	if err != nil {
+		return "", logThenErrorf("marshal error: %+v", err)
+	}
+	return base64.StdEncoding.EncodeToString(claimStatusBytes), nil
+}
+
+/*
+ * ReclaimAsset cc is used by the pledger to reclaim an asset whose pledge has expired without being claimed. The claim
+ * status is read from a view (from the asset transfer contract recorded for the network the asset was pledged to) of
+ * GetAssetClaimStatus queried for the pledge-id;
+ * it needs to show that the asset was not claimed and that the pledge expiry time has elapsed on that network too, so that
+ * it cannot be claimed any more. The pledge is deleted, and returned (as serialized base64 form) for the application to restore the asset.
+ */
+func (s *SmartContract) ReclaimAsset(ctx contractapi.TransactionContextInterface, pledgeId string, claimStatusViewAddress string,
+	claimStatusViewB64 string) (string, error) {
+	assetPledge, err := fetchAssetPledge(ctx, pledgeId)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	txCreatorECertBase64, err := getECertOfTxCreatorBase64(ctx)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	if assetPledge.Pledger != txCreatorECertBase64 {
+		return "", logThenErrorf("cannot reclaim asset pledged with pledgeId %s as the caller is not the pledger", pledgeId)
+	}
+	expired, err := isLockExpired(ctx, assetPledge.ExpiryTimeSecs)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	if !expired {
+		return "", logThenErrorf("cannot reclaim asset pledged with pledgeId %s as the pledge has not expired yet", pledgeId)
+	}
+
+	err = validateAssetTransferViewAddress(ctx, claimStatusViewAddress, assetPledge.RemoteNetworkID, assetClaimStatusViewFunc, pledgeId)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	claimStatus := &common.AssetClaimStatus{}
+	err = s.parseAndValidateAssetTransferView(ctx, claimStatusViewAddress, claimStatusViewB64, claimStatus)
+	if err != nil {
+		return "", logThenErrorf("error in validating the view of the asset claim status: %+v", err)
+	}
+	log.Infof("assetClaimStatus: %+v", claimStatus)
+
+	if claimStatus.LocalNetworkID != assetPledge.RemoteNetworkID || claimStatus.RemoteNetworkID != assetPledge.LocalNetworkID {
+		return "", logThenErrorf("asset claim status does not match the asset pledged with pledgeId %s", pledgeId)
+	}
+	if claimStatus.ClaimStatus {
+		return "", logThenErrorf("cannot reclaim asset pledged with pledgeId %s as it has been claimed on network %s", pledgeId, assetPledge.RemoteNetworkID)
+	}
+	if claimStatus.StatusTimeSecs < assetPledge.ExpiryTimeSecs {
+		return "", logThenErrorf("cannot reclaim asset pledged with pledgeId %s as the pledge has not expired on network %s", pledgeId, assetPledge.RemoteNetworkID)
+	}
+
+	err = ctx.GetStub().DelState(generateAssetPledgeMapKey(pledgeId))
+	if err != nil {
+		return "", logThenErrorf("failed to delete asset pledge from the world state: %+v", err)
+	}
+
+	assetPledgeBytes, err := proto.Marshal(assetPledge)
+	if err != nil {
+		return "", logThenErrorf("marshal error: %+v", err)
+	}
+	return base64.StdEncoding.EncodeToString(assetPledgeBytes), nil
+}
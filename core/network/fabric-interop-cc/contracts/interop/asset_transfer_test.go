@@ -0,0 +1,318 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/fabric"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/core/network/fabric-interop-cc/contracts/interop/mocks"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/require"
+	protoV2 "google.golang.org/protobuf/proto"
+)
+
+// function that supplies a Fabric view of the given payload, notarized by an endorser of the given organization
+func getNotarizedFabricViewBase64(t *testing.T, key *ecdsa.PrivateKey, certPEM []byte, mspId string, address string, payload string) string {
+	interopPayloadBytes, err := protoV2.Marshal(&common.InteropPayload{Payload: []byte(payload), Address: address})
+	require.NoError(t, err)
+	response := &peer.Response{Status: 200, Payload: interopPayloadBytes}
+	chaincodeActionBytes, err := proto.Marshal(&peer.ChaincodeAction{Response: response})
+	require.NoError(t, err)
+	proposalResponsePayload := &peer.ProposalResponsePayload{Extension: chaincodeActionBytes}
+	proposalResponsePayloadBytes, err := proto.Marshal(proposalResponsePayload)
+	require.NoError(t, err)
+	endorser, err := proto.Marshal(&msp.SerializedIdentity{Mspid: mspId, IdBytes: certPEM})
+	require.NoError(t, err)
+	hashed, err := computeSHA2Hash(append(proposalResponsePayloadBytes, endorser...), 256)
+	require.NoError(t, err)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hashed)
+	require.NoError(t, err)
+
+	viewData, err := protoV2.Marshal(&fabric.FabricView{
+		Response:                response,
+		ProposalResponsePayload: proposalResponsePayload,
+		Endorsements:            []*peer.Endorsement{{Endorser: endorser, Signature: signature}},
+	})
+	require.NoError(t, err)
+	viewBytes, err := protoV2.Marshal(&common.View{
+		Meta: &common.Meta{Protocol: common.Meta_FABRIC, ProofType: "Notarization", SerializationFormat: "STRING"},
+		Data: viewData,
+	})
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(viewBytes)
+}
+
+// function that records the membership and verification policy of a remote network, whose views are notarized by
+// an endorser of the given organization holding the given (self-signed) certificate
+func recordRemoteNetworkConfig(t *testing.T, ctx *mocks.TransactionContext, mockStub *shimtest.MockStub, interopcc SmartContract,
+	securityDomain string, mspId string, certPEM []byte) {
+	membershipBytes, _ := json.Marshal(&common.Membership{
+		SecurityDomain: securityDomain,
+		Members:        map[string]*common.Member{mspId: {Value: string(certPEM), Type: "ca", Chain: []string{}}},
+	})
+	verificationPolicyBytes, _ := json.Marshal(&common.VerificationPolicy{
+		SecurityDomain: securityDomain,
+		Identifiers: []*common.Identifier{{
+			Pattern: "mychannel:simpleasset:*",
+			Policy:  &common.Policy{Criteria: []string{mspId}, Type: "signature"},
+		}},
+	})
+	mockStub.MockTransactionStart("config")
+	require.NoError(t, interopcc.CreateMembership(ctx, string(membershipBytes)))
+	require.NoError(t, interopcc.CreateVerificationPolicy(ctx, string(verificationPolicyBytes)))
+	mockStub.MockTransactionEnd("config")
+}
+
+func TestSetLocalNetworkId(t *testing.T) {
+	ctx, chaincodeStub, interopcc := prepMockStub()
+	clientIdentity := ctx.GetClientIdentity().(*mocks.ClientIdentity)
+
+	// Test failure with the caller not being an admin, or an empty network id
+	err := interopcc.SetLocalNetworkId(ctx, "network1")
+	require.EqualError(t, err, "caller is not an admin of the organization "+myOrg1Msp)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	clientIdentity.GetX509CertificateReturns(&x509.Certificate{Subject: pkix.Name{OrganizationalUnit: []string{"admin"}}}, nil)
+	err = interopcc.SetLocalNetworkId(ctx, "")
+	require.EqualError(t, err, "empty local network id")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with the network id recorded by an admin and read back from the ledger
+	err = interopcc.SetLocalNetworkId(ctx, "network1")
+	require.NoError(t, err)
+	key, value := chaincodeStub.PutStateArgsForCall(0)
+	require.Equal(t, localNetworkIdKey, key)
+	require.Equal(t, "network1", string(value))
+	ctx.GetStub().(*chaincodeStubWithConfig).config[localNetworkIdKey] = value
+	networkId, err := interopcc.GetLocalNetworkId(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "network1", networkId)
+}
+
+func TestSetRemoteAssetTransferContract(t *testing.T) {
+	ctx, chaincodeStub, interopcc := prepMockStub()
+	clientIdentity := ctx.GetClientIdentity().(*mocks.ClientIdentity)
+
+	// Test failure with the caller not being an admin, or an invalid network id, channel or contract
+	err := interopcc.SetRemoteAssetTransferContract(ctx, "network2", "mychannel", "simpleasset")
+	require.EqualError(t, err, "caller is not an admin of the organization "+myOrg1Msp)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	clientIdentity.GetX509CertificateReturns(&x509.Certificate{Subject: pkix.Name{OrganizationalUnit: []string{"admin"}}}, nil)
+	err = interopcc.SetRemoteAssetTransferContract(ctx, "", "mychannel", "simpleasset")
+	require.EqualError(t, err, "invalid remote network id ")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	err = interopcc.SetRemoteAssetTransferContract(ctx, "network2", "mychannel", "simpleasset:GetAssetPledgeStatus")
+	require.EqualError(t, err, "invalid channel or contract simpleasset:GetAssetPledgeStatus of the remote network")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with no contract recorded for the network
+	_, err = interopcc.GetRemoteAssetTransferContract(ctx, "network2")
+	require.EqualError(t, err, "no asset transfer contract is recorded for the network network2")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with the contract recorded by an admin and read back from the ledger
+	err = interopcc.SetRemoteAssetTransferContract(ctx, "network2", "mychannel", "simpleasset")
+	require.NoError(t, err)
+	key, value := chaincodeStub.PutStateArgsForCall(0)
+	require.Equal(t, remoteAssetTransferContractPrefix+"network2", key)
+	chaincodeStub.GetStateReturns(value, nil)
+	remoteContract, err := interopcc.GetRemoteAssetTransferContract(ctx, "network2")
+	require.NoError(t, err)
+	require.Equal(t, &RemoteAssetTransferContract{Channel: "mychannel", Contract: "simpleasset"}, remoteContract)
+}
+
+func TestAssetTransfer(t *testing.T) {
+	// the interop cc of the source network (network1) and of the destination network (network2)
+	sourceCtx, sourceStub, sourceInteropcc := prepShimMockStub()
+	destCtx, destStub, destInteropcc := prepShimMockStub()
+
+	template := x509.Certificate{
+		Subject:      pkix.Name{CommonName: "peer0.org1.example.com"},
+		SerialNumber: big.NewInt(1337),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+	sourceCertBytes, sourceKey, err := createECDSACertAndKeyFromTemplate(template)
+	require.NoError(t, err)
+	sourceCertPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: sourceCertBytes})
+	destCertBytes, destKey, err := createECDSACertAndKeyFromTemplate(template)
+	require.NoError(t, err)
+	destCertPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: destCertBytes})
+	recordRemoteNetworkConfig(t, destCtx, destStub, destInteropcc, "network1", "Org1MSP", sourceCertPEM)
+	recordRemoteNetworkConfig(t, sourceCtx, sourceStub, sourceInteropcc, "network2", "Org2MSP", destCertPEM)
+
+	// Test failure with no local network id recorded on the ledger
+	_, err = destInteropcc.GetAssetClaimStatus(destCtx, "pledge-id", "network1")
+	require.EqualError(t, err, "no local network id is recorded on the ledger")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	sourceStub.MockTransactionStart("network-id")
+	require.NoError(t, sourceStub.PutState(localNetworkIdKey, []byte("network1")))
+	sourceStub.MockTransactionEnd("network-id")
+	destStub.MockTransactionStart("network-id")
+	require.NoError(t, destStub.PutState(localNetworkIdKey, []byte("network2")))
+	destStub.MockTransactionEnd("network-id")
+
+	// the caller pledges the asset on the source network for itself on the destination network
+	party := getTxCreatorECertBase64()
+	currentTimeSecs := uint64(time.Now().Unix())
+	expiryTimeSecs := currentTimeSecs + defaultTimeLockSecs
+	pledge := func(txId string, localNetworkId string, expiryTimeSecs uint64) (string, error) {
+		assetPledgeBytes, _ := proto.Marshal(&common.AssetPledge{
+			AssetType:       "bond",
+			AssetDetails:    []byte(`{"id":"A001","owner":"Alice"}`),
+			LocalNetworkID:  localNetworkId,
+			RemoteNetworkID: "network2",
+			Recipient:       party,
+			ExpiryTimeSecs:  expiryTimeSecs,
+		})
+		sourceStub.MockTransactionStart(txId)
+		defer sourceStub.MockTransactionEnd(txId)
+		return sourceInteropcc.PledgeAsset(sourceCtx, base64.StdEncoding.EncodeToString(assetPledgeBytes))
+	}
+	pledgeViewOfContract := func(pledgeId string, contract string, viewFunc string, key *ecdsa.PrivateKey) (string, string) {
+		address := fmt.Sprintf("localhost:9080/network1/mychannel:%s:%s:%s", contract, viewFunc, pledgeId)
+		assetPledgeBase64, err := sourceInteropcc.GetAssetPledgeStatus(sourceCtx, pledgeId)
+		require.NoError(t, err)
+		return address, getNotarizedFabricViewBase64(t, key, sourceCertPEM, "Org1MSP", address, assetPledgeBase64)
+	}
+	pledgeView := func(pledgeId string, viewFunc string, key *ecdsa.PrivateKey) (string, string) {
+		return pledgeViewOfContract(pledgeId, "simpleasset", viewFunc, key)
+	}
+	claim := func(txId string, pledgeId string, remoteNetworkId string, address string, viewB64 string) (string, error) {
+		destStub.MockTransactionStart(txId)
+		defer destStub.MockTransactionEnd(txId)
+		return destInteropcc.ClaimRemoteAsset(destCtx, pledgeId, remoteNetworkId, address, viewB64)
+	}
+	claimStatusView := func(pledgeId string, timeSecs uint64) (string, string) {
+		address := fmt.Sprintf("localhost:9080/network2/mychannel:simpleasset:GetAssetClaimStatus:%s:network1", pledgeId)
+		destStub.MockTransactionStart("query")
+		destStub.TxTimestamp = &timestamp.Timestamp{Seconds: int64(timeSecs)}
+		claimStatusBase64, err := destInteropcc.GetAssetClaimStatus(destCtx, pledgeId, "network1")
+		require.NoError(t, err)
+		destStub.MockTransactionEnd("query")
+		return address, getNotarizedFabricViewBase64(t, destKey, destCertPEM, "Org2MSP", address, claimStatusBase64)
+	}
+	reclaim := func(txId string, pledgeId string, address string, viewB64 string) (string, error) {
+		sourceStub.MockTransactionStart(txId)
+		sourceStub.TxTimestamp = &timestamp.Timestamp{Seconds: int64(expiryTimeSecs + 1)}
+		defer sourceStub.MockTransactionEnd(txId)
+		return sourceInteropcc.ReclaimAsset(sourceCtx, pledgeId, address, viewB64)
+	}
+
+	// Test failure with a pledge that has already expired, or whose local network is not the one recorded on the ledger
+	_, err = pledge("tx1", "network1", currentTimeSecs-1)
+	require.True(t, strings.HasPrefix(err.Error(), fmt.Sprintf("pledge expiry time %d is not after the transaction time", currentTimeSecs-1)))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	_, err = pledge("tx2", "network2", expiryTimeSecs)
+	require.EqualError(t, err, "local network id network2 in the asset pledge is not the local network id network1 recorded on the ledger")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with the asset pledged on the source network, its local network id defaulting to the one on the ledger
+	pledgeId, err := pledge("tx3", "", expiryTimeSecs)
+	require.NoError(t, err)
+	assetPledge, err := fetchAssetPledge(sourceCtx, pledgeId)
+	require.NoError(t, err)
+	require.Equal(t, party, assetPledge.Pledger)
+	require.Equal(t, "network1", assetPledge.LocalNetworkID)
+
+	// Test failure with no asset transfer contract recorded for the source network on the destination network
+	address, viewB64 := pledgeView(pledgeId, "GetAssetPledgeStatus", sourceKey)
+	_, err = claim("tx4", pledgeId, "network1", address, viewB64)
+	require.EqualError(t, err, "no asset transfer contract is recorded for the network network1")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	remoteContractBytes, _ := json.Marshal(&RemoteAssetTransferContract{Channel: "mychannel", Contract: "simpleasset"})
+	sourceStub.MockTransactionStart("remote-contract")
+	require.NoError(t, sourceStub.PutState(remoteAssetTransferContractPrefix+"network2", remoteContractBytes))
+	sourceStub.MockTransactionEnd("remote-contract")
+	destStub.MockTransactionStart("remote-contract")
+	require.NoError(t, destStub.PutState(remoteAssetTransferContractPrefix+"network1", remoteContractBytes))
+	destStub.MockTransactionEnd("remote-contract")
+
+	// Test failure with a view of another chaincode (with a function of the same name)
+	address, viewB64 = pledgeViewOfContract(pledgeId, "othercc", "GetAssetPledgeStatus", sourceKey)
+	_, err = claim("tx4", pledgeId, "network1", address, viewB64)
+	require.EqualError(t, err, fmt.Sprintf("view address %s does not refer to the asset transfer contract mychannel:simpleasset of the network network1", address))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a view of a different query, a view notarized by an unknown endorser, or a pledge meant for another network
+	address, viewB64 = pledgeView(pledgeId, "GetAssetClaimStatus", sourceKey)
+	_, err = claim("tx4", pledgeId, "network1", address, viewB64)
+	require.EqualError(t, err, fmt.Sprintf("view address %s does not refer to GetAssetPledgeStatus for the pledge %s", address, pledgeId))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	address, viewB64 = pledgeView(pledgeId, "GetAssetPledgeStatus", destKey)
+	_, err = claim("tx5", pledgeId, "network1", address, viewB64)
+	require.True(t, strings.HasPrefix(err.Error(), "error in validating the view of the asset pledge"))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	address, viewB64 = pledgeView(pledgeId, "GetAssetPledgeStatus", sourceKey)
+	destStub.MockTransactionStart("network-id")
+	require.NoError(t, destStub.PutState(localNetworkIdKey, []byte("network3")))
+	destStub.MockTransactionEnd("network-id")
+	_, err = claim("tx6", pledgeId, "network1", address, viewB64)
+	require.EqualError(t, err, fmt.Sprintf("asset pledged with pledgeId %s is not meant for transfer from network network1 to network network3", pledgeId))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	destStub.MockTransactionStart("network-id")
+	require.NoError(t, destStub.PutState(localNetworkIdKey, []byte("network2")))
+	destStub.MockTransactionEnd("network-id")
+
+	// Test success with the asset claimed on the destination network, and failure with it claimed again
+	assetPledgeBase64, err := claim("tx7", pledgeId, "network1", address, viewB64)
+	require.NoError(t, err)
+	claimedAssetPledgeBytes, _ := base64.StdEncoding.DecodeString(assetPledgeBase64)
+	claimedAssetPledge := &common.AssetPledge{}
+	require.NoError(t, proto.Unmarshal(claimedAssetPledgeBytes, claimedAssetPledge))
+	require.True(t, proto.Equal(assetPledge, claimedAssetPledge))
+	_, err = claim("tx8", pledgeId, "network1", address, viewB64)
+	require.EqualError(t, err, fmt.Sprintf("asset pledged with pledgeId %s on network network1 has already been claimed", pledgeId))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with the claimed asset reclaimed, before or after the pledge expiry
+	address, viewB64 = claimStatusView(pledgeId, expiryTimeSecs+1)
+	sourceStub.MockTransactionStart("tx9")
+	_, err = sourceInteropcc.ReclaimAsset(sourceCtx, pledgeId, address, viewB64)
+	require.EqualError(t, err, fmt.Sprintf("cannot reclaim asset pledged with pledgeId %s as the pledge has not expired yet", pledgeId))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	sourceStub.MockTransactionEnd("tx9")
+	_, err = reclaim("tx10", pledgeId, address, viewB64)
+	require.EqualError(t, err, fmt.Sprintf("cannot reclaim asset pledged with pledgeId %s as it has been claimed on network network2", pledgeId))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with an unclaimed asset reclaimed before the pledge has expired on the destination network
+	pledgeId, err = pledge("tx11", "network1", expiryTimeSecs)
+	require.NoError(t, err)
+	address, viewB64 = claimStatusView(pledgeId, currentTimeSecs)
+	_, err = reclaim("tx12", pledgeId, address, viewB64)
+	require.EqualError(t, err, fmt.Sprintf("cannot reclaim asset pledged with pledgeId %s as the pledge has not expired on network network2", pledgeId))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with an unclaimed asset reclaimed after the pledge has expired, after which it can no longer be claimed
+	address, viewB64 = claimStatusView(pledgeId, expiryTimeSecs+1)
+	assetPledgeBase64, err = reclaim("tx13", pledgeId, address, viewB64)
+	require.NoError(t, err)
+	require.NotEmpty(t, assetPledgeBase64)
+	assetPledgeBase64, err = sourceInteropcc.GetAssetPledgeStatus(sourceCtx, pledgeId)
+	require.NoError(t, err)
+	require.Empty(t, assetPledgeBase64)
+	_, err = reclaim("tx14", pledgeId, address, viewB64)
+	require.EqualError(t, err, fmt.Sprintf("no asset is pledged with pledgeId %s", pledgeId))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+}
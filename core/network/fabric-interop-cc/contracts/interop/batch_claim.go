@@ -0,0 +1,73 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// batch_claim contains the functions used by a recipient to claim, in a single transaction, several HTLCs locked with
+// the same hash (e.g., the legs of a multi-leg swap that land on this ledger)
+package main
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// function to check that a claim can be applied to every lock of a batch: it needs to be an HTLC claim of the whole lock
+func validateBatchClaimInfo(claimInfo *common.AssetClaim) error {
+	if claimInfo.LockMechanism != common.LockMechanism_HTLC {
+		return logThenErrorf("batch claims are only supported for the HTLC lock mechanism")
+	}
+	claimInfoHTLC := &common.AssetClaimHTLC{}
+	err := proto.Unmarshal(claimInfo.ClaimInfo, claimInfoHTLC)
+	if err != nil {
+		return logThenErrorf("unmarshal claimInfo.ClaimInfo error: %s", err)
+	}
+	if claimInfoHTLC.NumUnits != 0 {
+		return logThenErrorf("a batch claim cannot claim a subset of the locked units")
+	}
+	return nil
+}
+
+// ClaimAssetsUsingContractIds cc is used to claim atomically the HTLCs (fungible, non-fungible and asset bundles)
+// associated with contractIds, using the hash preimage in claimInfo. The caller needs to be the recipient of each lock;
+// if any of them cannot be claimed (e.g., it has expired or is locked with a different hash), the whole transaction fails.
+func (s *SmartContract) ClaimAssetsUsingContractIds(ctx contractapi.TransactionContextInterface, contractIds []string, claimInfoBytesBase64 string) error {
+	if len(contractIds) == 0 {
+		return logThenErrorf("empty list of contractIds")
+	}
+	seenContractIds := map[string]bool{}
+	for _, contractId := range contractIds {
+		if seenContractIds[contractId] {
+			return logThenErrorf("contractId %s is listed more than once", contractId)
+		}
+		seenContractIds[contractId] = true
+	}
+	claimInfo, err := getClaimInfo(claimInfoBytesBase64)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	err = validateBatchClaimInfo(claimInfo)
+	if err != nil {
+		return err
+	}
+
+	// the ledger updates of the claims made so far are discarded along with the transaction if a later claim fails
+	for _, contractId := range contractIds {
+		lockKind, err := getLockKindOfContractId(ctx, contractId)
+		if err == nil {
+			if lockKind == nonFungibleLockKind {
+				err = s.ClaimAssetUsingContractId(ctx, contractId, claimInfoBytesBase64)
+			} else if lockKind == assetBundleLockKind {
+				err = s.ClaimAssetBundle(ctx, contractId, claimInfoBytesBase64)
+			} else {
+				_, err = s.ClaimFungibleAsset(ctx, contractId, claimInfoBytesBase64)
+			}
+		}
+		if err != nil {
+			return logThenErrorf("cannot claim the batch of assets as the claim of contractId %s failed: %+v", contractId, err)
+		}
+	}
+	return nil
+}
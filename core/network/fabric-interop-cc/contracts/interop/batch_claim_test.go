@@ -0,0 +1,120 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimAssetsUsingContractIds(t *testing.T) {
+	ctx, mockStub, interopcc := prepShimMockStub()
+
+	// the caller locks the assets for itself, so that it can claim them too
+	recipient := getTxCreatorECertBase64()
+	currentTimeSecs := uint64(time.Now().Unix())
+
+	lockBond := func(txId string, assetId string, preimage string) string {
+		assetAgreement := &common.AssetExchangeAgreement{
+			Type:      "bond",
+			Id:        assetId,
+			Recipient: recipient,
+			Locker:    recipient,
+		}
+		assetAgreementBytes, _ := proto.Marshal(assetAgreement)
+		mockStub.MockTransactionStart(txId)
+		contractId, err := interopcc.LockAsset(ctx, base64.StdEncoding.EncodeToString(assetAgreementBytes),
+			getHTLCLockInfoBase64(generateSHA256HashInBase64Form(preimage), currentTimeSecs+defaultTimeLockSecs))
+		require.NoError(t, err)
+		mockStub.MockTransactionEnd(txId)
+		return contractId
+	}
+	lockFungible := func(txId string, numUnits uint64, preimage string) string {
+		assetAgreement := &common.FungibleAssetExchangeAgreement{
+			Type:      "cbdc",
+			NumUnits:  numUnits,
+			Recipient: recipient,
+			Locker:    recipient,
+		}
+		assetAgreementBytes, _ := proto.Marshal(assetAgreement)
+		mockStub.MockTransactionStart(txId)
+		contractId, err := interopcc.LockFungibleAsset(ctx, base64.StdEncoding.EncodeToString(assetAgreementBytes),
+			getHTLCLockInfoBase64(generateSHA256HashInBase64Form(preimage), currentTimeSecs+defaultTimeLockSecs))
+		require.NoError(t, err)
+		mockStub.MockTransactionEnd(txId)
+		return contractId
+	}
+	getClaimInfoBase64 := func(preimage string, numUnits uint64) string {
+		claimInfoHTLC := &common.AssetClaimHTLC{
+			HashPreimageBase64: []byte(base64.StdEncoding.EncodeToString([]byte(preimage))),
+			NumUnits:           numUnits,
+		}
+		claimInfoHTLCBytes, _ := proto.Marshal(claimInfoHTLC)
+		claimInfo := &common.AssetClaim{
+			LockMechanism: common.LockMechanism_HTLC,
+			ClaimInfo:     claimInfoHTLCBytes,
+		}
+		claimInfoBytes, _ := proto.Marshal(claimInfo)
+		return base64.StdEncoding.EncodeToString(claimInfoBytes)
+	}
+	bondContractId := lockBond("tx1", "A001", "abcd")
+	cbdcContractId := lockFungible("tx2", 10, "abcd")
+	otherHashContractId := lockFungible("tx3", 20, "wxyz")
+
+	// Test failure with invalid arguments
+	err := interopcc.ClaimAssetsUsingContractIds(ctx, []string{}, getClaimInfoBase64("abcd", 0))
+	require.EqualError(t, err, "empty list of contractIds")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	err = interopcc.ClaimAssetsUsingContractIds(ctx, []string{bondContractId, bondContractId}, getClaimInfoBase64("abcd", 0))
+	require.EqualError(t, err, fmt.Sprintf("contractId %s is listed more than once", bondContractId))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	err = interopcc.ClaimAssetsUsingContractIds(ctx, []string{bondContractId, cbdcContractId}, getEscrowClaimInfoBase64([]byte("signature")))
+	require.EqualError(t, err, "batch claims are only supported for the HTLC lock mechanism")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	err = interopcc.ClaimAssetsUsingContractIds(ctx, []string{bondContractId, cbdcContractId}, getClaimInfoBase64("abcd", 5))
+	require.EqualError(t, err, "a batch claim cannot claim a subset of the locked units")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a leg locked with a different hash, which fails the whole batch
+	mockStub.MockTransactionStart("tx4")
+	err = interopcc.ClaimAssetsUsingContractIds(ctx, []string{otherHashContractId, bondContractId, cbdcContractId}, getClaimInfoBase64("abcd", 0))
+	require.Error(t, err)
+	require.True(t, strings.HasPrefix(err.Error(), fmt.Sprintf("cannot claim the batch of assets as the claim of contractId %s failed", otherHashContractId)))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	mockStub.MockTransactionEnd("tx4")
+	isLocked, err := interopcc.IsAssetLockedQueryUsingContractId(ctx, bondContractId)
+	require.NoError(t, err)
+	require.True(t, isLocked)
+
+	// Test success with all the legs claimed, and the hash preimage recorded against each of them
+	mockStub.MockTransactionStart("tx5")
+	err = interopcc.ClaimAssetsUsingContractIds(ctx, []string{bondContractId, cbdcContractId}, getClaimInfoBase64("abcd", 0))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx5")
+	for _, contractId := range []string{bondContractId, cbdcContractId} {
+		hashPreimageBase64, err := interopcc.GetHTLCHashPreImage(ctx, contractId)
+		require.NoError(t, err)
+		require.Equal(t, base64.StdEncoding.EncodeToString([]byte("abcd")), hashPreimageBase64)
+	}
+	isLocked, err = interopcc.IsFungibleAssetLocked(ctx, otherHashContractId)
+	require.NoError(t, err)
+	require.True(t, isLocked)
+
+	// Test failure with legs that are already claimed
+	mockStub.MockTransactionStart("tx6")
+	err = interopcc.ClaimAssetsUsingContractIds(ctx, []string{bondContractId}, getClaimInfoBase64("abcd", 0))
+	require.Error(t, err)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	mockStub.MockTransactionEnd("tx6")
+}
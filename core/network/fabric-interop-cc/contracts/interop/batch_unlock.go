@@ -0,0 +1,110 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// batch_unlock contains the functions used by a locker to release several expired asset locks in a single transaction
+package main
+
+import (
+	"encoding/base64"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// function to release each of the expired locks associated with contractIds, recording the outcome of every release;
+// the error of an outcome is set if the lock was not released. The unlock functions validate a lock completely before
+// writing to the ledger, so a lock failing validation is left untouched.
+func unlockExpiredAssets(ctx contractapi.TransactionContextInterface, s *SmartContract, contractIds []string) *common.AssetBatchUnlockOutcomes {
+	outcomes := &common.AssetBatchUnlockOutcomes{Outcomes: []*common.AssetUnlockOutcome{}}
+	for _, contractId := range contractIds {
+		outcome := &common.AssetUnlockOutcome{ContractId: contractId}
+		lockKind, err := getLockKindOfContractId(ctx, contractId)
+		if err == nil {
+			if lockKind == nonFungibleLockKind {
+				err = s.UnlockAssetUsingContractId(ctx, contractId)
+			} else if lockKind == assetBundleLockKind {
+				err = s.UnlockAssetBundle(ctx, contractId)
+			} else {
+				err = s.UnlockFungibleAsset(ctx, contractId)
+			}
+		}
+		if err != nil {
+			outcome.Error = err.Error()
+		} else {
+			outcome.Unlocked = true
+		}
+		outcomes.Outcomes = append(outcomes.Outcomes, outcome)
+	}
+	return outcomes
+}
+
+// function to serialize the outcomes of a batch unlock in base64 form, as returned by the batch unlock functions
+func encodeAssetBatchUnlockOutcomes(outcomes *common.AssetBatchUnlockOutcomes) (string, error) {
+	outcomesBytes, err := proto.Marshal(outcomes)
+	if err != nil {
+		return "", logThenErrorf("marshal error: %s", err)
+	}
+	return base64.StdEncoding.EncodeToString(outcomesBytes), nil
+}
+
+// UnlockExpiredAssetsUsingContractIds cc is used to release, in one transaction, the expired locks (fungible,
+// non-fungible and asset bundles) associated with contractIds; the caller needs to be the locker of each. It returns
+// the outcome of every release as a serialized AssetBatchUnlockOutcomes in base64 form
+func (s *SmartContract) UnlockExpiredAssetsUsingContractIds(ctx contractapi.TransactionContextInterface, contractIds []string) (string, error) {
+	if len(contractIds) == 0 {
+		return "", logThenErrorf("empty list of contractIds")
+	}
+	seenContractIds := map[string]bool{}
+	for _, contractId := range contractIds {
+		if seenContractIds[contractId] {
+			return "", logThenErrorf("contractId %s is listed more than once", contractId)
+		}
+		seenContractIds[contractId] = true
+	}
+	return encodeAssetBatchUnlockOutcomes(unlockExpiredAssets(ctx, s, contractIds))
+}
+
+// UnlockExpiredAssetsOfLocker cc is used to release, in one transaction, all the locks (fungible, non-fungible and
+// asset bundles) held by the locker that expire at or before expiryCutoffSecs and have already expired; the locker,
+// which defaults to the caller, needs to be the caller. It returns the outcomes in the same form as
+// UnlockExpiredAssetsUsingContractIds
+func (s *SmartContract) UnlockExpiredAssetsOfLocker(ctx contractapi.TransactionContextInterface, locker string, expiryCutoffSecs uint64) (string, error) {
+	locker, err := resolveLockPartyOfQuery(ctx, locker)
+	if err != nil {
+		return "", err
+	}
+	txCreatorECertBase64, err := getECertOfTxCreatorBase64(ctx)
+	if err != nil {
+		return "", logThenErrorf("unable to get the transaction creator information: %+v", err)
+	}
+	if locker != txCreatorECertBase64 {
+		return "", logThenErrorf("locks held by %s cannot be unlocked by %s", locker, txCreatorECertBase64)
+	}
+
+	indexEntries, err := queryAssetLockIndex(ctx, lockerIndexObjectType, []string{locker})
+	if err != nil {
+		return "", err
+	}
+	contractIds := []string{}
+	for _, indexEntry := range indexEntries {
+		lockedAssetInfo, err := fetchLockedAssetInfo(ctx, indexEntry[1], indexEntry[2])
+		if err != nil {
+			return "", err
+		}
+		if lockedAssetInfo.ExpiryTimeSecs > expiryCutoffSecs {
+			continue
+		}
+		isExpired, err := isLockExpired(ctx, lockedAssetInfo.ExpiryTimeSecs)
+		if err != nil {
+			return "", logThenErrorf(err.Error())
+		}
+		if isExpired {
+			contractIds = append(contractIds, lockedAssetInfo.ContractId)
+		}
+	}
+	return encodeAssetBatchUnlockOutcomes(unlockExpiredAssets(ctx, s, contractIds))
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/stretchr/testify/require"
+)
+
+// function that decodes the serialized outcomes (in base64 form) returned by the batch unlock functions
+func decodeAssetBatchUnlockOutcomes(t *testing.T, outcomesBase64 string) []*common.AssetUnlockOutcome {
+	outcomesBytes, err := base64.StdEncoding.DecodeString(outcomesBase64)
+	require.NoError(t, err)
+	outcomes := &common.AssetBatchUnlockOutcomes{}
+	err = proto.Unmarshal(outcomesBytes, outcomes)
+	require.NoError(t, err)
+	return outcomes.Outcomes
+}
+
+func TestUnlockExpiredAssets(t *testing.T) {
+	ctx, mockStub, interopcc := prepShimMockStub()
+
+	locker := getTxCreatorECertBase64()
+	hashBase64 := generateSHA256HashInBase64Form("abcd")
+	currentTimeSecs := uint64(time.Now().Unix())
+
+	lockBond := func(txId string, assetId string, expiryTimeSecs uint64) string {
+		assetAgreement := &common.AssetExchangeAgreement{
+			Type:      "bond",
+			Id:        assetId,
+			Recipient: "Bob",
+			Locker:    locker,
+		}
+		assetAgreementBytes, _ := proto.Marshal(assetAgreement)
+		mockStub.MockTransactionStart(txId)
+		contractId, err := interopcc.LockAsset(ctx, base64.StdEncoding.EncodeToString(assetAgreementBytes), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+		require.NoError(t, err)
+		mockStub.MockTransactionEnd(txId)
+		return contractId
+	}
+	lockFungible := func(txId string, numUnits uint64, expiryTimeSecs uint64) string {
+		assetAgreement := &common.FungibleAssetExchangeAgreement{
+			Type:      "cbdc",
+			NumUnits:  numUnits,
+			Recipient: "Bob",
+			Locker:    locker,
+		}
+		assetAgreementBytes, _ := proto.Marshal(assetAgreement)
+		mockStub.MockTransactionStart(txId)
+		contractId, err := interopcc.LockFungibleAsset(ctx, base64.StdEncoding.EncodeToString(assetAgreementBytes), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+		require.NoError(t, err)
+		mockStub.MockTransactionEnd(txId)
+		return contractId
+	}
+	bondContractId := lockBond("tx1", "A001", currentTimeSecs+defaultTimeLockSecs)
+	laterBondContractId := lockBond("tx2", "A002", currentTimeSecs+3*defaultTimeLockSecs)
+	lockFungible("tx3", 10, currentTimeSecs+defaultTimeLockSecs)
+	lockFungible("tx4", 20, currentTimeSecs+2*defaultTimeLockSecs)
+	mockStub.MockTransactionStart("tx4b")
+	bundleAssets := []*common.AssetBundleItem{{Type: "bond", Id: "A003"}, {Type: "cbdc", NumUnits: 5}}
+	_, err := interopcc.LockAssetBundle(ctx, getAssetBundleAgreementBase64(bundleAssets, locker, "Bob"), getHTLCLockInfoBase64(hashBase64, currentTimeSecs+defaultTimeLockSecs))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx4b")
+
+	// move the transaction time past the expiry of all the locks but the one on A002
+	mockStub.MockTransactionStart("tx5")
+	mockStub.TxTimestamp = &timestamp.Timestamp{Seconds: int64(currentTimeSecs + 2*defaultTimeLockSecs + 1)}
+
+	// Test failure with an empty list, or a contractId listed twice
+	_, err = interopcc.UnlockExpiredAssetsUsingContractIds(ctx, []string{})
+	require.EqualError(t, err, "empty list of contractIds")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	_, err = interopcc.UnlockExpiredAssetsUsingContractIds(ctx, []string{bondContractId, bondContractId})
+	require.EqualError(t, err, fmt.Sprintf("contractId %s is listed more than once", bondContractId))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with per-contract outcomes, only the expired lock being released
+	outcomesBase64, err := interopcc.UnlockExpiredAssetsUsingContractIds(ctx, []string{bondContractId, laterBondContractId, "unknown-contract-id"})
+	require.NoError(t, err)
+	outcomes := decodeAssetBatchUnlockOutcomes(t, outcomesBase64)
+	require.Len(t, outcomes, 3)
+	require.Equal(t, bondContractId, outcomes[0].ContractId)
+	require.True(t, outcomes[0].Unlocked)
+	require.Empty(t, outcomes[0].Error)
+	require.False(t, outcomes[1].Unlocked)
+	require.Equal(t, fmt.Sprintf("cannot unlock asset associated with the contractId %s as the expiry time is not yet elapsed", laterBondContractId), outcomes[1].Error)
+	require.False(t, outcomes[2].Unlocked)
+	require.Equal(t, "no contractId unknown-contract-id exists on the ledger", outcomes[2].Error)
+
+	// Test failure with the locker not being the caller
+	_, err = interopcc.UnlockExpiredAssetsOfLocker(ctx, "Bob", currentTimeSecs+3*defaultTimeLockSecs)
+	require.Error(t, err)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with the expired locks of the caller up to a cutoff, including the asset bundle, which leaves out
+	// the unexpired lock on A002
+	outcomesBase64, err = interopcc.UnlockExpiredAssetsOfLocker(ctx, "", currentTimeSecs+3*defaultTimeLockSecs)
+	require.NoError(t, err)
+	outcomes = decodeAssetBatchUnlockOutcomes(t, outcomesBase64)
+	require.Len(t, outcomes, 3)
+	for _, outcome := range outcomes {
+		require.True(t, outcome.Unlocked)
+	}
+	mockStub.MockTransactionEnd("tx5")
+
+	lockedAssets, err := interopcc.GetAllLockedAssets(ctx, "*", locker)
+	require.NoError(t, err)
+	require.Len(t, lockedAssets, 1)
+	totalUnits, err := interopcc.GetTotalFungibleLockedAssets(ctx, "cbdc")
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), totalUnits)
+
+	// Test success with nothing left to unlock before the cutoff
+	mockStub.MockTransactionStart("tx6")
+	outcomesBase64, err = interopcc.UnlockExpiredAssetsOfLocker(ctx, "", currentTimeSecs+2*defaultTimeLockSecs)
+	require.NoError(t, err)
+	outcomes = decodeAssetBatchUnlockOutcomes(t, outcomesBase64)
+	require.Len(t, outcomes, 0)
+	mockStub.MockTransactionEnd("tx6")
+}
@@ -72,12 +72,12 @@ func getCertChainOptions(rootCerts []interface{}, intermediateCerts []interface{
 	return *certOptions, nil
 }
 
-func verifyCaCertificate(cert *x509.Certificate, memberCertificate string) error {
+func verifyCaCertificate(cert *x509.Certificate, memberCertificate string, currentTime time.Time, clockSkewTolerance time.Duration) error {
 	memberX509Cert, err := parseCert(memberCertificate)
 	if err != nil {
 		return err
 	}
-	err = validateCertificateUsingCA(cert, memberX509Cert, true)
+	err = validateCertificateUsingCA(cert, memberX509Cert, true, currentTime, clockSkewTolerance)
 	if err != nil {
 		return fmt.Errorf("CA Certificate is not valid: %s", err.Error())
 	}
@@ -88,7 +88,7 @@ func verifyCaCertificate(cert *x509.Certificate, memberCertificate string) error
    The assumption is that a Corda network has a single Root CA and Doorman CA, and one or more Node CAs corresponding to nodes.
    This function will receive arguments for exactly one node with the following cert chain assumed: <root cert> -> <int cert 0> -> <int cert 1>
 */
-func verifyCertificateChain(cert *x509.Certificate, certPEMs []string) error {
+func verifyCertificateChain(cert *x509.Certificate, certPEMs []string, currentTime time.Time, clockSkewTolerance time.Duration) error {
 	var parentCert *x509.Certificate
 	for i, certPEM := range certPEMs {
 		decodedCert, _ := pem.Decode([]byte(certPEM))
@@ -101,13 +101,13 @@ func verifyCertificateChain(cert *x509.Certificate, certPEMs []string) error {
 		}
 
 		if i > 0 {
-			err := validateCertificateUsingCA(caCert, parentCert, i == 1)
+			err := validateCertificateUsingCA(caCert, parentCert, i == 1, currentTime, clockSkewTolerance)
 			if err != nil {
 				errMsg := fmt.Sprintf("Certificate link for Subject %s with Parent Subject %s invalid", caCert.Subject.String(), parentCert.Subject.String())
 				return errors.New(errMsg)
 			}
 			if i == len(certPEMs)-1 {
-				err := validateCertificateUsingCA(cert, caCert, i == 1)
+				err := validateCertificateUsingCA(cert, caCert, i == 1, currentTime, clockSkewTolerance)
 				if err != nil {
 					return errors.New("Certificate link invalid for endorser")
 				}
@@ -119,7 +119,7 @@ func verifyCertificateChain(cert *x509.Certificate, certPEMs []string) error {
 	return nil
 }
 
-func validateCertificateUsingCA(cert *x509.Certificate, signerCACert *x509.Certificate, isSignerRootCA bool) error {
+func validateCertificateUsingCA(cert *x509.Certificate, signerCACert *x509.Certificate, isSignerRootCA bool, currentTime time.Time, clockSkewTolerance time.Duration) error {
 	var err error
 	if isSignerRootCA {
 		if err = signerCACert.CheckSignature(signerCACert.SignatureAlgorithm, signerCACert.RawTBSCertificate, signerCACert.Signature); err != nil {
@@ -129,7 +129,7 @@ func validateCertificateUsingCA(cert *x509.Certificate, signerCACert *x509.Certi
 	if err = signerCACert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature); err != nil {
 		return err
 	}
-	err = isCertificateWithinExpiry(cert)
+	err = isCertificateWithinExpiry(cert, currentTime, clockSkewTolerance)
 	if err != nil {
 		errMsg := fmt.Sprintf("Certificate is outside of expiry date. No longer valid. Cert: %s", cert.Subject.String())
 		return errors.New(errMsg)
@@ -249,13 +249,15 @@ func parseCert(certString string) (*x509.Certificate, error) {
 	return cert, err
 }
 
-func isCertificateWithinExpiry(cert *x509.Certificate) error {
+// isCertificateWithinExpiry checks that currentTime (the transaction time, so that all endorsers agree) lies within
+// the validity period of the certificate, widened on either side by the clock skew tolerance
+func isCertificateWithinExpiry(cert *x509.Certificate, currentTime time.Time, clockSkewTolerance time.Duration) error {
 	if cert == nil {
 		return errors.New("Cert is nil")
 	}
 	certLocation := cert.NotBefore.Location()
-	currentDate := time.Now().In(certLocation)
-	if currentDate.After(cert.NotBefore) && currentDate.Before(cert.NotAfter) {
+	currentDate := currentTime.In(certLocation)
+	if currentDate.After(cert.NotBefore.Add(-clockSkewTolerance)) && currentDate.Before(cert.NotAfter.Add(clockSkewTolerance)) {
 		return nil
 	}
 	return errors.New("Cert is invalid")
@@ -78,7 +78,7 @@ GqO4M1KlfimphQwCICiq00hDanT5W8bTLqE7GIGuplf/O8AABlpWrUg6uiUB
 	cordaCert, err := parseCert("-----BEGIN CERTIFICATE-----\nMIIBwjCCAV+gAwIBAgIIUJkQvmKm35YwFAYIKoZIzj0EAwIGCCqGSM49AwEHMC8x\nCzAJBgNVBAYTAkdCMQ8wDQYDVQQHDAZMb25kb24xDzANBgNVBAoMBlBhcnR5QTAe\nFw0yMDA3MjQwMDAwMDBaFw0yNzA1MjAwMDAwMDBaMC8xCzAJBgNVBAYTAkdCMQ8w\nDQYDVQQHDAZMb25kb24xDzANBgNVBAoMBlBhcnR5QTAqMAUGAytlcAMhAMMKaREK\nhcTgSBMMzK81oPUSPoVmG/fJMLXq/ujSmse9o4GJMIGGMB0GA1UdDgQWBBRMXtDs\nKFZzULdQ3c2DCUEx3T1CUDAPBgNVHRMBAf8EBTADAQH/MAsGA1UdDwQEAwIChDAT\nBgNVHSUEDDAKBggrBgEFBQcDAjAfBgNVHSMEGDAWgBR4hwLuLgfIZMEWzG4n3Axw\nfgPbezARBgorBgEEAYOKYgEBBAMCAQYwFAYIKoZIzj0EAwIGCCqGSM49AwEHA0cA\nMEQCIC7J46SxDDz3LjDNrEPjjwP2prgMEMh7r/gJpouQHBk+AiA+KzXD0d5miI86\nD2mYK4C3tRli3X3VgnCe8COqfYyuQg==\n-----END CERTIFICATE-----")
 	require.NoError(t, err)
 
-	err = verifyCertificateChain(cordaCert, certs)
+	err = verifyCertificateChain(cordaCert, certs, time.Now(), 0)
 	require.NoError(t, err)
 }
 func TestParseCert(t *testing.T) {
@@ -108,7 +108,7 @@ func TestIsCertificateWithinExpiry(t *testing.T) {
 		fmt.Printf("Parse ERROR %s \n", err.Error())
 		t.Fatal(fmt.Sprintf("Parse ERROR %s \n", err.Error()))
 	}
-	err = isCertificateWithinExpiry(x509Cert)
+	err = isCertificateWithinExpiry(x509Cert, now, 0)
 	require.NoError(t, err)
 
 	// Test: Expired cert case
@@ -117,7 +117,7 @@ func TestIsCertificateWithinExpiry(t *testing.T) {
 		fmt.Printf("Parse ERROR %s \n", err.Error())
 		t.Fatal(fmt.Sprintf("Parse ERROR %s \n", err.Error()))
 	}
-	err = isCertificateWithinExpiry(x509Cert)
+	err = isCertificateWithinExpiry(x509Cert, now, 0)
 	require.EqualError(t, err, fmt.Sprintf("Cert is invalid"))
 
 	// Test: Not valid yet case
@@ -126,8 +126,16 @@ func TestIsCertificateWithinExpiry(t *testing.T) {
 		fmt.Printf("Parse ERROR %s \n", err.Error())
 		t.Fatal(fmt.Sprintf("Parse ERROR %s \n", err.Error()))
 	}
-	err = isCertificateWithinExpiry(x509Cert)
+	err = isCertificateWithinExpiry(x509Cert, now, 0)
 	require.EqualError(t, err, fmt.Sprintf("Cert is invalid"))
+
+	// Test: Not valid yet case, but within the clock skew tolerance
+	err = isCertificateWithinExpiry(x509Cert, now, 4*threeDays)
+	require.NoError(t, err)
+
+	// Test: Validity evaluated at the supplied time rather than the wall clock
+	err = isCertificateWithinExpiry(x509Cert, now.Add(3*threeDays+time.Hour), 0)
+	require.NoError(t, err)
 }
 
 func TestValidateSignature(t *testing.T) {
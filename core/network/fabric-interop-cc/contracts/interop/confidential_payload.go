@@ -0,0 +1,147 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// confidential_payload contains the functions used to encrypt the payload of a view for the requestor of a
+// confidential query, and to check the contents decrypted by the requestor against the hash carried by the view
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	protoV2 "google.golang.org/protobuf/proto"
+)
+
+// confidentialQueryField is the last field of the message signed by the requestor of a confidential query
+const confidentialQueryField = "confidential"
+
+func computeHMACSHA256(key []byte, message []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(message)
+	return mac.Sum(nil)
+}
+
+// function to derive an AES-256-GCM cipher from an ECDH shared secret (ANSI X9.63 KDF with SHA-256)
+func newECIESCipher(curve elliptic.Curve, sharedX *big.Int, ephemeralPublicKey []byte) (cipher.AEAD, error) {
+	sharedSecret := make([]byte, (curve.Params().BitSize+7)/8)
+	sharedX.FillBytes(sharedSecret)
+	kdf := sha256.New()
+	kdf.Write(sharedSecret)
+	kdf.Write([]byte{0x00, 0x00, 0x00, 0x01})
+	kdf.Write(ephemeralPublicKey)
+	block, err := aes.NewCipher(kdf.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+/*
+ * Function to encrypt plaintext for the holder of the private key matching publicKey (ECIES: ephemeral ECDH on the
+ * curve of publicKey, X9.63 KDF, AES-256-GCM). The ciphertext is the uncompressed ephemeral public key followed by the
+ * sealed plaintext. The ephemeral private key is derived from seed rather than drawn at random, since all endorsers
+ * of a view need to return the same response; as a fresh key is derived for each seed, the GCM nonce can be fixed.
+ */
+func eciesEncrypt(publicKey *ecdsa.PublicKey, plaintext []byte, seed []byte) ([]byte, error) {
+	curve := publicKey.Curve
+	if publicKey.X == nil || publicKey.Y == nil || !curve.IsOnCurve(publicKey.X, publicKey.Y) {
+		return nil, fmt.Errorf("public key is not a point of its curve")
+	}
+	// expand the seed to 64 bits more than the order of the curve, so that the reduction bias is negligible
+	n := curve.Params().N
+	expandedSeed := []byte{}
+	for counter := byte(1); len(expandedSeed) < (n.BitLen()+64+7)/8; counter++ {
+		expandedSeed = append(expandedSeed, computeHMACSHA256(seed, []byte{counter})...)
+	}
+	ephemeralScalar := new(big.Int).SetBytes(expandedSeed)
+	ephemeralScalar.Mod(ephemeralScalar, new(big.Int).Sub(n, big.NewInt(1)))
+	ephemeralScalar.Add(ephemeralScalar, big.NewInt(1))
+
+	ephemeralX, ephemeralY := curve.ScalarBaseMult(ephemeralScalar.Bytes())
+	ephemeralPublicKey := elliptic.Marshal(curve, ephemeralX, ephemeralY)
+	sharedX, _ := curve.ScalarMult(publicKey.X, publicKey.Y, ephemeralScalar.Bytes())
+	aead, err := newECIESCipher(curve, sharedX, ephemeralPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(ephemeralPublicKey, make([]byte, aead.NonceSize()), plaintext, nil), nil
+}
+
+/*
+ * Function to produce the payload of a view for a confidential query: the serialized ConfidentialPayload carrying the
+ * payload (along with random bytes) encrypted for the requestor certificate, and the HMAC of the payload keyed with the
+ * random bytes. The endorsers sign the view, and thus the HMAC, so the requestor can prove to its own network what the
+ * plaintext is by revealing the decrypted contents.
+ * The randomness is derived from the transaction, the query and the payload, so that all endorsers produce the same
+ * response; like any deterministic encryption, this lets a party that can guess the whole payload confirm its guess.
+ */
+func generateConfidentialPayload(ctx contractapi.TransactionContextInterface, query *common.Query, requestorCert *x509.Certificate,
+	payload []byte) ([]byte, error) {
+	publicKey, ok := requestorCert.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("requestor certificate does not carry an ECDSA public key")
+	}
+	seedHash := sha256.New()
+	for _, seedPart := range [][]byte{[]byte(ctx.GetStub().GetTxID()), []byte(query.Certificate), []byte(query.Nonce), payload} {
+		seedHash.Write(computeHMACSHA256([]byte("ConfidentialPayloadSeed"), seedPart))
+	}
+	seed := seedHash.Sum(nil)
+	random := computeHMACSHA256(seed, []byte("ConfidentialPayloadContents"))
+
+	contentsBytes, err := protoV2.Marshal(&common.ConfidentialPayloadContents{Payload: payload, Random: random})
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal confidential payload contents: %s", err.Error())
+	}
+	encryptedPayload, err := eciesEncrypt(publicKey, contentsBytes, seed)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encrypt payload for the requestor: %s", err.Error())
+	}
+	confidentialPayloadBytes, err := protoV2.Marshal(&common.ConfidentialPayload{
+		EncryptedPayload: encryptedPayload,
+		HashType:         common.ConfidentialPayload_HMAC,
+		Hash:             computeHMACSHA256(random, payload),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal confidential payload: %s", err.Error())
+	}
+	return confidentialPayloadBytes, nil
+}
+
+// function to check the contents decrypted by the requestor (a base64 encoded ConfidentialPayloadContents) against the
+// hash in the confidential payload of a view, and to extract the plaintext payload from them
+func verifyConfidentialPayloadContents(confidentialPayloadBytes []byte, b64Contents string) ([]byte, error) {
+	var confidentialPayload common.ConfidentialPayload
+	err := protoV2.Unmarshal(confidentialPayloadBytes, &confidentialPayload)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to Unmarshal confidential payload: %s", err.Error())
+	}
+	contentsBytes, err := base64.StdEncoding.DecodeString(b64Contents)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to base64 decode view contents: %s", err.Error())
+	}
+	var contents common.ConfidentialPayloadContents
+	err = protoV2.Unmarshal(contentsBytes, &contents)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to Unmarshal view contents: %s", err.Error())
+	}
+	if confidentialPayload.HashType != common.ConfidentialPayload_HMAC {
+		return nil, fmt.Errorf("Unsupported confidential payload hash type: %s", confidentialPayload.HashType)
+	}
+	if !hmac.Equal(computeHMACSHA256(contents.Random, contents.Payload), confidentialPayload.Hash) {
+		return nil, fmt.Errorf("View contents do not match the hash in the confidential payload")
+	}
+	return contents.Payload, nil
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/fabric"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/require"
+	protoV2 "google.golang.org/protobuf/proto"
+)
+
+// eciesDecrypt reverses eciesEncrypt, as the requestor of a confidential query does with its private key
+func eciesDecrypt(privateKey *ecdsa.PrivateKey, ciphertext []byte) ([]byte, error) {
+	curve := privateKey.Curve
+	ephemeralPublicKeyLength := 1 + 2*((curve.Params().BitSize+7)/8)
+	if len(ciphertext) < ephemeralPublicKeyLength {
+		return nil, fmt.Errorf("ciphertext is too short")
+	}
+	ephemeralPublicKey := ciphertext[:ephemeralPublicKeyLength]
+	ephemeralX, ephemeralY := elliptic.Unmarshal(curve, ephemeralPublicKey)
+	if ephemeralX == nil {
+		return nil, fmt.Errorf("invalid ephemeral public key")
+	}
+	sharedX, _ := curve.ScalarMult(ephemeralX, ephemeralY, privateKey.D.Bytes())
+	aead, err := newECIESCipher(curve, sharedX, ephemeralPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Open(nil, make([]byte, aead.NonceSize()), ciphertext[ephemeralPublicKeyLength:], nil)
+}
+
+func TestECIES(t *testing.T) {
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		privateKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+		require.NoError(t, err)
+		otherPrivateKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+		require.NoError(t, err)
+
+		// Test success with the decryption by the holder of the private key, and the same ciphertext for the same seed
+		ciphertext, err := eciesEncrypt(&privateKey.PublicKey, []byte("17.12"), []byte("seed"))
+		require.NoError(t, err)
+		plaintext, err := eciesDecrypt(privateKey, ciphertext)
+		require.NoError(t, err)
+		require.Equal(t, []byte("17.12"), plaintext)
+		sameCiphertext, err := eciesEncrypt(&privateKey.PublicKey, []byte("17.12"), []byte("seed"))
+		require.NoError(t, err)
+		require.Equal(t, ciphertext, sameCiphertext)
+		otherCiphertext, err := eciesEncrypt(&privateKey.PublicKey, []byte("17.12"), []byte("other seed"))
+		require.NoError(t, err)
+		require.NotEqual(t, ciphertext, otherCiphertext)
+
+		// Test failure with the decryption by another key, and with a tampered ciphertext
+		_, err = eciesDecrypt(otherPrivateKey, ciphertext)
+		require.Error(t, err)
+		ciphertext[len(ciphertext)-1] ^= 0x01
+		_, err = eciesDecrypt(privateKey, ciphertext)
+		require.Error(t, err)
+	}
+}
+
+func TestConfidentialPayload(t *testing.T) {
+	ctx, chaincodeStub, _ := prepMockStub()
+	chaincodeStub.GetTxIDReturns("tx1")
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	query := &common.Query{Certificate: "cert", Nonce: "nonce"}
+
+	// Test failure with a requestor certificate that does not carry an ECDSA key
+	_, err = generateConfidentialPayload(ctx, query, &x509.Certificate{}, []byte("17.12"))
+	require.EqualError(t, err, "requestor certificate does not carry an ECDSA public key")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with the contents decrypted by the requestor matching the hash in the payload
+	confidentialPayloadBytes, err := generateConfidentialPayload(ctx, query, &x509.Certificate{PublicKey: &privateKey.PublicKey}, []byte("17.12"))
+	require.NoError(t, err)
+	var confidentialPayload common.ConfidentialPayload
+	err = protoV2.Unmarshal(confidentialPayloadBytes, &confidentialPayload)
+	require.NoError(t, err)
+	require.Equal(t, common.ConfidentialPayload_HMAC, confidentialPayload.HashType)
+	contentsBytes, err := eciesDecrypt(privateKey, confidentialPayload.EncryptedPayload)
+	require.NoError(t, err)
+	payload, err := verifyConfidentialPayloadContents(confidentialPayloadBytes, base64.StdEncoding.EncodeToString(contentsBytes))
+	require.NoError(t, err)
+	require.Equal(t, []byte("17.12"), payload)
+
+	// Test failure with contents whose payload differs from the one the hash was computed over
+	var contents common.ConfidentialPayloadContents
+	err = protoV2.Unmarshal(contentsBytes, &contents)
+	require.NoError(t, err)
+	contents.Payload = []byte("99.99")
+	forgedContentsBytes, err := protoV2.Marshal(&contents)
+	require.NoError(t, err)
+	_, err = verifyConfidentialPayloadContents(confidentialPayloadBytes, base64.StdEncoding.EncodeToString(forgedContentsBytes))
+	require.EqualError(t, err, "View contents do not match the hash in the confidential payload")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with contents that are not base64 encoded
+	_, err = verifyConfidentialPayloadContents(confidentialPayloadBytes, "not base64")
+	require.EqualError(t, err, "Unable to base64 decode view contents: illegal base64 data at input byte 3")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+}
+
+func TestExtractDataFromConfidentialView(t *testing.T) {
+	ctx, _, _ := prepMockStub()
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	getFabricView := func(interopPayload *common.InteropPayload) *common.View {
+		interopPayloadBytes, err := protoV2.Marshal(interopPayload)
+		require.NoError(t, err)
+		viewData, err := protoV2.Marshal(&fabric.FabricView{Response: &peer.Response{Payload: interopPayloadBytes}})
+		require.NoError(t, err)
+		return &common.View{Meta: &common.Meta{Protocol: common.Meta_FABRIC}, Data: viewData}
+	}
+	confidentialPayloadBytes, err := generateConfidentialPayload(ctx, &common.Query{}, &x509.Certificate{PublicKey: &privateKey.PublicKey}, []byte("17.12"))
+	require.NoError(t, err)
+	var confidentialPayload common.ConfidentialPayload
+	err = protoV2.Unmarshal(confidentialPayloadBytes, &confidentialPayload)
+	require.NoError(t, err)
+	contentsBytes, err := eciesDecrypt(privateKey, confidentialPayload.EncryptedPayload)
+	require.NoError(t, err)
+	b64Contents := base64.StdEncoding.EncodeToString(contentsBytes)
+	confidentialView := getFabricView(&common.InteropPayload{Payload: confidentialPayloadBytes, Confidential: true})
+	plainView := getFabricView(&common.InteropPayload{Payload: []byte("17.12")})
+
+	// Test success with the data of a confidential view taken from its decrypted contents
+	viewData, err := ExtractDataFromView(confidentialView, b64Contents)
+	require.NoError(t, err)
+	require.Equal(t, []byte("17.12"), viewData)
+	viewData, err = ExtractDataFromView(plainView, "")
+	require.NoError(t, err)
+	require.Equal(t, []byte("17.12"), viewData)
+
+	// Test failure with the contents missing for a confidential view, or supplied for a view that is not confidential
+	_, err = ExtractDataFromView(confidentialView, "")
+	require.EqualError(t, err, "Cannot extract data from a confidential view without its decrypted contents")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	_, err = ExtractDataFromView(plainView, b64Contents)
+	require.EqualError(t, err, "View contents supplied for a view that is not confidential")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// contract_ids contains the functions used to resolve the contractId of a new asset lock, which can be chosen by the
+// client (directly or through a nonce) so that it is known before the lock is submitted, and to record the transaction
+// that created each lock
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+const contractIdTxIdPrefix = "ContractIdTxId_" // prefix for the map, contractId --> txId of the transaction that created the lock
+
+// Composite-key index over the contractIds of the locks created by each transaction; like the asset lock indexes, each
+// index entry ends with the lock kind and the contractId
+const contractIdByTxIdObjectType = "ContractIdByTxId" // <txId, lock-kind, contractId>
+
+// contractIds chosen by clients are restricted to the characters of the (standard and URL-safe) base64 alphabets
+var clientContractIdPattern = regexp.MustCompile(`^[A-Za-z0-9+/=_-]{1,128}$`)
+
+const maxContractIdNonceLength = 256
+
+// function to derive the contractId of a lock from the nonce chosen by the locker
+func generateContractIdOfNonce(locker string, contractIdNonce string) string {
+	return generateSHA256HashInBase64Form(fmt.Sprintf("ContractIdNonce:%s:%s", locker, contractIdNonce))
+}
+
+// function to check whether a contractId has the form of the contractIds the interop cc derives (the base64 encoded
+// SHA-256 hash of the asset, the agreement or a nonce of the locker), which clients cannot choose directly: the
+// default contractId of a non-fungible asset is predictable, and taking it would block the owner from locking it
+func hasDerivedContractIdForm(contractId string) bool {
+	decoded, err := base64.StdEncoding.DecodeString(contractId)
+	return err == nil && len(decoded) == sha256.Size
+}
+
+// function to check that a contractId chosen by a client is not associated with any lock, current or past
+func validateUniqueContractId(ctx contractapi.TransactionContextInterface, contractId string) error {
+	for _, key := range []string{generateContractIdMapKey(contractId), generateBundleContractIdMapKey(contractId),
+		contractIdTxIdPrefix + contractId, claimedContractIdPrefix + contractId} {
+		valBytes, err := ctx.GetStub().GetState(key)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve from the world state: %+v", err)
+		}
+		if valBytes != nil {
+			return fmt.Errorf("contractId %s is already in use", contractId)
+		}
+	}
+	return nil
+}
+
+/*
+ * Function to resolve the contractId of a new lock: the contractId chosen by the client, or the one derived from the
+ * nonce chosen by the client, if either is set in the agreement; the default contractId otherwise. A contractId chosen
+ * by the client (directly or through a nonce) needs to be unique on the ledger, and one chosen directly cannot have the
+ * form of a derived contractId.
+ */
+func resolveContractIdOfNewLock(ctx contractapi.TransactionContextInterface, defaultContractId string, clientContractId string,
+	contractIdNonce string, locker string) (string, error) {
+	if len(clientContractId) > 0 && len(contractIdNonce) > 0 {
+		return "", fmt.Errorf("both a contractId and a contractId nonce are set in the asset agreement")
+	}
+	contractId := clientContractId
+	if len(contractIdNonce) > 0 {
+		if len(contractIdNonce) > maxContractIdNonceLength {
+			return "", fmt.Errorf("contractId nonce is longer than %d characters", maxContractIdNonceLength)
+		}
+		contractId = generateContractIdOfNonce(locker, contractIdNonce)
+	} else if len(clientContractId) == 0 {
+		return defaultContractId, nil
+	} else if !clientContractIdPattern.MatchString(clientContractId) {
+		return "", fmt.Errorf("contractId %s is not made of 1 to 128 base64 characters", clientContractId)
+	} else if hasDerivedContractIdForm(clientContractId) {
+		return "", fmt.Errorf("contractId %s has the form of a contractId derived by the interop cc", clientContractId)
+	}
+	err := validateUniqueContractId(ctx, contractId)
+	if err != nil {
+		return "", err
+	}
+	return contractId, nil
+}
+
+// function to record the transaction that created the lock (of the given kind) associated with contractId
+func recordTxIdOfContractId(ctx contractapi.TransactionContextInterface, lockKind string, contractId string) error {
+	txId := ctx.GetStub().GetTxID()
+	err := ctx.GetStub().PutState(contractIdTxIdPrefix+contractId, []byte(txId))
+	if err != nil {
+		return logThenErrorf("failed to record the transaction of contractId %s: %+v", contractId, err)
+	}
+	indexKey, err := ctx.GetStub().CreateCompositeKey(contractIdByTxIdObjectType, []string{txId, lockKind, contractId})
+	if err != nil {
+		return logThenErrorf("error while creating composite key for index %s: %+v", contractIdByTxIdObjectType, err)
+	}
+	// the index entries carry no value of their own; everything needed is in the composite key
+	err = ctx.GetStub().PutState(indexKey, []byte{0x00})
+	if err != nil {
+		return logThenErrorf("failed to write index entry for contractId %s: %+v", contractId, err)
+	}
+	return nil
+}
+
+// GetContractIdsOfTxId cc is used to list the contractIds of the asset locks (of any kind) created by the transaction txId
+func (s *SmartContract) GetContractIdsOfTxId(ctx contractapi.TransactionContextInterface, txId string) ([]string, error) {
+	if len(txId) == 0 {
+		return []string{}, logThenErrorf("empty transaction id")
+	}
+	indexEntries, err := queryAssetLockIndex(ctx, contractIdByTxIdObjectType, []string{txId})
+	if err != nil {
+		return []string{}, err
+	}
+	contractIds := []string{}
+	for _, indexEntry := range indexEntries {
+		contractIds = append(contractIds, indexEntry[2])
+	}
+	return contractIds, nil
+}
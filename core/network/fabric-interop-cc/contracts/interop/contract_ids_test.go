@@ -0,0 +1,140 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientChosenContractIds(t *testing.T) {
+	ctx, mockStub, interopcc := prepShimMockStub()
+
+	// the caller locks the assets for itself, so that it can claim them too
+	caller := getTxCreatorECertBase64()
+	currentTimeSecs := uint64(time.Now().Unix())
+	lockInfo := getHTLCLockInfoBase64(generateSHA256HashInBase64Form("abcd"), currentTimeSecs+defaultTimeLockSecs)
+	getAgreementBase64 := func(assetId string, contractId string, contractIdNonce string) string {
+		assetAgreement := &common.AssetExchangeAgreement{
+			Type:            "bond",
+			Id:              assetId,
+			Locker:          caller,
+			Recipient:       caller,
+			ContractId:      contractId,
+			ContractIdNonce: contractIdNonce,
+		}
+		assetAgreementBytes, _ := proto.Marshal(assetAgreement)
+		return base64.StdEncoding.EncodeToString(assetAgreementBytes)
+	}
+	getFungibleAgreementBase64 := func(numUnits uint64, contractId string, contractIdNonce string) string {
+		assetAgreement := &common.FungibleAssetExchangeAgreement{
+			Type:            "cbdc",
+			NumUnits:        numUnits,
+			Locker:          caller,
+			Recipient:       caller,
+			ContractId:      contractId,
+			ContractIdNonce: contractIdNonce,
+		}
+		assetAgreementBytes, _ := proto.Marshal(assetAgreement)
+		return base64.StdEncoding.EncodeToString(assetAgreementBytes)
+	}
+	claimInfoHTLC := &common.AssetClaimHTLC{
+		HashPreimageBase64: []byte(base64.StdEncoding.EncodeToString([]byte("abcd"))),
+	}
+	claimInfoHTLCBytes, _ := proto.Marshal(claimInfoHTLC)
+	claimInfoBytes, _ := proto.Marshal(&common.AssetClaim{LockMechanism: common.LockMechanism_HTLC, ClaimInfo: claimInfoHTLCBytes})
+	claimInfo := base64.StdEncoding.EncodeToString(claimInfoBytes)
+
+	// Test failure with invalid client choices of the contractId
+	_, err := interopcc.LockAsset(ctx, getAgreementBase64("A001", "leg-1", "nonce-1"), lockInfo)
+	require.EqualError(t, err, "error in contractId validation: both a contractId and a contractId nonce are set in the asset agreement")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	_, err = interopcc.LockAsset(ctx, getAgreementBase64("A001", "leg:1", ""), lockInfo)
+	require.EqualError(t, err, "error in contractId validation: contractId leg:1 is not made of 1 to 128 base64 characters")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a client squatting the predictable default contractId of an asset of another owner, or a
+	// contractId derived from a nonce of another locker, and success with the owner locking the asset afterwards
+	_, defaultContractId, err := generateAssetLockKeyAndContractId(ctx, &common.AssetExchangeAgreement{Type: "bond", Id: "A003"})
+	require.NoError(t, err)
+	mockStub.MockTransactionStart("tx0")
+	for _, squattedContractId := range []string{defaultContractId, generateContractIdOfNonce("another-locker", "nonce-1")} {
+		_, err = interopcc.LockAsset(ctx, getAgreementBase64("A002", squattedContractId, ""), lockInfo)
+		require.EqualError(t, err, "error in contractId validation: contractId "+squattedContractId+" has the form of a contractId derived by the interop cc")
+		fmt.Printf("Test failed as expected with error: %s\n", err)
+	}
+	contractId, err := interopcc.LockAsset(ctx, getAgreementBase64("A003", "", ""), lockInfo)
+	require.NoError(t, err)
+	require.Equal(t, defaultContractId, contractId)
+	mockStub.MockTransactionEnd("tx0")
+
+	// Test success with a non-fungible asset locked, and claimed through its agreement, using a contractId chosen by the client
+	mockStub.MockTransactionStart("tx1")
+	contractId, err = interopcc.LockAsset(ctx, getAgreementBase64("A001", "leg-1", ""), lockInfo)
+	require.NoError(t, err)
+	require.Equal(t, "leg-1", contractId)
+	mockStub.MockTransactionEnd("tx1")
+	contractIds, err := interopcc.GetContractIdsOfTxId(ctx, "tx1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"leg-1"}, contractIds)
+	isLocked, err := interopcc.IsAssetLockedQueryUsingContractId(ctx, "leg-1")
+	require.NoError(t, err)
+	require.True(t, isLocked)
+	mockStub.MockTransactionStart("tx2")
+	err = interopcc.ClaimAsset(ctx, getAgreementBase64("A001", "", ""), claimInfo)
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx2")
+	_, err = interopcc.IsAssetLockedQueryUsingContractId(ctx, "leg-1")
+	require.Error(t, err)
+	hashPreimage, err := interopcc.GetHTLCHashPreImage(ctx, "leg-1")
+	require.NoError(t, err)
+	require.Equal(t, base64.StdEncoding.EncodeToString([]byte("abcd")), hashPreimage)
+
+	// Test failure with a contractId that was used by a lock already settled
+	mockStub.MockTransactionStart("tx3")
+	_, err = interopcc.LockAsset(ctx, getAgreementBase64("A002", "leg-1", ""), lockInfo)
+	require.EqualError(t, err, "error in contractId validation: contractId leg-1 is already in use")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	mockStub.MockTransactionEnd("tx3")
+
+	// Test success with a fungible asset locked using a contractId derived from a nonce chosen by the client
+	mockStub.MockTransactionStart("tx4")
+	contractId, err = interopcc.LockFungibleAsset(ctx, getFungibleAgreementBase64(10, "", "nonce-1"), lockInfo)
+	require.NoError(t, err)
+	require.Equal(t, generateContractIdOfNonce(caller, "nonce-1"), contractId)
+	mockStub.MockTransactionEnd("tx4")
+	contractIds, err = interopcc.GetContractIdsOfTxId(ctx, "tx4")
+	require.NoError(t, err)
+	require.Equal(t, []string{contractId}, contractIds)
+
+	// Test failure with a nonce already used by the locker
+	mockStub.MockTransactionStart("tx5")
+	_, err = interopcc.LockFungibleAsset(ctx, getFungibleAgreementBase64(20, "", "nonce-1"), lockInfo)
+	require.EqualError(t, err, fmt.Sprintf("error in contractId validation: contractId %s is already in use", contractId))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	mockStub.MockTransactionEnd("tx5")
+
+	// Test failure with two identical fungible asset locks, with derived contractIds, in the same transaction
+	mockStub.MockTransactionStart("tx6")
+	contractId, err = interopcc.LockFungibleAsset(ctx, getFungibleAgreementBase64(30, "", ""), lockInfo)
+	require.NoError(t, err)
+	_, err = interopcc.LockFungibleAsset(ctx, getFungibleAgreementBase64(30, "", ""), lockInfo)
+	require.EqualError(t, err, fmt.Sprintf("contractId %s already exists for the requested fungible asset agreement", contractId))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	mockStub.MockTransactionEnd("tx6")
+
+	// Test success with the listing of the contractIds of a transaction that created no lock
+	contractIds, err = interopcc.GetContractIdsOfTxId(ctx, "tx5")
+	require.NoError(t, err)
+	require.Len(t, contractIds, 0)
+}
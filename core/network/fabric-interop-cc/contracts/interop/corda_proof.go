@@ -0,0 +1,96 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// corda_proof contains the checks of the notarization proof of a Corda view that need no ledger state: the address
+// of the payload, the signatures of the notarizations, and the identities they claim
+package main
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/corda"
+	protoV2 "google.golang.org/protobuf/proto"
+)
+
+// cordaNotarization is a notarization of a Corda view whose signature and id were checked against its certificate
+type cordaNotarization struct {
+	certificate *x509.Certificate
+	id          string
+}
+
+// function to get the organisation of the X.500 name of a Corda node certificate, which is the id the interop CorDapp
+// gives the node in its notarizations
+func getCordaX500Organisation(cert *x509.Certificate) (string, error) {
+	if len(cert.Subject.Organization) != 1 || cert.Subject.Organization[0] == "" {
+		return "", fmt.Errorf("X.500 name %s in certificate does not have a single organisation", cert.Subject.String())
+	}
+	return cert.Subject.Organization[0], nil
+}
+
+// function to check that the id of a notarization names the node of its certificate
+func verifyCordaNotarizationId(cert *x509.Certificate, id string) error {
+	organisation, err := getCordaX500Organisation(cert)
+	if err != nil {
+		return err
+	}
+	if id != organisation {
+		return fmt.Errorf("Notarization id %s does not match the X.500 name in its certificate: %s", id, cert.Subject.String())
+	}
+	return nil
+}
+
+/*
+ * Function to verify the notarization proof of the data of a Corda view, independently of the ledger state:
+ * 1. Create [CordaViewData] from the view.
+ * 2. Verify address in payload is the same as original address
+ * 3. Verify each of the signatures in the Notarization array according to the data bytes and certificate, and that
+ *    the id of the Notarization is the organisation of the X.500 name in the certificate.
+ * It returns the interop payload and the verified notarizations, whose certificates still need to be checked against
+ * the membership of the network.
+ */
+func verifyCordaProof(data []byte, address string) (*common.InteropPayload, []cordaNotarization, error) {
+	// 1. Create [CordaViewData] from the view.
+	var cordaViewData corda.ViewData
+	err := protoV2.Unmarshal(data, &cordaViewData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Unable to decode corda view data: %s", err.Error())
+	}
+	// 2. Verify address in payload is the same as original address
+	var interopPayload common.InteropPayload
+	err = protoV2.Unmarshal(cordaViewData.Payload, &interopPayload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Unable to decode corda view data: %s", err.Error())
+	}
+	if address != interopPayload.Address {
+		return nil, nil, fmt.Errorf("Address in response does not match original address: Original: %s Response: %s", address, interopPayload.Address)
+	}
+	// 3. Verify each of the signatures in the Notarization array according to the data bytes and certificate, and
+	// that the id of the Notarization is the organisation of the X.500 name in the certificate.
+	notarizations := []cordaNotarization{}
+	for _, value := range cordaViewData.Notarizations {
+		x509Cert, err := parseCert(value.Certificate)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Unable to parse certificate: %s", err.Error())
+		}
+		decodedSignature, err := base64.StdEncoding.DecodeString(value.Signature)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Corda signature could not be decoded from base64: %s", err.Error())
+		}
+		err = validateSignature(string(cordaViewData.Payload), x509Cert, string(decodedSignature))
+		if err != nil {
+			return nil, nil, fmt.Errorf("Unable to Validate Signature: %s", err.Error())
+		}
+		err = verifyCordaNotarizationId(x509Cert, value.Id)
+		if err != nil {
+			return nil, nil, err
+		}
+		notarizations = append(notarizations, cordaNotarization{certificate: x509Cert, id: value.Id})
+	}
+	return &interopPayload, notarizations, nil
+}
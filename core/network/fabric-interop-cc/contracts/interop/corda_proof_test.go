@@ -0,0 +1,145 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/corda"
+	"github.com/stretchr/testify/require"
+)
+
+const cordaViewAddress = "localhost:9080/Corda_Network/localhost:10006#com.cordaSimpleApplication.flow.GetStateByKey:H"
+
+// function that supplies the key and the PEM certificate of a Corda node with the given X.500 name
+func getCordaNodeCertificate(t *testing.T, subject pkix.Name) (ed25519.PrivateKey, string) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      subject,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, publicKey, privateKey)
+	require.NoError(t, err)
+	return privateKey, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes}))
+}
+
+// function that supplies the data of a Corda view of the given payload, notarized by the given nodes under the ids
+func getCordaViewData(address string, payload []byte, privateKeys []ed25519.PrivateKey, certs []string, ids []string) []byte {
+	interopPayloadBytes, _ := proto.Marshal(&common.InteropPayload{Address: address, Payload: payload})
+	cordaViewData := &corda.ViewData{Payload: interopPayloadBytes}
+	for i, privateKey := range privateKeys {
+		cordaViewData.Notarizations = append(cordaViewData.Notarizations, &corda.ViewData_Notarization{
+			Signature:   base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, interopPayloadBytes)),
+			Certificate: certs[i],
+			Id:          ids[i],
+		})
+	}
+	cordaViewDataBytes, _ := proto.Marshal(cordaViewData)
+	return cordaViewDataBytes
+}
+
+func TestVerifyCordaProof(t *testing.T) {
+	// Test success with a view recorded from the interop CorDapp, notarized by O=PartyA, L=London, C=GB
+	viewBytes, err := base64.StdEncoding.DecodeString(cordaB64View)
+	require.NoError(t, err)
+	var view common.View
+	require.NoError(t, proto.Unmarshal(viewBytes, &view))
+	interopPayload, notarizations, err := verifyCordaProof(view.Data, cordaViewAddress)
+	require.NoError(t, err)
+	require.Equal(t, cordaViewAddress, interopPayload.Address)
+	require.Len(t, notarizations, 1)
+	require.Equal(t, "PartyA", notarizations[0].id)
+	require.Equal(t, "O=PartyA,L=London,C=GB", notarizations[0].certificate.Subject.String())
+
+	// Test failure with the recorded view for another address than the one requested
+	otherAddress := "localhost:9080/Corda_Network/localhost:10006#com.cordaSimpleApplication.flow.GetStateByKey:I"
+	_, _, err = verifyCordaProof(view.Data, otherAddress)
+	require.EqualError(t, err, "Address in response does not match original address: Original: "+otherAddress+" Response: "+cordaViewAddress)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	partyBKey, partyBCert := getCordaNodeCertificate(t, pkix.Name{Organization: []string{"PartyB"}, Locality: []string{"New York"}, Country: []string{"US"}})
+	partyCKey, partyCCert := getCordaNodeCertificate(t, pkix.Name{Organization: []string{"PartyC"}, Locality: []string{"Paris"}, Country: []string{"FR"}})
+	payload := []byte("SimpleState(key=H, value=1)")
+
+	// Test success with a view notarized by several nodes, each under the organisation of its X.500 name
+	data := getCordaViewData(cordaViewAddress, payload, []ed25519.PrivateKey{partyBKey, partyCKey}, []string{partyBCert, partyCCert}, []string{"PartyB", "PartyC"})
+	interopPayload, notarizations, err = verifyCordaProof(data, cordaViewAddress)
+	require.NoError(t, err)
+	require.Equal(t, payload, interopPayload.Payload)
+	require.Len(t, notarizations, 2)
+	require.Equal(t, "PartyB", notarizations[0].id)
+	require.Equal(t, "PartyC", notarizations[1].id)
+
+	// Test failure with a notarization claiming the id of another node than the one of its certificate
+	data = getCordaViewData(cordaViewAddress, payload, []ed25519.PrivateKey{partyBKey, partyCKey}, []string{partyBCert, partyCCert}, []string{"PartyB", "PartyB"})
+	_, _, err = verifyCordaProof(data, cordaViewAddress)
+	require.EqualError(t, err, "Notarization id PartyB does not match the X.500 name in its certificate: O=PartyC,L=Paris,C=FR")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a notarization whose id is the full X.500 name rather than its organisation
+	data = getCordaViewData(cordaViewAddress, payload, []ed25519.PrivateKey{partyBKey}, []string{partyBCert}, []string{"O=PartyB,L=New York,C=US"})
+	_, _, err = verifyCordaProof(data, cordaViewAddress)
+	require.EqualError(t, err, "Notarization id O=PartyB,L=New York,C=US does not match the X.500 name in its certificate: O=PartyB,L=New York,C=US")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a certificate whose X.500 name has no organisation
+	noOrgKey, noOrgCert := getCordaNodeCertificate(t, pkix.Name{CommonName: "PartyB", Locality: []string{"New York"}, Country: []string{"US"}})
+	data = getCordaViewData(cordaViewAddress, payload, []ed25519.PrivateKey{noOrgKey}, []string{noOrgCert}, []string{"PartyB"})
+	_, _, err = verifyCordaProof(data, cordaViewAddress)
+	require.EqualError(t, err, "X.500 name CN=PartyB,L=New York,C=US in certificate does not have a single organisation")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a notarization signed by another node than the one of its certificate
+	data = getCordaViewData(cordaViewAddress, payload, []ed25519.PrivateKey{partyCKey}, []string{partyBCert}, []string{"PartyB"})
+	_, _, err = verifyCordaProof(data, cordaViewAddress)
+	require.EqualError(t, err, "Unable to Validate Signature: Signature is not valid. ED25519 VERIFY")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with view data that is not protobuf
+	_, _, err = verifyCordaProof([]byte("not a view"), cordaViewAddress)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Unable to decode corda view data")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+}
+
+func TestVerifyCordaNotarizationId(t *testing.T) {
+	_, partyBCert := getCordaNodeCertificate(t, pkix.Name{Organization: []string{"PartyB"}, Locality: []string{"New York"}, Country: []string{"US"}})
+	cert, err := parseCert(partyBCert)
+	require.NoError(t, err)
+
+	// Test success with the organisation of the X.500 name
+	require.NoError(t, verifyCordaNotarizationId(cert, "PartyB"))
+
+	// Test failure with other attributes of the X.500 name, or a name differing only in case
+	for _, id := range []string{"partyb", "New York", "US", ""} {
+		err = verifyCordaNotarizationId(cert, id)
+		require.EqualError(t, err, fmt.Sprintf("Notarization id %s does not match the X.500 name in its certificate: O=PartyB,L=New York,C=US", id))
+		fmt.Printf("Test failed as expected with error: %s\n", err)
+	}
+
+	// Test failure with a certificate whose X.500 name has several organisations
+	_, multiOrgCert := getCordaNodeCertificate(t, pkix.Name{Organization: []string{"PartyB", "PartyC"}, Locality: []string{"New York"}, Country: []string{"US"}})
+	cert, err = parseCert(multiOrgCert)
+	require.NoError(t, err)
+	err = verifyCordaNotarizationId(cert, "PartyB")
+	require.EqualError(t, err, "X.500 name O=PartyB+O=PartyC,L=New York,C=US in certificate does not have a single organisation")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+}
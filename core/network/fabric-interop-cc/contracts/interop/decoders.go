@@ -16,6 +16,7 @@ import (
 	"strings"
 
 	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/ethereum"
 )
 
 func decodeMembership(jsonBytes []byte) (*common.Membership, error) {
@@ -50,3 +51,14 @@ func decodeAccessControlPolicy(jsonBytes []byte) (*common.AccessControlPolicy, e
 	}
 	return &decodeObj, nil
 }
+
+func decodeValidatorSet(jsonBytes []byte) (*ethereum.ValidatorSet, error) {
+	var decodeObj ethereum.ValidatorSet
+	dec := json.NewDecoder(strings.NewReader(string(jsonBytes)))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(&decodeObj)
+	if err != nil {
+		return nil, err
+	}
+	return &decodeObj, nil
+}
@@ -0,0 +1,305 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// ethereum_validator_set contains all the code related to the ValidatorSet struct, which captures the validators of
+// an external Ethereum network with BFT finality, including CRUD operations and the verification of the committed
+// seals of block headers
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/ethereum"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+const validatorSetObjectType = "validatorSet"
+
+// BFT protocols whose block headers can be verified; they differ in how the extra data of a header is encoded for
+// the committed seals: IBFT 2.0 drops the list of seals, while QBFT replaces it with an empty list
+const (
+	ibft2Consensus = "IBFT2"
+	qbftConsensus  = "QBFT"
+)
+
+// indexes of fields of the RLP encoded block header, and of the BFT extra data
+const (
+	headerStateRootIndex    = 3
+	headerReceiptsRootIndex = 5
+	headerTimestampIndex    = 11
+	headerExtraDataIndex    = 12
+	bftExtraDataLength      = 5 // vanity, validators, vote, round, committed seals
+	bftExtraDataSealsIndex  = 4
+)
+
+// function to check a validator set, and to normalize the addresses of its validators to lower case
+func validateValidatorSet(validatorSet *ethereum.ValidatorSet) error {
+	if validatorSet.SecurityDomain == "" {
+		return fmt.Errorf("security domain not supplied")
+	}
+	if validatorSet.Consensus != ibft2Consensus && validatorSet.Consensus != qbftConsensus {
+		return fmt.Errorf("unsupported consensus %s; supported ones are %s and %s", validatorSet.Consensus, ibft2Consensus, qbftConsensus)
+	}
+	if len(validatorSet.Validators) == 0 {
+		return fmt.Errorf("no validators supplied")
+	}
+	if validatorSet.MaxBlockAgeSecs == 0 {
+		return fmt.Errorf("maximum block age not supplied")
+	}
+	for i, validator := range validatorSet.Validators {
+		validator = strings.ToLower(validator)
+		addressBytes, err := hex.DecodeString(strings.TrimPrefix(validator, "0x"))
+		if err != nil || len(addressBytes) != 20 || !strings.HasPrefix(validator, "0x") {
+			return fmt.Errorf("validator %s is not a 0x-prefixed 20-byte hex address", validatorSet.Validators[i])
+		}
+		if Contains(validatorSet.Validators[:i], validator) {
+			return fmt.Errorf("validator %s is listed more than once", validator)
+		}
+		validatorSet.Validators[i] = validator
+	}
+	return nil
+}
+
+// CreateValidatorSet cc is used to store a ValidatorSet in the ledger
+func (s *SmartContract) CreateValidatorSet(ctx contractapi.TransactionContextInterface, validatorSetJSON string) error {
+	validatorSet, err := decodeValidatorSet([]byte(validatorSetJSON))
+	if err != nil {
+		return fmt.Errorf("Unmarshal error: %s", err)
+	}
+	err = validateValidatorSet(validatorSet)
+	if err != nil {
+		return fmt.Errorf("Invalid validator set: %s", err)
+	}
+	validatorSetKey, err := ctx.GetStub().CreateCompositeKey(validatorSetObjectType, []string{validatorSet.SecurityDomain})
+	if err != nil {
+		return err
+	}
+	existing, err := ctx.GetStub().GetState(validatorSetKey)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("ValidatorSet already exists for security domain: %s", validatorSet.SecurityDomain)
+	}
+
+	validatorSetBytes, err := json.Marshal(validatorSet)
+	if err != nil {
+		return fmt.Errorf("Marshal error: %s", err)
+	}
+	return ctx.GetStub().PutState(validatorSetKey, validatorSetBytes)
+}
+
+// UpdateValidatorSet cc is used to update an existing ValidatorSet in the ledger
+func (s *SmartContract) UpdateValidatorSet(ctx contractapi.TransactionContextInterface, validatorSetJSON string) error {
+	validatorSet, err := decodeValidatorSet([]byte(validatorSetJSON))
+	if err != nil {
+		return fmt.Errorf("Unmarshal error: %s", err)
+	}
+	err = validateValidatorSet(validatorSet)
+	if err != nil {
+		return fmt.Errorf("Invalid validator set: %s", err)
+	}
+	validatorSetKey, err := ctx.GetStub().CreateCompositeKey(validatorSetObjectType, []string{validatorSet.SecurityDomain})
+	if err != nil {
+		return err
+	}
+	_, err = s.GetValidatorSetBySecurityDomain(ctx, validatorSet.SecurityDomain)
+	if err != nil {
+		return err
+	}
+
+	validatorSetBytes, err := json.Marshal(validatorSet)
+	if err != nil {
+		return fmt.Errorf("Marshal error: %s", err)
+	}
+	return ctx.GetStub().PutState(validatorSetKey, validatorSetBytes)
+}
+
+// DeleteValidatorSet cc is used to delete an existing ValidatorSet in the ledger
+func (s *SmartContract) DeleteValidatorSet(ctx contractapi.TransactionContextInterface, securityDomain string) error {
+	validatorSetKey, err := ctx.GetStub().CreateCompositeKey(validatorSetObjectType, []string{securityDomain})
+	if err != nil {
+		return err
+	}
+	bytes, err := ctx.GetStub().GetState(validatorSetKey)
+	if err != nil {
+		return err
+	}
+	if bytes == nil {
+		return fmt.Errorf("ValidatorSet with id: %s does not exist", securityDomain)
+	}
+	err = ctx.GetStub().DelState(validatorSetKey)
+	if err != nil {
+		return fmt.Errorf("failed to delete asset %s: %v", validatorSetKey, err)
+	}
+	return nil
+}
+
+// GetValidatorSetBySecurityDomain cc gets the ValidatorSet for the provided security domain
+func (s *SmartContract) GetValidatorSetBySecurityDomain(ctx contractapi.TransactionContextInterface, securityDomain string) (string, error) {
+	validatorSetKey, err := ctx.GetStub().CreateCompositeKey(validatorSetObjectType, []string{securityDomain})
+	if err != nil {
+		return "", err
+	}
+	bytes, err := ctx.GetStub().GetState(validatorSetKey)
+	if err != nil {
+		return "", err
+	}
+	if bytes == nil {
+		return "", fmt.Errorf("ValidatorSet with id: %s does not exist", securityDomain)
+	}
+	return string(bytes), nil
+}
+
+// function to get the number of committed seals a block needs in a BFT network of numValidators validators
+func getBFTQuorum(numValidators int) int {
+	return (2*numValidators + 2) / 3
+}
+
+// bftBlockHeader holds the fields of a block header that views from an Ethereum network are verified against
+type bftBlockHeader struct {
+	stateRoot     []byte
+	receiptsRoot  []byte
+	timestampSecs uint64
+}
+
+// function to decode a 32-byte root hash from a block header field
+func decodeHeaderRoot(fields []rlp.RawValue, index int) ([]byte, error) {
+	var root []byte
+	err := rlp.DecodeBytes(fields[index], &root)
+	if err != nil || len(root) != 32 {
+		return nil, fmt.Errorf("Invalid block header: field %d is not a 32-byte root", index)
+	}
+	return root, nil
+}
+
+/*
+ * Function to verify that a block header is final in the Ethereum network of the given security domain, and recent
+ * enough: its committed seals need to be signatures, by a quorum of the validators in the ValidatorSet of the network,
+ * of the hash of the header with the seals removed from its extra data, and its timestamp can be at most the maximum
+ * block age of the network (beyond the clock skew tolerance) before the transaction time. It returns the roots and
+ * timestamp of the header, and the (distinct) validators that sealed it.
+ */
+func verifyBFTBlockHeader(s *SmartContract, ctx contractapi.TransactionContextInterface, headerBytes []byte, securityDomain string) (*bftBlockHeader, []string, error) {
+	validatorSetString, err := s.GetValidatorSetBySecurityDomain(ctx, securityDomain)
+	if err != nil {
+		return nil, nil, err
+	}
+	validatorSet, err := decodeValidatorSet([]byte(validatorSetString))
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to unmarshal validator set: %s", err.Error())
+	}
+
+	var fields []rlp.RawValue
+	err = rlp.DecodeBytes(headerBytes, &fields)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Invalid block header: %s", err.Error())
+	}
+	if len(fields) <= headerExtraDataIndex {
+		return nil, nil, fmt.Errorf("Invalid block header: not a list of at least %d fields", headerExtraDataIndex+1)
+	}
+	header := &bftBlockHeader{}
+	header.stateRoot, err = decodeHeaderRoot(fields, headerStateRootIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+	header.receiptsRoot, err = decodeHeaderRoot(fields, headerReceiptsRootIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = rlp.DecodeBytes(fields[headerTimestampIndex], &header.timestampSecs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Invalid block header: invalid timestamp")
+	}
+	var extraDataBytes []byte
+	var extraData []rlp.RawValue
+	var seals [][]byte
+	err = rlp.DecodeBytes(fields[headerExtraDataIndex], &extraDataBytes)
+	if err == nil {
+		err = rlp.DecodeBytes(extraDataBytes, &extraData)
+	}
+	if err == nil && len(extraData) == bftExtraDataLength {
+		err = rlp.DecodeBytes(extraData[bftExtraDataSealsIndex], &seals)
+	}
+	if err != nil || len(extraData) != bftExtraDataLength {
+		return nil, nil, fmt.Errorf("Invalid block header: extra data is not in the BFT format")
+	}
+
+	// hash of the header with the seals removed from its extra data, which is what each validator signs
+	sealingExtraData := append([]rlp.RawValue{}, extraData[:bftExtraDataSealsIndex]...)
+	if validatorSet.Consensus == qbftConsensus {
+		sealingExtraData = append(sealingExtraData, rlp.EmptyList)
+	}
+	sealingExtraDataBytes, err := rlp.EncodeToBytes(sealingExtraData)
+	if err != nil {
+		return nil, nil, err
+	}
+	sealingFields := append([]rlp.RawValue{}, fields...)
+	sealingFields[headerExtraDataIndex], err = rlp.EncodeToBytes(sealingExtraDataBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	sealingHeaderBytes, err := rlp.EncodeToBytes(sealingFields)
+	if err != nil {
+		return nil, nil, err
+	}
+	sealHash := crypto.Keccak256(sealingHeaderBytes)
+
+	signerList := []string{}
+	for i, seal := range seals {
+		signer, err := recoverSealSigner(sealHash, seal)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Invalid committed seal %d: %s", i, err.Error())
+		}
+		if !Contains(validatorSet.Validators, signer) {
+			return nil, nil, fmt.Errorf("Committed seal %d is not by a validator of %s: %s", i, securityDomain, signer)
+		}
+		if !Contains(signerList, signer) {
+			signerList = append(signerList, signer)
+		}
+	}
+	quorum := getBFTQuorum(len(validatorSet.Validators))
+	if len(signerList) < quorum {
+		return nil, nil, fmt.Errorf("Block header is sealed by %d validators, fewer than the quorum of %d", len(signerList), quorum)
+	}
+
+	txTime, clockSkewTolerance, err := getTxTimeAndClockSkewTolerance(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	blockTime := time.Unix(int64(header.timestampSecs), 0)
+	if blockTime.After(txTime.Add(clockSkewTolerance)) {
+		return nil, nil, fmt.Errorf("Block time %d is after the transaction time %d", header.timestampSecs, txTime.Unix())
+	}
+	if blockTime.Add(time.Duration(validatorSet.MaxBlockAgeSecs)*time.Second + clockSkewTolerance).Before(txTime) {
+		return nil, nil, fmt.Errorf("Block time %d is more than the maximum block age of %d seconds before the transaction time %d",
+			header.timestampSecs, validatorSet.MaxBlockAgeSecs, txTime.Unix())
+	}
+	return header, signerList, nil
+}
+
+// function to recover the address (in 0x-prefixed lower case hex form) of the validator that produced a committed
+// seal, a recoverable secp256k1 signature (r || s || v, with the recovery id v being 0 or 1, or 27 or 28) of sealHash
+func recoverSealSigner(sealHash []byte, seal []byte) (string, error) {
+	if len(seal) != crypto.SignatureLength {
+		return "", fmt.Errorf("signature has %d bytes instead of %d", len(seal), crypto.SignatureLength)
+	}
+	signature := append([]byte{}, seal...)
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	publicKey, err := crypto.SigToPub(sealHash, signature)
+	if err != nil {
+		return "", err
+	}
+	return "0x" + hex.EncodeToString(crypto.PubkeyToAddress(*publicKey).Bytes()), nil
+}
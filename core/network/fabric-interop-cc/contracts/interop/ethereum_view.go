@@ -0,0 +1,259 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// ethereum_view contains the verification of views from Ethereum networks with BFT finality (see
+// rfcs/formats/views-ethereum.md), whose data is proved by Merkle-Patricia proofs against a sealed block header
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/ethereum"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	protoV2 "google.golang.org/protobuf/proto"
+)
+
+// proof type of Ethereum views, whose data is proved against the state of a block sealed by the validators
+const ethereumStateProofType = "StateProof"
+
+// kinds of queries of Ethereum views, given after the contract address in the view segment of the address
+const (
+	ethereumStorageQuery = "storage" // contract-address:storage:slot{:slot}
+	ethereumEventQuery   = "event"   // contract-address:event:topic{:topic}
+)
+
+const ethereumWordLength = 32
+
+// ethereumViewQuery is the query of the view segment of an Ethereum address: the contract, and either the storage
+// slots whose values are requested, or the topics of the event whose receipt is requested
+type ethereumViewQuery struct {
+	contractAddress ethcommon.Address
+	kind            string
+	words           [][]byte
+}
+
+// function to decode a 0x-prefixed hex string of the given number of bytes
+func decodeEthereumHex(hexString string, length int) ([]byte, error) {
+	if !strings.HasPrefix(strings.ToLower(hexString), "0x") {
+		return nil, fmt.Errorf("%s is not 0x-prefixed", hexString)
+	}
+	decoded, err := hex.DecodeString(hexString[2:])
+	if err != nil || len(decoded) != length {
+		return nil, fmt.Errorf("%s is not a %d-byte hex string", hexString, length)
+	}
+	return decoded, nil
+}
+
+// parseEthereumViewQuery gets the query from the view segment of an Ethereum address, of the form
+// contract-address:storage:slot{:slot} or contract-address:event:topic{:topic}
+func parseEthereumViewQuery(viewAddress string) (*ethereumViewQuery, error) {
+	parts := strings.Split(viewAddress, ":")
+	if len(parts) < 3 {
+		return nil, fmt.Errorf("View segment is not of the form contract-address:%s:slot{:slot} or contract-address:%s:topic{:topic}: %s",
+			ethereumStorageQuery, ethereumEventQuery, viewAddress)
+	}
+	contractAddress, err := decodeEthereumHex(parts[0], ethcommon.AddressLength)
+	if err != nil {
+		return nil, fmt.Errorf("View segment does not start with a 0x-prefixed 20-byte contract address: %s", viewAddress)
+	}
+	query := &ethereumViewQuery{contractAddress: ethcommon.BytesToAddress(contractAddress), kind: parts[1]}
+	if query.kind != ethereumStorageQuery && query.kind != ethereumEventQuery {
+		return nil, fmt.Errorf("Unsupported query %s in view segment; supported ones are %s and %s", query.kind, ethereumStorageQuery, ethereumEventQuery)
+	}
+	for _, part := range parts[2:] {
+		word, err := decodeEthereumHex(part, ethereumWordLength)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid slot or topic in view segment: %s", err.Error())
+		}
+		query.words = append(query.words, word)
+	}
+	return query, nil
+}
+
+// function to verify a Merkle-Patricia proof (the RLP encoded nodes on the path from the root, as returned by
+// eth_getProof) of the value at key in the trie with the given root; the value is nil if the trie has none at key
+func verifyMerkleProof(root []byte, key []byte, proof [][]byte) ([]byte, error) {
+	// the trie of an account without storage is empty, and is proved by no nodes at all
+	if len(proof) == 0 && bytes.Equal(root, types.EmptyRootHash.Bytes()) {
+		return nil, nil
+	}
+	proofDb := memorydb.New()
+	for _, node := range proof {
+		err := proofDb.Put(crypto.Keccak256(node), node)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return trie.VerifyProof(ethcommon.BytesToHash(root), key, proofDb)
+}
+
+// function to verify the storage proof of an Ethereum view against the state root of its block, and to get the data
+// the proof establishes: the concatenation of the 32-byte (left-padded) values of the slots requested by the query
+func verifyEthereumStorageProof(storageProof *ethereum.StorageProof, stateRoot []byte, query *ethereumViewQuery) ([]byte, error) {
+	if !bytes.Equal(storageProof.Account, query.contractAddress.Bytes()) {
+		return nil, fmt.Errorf("Storage proof is for account %x instead of the view contract %x", storageProof.Account, query.contractAddress.Bytes())
+	}
+	accountBytes, err := verifyMerkleProof(stateRoot, crypto.Keccak256(storageProof.Account), storageProof.AccountProof)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid account proof: %s", err.Error())
+	}
+	if accountBytes == nil {
+		return nil, fmt.Errorf("Account %x does not exist in the state of the block", storageProof.Account)
+	}
+	var account types.StateAccount
+	err = rlp.DecodeBytes(accountBytes, &account)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid account %x in the state of the block", storageProof.Account)
+	}
+
+	if len(storageProof.Entries) != len(query.words) {
+		return nil, fmt.Errorf("Storage proof has %d entries for the %d slots of the query", len(storageProof.Entries), len(query.words))
+	}
+	data := []byte{}
+	for i, entry := range storageProof.Entries {
+		if !bytes.Equal(entry.Key, query.words[i]) {
+			return nil, fmt.Errorf("Storage entry %d is for slot %x instead of slot %x of the query", i, entry.Key, query.words[i])
+		}
+		if len(entry.Value) > ethereumWordLength || (len(entry.Value) > 0 && entry.Value[0] == 0) {
+			return nil, fmt.Errorf("Storage entry %d has a value that is not a word without leading zeros", i)
+		}
+		encodedValue, err := verifyMerkleProof(account.Root.Bytes(), crypto.Keccak256(entry.Key), entry.Proof)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid proof of storage entry %d: %s", i, err.Error())
+		}
+		// slots are stored as RLP encoded strings, and zero slots are absent from the trie
+		var value []byte
+		if encodedValue != nil {
+			err = rlp.DecodeBytes(encodedValue, &value)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid value of storage entry %d in the trie", i)
+			}
+		}
+		if !bytes.Equal(value, entry.Value) {
+			return nil, fmt.Errorf("Value of storage entry %d does not match the proof", i)
+		}
+		data = append(data, ethcommon.LeftPadBytes(value, ethereumWordLength)...)
+	}
+	return data, nil
+}
+
+// function to verify the receipt proof of an Ethereum view against the receipts root of its block, and to get the
+// data the proof establishes: the receipt, which needs to hold a log of the contract with the topics of the query
+func verifyEthereumReceiptProof(receiptProof *ethereum.ReceiptProof, receiptsRoot []byte, query *ethereumViewQuery) ([]byte, error) {
+	receiptBytes, err := verifyMerkleProof(receiptsRoot, rlp.AppendUint64(nil, receiptProof.TransactionIndex), receiptProof.Proof)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid receipt proof: %s", err.Error())
+	}
+	if receiptBytes == nil {
+		return nil, fmt.Errorf("Block has no receipt at transaction index %d", receiptProof.TransactionIndex)
+	}
+	if !bytes.Equal(receiptBytes, receiptProof.Receipt) {
+		return nil, fmt.Errorf("Receipt does not match the proof")
+	}
+	var receipt types.Receipt
+	err = receipt.UnmarshalBinary(receiptBytes)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid receipt: %s", err.Error())
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("Receipt is of a failed transaction")
+	}
+	for _, log := range receipt.Logs {
+		if log.Address != query.contractAddress || len(log.Topics) < len(query.words) {
+			continue
+		}
+		matches := true
+		for i, topic := range query.words {
+			matches = matches && bytes.Equal(log.Topics[i].Bytes(), topic)
+		}
+		if matches {
+			return receiptBytes, nil
+		}
+	}
+	return nil, fmt.Errorf("Receipt has no log of contract %x with the topics of the query", query.contractAddress.Bytes())
+}
+
+// The verifyEthereumStateProof function is used to verify views that come from an Ethereum network with BFT
+// finality, whose data is proved against the state of a block sealed by the validators of the network.
+//
+// Verification requires the following steps:
+// 1. Create [EthereumViewData] from the view.
+// 2. Verify address in payload is the same as original address
+// 3. Verify the committed seals of the block header against the ValidatorSet of the network, and that the block is
+//    recent enough.
+// 4. Verify the storage or receipt proof against the block header, and that it answers the query of the address
+//    (the storage slots, or the contract and topics of an event) and the payload is the data it proves.
+// 5. Check the validators that sealed the block fulfill the verification policy of the request.
+func verifyEthereumStateProof(s *SmartContract, ctx contractapi.TransactionContextInterface, data []byte, verificationPolicy *common.Policy, securityDomain string, address string) error {
+	// 1. Create [EthereumViewData] from the view.
+	var ethereumViewData ethereum.ViewData
+	err := protoV2.Unmarshal(data, &ethereumViewData)
+	if err != nil {
+		return fmt.Errorf("Unable to decode ethereum view data: %s", err.Error())
+	}
+	// 2. Verify address in payload is the same as original address
+	var interopPayload common.InteropPayload
+	err = protoV2.Unmarshal(ethereumViewData.Payload, &interopPayload)
+	if err != nil {
+		return fmt.Errorf("Unable to Unmarshal interopPayload: %s", err.Error())
+	}
+	if address != interopPayload.Address {
+		return fmt.Errorf("Address in response does not match original address: Original: %s Response: %s", address, interopPayload.Address)
+	}
+	if interopPayload.Confidential {
+		return fmt.Errorf("Confidential payloads are not supported in ethereum views")
+	}
+	// 3. Verify the committed seals of the block header against the ValidatorSet of the network, and its age.
+	header, signerList, err := verifyBFTBlockHeader(s, ctx, ethereumViewData.BlockHeader, securityDomain)
+	if err != nil {
+		return err
+	}
+	// 4. Verify the storage or receipt proof against the block header, and that it answers the query of the address.
+	addressStruct, err := parseAddress(address)
+	if err != nil {
+		return fmt.Errorf("Unable to parse address: %s", err.Error())
+	}
+	query, err := parseEthereumViewQuery(addressStruct.ViewSegment)
+	if err != nil {
+		return err
+	}
+	var provedData []byte
+	switch proof := ethereumViewData.Proof.(type) {
+	case *ethereum.ViewData_StorageProof:
+		if query.kind != ethereumStorageQuery {
+			return fmt.Errorf("Storage proof supplied for a query of kind %s", query.kind)
+		}
+		provedData, err = verifyEthereumStorageProof(proof.StorageProof, header.stateRoot, query)
+		if err != nil {
+			return err
+		}
+	case *ethereum.ViewData_ReceiptProof:
+		if query.kind != ethereumEventQuery {
+			return fmt.Errorf("Receipt proof supplied for a query of kind %s", query.kind)
+		}
+		provedData, err = verifyEthereumReceiptProof(proof.ReceiptProof, header.receiptsRoot, query)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("Ethereum view data has no proof")
+	}
+	if !bytes.Equal(provedData, interopPayload.Payload) {
+		return fmt.Errorf("Payload in response does not match the data proved by the view")
+	}
+	// 5. Check the validators that sealed the block fulfill the verification policy of the request.
+	return verifyPolicySatisfiedBySigners(verificationPolicy, signerList)
+}
@@ -0,0 +1,602 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/ethereum"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/core/network/fabric-interop-cc/contracts/interop/mocks"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ethereumSecurityDomain   = "besu_network"
+	ethereumContractAddress  = "0x51c9c2475f106fd6bed2bd45824a9ab5b0d24113"
+	ethereumValidatorsNumber = 4
+	ethereumMaxBlockAgeSecs  = 600
+)
+
+// topic of the event the view contract emits when a value is stored
+var ethereumStoredTopic = crypto.Keccak256Hash([]byte("Stored(uint256)"))
+
+var (
+	ethereumStorageAddress = getEthereumAddress(ethereumStorageQuery, ethereumSlot(0), ethereumSlot(1), ethereumSlot(2))
+	ethereumEventAddress   = getEthereumAddress(ethereumEventQuery, ethereumStoredTopic)
+)
+
+// function that supplies the storage slot of the given index
+func ethereumSlot(i int64) ethcommon.Hash {
+	return ethcommon.BigToHash(big.NewInt(i))
+}
+
+// function that supplies the address of a view of the test Ethereum network for the given query of the view contract
+func getEthereumAddress(kind string, words ...ethcommon.Hash) string {
+	address := "localhost:8545/" + ethereumSecurityDomain + "/" + ethereumContractAddress + ":" + kind
+	for _, word := range words {
+		address += ":" + word.Hex()
+	}
+	return address
+}
+
+// function that supplies the private keys and addresses of the validators of a test Ethereum network
+func getTestValidators(t *testing.T) ([]*ecdsa.PrivateKey, []string) {
+	privateKeys := []*ecdsa.PrivateKey{}
+	addresses := []string{}
+	for i := 0; i < ethereumValidatorsNumber; i++ {
+		privateKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		privateKeys = append(privateKeys, privateKey)
+		addresses = append(addresses, strings.ToLower(crypto.PubkeyToAddress(privateKey.PublicKey).Hex()))
+	}
+	return privateKeys, addresses
+}
+
+func getValidatorSetJSON(consensus string, validators []string) string {
+	validatorSet := &ethereum.ValidatorSet{
+		SecurityDomain:  ethereumSecurityDomain,
+		Consensus:       consensus,
+		Validators:      validators,
+		MaxBlockAgeSecs: ethereumMaxBlockAgeSecs,
+	}
+	validatorSetBytes, _ := json.Marshal(validatorSet)
+	return string(validatorSetBytes)
+}
+
+// function that builds a block header with the given roots and timestamp, sealed by the validators of the given
+// private keys
+func getSealedBlockHeader(consensus string, stateRoot []byte, receiptsRoot []byte, timestampSecs uint64, validators []string, signerKeys []*ecdsa.PrivateKey) []byte {
+	validatorAddresses := []ethcommon.Address{}
+	for _, validator := range validators {
+		validatorAddresses = append(validatorAddresses, ethcommon.HexToAddress(validator))
+	}
+	// IBFT 2.0 encodes the round as a 4-byte integer, and QBFT as a scalar
+	var round interface{} = []byte{0, 0, 0, 0}
+	if consensus == qbftConsensus {
+		round = uint64(0)
+	}
+	extraDataItems := []interface{}{make([]byte, 32), validatorAddresses, []interface{}{}, round}
+	header := &types.Header{
+		Root:        ethcommon.BytesToHash(stateRoot),
+		TxHash:      types.EmptyRootHash,
+		ReceiptHash: ethcommon.BytesToHash(receiptsRoot),
+		Difficulty:  big.NewInt(1),
+		Number:      big.NewInt(100),
+		GasLimit:    0x1fffffffffffff,
+		GasUsed:     21000,
+		Time:        timestampSecs,
+	}
+	sealingExtraDataItems := append([]interface{}{}, extraDataItems...)
+	if consensus == qbftConsensus {
+		sealingExtraDataItems = append(sealingExtraDataItems, [][]byte{})
+	}
+	header.Extra, _ = rlp.EncodeToBytes(sealingExtraDataItems)
+	sealHash := header.Hash().Bytes()
+	seals := [][]byte{}
+	for _, signerKey := range signerKeys {
+		seal, _ := crypto.Sign(sealHash, signerKey)
+		seals = append(seals, seal)
+	}
+	header.Extra, _ = rlp.EncodeToBytes(append(extraDataItems, seals))
+	headerBytes, _ := rlp.EncodeToBytes(header)
+	return headerBytes
+}
+
+// function that supplies the base64 encoded view of an Ethereum network for the given view data and payload
+func getEthereumViewB64(viewData *ethereum.ViewData, address string, payload []byte) string {
+	interopPayloadBytes, _ := proto.Marshal(&common.InteropPayload{Address: address, Payload: payload})
+	viewData.Payload = interopPayloadBytes
+	viewDataBytes, _ := proto.Marshal(viewData)
+	viewBytes, _ := proto.Marshal(&common.View{
+		Meta: &common.Meta{
+			Protocol:  common.Meta_ETHEREUM,
+			Timestamp: "2022-04-15T09:00:00Z",
+			ProofType: ethereumStateProofType,
+		},
+		Data: viewDataBytes,
+	})
+	return base64.StdEncoding.EncodeToString(viewBytes)
+}
+
+// function to record the verification policy and the validator set of the test Ethereum network
+func setupEthereumNetwork(t *testing.T, interopcc *SmartContract, ctx *mocks.TransactionContext, mockStub *shimtest.MockStub, policy *common.Policy, consensus string, validators []string) {
+	verificationPolicy := &common.VerificationPolicy{
+		SecurityDomain: ethereumSecurityDomain,
+		Identifiers: []*common.Identifier{
+			{Pattern: ethereumContractAddress + ":*", Policy: policy},
+		},
+	}
+	verificationPolicyBytes, _ := json.Marshal(verificationPolicy)
+	mockStub.MockTransactionStart("setup")
+	defer mockStub.MockTransactionEnd("setup")
+	require.NoError(t, interopcc.CreateVerificationPolicy(ctx, string(verificationPolicyBytes)))
+	require.NoError(t, interopcc.CreateValidatorSet(ctx, getValidatorSetJSON(consensus, validators)))
+}
+
+// testTrie is a Merkle-Patricia trie of test Ethereum state or receipts, whose keys are hashed if it is secure (as in
+// the state and storage tries)
+type testTrie struct {
+	trie   *trie.Trie
+	root   []byte
+	secure bool
+}
+
+func newTestTrie(entries map[string][]byte, secure bool) *testTrie {
+	t := trie.NewEmpty(trie.NewDatabase(memorydb.New()))
+	for key, value := range entries {
+		if secure {
+			key = string(crypto.Keccak256([]byte(key)))
+		}
+		t.Update([]byte(key), value)
+	}
+	return &testTrie{trie: t, root: t.Hash().Bytes(), secure: secure}
+}
+
+// proofList collects the nodes of a Merkle-Patricia proof, in the order of eth_getProof (from the root)
+type proofList [][]byte
+
+func (l *proofList) Put(key []byte, value []byte) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func (l *proofList) Delete(key []byte) error {
+	return fmt.Errorf("proof nodes cannot be deleted")
+}
+
+// function that supplies the proof of the value at key in the trie, or of its absence
+func (t *testTrie) proof(key []byte) [][]byte {
+	if t.secure {
+		key = crypto.Keccak256(key)
+	}
+	var proof proofList
+	t.trie.Prove(key, 0, &proof)
+	return proof
+}
+
+// function that supplies the value at key in the trie (nil if absent)
+func (t *testTrie) get(key []byte) []byte {
+	if t.secure {
+		key = crypto.Keccak256(key)
+	}
+	value, _ := t.trie.TryGet(key)
+	return value
+}
+
+func TestValidatorSet(t *testing.T) {
+	ctx, mockStub, interopcc := prepShimMockStub()
+	_, validators := getTestValidators(t)
+	mixedCaseValidators := append([]string{strings.ToUpper(validators[0][:4]) + validators[0][4:]}, validators[1:]...)
+
+	// Test success with a new validator set, whose addresses get normalized to lower case
+	mockStub.MockTransactionStart("tx1")
+	err := interopcc.CreateValidatorSet(ctx, getValidatorSetJSON(ibft2Consensus, mixedCaseValidators))
+	mockStub.MockTransactionEnd("tx1")
+	require.NoError(t, err)
+	validatorSetJSON, err := interopcc.GetValidatorSetBySecurityDomain(ctx, ethereumSecurityDomain)
+	require.NoError(t, err)
+	validatorSet, err := decodeValidatorSet([]byte(validatorSetJSON))
+	require.NoError(t, err)
+	require.Equal(t, ibft2Consensus, validatorSet.Consensus)
+	require.Equal(t, validators, validatorSet.Validators)
+
+	// Test failure to create the validator set of a security domain twice
+	mockStub.MockTransactionStart("tx2")
+	err = interopcc.CreateValidatorSet(ctx, getValidatorSetJSON(ibft2Consensus, validators))
+	mockStub.MockTransactionEnd("tx2")
+	require.EqualError(t, err, "ValidatorSet already exists for security domain: "+ethereumSecurityDomain)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with an update of the validator set
+	mockStub.MockTransactionStart("tx3")
+	err = interopcc.UpdateValidatorSet(ctx, getValidatorSetJSON(qbftConsensus, validators[1:]))
+	mockStub.MockTransactionEnd("tx3")
+	require.NoError(t, err)
+	validatorSetJSON, err = interopcc.GetValidatorSetBySecurityDomain(ctx, ethereumSecurityDomain)
+	require.NoError(t, err)
+	validatorSet, err = decodeValidatorSet([]byte(validatorSetJSON))
+	require.NoError(t, err)
+	require.Equal(t, qbftConsensus, validatorSet.Consensus)
+	require.Equal(t, validators[1:], validatorSet.Validators)
+
+	// Test failure with invalid validator sets
+	for validatorSetJSON, expectedErr := range map[string]string{
+		getValidatorSetJSON("Clique", validators):                                                                       "unsupported consensus Clique; supported ones are IBFT2 and QBFT",
+		getValidatorSetJSON(qbftConsensus, []string{}):                                                                  "no validators supplied",
+		getValidatorSetJSON(qbftConsensus, []string{validators[0][2:]}):                                                 "validator " + validators[0][2:] + " is not a 0x-prefixed 20-byte hex address",
+		getValidatorSetJSON(qbftConsensus, []string{"0x1234"}):                                                          "validator 0x1234 is not a 0x-prefixed 20-byte hex address",
+		getValidatorSetJSON(qbftConsensus, append(validators, validators[2])):                                           "validator " + validators[2] + " is listed more than once",
+		`{"consensus":"QBFT","validators":["` + validators[0] + `"]}`:                                                   "security domain not supplied",
+		`{"securityDomain":"` + ethereumSecurityDomain + `","consensus":"QBFT","validators":["` + validators[0] + `"]}`: "maximum block age not supplied",
+	} {
+		mockStub.MockTransactionStart("tx4")
+		err = interopcc.UpdateValidatorSet(ctx, validatorSetJSON)
+		mockStub.MockTransactionEnd("tx4")
+		require.EqualError(t, err, "Invalid validator set: "+expectedErr)
+		fmt.Printf("Test failed as expected with error: %s\n", err)
+	}
+
+	// Test success with the deletion of the validator set, and failure to get or update it afterwards
+	mockStub.MockTransactionStart("tx5")
+	err = interopcc.DeleteValidatorSet(ctx, ethereumSecurityDomain)
+	mockStub.MockTransactionEnd("tx5")
+	require.NoError(t, err)
+	_, err = interopcc.GetValidatorSetBySecurityDomain(ctx, ethereumSecurityDomain)
+	require.EqualError(t, err, "ValidatorSet with id: "+ethereumSecurityDomain+" does not exist")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	mockStub.MockTransactionStart("tx6")
+	err = interopcc.UpdateValidatorSet(ctx, getValidatorSetJSON(qbftConsensus, validators))
+	mockStub.MockTransactionEnd("tx6")
+	require.EqualError(t, err, "ValidatorSet with id: "+ethereumSecurityDomain+" does not exist")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+}
+
+// function that supplies a test Ethereum state: the state trie with the view contract account, whose storage holds
+// the first two slots, and the storage trie of the account
+func getTestEthereumState() (*testTrie, *testTrie, []byte) {
+	contractAddress := ethcommon.HexToAddress(ethereumContractAddress).Bytes()
+	storageValue := func(value []byte) []byte {
+		encodedValue, _ := rlp.EncodeToBytes(value)
+		return encodedValue
+	}
+	storageTrie := newTestTrie(map[string][]byte{
+		string(ethereumSlot(0).Bytes()): storageValue([]byte{0x45}),
+		string(ethereumSlot(1).Bytes()): storageValue([]byte("a string of 31 bytes in storage")),
+	}, true)
+	account := func(stateAccount *types.StateAccount) []byte {
+		encodedAccount, _ := rlp.EncodeToBytes(stateAccount)
+		return encodedAccount
+	}
+	otherAddress := make([]byte, 20)
+	stateTrie := newTestTrie(map[string][]byte{
+		string(contractAddress): account(&types.StateAccount{Nonce: 1, Balance: big.NewInt(0), Root: ethcommon.BytesToHash(storageTrie.root), CodeHash: crypto.Keccak256([]byte("code"))}),
+		string(otherAddress):    account(&types.StateAccount{Nonce: 7, Balance: big.NewInt(1000), Root: types.EmptyRootHash, CodeHash: crypto.Keccak256(nil)}),
+	}, true)
+	return stateTrie, storageTrie, contractAddress
+}
+
+// function that supplies the storage proof of the first three slots of the view contract (the third one being empty),
+// and the data it proves
+func getTestStorageProof(stateTrie *testTrie, storageTrie *testTrie, contractAddress []byte) (*ethereum.StorageProof, []byte) {
+	storageProof := &ethereum.StorageProof{
+		Account:      contractAddress,
+		AccountProof: stateTrie.proof(contractAddress),
+	}
+	data := []byte{}
+	for i := int64(0); i < 3; i++ {
+		key := ethereumSlot(i).Bytes()
+		var value []byte
+		if encodedValue := storageTrie.get(key); encodedValue != nil {
+			rlp.DecodeBytes(encodedValue, &value)
+		}
+		storageProof.Entries = append(storageProof.Entries, &ethereum.StorageProof_StorageEntry{Key: key, Value: value, Proof: storageTrie.proof(key)})
+		data = append(data, ethcommon.LeftPadBytes(value, 32)...)
+	}
+	return storageProof, data
+}
+
+func TestParseEthereumViewQuery(t *testing.T) {
+	// Test success with storage and event queries
+	query, err := parseEthereumViewQuery(ethereumContractAddress + ":storage:" + ethereumSlot(0).Hex() + ":" + ethereumSlot(1).Hex())
+	require.NoError(t, err)
+	require.Equal(t, ethcommon.HexToAddress(ethereumContractAddress), query.contractAddress)
+	require.Equal(t, ethereumStorageQuery, query.kind)
+	require.Equal(t, [][]byte{ethereumSlot(0).Bytes(), ethereumSlot(1).Bytes()}, query.words)
+	query, err = parseEthereumViewQuery(strings.ToUpper(ethereumContractAddress[:10]) + ethereumContractAddress[10:] + ":event:" + ethereumStoredTopic.Hex())
+	require.NoError(t, err)
+	require.Equal(t, ethereumEventQuery, query.kind)
+	require.Equal(t, [][]byte{ethereumStoredTopic.Bytes()}, query.words)
+
+	// Test failure with invalid view segments
+	for viewSegment, expectedErr := range map[string]string{
+		ethereumContractAddress + ":0x2e64cec1":                           "View segment is not of the form contract-address:storage:slot{:slot} or contract-address:event:topic{:topic}: " + ethereumContractAddress + ":0x2e64cec1",
+		ethereumContractAddress[2:] + ":storage:" + ethereumSlot(0).Hex(): "View segment does not start with a 0x-prefixed 20-byte contract address: " + ethereumContractAddress[2:] + ":storage:" + ethereumSlot(0).Hex(),
+		ethereumContractAddress + ":call:" + ethereumSlot(0).Hex():        "Unsupported query call in view segment; supported ones are storage and event",
+		ethereumContractAddress + ":storage:0x01":                         "Invalid slot or topic in view segment: 0x01 is not a 32-byte hex string",
+	} {
+		_, err = parseEthereumViewQuery(viewSegment)
+		require.EqualError(t, err, expectedErr)
+		fmt.Printf("Test failed as expected with error: %s\n", err)
+	}
+}
+
+func TestRecoverSealSigner(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := strings.ToLower(crypto.PubkeyToAddress(privateKey.PublicKey).Hex())
+	sealHash := crypto.Keccak256([]byte("header"))
+	seal, err := crypto.Sign(sealHash, privateKey)
+	require.NoError(t, err)
+
+	// Test success with recovery ids of 0 or 1, and of 27 or 28
+	recovered, err := recoverSealSigner(sealHash, seal)
+	require.NoError(t, err)
+	require.Equal(t, signer, recovered)
+	seal[crypto.RecoveryIDOffset] += 27
+	recovered, err = recoverSealSigner(sealHash, seal)
+	require.NoError(t, err)
+	require.Equal(t, signer, recovered)
+
+	// Test failure with a seal of the wrong length
+	_, err = recoverSealSigner(sealHash, seal[1:])
+	require.EqualError(t, err, "signature has 64 bytes instead of 65")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+}
+
+func TestVerifyEthereumStorageView(t *testing.T) {
+	ctx, mockStub, interopcc := prepShimMockStub()
+	privateKeys, validators := getTestValidators(t)
+	setupEthereumNetwork(t, &interopcc, ctx, mockStub, &common.Policy{Type: policyDSLType, Criteria: []string{"count >= 3"}}, ibft2Consensus, validators)
+	stateTrie, storageTrie, contractAddress := getTestEthereumState()
+	storageProof, data := getTestStorageProof(stateTrie, storageTrie, contractAddress)
+	receiptsRoot := types.EmptyRootHash.Bytes()
+	nowSecs := uint64(time.Now().Unix())
+
+	// Test success with a recent block sealed by a quorum of validators, and extraction of the proved data
+	header := getSealedBlockHeader(ibft2Consensus, stateTrie.root, receiptsRoot, nowSecs, validators, privateKeys[:3])
+	viewData := &ethereum.ViewData{BlockHeader: header, Proof: &ethereum.ViewData_StorageProof{StorageProof: storageProof}}
+	viewB64 := getEthereumViewB64(viewData, ethereumStorageAddress, data)
+	err := interopcc.VerifyView(ctx, viewB64, ethereumStorageAddress)
+	require.NoError(t, err)
+	extractedData, err := interopcc.ParseAndValidateView(ctx, ethereumStorageAddress, viewB64)
+	require.NoError(t, err)
+	require.Equal(t, data, extractedData)
+	require.Equal(t, []byte{0x45}, extractedData[31:32])
+
+	// Test failure with a block sealed by fewer validators than the quorum
+	header = getSealedBlockHeader(ibft2Consensus, stateTrie.root, receiptsRoot, nowSecs, validators, privateKeys[:2])
+	viewData = &ethereum.ViewData{BlockHeader: header, Proof: &ethereum.ViewData_StorageProof{StorageProof: storageProof}}
+	err = interopcc.VerifyView(ctx, getEthereumViewB64(viewData, ethereumStorageAddress, data), ethereumStorageAddress)
+	require.EqualError(t, err, "Block header is sealed by 2 validators, fewer than the quorum of 3")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a block sealed by a key that is not of a validator
+	outsiderKey, _ := crypto.GenerateKey()
+	outsider := strings.ToLower(crypto.PubkeyToAddress(outsiderKey.PublicKey).Hex())
+	header = getSealedBlockHeader(ibft2Consensus, stateTrie.root, receiptsRoot, nowSecs, validators, append([]*ecdsa.PrivateKey{outsiderKey}, privateKeys[:3]...))
+	viewData = &ethereum.ViewData{BlockHeader: header, Proof: &ethereum.ViewData_StorageProof{StorageProof: storageProof}}
+	err = interopcc.VerifyView(ctx, getEthereumViewB64(viewData, ethereumStorageAddress, data), ethereumStorageAddress)
+	require.EqualError(t, err, "Committed seal 0 is not by a validator of "+ethereumSecurityDomain+": "+outsider)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a block sealed for another consensus protocol than the one of the validator set
+	header = getSealedBlockHeader(qbftConsensus, stateTrie.root, receiptsRoot, nowSecs, validators, privateKeys[:3])
+	viewData = &ethereum.ViewData{BlockHeader: header, Proof: &ethereum.ViewData_StorageProof{StorageProof: storageProof}}
+	err = interopcc.VerifyView(ctx, getEthereumViewB64(viewData, ethereumStorageAddress, data), ethereumStorageAddress)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Committed seal 0 is not by a validator")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a block older than the maximum block age, or later than the transaction
+	header = getSealedBlockHeader(ibft2Consensus, stateTrie.root, receiptsRoot, nowSecs-ethereumMaxBlockAgeSecs-100, validators, privateKeys[:3])
+	viewData = &ethereum.ViewData{BlockHeader: header, Proof: &ethereum.ViewData_StorageProof{StorageProof: storageProof}}
+	err = interopcc.VerifyView(ctx, getEthereumViewB64(viewData, ethereumStorageAddress, data), ethereumStorageAddress)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), fmt.Sprintf("is more than the maximum block age of %d seconds before the transaction time", ethereumMaxBlockAgeSecs))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	header = getSealedBlockHeader(ibft2Consensus, stateTrie.root, receiptsRoot, nowSecs+100, validators, privateKeys[:3])
+	viewData = &ethereum.ViewData{BlockHeader: header, Proof: &ethereum.ViewData_StorageProof{StorageProof: storageProof}}
+	err = interopcc.VerifyView(ctx, getEthereumViewB64(viewData, ethereumStorageAddress, data), ethereumStorageAddress)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), fmt.Sprintf("Block time %d is after the transaction time", nowSecs+100))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a payload other than the data proved
+	header = getSealedBlockHeader(ibft2Consensus, stateTrie.root, receiptsRoot, nowSecs, validators, privateKeys[:3])
+	viewData = &ethereum.ViewData{BlockHeader: header, Proof: &ethereum.ViewData_StorageProof{StorageProof: storageProof}}
+	tamperedData := append([]byte{}, data...)
+	tamperedData[31] = 0x46
+	err = interopcc.VerifyView(ctx, getEthereumViewB64(viewData, ethereumStorageAddress, tamperedData), ethereumStorageAddress)
+	require.EqualError(t, err, "Payload in response does not match the data proved by the view")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a storage value other than the one proved
+	firstSlotAddress := getEthereumAddress(ethereumStorageQuery, ethereumSlot(0))
+	tamperedEntry := &ethereum.StorageProof_StorageEntry{Key: storageProof.Entries[0].Key, Value: []byte{0x46}, Proof: storageProof.Entries[0].Proof}
+	tamperedProof := &ethereum.StorageProof{Account: storageProof.Account, AccountProof: storageProof.AccountProof,
+		Entries: []*ethereum.StorageProof_StorageEntry{tamperedEntry}}
+	viewData = &ethereum.ViewData{BlockHeader: header, Proof: &ethereum.ViewData_StorageProof{StorageProof: tamperedProof}}
+	err = interopcc.VerifyView(ctx, getEthereumViewB64(viewData, firstSlotAddress, tamperedData[:32]), firstSlotAddress)
+	require.EqualError(t, err, "Value of storage entry 0 does not match the proof")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with storage entries other than the slots of the query, or fewer of them
+	otherSlotsAddress := getEthereumAddress(ethereumStorageQuery, ethereumSlot(0), ethereumSlot(1), ethereumSlot(3))
+	viewData = &ethereum.ViewData{BlockHeader: header, Proof: &ethereum.ViewData_StorageProof{StorageProof: storageProof}}
+	err = interopcc.VerifyView(ctx, getEthereumViewB64(viewData, otherSlotsAddress, data), otherSlotsAddress)
+	require.EqualError(t, err, fmt.Sprintf("Storage entry 2 is for slot %x instead of slot %x of the query", ethereumSlot(2).Bytes(), ethereumSlot(3).Bytes()))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	err = interopcc.VerifyView(ctx, getEthereumViewB64(viewData, firstSlotAddress, data), firstSlotAddress)
+	require.EqualError(t, err, "Storage proof has 3 entries for the 1 slots of the query")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a storage proof for an event query
+	err = interopcc.VerifyView(ctx, getEthereumViewB64(viewData, ethereumEventAddress, data), ethereumEventAddress)
+	require.EqualError(t, err, "Storage proof supplied for a query of kind event")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a storage proof of an account other than the view contract
+	otherAccountProof := &ethereum.StorageProof{Account: make([]byte, 20), AccountProof: stateTrie.proof(make([]byte, 20)),
+		Entries: storageProof.Entries}
+	viewData = &ethereum.ViewData{BlockHeader: header, Proof: &ethereum.ViewData_StorageProof{StorageProof: otherAccountProof}}
+	err = interopcc.VerifyView(ctx, getEthereumViewB64(viewData, ethereumStorageAddress, data), ethereumStorageAddress)
+	require.EqualError(t, err, fmt.Sprintf("Storage proof is for account %x instead of the view contract %x", make([]byte, 20), contractAddress))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a payload for another address than the one requested
+	viewData = &ethereum.ViewData{BlockHeader: header, Proof: &ethereum.ViewData_StorageProof{StorageProof: storageProof}}
+	err = interopcc.VerifyView(ctx, getEthereumViewB64(viewData, ethereumEventAddress, data), ethereumStorageAddress)
+	require.EqualError(t, err, "Address in response does not match original address: Original: "+ethereumStorageAddress+" Response: "+ethereumEventAddress)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a block sealed by a quorum that does not satisfy the verification policy
+	mockStub.MockTransactionStart("tx1")
+	verificationPolicy := &common.VerificationPolicy{
+		SecurityDomain: ethereumSecurityDomain,
+		Identifiers: []*common.Identifier{
+			{Pattern: ethereumContractAddress + ":*", Policy: &common.Policy{Type: policyDSLType, Criteria: []string{"count >= 4"}}},
+		},
+	}
+	verificationPolicyBytes, _ := json.Marshal(verificationPolicy)
+	require.NoError(t, interopcc.UpdateVerificationPolicy(ctx, string(verificationPolicyBytes)))
+	mockStub.MockTransactionEnd("tx1")
+	err = interopcc.VerifyView(ctx, viewB64, ethereumStorageAddress)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "do not satisfy the policy criterion: count >= 4")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+}
+
+// function that supplies the receipts of a test block: a legacy receipt with a log of another contract, an EIP-2718
+// typed receipt with a log of the view contract, and the receipt of a failed transaction
+func getTestReceipts(t *testing.T) (types.Receipts, map[string][]byte) {
+	storedLog := func(contractAddress ethcommon.Address, value int64) *types.Log {
+		return &types.Log{Address: contractAddress, Topics: []ethcommon.Hash{ethereumStoredTopic}, Data: ethcommon.BigToHash(big.NewInt(value)).Bytes()}
+	}
+	receipts := types.Receipts{
+		{Type: types.LegacyTxType, Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 21000,
+			Logs: []*types.Log{storedLog(ethcommon.Address{}, 1)}},
+		{Type: types.DynamicFeeTxType, Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 64000,
+			Logs: []*types.Log{storedLog(ethcommon.HexToAddress(ethereumContractAddress), 2)}},
+		{Type: types.LegacyTxType, Status: types.ReceiptStatusFailed, CumulativeGasUsed: 85000},
+	}
+	entries := map[string][]byte{}
+	for i, receipt := range receipts {
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+		receiptBytes, err := receipt.MarshalBinary()
+		require.NoError(t, err)
+		entries[string(rlp.AppendUint64(nil, uint64(i)))] = receiptBytes
+	}
+	return receipts, entries
+}
+
+func TestVerifyEthereumReceiptView(t *testing.T) {
+	ctx, mockStub, interopcc := prepShimMockStub()
+	privateKeys, validators := getTestValidators(t)
+	// validator addresses are not identifiers of the policy DSL, so the policy requiring one lists it as a signer
+	setupEthereumNetwork(t, &interopcc, ctx, mockStub, &common.Policy{Type: "Signature", Criteria: []string{validators[0]}}, qbftConsensus, validators)
+
+	receipts, receiptEntries := getTestReceipts(t)
+	receiptTrie := newTestTrie(receiptEntries, false)
+	// the receipts root of the test trie is the one the block of the receipts commits to
+	require.Equal(t, types.DeriveSha(receipts, trie.NewStackTrie(nil)).Bytes(), receiptTrie.root)
+	receiptKey := func(transactionIndex uint64) []byte {
+		return rlp.AppendUint64(nil, transactionIndex)
+	}
+	getReceiptProof := func(transactionIndex uint64) *ethereum.ReceiptProof {
+		return &ethereum.ReceiptProof{TransactionIndex: transactionIndex, Receipt: receiptTrie.get(receiptKey(transactionIndex)), Proof: receiptTrie.proof(receiptKey(transactionIndex))}
+	}
+	stateRoot := crypto.Keccak256([]byte("state"))
+	receiptProof := getReceiptProof(1)
+	nowSecs := uint64(time.Now().Unix())
+
+	// Test success with a block sealed by a quorum including the validator the policy requires
+	header := getSealedBlockHeader(qbftConsensus, stateRoot, receiptTrie.root, nowSecs, validators, privateKeys[:3])
+	viewData := &ethereum.ViewData{BlockHeader: header, Proof: &ethereum.ViewData_ReceiptProof{ReceiptProof: receiptProof}}
+	viewB64 := getEthereumViewB64(viewData, ethereumEventAddress, receiptProof.Receipt)
+	err := interopcc.VerifyView(ctx, viewB64, ethereumEventAddress)
+	require.NoError(t, err)
+	extractedData, err := interopcc.ParseAndValidateView(ctx, ethereumEventAddress, viewB64)
+	require.NoError(t, err)
+	require.Equal(t, receiptProof.Receipt, extractedData)
+
+	// Test failure with a block sealed by a quorum without the validator the policy requires
+	header = getSealedBlockHeader(qbftConsensus, stateRoot, receiptTrie.root, nowSecs, validators, privateKeys[1:])
+	viewData = &ethereum.ViewData{BlockHeader: header, Proof: &ethereum.ViewData_ReceiptProof{ReceiptProof: receiptProof}}
+	err = interopcc.VerifyView(ctx, getEthereumViewB64(viewData, ethereumEventAddress, receiptProof.Receipt), ethereumEventAddress)
+	require.EqualError(t, err, "Notarizations missing signer: "+validators[0])
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with the proof of a receipt at another transaction index
+	header = getSealedBlockHeader(qbftConsensus, stateRoot, receiptTrie.root, nowSecs, validators, privateKeys[:3])
+	otherIndexProof := &ethereum.ReceiptProof{TransactionIndex: 2, Receipt: receiptProof.Receipt, Proof: receiptTrie.proof(receiptKey(2))}
+	viewData = &ethereum.ViewData{BlockHeader: header, Proof: &ethereum.ViewData_ReceiptProof{ReceiptProof: otherIndexProof}}
+	err = interopcc.VerifyView(ctx, getEthereumViewB64(viewData, ethereumEventAddress, receiptProof.Receipt), ethereumEventAddress)
+	require.EqualError(t, err, "Receipt does not match the proof")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a proof of a receipt absent from the block
+	absentProof := &ethereum.ReceiptProof{TransactionIndex: 3, Receipt: receiptProof.Receipt, Proof: receiptTrie.proof(receiptKey(3))}
+	viewData = &ethereum.ViewData{BlockHeader: header, Proof: &ethereum.ViewData_ReceiptProof{ReceiptProof: absentProof}}
+	err = interopcc.VerifyView(ctx, getEthereumViewB64(viewData, ethereumEventAddress, receiptProof.Receipt), ethereumEventAddress)
+	require.EqualError(t, err, "Block has no receipt at transaction index 3")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with the receipt of a failed transaction
+	failedProof := getReceiptProof(2)
+	viewData = &ethereum.ViewData{BlockHeader: header, Proof: &ethereum.ViewData_ReceiptProof{ReceiptProof: failedProof}}
+	err = interopcc.VerifyView(ctx, getEthereumViewB64(viewData, ethereumEventAddress, failedProof.Receipt), ethereumEventAddress)
+	require.EqualError(t, err, "Receipt is of a failed transaction")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a receipt whose log is of another contract, or has other topics than the query
+	noLogError := fmt.Sprintf("Receipt has no log of contract %x with the topics of the query", ethcommon.HexToAddress(ethereumContractAddress).Bytes())
+	otherContractProof := getReceiptProof(0)
+	viewData = &ethereum.ViewData{BlockHeader: header, Proof: &ethereum.ViewData_ReceiptProof{ReceiptProof: otherContractProof}}
+	err = interopcc.VerifyView(ctx, getEthereumViewB64(viewData, ethereumEventAddress, otherContractProof.Receipt), ethereumEventAddress)
+	require.EqualError(t, err, noLogError)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	otherEventAddress := getEthereumAddress(ethereumEventQuery, crypto.Keccak256Hash([]byte("Removed(uint256)")))
+	viewData = &ethereum.ViewData{BlockHeader: header, Proof: &ethereum.ViewData_ReceiptProof{ReceiptProof: receiptProof}}
+	err = interopcc.VerifyView(ctx, getEthereumViewB64(viewData, otherEventAddress, receiptProof.Receipt), otherEventAddress)
+	require.EqualError(t, err, noLogError)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a receipt proof for a storage query
+	err = interopcc.VerifyView(ctx, getEthereumViewB64(viewData, ethereumStorageAddress, receiptProof.Receipt), ethereumStorageAddress)
+	require.EqualError(t, err, "Receipt proof supplied for a query of kind storage")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with view data without a proof
+	viewData = &ethereum.ViewData{BlockHeader: header}
+	err = interopcc.VerifyView(ctx, getEthereumViewB64(viewData, ethereumEventAddress, receiptProof.Receipt), ethereumEventAddress)
+	require.EqualError(t, err, "Ethereum view data has no proof")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure without a validator set for the network
+	mockStub.MockTransactionStart("tx1")
+	require.NoError(t, interopcc.DeleteValidatorSet(ctx, ethereumSecurityDomain))
+	mockStub.MockTransactionEnd("tx1")
+	err = interopcc.VerifyView(ctx, viewB64, ethereumEventAddress)
+	require.EqualError(t, err, "ValidatorSet with id: "+ethereumSecurityDomain+" does not exist")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+}
@@ -26,7 +26,9 @@ import (
 // 1. Checks the validity of query signature
 // 2. Checks that the certificate of the requester is valid according to the network's Membership
 // 3. Checks the access control policy for the requester and view address is met
-// 4. Calls application chaincode
+// 4. Checks that the query is fresh and that its nonce has not been used before
+// 5. Calls application chaincode
+// 6. Encrypts the response for the requester if the query is confidential
 func (s *SmartContract) HandleExternalRequest(ctx contractapi.TransactionContextInterface, b64QueryBytes string) (string, error) {
 	queryBytes, err := base64.StdEncoding.DecodeString(b64QueryBytes)
 	if err != nil {
@@ -54,7 +56,7 @@ func (s *SmartContract) HandleExternalRequest(ctx contractapi.TransactionContext
 		log.Error(errorMessage)
 		return "", errors.New(errorMessage)
 	}
-	err = validateSignature(query.Address+query.Nonce, x509Cert, string(signatureBytes))
+	err = validateSignature(getSignedQueryMessage(&query), x509Cert, string(signatureBytes))
 	if err != nil {
 		errorMessage := fmt.Sprintf("Invalid Signature: %s", err)
 		log.Error(errorMessage)
@@ -90,7 +92,14 @@ func (s *SmartContract) HandleExternalRequest(ctx contractapi.TransactionContext
 		log.Error(errorMessage)
 		return "", errors.New(errorMessage)
 	}
-	// 4. Calls application chaincode
+	// 4. Checks that the query is fresh and that its nonce has not been used before
+	err = checkAndConsumeQueryNonce(ctx, &query)
+	if err != nil {
+		errorMessage := fmt.Sprintf("Replay check failed: %s", err)
+		log.Error(errorMessage)
+		return "", errors.New(errorMessage)
+	}
+	// 5. Calls application chaincode
 	arr := append([]string{viewAddress.CCFunc}, viewAddress.Args...)
 	byteArgs := strArrToBytesArr(arr)
 	pbResp := ctx.GetStub().InvokeChaincode(viewAddress.Contract, byteArgs, viewAddress.Channel)
@@ -100,10 +109,20 @@ func (s *SmartContract) HandleExternalRequest(ctx contractapi.TransactionContext
 		return "", errors.New(errorMessage)
 	}
 
+	// 6. Encrypts the response for the requester if the query is confidential
 	interopPayloadStruct := common.InteropPayload{
 		Address: query.Address,
 		Payload: pbResp.Payload,
 	}
+	if query.Confidential {
+		interopPayloadStruct.Payload, err = generateConfidentialPayload(ctx, &query, x509Cert, pbResp.Payload)
+		if err != nil {
+			errorMessage := fmt.Sprintf("Unable to generate confidential payload: %s", err)
+			log.Error(errorMessage)
+			return "", errors.New(errorMessage)
+		}
+		interopPayloadStruct.Confidential = true
+	}
 	interopPayloadBytes, err := protoV2.Marshal(&interopPayloadStruct)
 	if err != nil {
 		errorMessage := fmt.Sprintf("Unable to marshal interop payload: %s", err)
@@ -21,6 +21,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/stretchr/testify/require"
@@ -108,6 +109,10 @@ func TestHandleExternalRequest(t *testing.T) {
 	testHandleExternalRequestNoMembership(t, &query, validCertificate, signature, pbResp)
 	// Happy case. ECDSA Cert and Valid Signature
 	testHandleExternalRequestECDSAHappyCase(t, &query, validCertificate, signature, pbResp, &accessControlAsset, &membershipAsset)
+	// Replayed query, and query signed with a timestamp under a freshness window
+	testHandleExternalRequestReplay(t, &query, validCertificate, key, pbResp, &accessControlAsset, &membershipAsset)
+	// Confidential query, with the response encrypted for the requestor
+	testHandleExternalRequestConfidential(t, &query, validCertificate, key, pbResp, &accessControlAsset, &membershipAsset)
 	// ed25519 Cert and Signature
 	testHandleExternalRequestED25519Signature(t, &query, pbResp, &accessControlAsset, &membershipAsset, template)
 }
@@ -188,6 +193,117 @@ func testHandleExternalRequestECDSAHappyCase(t *testing.T, query *common.Query,
 	require.NoError(t, err)
 }
 
+func testHandleExternalRequestReplay(t *testing.T, query *common.Query, validCertificate string, key *ecdsa.PrivateKey, pbResp pb.Response, accessControl *common.AccessControlPolicy, membership *common.Membership) {
+	ctx, chaincodeStub, interopcc := prepMockStub()
+
+	membershipBytes, err := json.Marshal(membership)
+	require.NoError(t, err)
+	accessControlBytes, err := json.Marshal(accessControl)
+	require.NoError(t, err)
+	chaincodeStub.InvokeChaincodeReturns(pbResp)
+	getB64QueryBytes := func(timestampSecs uint64) string {
+		query.Certificate = validCertificate
+		query.Timestamp = timestampSecs
+		hashed, err := computeSHA2Hash([]byte(getSignedQueryMessage(query)), key.PublicKey.Params().BitSize)
+		require.NoError(t, err)
+		signature, err := ecdsa.SignASN1(rand.Reader, key, hashed)
+		require.NoError(t, err)
+		query.RequestorSignature = base64.StdEncoding.EncodeToString(signature)
+		queryBytes, err := protoV2.Marshal(query)
+		require.NoError(t, err)
+		return base64.StdEncoding.EncodeToString(queryBytes)
+	}
+
+	// the nonce of the query has been consumed by an earlier request
+	chaincodeStub.GetStateReturnsOnCall(0, membershipBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, accessControlBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(2, []byte("1"), nil)
+	_, err = interopcc.HandleExternalRequest(ctx, getB64QueryBytes(0))
+	require.EqualError(t, err, fmt.Sprintf("Replay check failed: nonce %s has already been used by org %s of network %s", query.Nonce, query.RequestingOrg, query.RequestingNetwork))
+
+	// the query timestamp is outside the freshness window, or is not covered by the signature
+	ctx.GetStub().(*chaincodeStubWithConfig).config[replayProtectionPolicyKey] = []byte(`{"freshnessWindowSecs":60}`)
+	txTimeSecs := uint64(time.Now().Unix())
+	chaincodeStub.GetTxTimestampReturns(&timestamp.Timestamp{Seconds: int64(txTimeSecs)}, nil)
+	chaincodeStub.GetStateReturnsOnCall(3, membershipBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(4, accessControlBytes, nil)
+	_, err = interopcc.HandleExternalRequest(ctx, getB64QueryBytes(txTimeSecs-120))
+	require.EqualError(t, err, fmt.Sprintf("Replay check failed: query timestamp %d is outside the freshness window of 60 secs around the transaction time %d", txTimeSecs-120, txTimeSecs))
+	getB64QueryBytes(txTimeSecs - 120)
+	query.Timestamp = txTimeSecs
+	queryBytes, err := protoV2.Marshal(query)
+	require.NoError(t, err)
+	_, err = interopcc.HandleExternalRequest(ctx, base64.StdEncoding.EncodeToString(queryBytes))
+	require.EqualError(t, err, "Invalid Signature: Signature Verification failed. ECDSA VERIFY")
+
+	// the query carries a fresh timestamp and an unused nonce, which is consumed
+	chaincodeStub.GetStateReturnsOnCall(5, membershipBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(6, accessControlBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(7, nil, nil)
+	_, err = interopcc.HandleExternalRequest(ctx, getB64QueryBytes(txTimeSecs-30))
+	require.NoError(t, err)
+	objectType, attributes := chaincodeStub.CreateCompositeKeyArgsForCall(chaincodeStub.CreateCompositeKeyCallCount() - 1)
+	require.Equal(t, requestNonceObjectType, objectType)
+	require.Equal(t, []string{query.RequestingNetwork, query.RequestingOrg, query.Nonce}, attributes)
+	_, value := chaincodeStub.PutStateArgsForCall(chaincodeStub.PutStateCallCount() - 1)
+	require.Equal(t, fmt.Sprintf("%d", txTimeSecs-30), string(value))
+	query.Timestamp = 0
+}
+
+func testHandleExternalRequestConfidential(t *testing.T, query *common.Query, validCertificate string, key *ecdsa.PrivateKey, pbResp pb.Response, accessControl *common.AccessControlPolicy, membership *common.Membership) {
+	membershipBytes, err := json.Marshal(membership)
+	require.NoError(t, err)
+	accessControlBytes, err := json.Marshal(accessControl)
+	require.NoError(t, err)
+	query.Certificate = validCertificate
+	query.Confidential = true
+	hashed, err := computeSHA2Hash([]byte(getSignedQueryMessage(query)), key.PublicKey.Params().BitSize)
+	require.NoError(t, err)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hashed)
+	require.NoError(t, err)
+	query.RequestorSignature = base64.StdEncoding.EncodeToString(signature)
+	queryBytes, err := protoV2.Marshal(query)
+	require.NoError(t, err)
+	b64QueryBytes := base64.StdEncoding.EncodeToString(queryBytes)
+	handleRequest := func() (string, error) {
+		ctx, chaincodeStub, interopcc := prepMockStub()
+		chaincodeStub.GetStateReturnsOnCall(0, membershipBytes, nil)
+		chaincodeStub.GetStateReturnsOnCall(1, accessControlBytes, nil)
+		chaincodeStub.InvokeChaincodeReturns(pbResp)
+		chaincodeStub.GetTxIDReturns("tx1")
+		return interopcc.HandleExternalRequest(ctx, b64QueryBytes)
+	}
+
+	// the response is encrypted for the requestor, and is the same for every endorser of the transaction
+	interopResponse, err := handleRequest()
+	require.NoError(t, err)
+	otherInteropResponse, err := handleRequest()
+	require.NoError(t, err)
+	require.Equal(t, interopResponse, otherInteropResponse)
+	var interopPayload common.InteropPayload
+	err = protoV2.Unmarshal([]byte(interopResponse), &interopPayload)
+	require.NoError(t, err)
+	require.True(t, interopPayload.Confidential)
+	require.Equal(t, query.Address, interopPayload.Address)
+	require.False(t, bytes.Contains(interopPayload.Payload, pbResp.Payload))
+	var confidentialPayload common.ConfidentialPayload
+	err = protoV2.Unmarshal(interopPayload.Payload, &confidentialPayload)
+	require.NoError(t, err)
+	contentsBytes, err := eciesDecrypt(key, confidentialPayload.EncryptedPayload)
+	require.NoError(t, err)
+	payload, err := verifyConfidentialPayloadContents(interopPayload.Payload, base64.StdEncoding.EncodeToString(contentsBytes))
+	require.NoError(t, err)
+	require.Equal(t, pbResp.Payload, payload)
+
+	// a relay cannot drop the confidentiality flag, which is covered by the requestor signature
+	query.Confidential = false
+	queryBytes, err = protoV2.Marshal(query)
+	require.NoError(t, err)
+	b64QueryBytes = base64.StdEncoding.EncodeToString(queryBytes)
+	_, err = handleRequest()
+	require.EqualError(t, err, "Invalid Signature: Signature Verification failed. ECDSA VERIFY")
+}
+
 func testHandleExternalRequestED25519Signature(t *testing.T, query *common.Query, pbResp pb.Response, accessControl *common.AccessControlPolicy, fabricMembership *common.Membership, template x509.Certificate) {
 	ctx, chaincodeStub, interopcc := prepMockStub()
 
@@ -0,0 +1,125 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// lock_delegation contains the functions used by a delegate (e.g., a custodian) to lock an asset on behalf of its
+// owner, using an authorization signed by the owner; the owner remains the recorded locker of the asset
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	log "github.com/sirupsen/logrus"
+)
+
+const lockDelegationPrefix = "LockDelegation_" // prefix for the map, lock-delegation-hash --> txId of the lock that used it
+
+// function to construct the message that the owner of an asset signs to authorize a delegate to lock it
+func getLockDelegationMessage(delegate string, assetAgreementBytesBase64 string, lockInfoBytesBase64 string) string {
+	return fmt.Sprintf("LockDelegation:%s:%s:%s", delegate, assetAgreementBytesBase64, lockInfoBytesBase64)
+}
+
+/*
+ * Function to check that the transaction creator is authorized by the owner (the locker in the asset agreement) to
+ * lock the asset with the given lock information, i.e., that the delegation names the transaction creator as the
+ * delegate and carries the owner's signature over the delegation message. A delegation can be used only once, so
+ * that a lock that is reclaimed cannot be set up again by the delegate without a fresh authorization.
+ */
+func validateLockDelegation(ctx contractapi.TransactionContextInterface, owner string, assetAgreementBytesBase64 string,
+	lockInfoBytesBase64 string, delegationBytesBase64 string) error {
+	delegationBytes, err := base64.StdEncoding.DecodeString(delegationBytesBase64)
+	if err != nil {
+		return fmt.Errorf("error in base64 decode of lock delegation: %+v", err)
+	}
+	if len(delegationBytes) == 0 {
+		return fmt.Errorf("empty lock delegation")
+	}
+	delegation := &common.AssetLockDelegation{}
+	err = proto.Unmarshal(delegationBytes, delegation)
+	if err != nil {
+		return fmt.Errorf("unmarshal error: %s", err)
+	}
+
+	txCreatorECertBase64, err := getECertOfTxCreatorBase64(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get the transaction creator information: %+v", err)
+	}
+	if delegation.Delegate != txCreatorECertBase64 {
+		return fmt.Errorf("lock delegation is not meant for the transaction creator %s", txCreatorECertBase64)
+	}
+	ownerCert, err := parseLockPartyCert(owner, "asset owner")
+	if err != nil {
+		return err
+	}
+	delegationMessage := getLockDelegationMessage(delegation.Delegate, assetAgreementBytesBase64, lockInfoBytesBase64)
+	err = validateSignature(delegationMessage, ownerCert, string(delegation.OwnerSignature))
+	if err != nil {
+		return fmt.Errorf("lock delegation is not signed by the asset owner: %+v", err)
+	}
+
+	delegationKey := lockDelegationPrefix + generateSHA256HashInBase64Form(delegationMessage)
+	delegationValBytes, err := ctx.GetStub().GetState(delegationKey)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve from the world state: %+v", err)
+	}
+	if delegationValBytes != nil {
+		return fmt.Errorf("lock delegation has already been used in transaction %s", string(delegationValBytes))
+	}
+	err = ctx.GetStub().PutState(delegationKey, []byte(ctx.GetStub().GetTxID()))
+	if err != nil {
+		return fmt.Errorf("failed to write to the world state: %+v", err)
+	}
+	return nil
+}
+
+// LockAssetAsDelegate cc is used by a delegate to record the lock of a non-fungible asset on behalf of its owner, who
+// is the locker in the asset agreement and has authorized the lock in the delegation
+func (s *SmartContract) LockAssetAsDelegate(ctx contractapi.TransactionContextInterface, assetAgreementBytesBase64 string,
+	lockInfoBytesBase64 string, delegationBytesBase64 string) (string, error) {
+	assetAgreementBytes, err := base64.StdEncoding.DecodeString(assetAgreementBytesBase64)
+	if err != nil {
+		return "", logThenErrorf("error in base64 decode of asset agreement: %+v", err)
+	}
+	assetAgreement := &common.AssetExchangeAgreement{}
+	err = proto.Unmarshal(assetAgreementBytes, assetAgreement)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	log.Infof("assetExchangeAgreement: %+v", assetAgreement)
+
+	err = validateLockDelegation(ctx, assetAgreement.Locker, assetAgreementBytesBase64, lockInfoBytesBase64, delegationBytesBase64)
+	if err != nil {
+		return "", logThenErrorf("error in lock delegation validation: %+v", err)
+	}
+
+	return lockAssetOfAgreement(ctx, assetAgreement, lockInfoBytesBase64)
+}
+
+// LockFungibleAssetAsDelegate cc is used by a delegate to record the lock of a fungible asset on behalf of its owner,
+// who is the locker in the asset agreement and has authorized the lock in the delegation
+func (s *SmartContract) LockFungibleAssetAsDelegate(ctx contractapi.TransactionContextInterface, fungibleAssetAgreementBytesBase64 string,
+	lockInfoBytesBase64 string, delegationBytesBase64 string) (string, error) {
+	fungibleAssetAgreementBytes, err := base64.StdEncoding.DecodeString(fungibleAssetAgreementBytesBase64)
+	if err != nil {
+		return "", logThenErrorf("error in base64 decode of asset agreement: %+v", err)
+	}
+	assetAgreement := &common.FungibleAssetExchangeAgreement{}
+	err = proto.Unmarshal(fungibleAssetAgreementBytes, assetAgreement)
+	if err != nil {
+		return "", logThenErrorf("unmarshal error: %s", err)
+	}
+	log.Infof("fungibleAssetExchangeAgreement: %+v", assetAgreement)
+
+	err = validateLockDelegation(ctx, assetAgreement.Locker, fungibleAssetAgreementBytesBase64, lockInfoBytesBase64, delegationBytesBase64)
+	if err != nil {
+		return "", logThenErrorf("error in lock delegation validation: %+v", err)
+	}
+
+	return lockFungibleAssetOfAgreement(ctx, assetAgreement, lockInfoBytesBase64)
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/stretchr/testify/require"
+)
+
+func getLockDelegationBase64(t *testing.T, key *ecdsa.PrivateKey, delegate string, assetAgreementBytesBase64 string, lockInfoBytesBase64 string) string {
+	hashed, err := computeSHA2Hash([]byte(getLockDelegationMessage(delegate, assetAgreementBytesBase64, lockInfoBytesBase64)), key.PublicKey.Params().BitSize)
+	require.NoError(t, err)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hashed)
+	require.NoError(t, err)
+	delegation := &common.AssetLockDelegation{
+		Delegate:       delegate,
+		OwnerSignature: signature,
+	}
+	delegationBytes, _ := proto.Marshal(delegation)
+	return base64.StdEncoding.EncodeToString(delegationBytes)
+}
+
+func TestLockAssetAsDelegate(t *testing.T) {
+	ctx, mockStub, interopcc := prepShimMockStub()
+
+	template := x509.Certificate{
+		Subject: pkix.Name{
+			CommonName: "owner.example.com",
+		},
+		SerialNumber: big.NewInt(1337),
+	}
+	ownerCertBytes, ownerKey, err := createECDSACertAndKeyFromTemplate(template)
+	require.NoError(t, err)
+	owner := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ownerCertBytes}))
+	_, otherKey, err := createECDSACertAndKeyFromTemplate(template)
+	require.NoError(t, err)
+
+	// the caller acts as the delegate of the owner
+	delegate := getTxCreatorECertBase64()
+	currentTimeSecs := uint64(time.Now().Unix())
+	lockInfo := getHTLCLockInfoBase64(generateSHA256HashInBase64Form("abcd"), currentTimeSecs+defaultTimeLockSecs)
+
+	assetAgreement := &common.AssetExchangeAgreement{
+		Type:      "bond",
+		Id:        "A001",
+		Recipient: "Bob",
+		Locker:    owner,
+	}
+	assetAgreementBytes, _ := proto.Marshal(assetAgreement)
+	assetAgreementBytesBase64 := base64.StdEncoding.EncodeToString(assetAgreementBytes)
+
+	// Test failure with a delegation signed by someone other than the owner
+	mockStub.MockTransactionStart("tx1")
+	_, err = interopcc.LockAssetAsDelegate(ctx, assetAgreementBytesBase64, lockInfo,
+		getLockDelegationBase64(t, otherKey, delegate, assetAgreementBytesBase64, lockInfo))
+	require.Error(t, err)
+	require.True(t, strings.HasPrefix(err.Error(), "error in lock delegation validation: lock delegation is not signed by the asset owner"))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	mockStub.MockTransactionEnd("tx1")
+
+	// Test failure with a delegation meant for someone other than the caller
+	mockStub.MockTransactionStart("tx2")
+	_, err = interopcc.LockAssetAsDelegate(ctx, assetAgreementBytesBase64, lockInfo,
+		getLockDelegationBase64(t, ownerKey, "Charlie", assetAgreementBytesBase64, lockInfo))
+	require.EqualError(t, err, fmt.Sprintf("error in lock delegation validation: lock delegation is not meant for the transaction creator %s", delegate))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	mockStub.MockTransactionEnd("tx2")
+
+	// Test failure with a delegation signed over a different lock
+	otherLockInfo := getHTLCLockInfoBase64(generateSHA256HashInBase64Form("abcd"), currentTimeSecs+2*defaultTimeLockSecs)
+	mockStub.MockTransactionStart("tx3")
+	_, err = interopcc.LockAssetAsDelegate(ctx, assetAgreementBytesBase64, otherLockInfo,
+		getLockDelegationBase64(t, ownerKey, delegate, assetAgreementBytesBase64, lockInfo))
+	require.Error(t, err)
+	require.True(t, strings.HasPrefix(err.Error(), "error in lock delegation validation: lock delegation is not signed by the asset owner"))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	mockStub.MockTransactionEnd("tx3")
+
+	// Test success with the owner recorded as the locker of the asset
+	delegation := getLockDelegationBase64(t, ownerKey, delegate, assetAgreementBytesBase64, lockInfo)
+	mockStub.MockTransactionStart("tx4")
+	contractId, err := interopcc.LockAssetAsDelegate(ctx, assetAgreementBytesBase64, lockInfo, delegation)
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx4")
+	lockedAssets, err := interopcc.GetAllLockedAssets(ctx, "*", owner)
+	require.NoError(t, err)
+	require.Len(t, lockedAssets, 1)
+	require.True(t, strings.Contains(lockedAssets[0], contractId))
+	lockedAssets, err = interopcc.GetAllLockedAssets(ctx, "*", delegate)
+	require.NoError(t, err)
+	require.Len(t, lockedAssets, 0)
+
+	// Test failure with a delegation that is replayed
+	mockStub.MockTransactionStart("tx5")
+	_, err = interopcc.LockAssetAsDelegate(ctx, assetAgreementBytesBase64, lockInfo, delegation)
+	require.EqualError(t, err, "error in lock delegation validation: lock delegation has already been used in transaction tx4")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	mockStub.MockTransactionEnd("tx5")
+
+	// Test success with a fungible asset
+	fungibleAssetAgreement := &common.FungibleAssetExchangeAgreement{
+		Type:      "cbdc",
+		NumUnits:  10,
+		Recipient: "Bob",
+		Locker:    owner,
+	}
+	fungibleAssetAgreementBytes, _ := proto.Marshal(fungibleAssetAgreement)
+	fungibleAssetAgreementBytesBase64 := base64.StdEncoding.EncodeToString(fungibleAssetAgreementBytes)
+	mockStub.MockTransactionStart("tx6")
+	contractId, err = interopcc.LockFungibleAssetAsDelegate(ctx, fungibleAssetAgreementBytesBase64, lockInfo,
+		getLockDelegationBase64(t, ownerKey, delegate, fungibleAssetAgreementBytesBase64, lockInfo))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx6")
+	isLocked, err := interopcc.IsFungibleAssetLocked(ctx, contractId)
+	require.NoError(t, err)
+	require.True(t, isLocked)
+	lockedAssets, err = interopcc.GetAllFungibleLockedAssets(ctx, "*", owner)
+	require.NoError(t, err)
+	require.Len(t, lockedAssets, 1)
+}
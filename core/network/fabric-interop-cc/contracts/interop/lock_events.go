@@ -0,0 +1,87 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// lock_events contains the functions used to notify clients of the lock lifecycle (lock, claim, unlock, extend)
+// through a single chaincode event per transaction, which aggregates all the notifications of that transaction
+package main
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	log "github.com/sirupsen/logrus"
+)
+
+const assetLockEventName = "AssetLockEvents" // name of the chaincode event carrying the lock lifecycle notifications
+
+// interopTransactionContext is the transaction context of the interop cc. A new context is created for every
+// transaction, so it gathers the lock notifications of its transaction: a chaincode event set later in a transaction
+// replaces the one set earlier, so the event is set afresh with the whole envelope on every notification.
+type interopTransactionContext struct {
+	contractapi.TransactionContext
+	lockEventEnvelope *common.AssetLockEventEnvelope
+}
+
+// function to infer the lock mechanism from the lock information recorded on the ledger
+func getLockMechanismOfLockInfo(lockInfo interface{}) common.LockMechanism {
+	if _, ok := normalizeLockInfo(lockInfo).(EscrowLock); ok {
+		return common.LockMechanism_ESCROW
+	}
+	return common.LockMechanism_HTLC
+}
+
+// function to build a lock lifecycle notification from the lock summary
+func newAssetLockEvent(action common.AssetLockEvent_Action, lockedAssetInfo LockedAssetInfo, lockInfo interface{}) *common.AssetLockEvent {
+	return &common.AssetLockEvent{
+		Action:         action,
+		ContractId:     lockedAssetInfo.ContractId,
+		LockMechanism:  getLockMechanismOfLockInfo(lockInfo),
+		Type:           lockedAssetInfo.Type,
+		Id:             lockedAssetInfo.Id,
+		NumUnits:       lockedAssetInfo.NumUnits,
+		Locker:         lockedAssetInfo.Locker,
+		Recipient:      lockedAssetInfo.Recipient,
+		ExpiryTimeSecs: lockedAssetInfo.ExpiryTimeSecs,
+	}
+}
+
+/*
+ * Function to add a notification to the event envelope of the transaction and set the envelope as the chaincode event.
+ * The envelope is kept in the interop transaction context; with any other context, it only carries this notification.
+ * Notifications are best-effort: a failure is logged and does not fail the transaction. The event is only delivered
+ * to clients when the interop chaincode is invoked directly; when it is invoked by another chaincode, the event of
+ * the calling chaincode is the one recorded in the transaction.
+ */
+func emitAssetLockEvent(ctx contractapi.TransactionContextInterface, event *common.AssetLockEvent) {
+	txId := ctx.GetStub().GetTxID()
+	envelope := &common.AssetLockEventEnvelope{TxId: txId}
+	if interopCtx, ok := ctx.(*interopTransactionContext); ok {
+		// a context is not meant to be reused across transactions, but a stale envelope must never leak into another one
+		if interopCtx.lockEventEnvelope == nil || interopCtx.lockEventEnvelope.TxId != txId {
+			interopCtx.lockEventEnvelope = envelope
+		}
+		envelope = interopCtx.lockEventEnvelope
+	}
+	envelope.Events = append(envelope.Events, event)
+
+	envelopeBytes, err := proto.Marshal(envelope)
+	if err == nil {
+		err = ctx.GetStub().SetEvent(assetLockEventName, envelopeBytes)
+	}
+	if err != nil {
+		log.Warnf("unable to set the '%s' event for contractId %s: %+v", assetLockEventName, event.ContractId, err)
+	}
+}
+
+// function to build a lock lifecycle notification for an asset bundle
+func newAssetBundleLockEvent(action common.AssetLockEvent_Action, contractId string, bundleLockVal AssetBundleLockValue) *common.AssetLockEvent {
+	event := newAssetLockEvent(action, LockedAssetInfo{ContractId: contractId, Locker: bundleLockVal.Locker,
+		Recipient: bundleLockVal.Recipient, ExpiryTimeSecs: bundleLockVal.ExpiryTimeSecs}, bundleLockVal.LockInfo)
+	for _, asset := range bundleLockVal.Assets {
+		event.BundleAssets = append(event.BundleAssets, &common.AssetBundleItem{Type: asset.Type, Id: asset.Id, NumUnits: asset.NumUnits})
+	}
+	return event
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/stretchr/testify/require"
+)
+
+// function that drains the chaincode events set so far and returns the envelope carried by the last one
+func getLastAssetLockEventEnvelope(t *testing.T, mockStub *shimtest.MockStub) *common.AssetLockEventEnvelope {
+	envelope := &common.AssetLockEventEnvelope{}
+	for {
+		select {
+		case event := <-mockStub.ChaincodeEventsChannel:
+			require.Equal(t, assetLockEventName, event.EventName)
+			err := proto.Unmarshal(event.Payload, envelope)
+			require.NoError(t, err)
+		default:
+			return envelope
+		}
+	}
+}
+
+// function that supplies an interop transaction context over the stub and client identity of the given context, as
+// the contract API does for every transaction
+func newInteropTransactionContext(ctx contractapi.TransactionContextInterface) *interopTransactionContext {
+	interopCtx := &interopTransactionContext{}
+	interopCtx.SetStub(ctx.GetStub())
+	interopCtx.SetClientIdentity(ctx.GetClientIdentity())
+	return interopCtx
+}
+
+func TestAssetLockEvents(t *testing.T) {
+	mockCtx, mockStub, interopcc := prepShimMockStub()
+	ctx := newInteropTransactionContext(mockCtx)
+
+	party := getTxCreatorECertBase64()
+	preimage := "abcd"
+	hashBase64 := generateSHA256HashInBase64Form(preimage)
+	expiryTimeSecs := uint64(time.Now().Unix()) + defaultTimeLockSecs
+
+	bondAgreement := &common.AssetExchangeAgreement{
+		Type:      "bond",
+		Id:        "A001",
+		Recipient: party,
+		Locker:    party,
+	}
+	bondAgreementBytes, _ := proto.Marshal(bondAgreement)
+	tokenAgreement := &common.FungibleAssetExchangeAgreement{
+		Type:      "cbdc",
+		NumUnits:  50,
+		Recipient: party,
+		Locker:    party,
+	}
+	tokenAgreementBytes, _ := proto.Marshal(tokenAgreement)
+
+	// Test success with both locks of a transaction aggregated in its event envelope
+	mockStub.MockTransactionStart("tx1")
+	bondContractId, err := interopcc.LockAsset(ctx, base64.StdEncoding.EncodeToString(bondAgreementBytes), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+	require.NoError(t, err)
+	tokenContractId, err := interopcc.LockFungibleAsset(ctx, base64.StdEncoding.EncodeToString(tokenAgreementBytes), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx1")
+	envelope := getLastAssetLockEventEnvelope(t, mockStub)
+	require.Equal(t, "tx1", envelope.TxId)
+	require.Len(t, envelope.Events, 2)
+	require.Equal(t, common.AssetLockEvent_LOCK, envelope.Events[0].Action)
+	require.Equal(t, bondContractId, envelope.Events[0].ContractId)
+	require.Equal(t, common.LockMechanism_HTLC, envelope.Events[0].LockMechanism)
+	require.Equal(t, "A001", envelope.Events[0].Id)
+	require.Equal(t, expiryTimeSecs, envelope.Events[0].ExpiryTimeSecs)
+	require.Equal(t, tokenContractId, envelope.Events[1].ContractId)
+	require.Equal(t, uint64(50), envelope.Events[1].NumUnits)
+
+	// Test success with the envelope of the next transaction carrying only its own notifications
+	claimInfoHTLC := &common.AssetClaimHTLC{
+		HashPreimageBase64: []byte(base64.StdEncoding.EncodeToString([]byte(preimage))),
+		NumUnits:           20,
+	}
+	claimInfoHTLCBytes, _ := proto.Marshal(claimInfoHTLC)
+	claimInfo := &common.AssetClaim{
+		LockMechanism: common.LockMechanism_HTLC,
+		ClaimInfo:     claimInfoHTLCBytes,
+	}
+	claimInfoBytes, _ := proto.Marshal(claimInfo)
+	ctx = newInteropTransactionContext(mockCtx)
+	mockStub.MockTransactionStart("tx2")
+	_, err = interopcc.ClaimFungibleAsset(ctx, tokenContractId, base64.StdEncoding.EncodeToString(claimInfoBytes))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx2")
+	envelope = getLastAssetLockEventEnvelope(t, mockStub)
+	require.Equal(t, "tx2", envelope.TxId)
+	require.Len(t, envelope.Events, 1)
+	require.Equal(t, common.AssetLockEvent_CLAIM, envelope.Events[0].Action)
+	require.Equal(t, uint64(20), envelope.Events[0].NumUnits)
+
+	// Test success with a bundle lock carrying the assets of the bundle
+	bundleAssets := []*common.AssetBundleItem{{Type: "bond", Id: "A002"}, {Type: "cbdc", NumUnits: 10}}
+	ctx = newInteropTransactionContext(mockCtx)
+	mockStub.MockTransactionStart("tx3")
+	bundleContractId, err := interopcc.LockAssetBundle(ctx, getAssetBundleAgreementBase64(bundleAssets, party, party), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx3")
+	envelope = getLastAssetLockEventEnvelope(t, mockStub)
+	require.Len(t, envelope.Events, 1)
+	require.Equal(t, bundleContractId, envelope.Events[0].ContractId)
+	require.Len(t, envelope.Events[0].BundleAssets, 2)
+	require.Equal(t, "A002", envelope.Events[0].BundleAssets[0].Id)
+
+	// Test success with a context reused for another transaction only carrying the notifications of that transaction
+	mockStub.MockTransactionStart("tx4")
+	_, err = interopcc.LockFungibleAsset(ctx, base64.StdEncoding.EncodeToString(tokenAgreementBytes), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx4")
+	envelope = getLastAssetLockEventEnvelope(t, mockStub)
+	require.Equal(t, "tx4", envelope.TxId)
+	require.Len(t, envelope.Events, 1)
+
+	// Test success with a context of another type carrying only the last notification of the transaction
+	mockStub.MockTransactionStart("tx5")
+	_, err = interopcc.LockFungibleAsset(mockCtx, base64.StdEncoding.EncodeToString(tokenAgreementBytes), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+	require.NoError(t, err)
+	tokenAgreement.NumUnits = 30
+	tokenAgreementBytes, _ = proto.Marshal(tokenAgreement)
+	_, err = interopcc.LockFungibleAsset(mockCtx, base64.StdEncoding.EncodeToString(tokenAgreementBytes), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx5")
+	envelope = getLastAssetLockEventEnvelope(t, mockStub)
+	require.Len(t, envelope.Events, 1)
+	require.Equal(t, uint64(30), envelope.Events[0].NumUnits)
+
+	// Test failure with no notification for a transaction that fails
+	ctx = newInteropTransactionContext(mockCtx)
+	mockStub.MockTransactionStart("tx6")
+	err = interopcc.UnlockAssetUsingContractId(ctx, bondContractId)
+	require.Error(t, err)
+	mockStub.MockTransactionEnd("tx6")
+	envelope = getLastAssetLockEventEnvelope(t, mockStub)
+	require.Empty(t, envelope.Events)
+}
+
+func TestInteropTransactionContext(t *testing.T) {
+	// Test success with the contract API accepting the interop transaction context for all the cc functions
+	interopcc := new(SmartContract)
+	interopcc.TransactionContextHandler = new(interopTransactionContext)
+	_, err := contractapi.NewChaincode(interopcc)
+	require.NoError(t, err)
+}
+
+func TestGetLockMechanismOfLockInfo(t *testing.T) {
+	require.Equal(t, common.LockMechanism_HTLC, getLockMechanismOfLockInfo(HashLock{HashBase64: "hash"}))
+	require.Equal(t, common.LockMechanism_ESCROW, getLockMechanismOfLockInfo(EscrowLock{Arbiter: "arbiter"}))
+	require.Equal(t, common.LockMechanism_ESCROW, getLockMechanismOfLockInfo(map[string]interface{}{"arbiter": "arbiter"}))
+}
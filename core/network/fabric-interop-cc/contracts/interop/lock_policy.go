@@ -0,0 +1,156 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// lock_policy contains the functions used to record, on the ledger, the limits that admins of the interop cc
+// place on asset locks (lock durations, asset types, units per lock), and to enforce them when assets are locked
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+const lockPolicyKey = "LockPolicy" // ledger key for the lock policy
+
+// Object used to capture the lock policy; a zero limit (or an empty list of asset types) places no restriction
+type LockPolicy struct {
+	MinLockDurationSecs     uint64   `json:"minLockDurationSecs"`
+	MaxLockDurationSecs     uint64   `json:"maxLockDurationSecs"`
+	AllowedAssetTypes       []string `json:"allowedAssetTypes"`
+	MaxFungibleUnitsPerLock uint64   `json:"maxFungibleUnitsPerLock"`
+}
+
+// function to check that the limits of a lock policy are consistent with one another
+func validateLockPolicy(lockPolicy *LockPolicy) error {
+	if lockPolicy.MaxLockDurationSecs != 0 && lockPolicy.MinLockDurationSecs > lockPolicy.MaxLockDurationSecs {
+		return fmt.Errorf("minimum lock duration %d secs exceeds the maximum lock duration %d secs",
+			lockPolicy.MinLockDurationSecs, lockPolicy.MaxLockDurationSecs)
+	}
+	for _, assetType := range lockPolicy.AllowedAssetTypes {
+		if assetType == "" {
+			return fmt.Errorf("empty asset type in the list of allowed asset types")
+		}
+	}
+	return nil
+}
+
+// SetLockPolicy cc is used by an admin of the interop cc to record the lock policy (in JSON form), replacing
+// any existing one; a policy with no limits set lifts all the restrictions
+func (s *SmartContract) SetLockPolicy(ctx contractapi.TransactionContextInterface, lockPolicyJSON string) error {
+	err := checkCallerIsAdmin(ctx)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	// reject unknown (e.g., misspelt) fields, which would otherwise be dropped silently and leave a limit unset
+	lockPolicy := &LockPolicy{}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(lockPolicyJSON)))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(lockPolicy)
+	if err != nil {
+		return logThenErrorf("unmarshal error: %+v", err)
+	}
+	err = validateLockPolicy(lockPolicy)
+	if err != nil {
+		return logThenErrorf("invalid lock policy: %+v", err)
+	}
+	lockPolicyBytes, err := json.Marshal(lockPolicy)
+	if err != nil {
+		return logThenErrorf("marshal error: %+v", err)
+	}
+	err = ctx.GetStub().PutState(lockPolicyKey, lockPolicyBytes)
+	if err != nil {
+		return logThenErrorf("failed to write to the world state: %+v", err)
+	}
+	return nil
+}
+
+// GetLockPolicy cc is used to query the lock policy in force (with no limits set if none has been recorded)
+func (s *SmartContract) GetLockPolicy(ctx contractapi.TransactionContextInterface) (*LockPolicy, error) {
+	return getLockPolicy(ctx)
+}
+
+// function to fetch the lock policy recorded on the ledger
+func getLockPolicy(ctx contractapi.TransactionContextInterface) (*LockPolicy, error) {
+	lockPolicy := &LockPolicy{}
+	lockPolicyBytes, err := ctx.GetStub().GetState(lockPolicyKey)
+	if err != nil {
+		return lockPolicy, logThenErrorf("failed to retrieve from the world state: %+v", err)
+	}
+	if lockPolicyBytes == nil {
+		return lockPolicy, nil
+	}
+	err = json.Unmarshal(lockPolicyBytes, lockPolicy)
+	if err != nil {
+		return lockPolicy, logThenErrorf("invalid lock policy recorded on the ledger: %+v", err)
+	}
+	return lockPolicy, nil
+}
+
+// function to check that a lock expiring at expiryTimeSecs expires after the transaction time, whatever the lock
+// policy, and that its duration as of the transaction time is within the limits of the lock policy
+func validateLockDurationAgainstPolicy(ctx contractapi.TransactionContextInterface, lockPolicy *LockPolicy, expiryTimeSecs uint64) error {
+	currentTimeSecs, err := getTxTimeSecs(ctx)
+	if err != nil {
+		return err
+	}
+	if expiryTimeSecs <= currentTimeSecs {
+		return fmt.Errorf("lock expiry time %d is not after the transaction time %d", expiryTimeSecs, currentTimeSecs)
+	}
+	lockDurationSecs := expiryTimeSecs - currentTimeSecs
+	if lockDurationSecs < lockPolicy.MinLockDurationSecs {
+		return fmt.Errorf("lock duration of %d secs is shorter than the minimum of %d secs allowed by the lock policy",
+			lockDurationSecs, lockPolicy.MinLockDurationSecs)
+	}
+	if lockPolicy.MaxLockDurationSecs != 0 && lockDurationSecs > lockPolicy.MaxLockDurationSecs {
+		return fmt.Errorf("lock duration of %d secs is longer than the maximum of %d secs allowed by the lock policy",
+			lockDurationSecs, lockPolicy.MaxLockDurationSecs)
+	}
+	return nil
+}
+
+/*
+ * Function to check a lock against the lock policy recorded on the ledger: the asset type needs to be one of the allowed
+ * types, the number of units (0 for a non-fungible asset) cannot exceed the maximum per lock, and the lock duration
+ * needs to be within the minimum and maximum durations.
+ */
+func enforceLockPolicy(ctx contractapi.TransactionContextInterface, assetType string, numUnits uint64, expiryTimeSecs uint64) error {
+	lockPolicy, err := getLockPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	if len(lockPolicy.AllowedAssetTypes) > 0 {
+		isAllowed := false
+		for _, allowedAssetType := range lockPolicy.AllowedAssetTypes {
+			if allowedAssetType == assetType {
+				isAllowed = true
+				break
+			}
+		}
+		if !isAllowed {
+			return fmt.Errorf("asset type %s is not allowed to be locked by the lock policy", assetType)
+		}
+	}
+	if lockPolicy.MaxFungibleUnitsPerLock != 0 && numUnits > lockPolicy.MaxFungibleUnitsPerLock {
+		return fmt.Errorf("%d units exceed the maximum of %d units per lock allowed by the lock policy",
+			numUnits, lockPolicy.MaxFungibleUnitsPerLock)
+	}
+	return validateLockDurationAgainstPolicy(ctx, lockPolicy, expiryTimeSecs)
+}
+
+// function to check that a lock extended until newExpiryTimeSecs does not exceed the maximum lock duration of the lock policy
+func enforceLockPolicyOnExtension(ctx contractapi.TransactionContextInterface, newExpiryTimeSecs uint64) error {
+	lockPolicy, err := getLockPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	if lockPolicy.MaxLockDurationSecs == 0 {
+		return nil
+	}
+	return validateLockDurationAgainstPolicy(ctx, &LockPolicy{MaxLockDurationSecs: lockPolicy.MaxLockDurationSecs}, newExpiryTimeSecs)
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/core/network/fabric-interop-cc/contracts/interop/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetLockPolicy(t *testing.T) {
+	ctx, chaincodeStub, interopcc := prepMockStub()
+	clientIdentity := ctx.GetClientIdentity().(*mocks.ClientIdentity)
+	lockPolicyJSON := `{"minLockDurationSecs":60,"maxLockDurationSecs":3600,"allowedAssetTypes":["bond","cbdc"],"maxFungibleUnitsPerLock":1000}`
+
+	// Test failure with the caller not being an admin
+	err := interopcc.SetLockPolicy(ctx, lockPolicyJSON)
+	require.EqualError(t, err, "caller is not an admin of the organization "+myOrg1Msp)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with an unknown (misspelt) field, or inconsistent limits
+	clientIdentity.GetX509CertificateReturns(&x509.Certificate{Subject: pkix.Name{OrganizationalUnit: []string{"admin"}}}, nil)
+	err = interopcc.SetLockPolicy(ctx, `{"maxLockDurationSec":60}`)
+	require.EqualError(t, err, `unmarshal error: json: unknown field "maxLockDurationSec"`)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	err = interopcc.SetLockPolicy(ctx, `{"minLockDurationSecs":3600,"maxLockDurationSecs":60}`)
+	require.EqualError(t, err, "invalid lock policy: minimum lock duration 3600 secs exceeds the maximum lock duration 60 secs")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	err = interopcc.SetLockPolicy(ctx, `{"allowedAssetTypes":["bond",""]}`)
+	require.EqualError(t, err, "invalid lock policy: empty asset type in the list of allowed asset types")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	require.Equal(t, 0, chaincodeStub.PutStateCallCount())
+
+	// Test success with the caller being an admin of the local organization
+	err = interopcc.SetLockPolicy(ctx, lockPolicyJSON)
+	require.NoError(t, err)
+	key, value := chaincodeStub.PutStateArgsForCall(0)
+	require.Equal(t, lockPolicyKey, key)
+	require.JSONEq(t, lockPolicyJSON, string(value))
+
+	// Test success with the policy read back from the ledger, and no limits if none is recorded
+	lockPolicy, err := interopcc.GetLockPolicy(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &LockPolicy{}, lockPolicy)
+	ctx.GetStub().(*chaincodeStubWithConfig).config[lockPolicyKey] = value
+	lockPolicy, err = interopcc.GetLockPolicy(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &LockPolicy{MinLockDurationSecs: 60, MaxLockDurationSecs: 3600,
+		AllowedAssetTypes: []string{"bond", "cbdc"}, MaxFungibleUnitsPerLock: 1000}, lockPolicy)
+}
+
+func TestLockPolicyEnforcement(t *testing.T) {
+	ctx, mockStub, interopcc := prepShimMockStub()
+
+	locker := getTxCreatorECertBase64()
+	hashBase64 := generateSHA256HashInBase64Form("abcd")
+	currentTimeSecs := uint64(time.Now().Unix())
+
+	lockPolicyBytes, _ := json.Marshal(&LockPolicy{MinLockDurationSecs: 60, MaxLockDurationSecs: 3600,
+		AllowedAssetTypes: []string{"bond", "cbdc"}, MaxFungibleUnitsPerLock: 1000})
+	mockStub.MockTransactionStart("tx0")
+	err := mockStub.PutState(lockPolicyKey, lockPolicyBytes)
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx0")
+
+	lockBond := func(txId string, assetType string, expiryTimeSecs uint64) (string, error) {
+		assetAgreement := &common.AssetExchangeAgreement{
+			Type:      assetType,
+			Id:        "A001",
+			Recipient: "Bob",
+			Locker:    locker,
+		}
+		assetAgreementBytes, _ := proto.Marshal(assetAgreement)
+		mockStub.MockTransactionStart(txId)
+		mockStub.TxTimestamp = &timestamp.Timestamp{Seconds: int64(currentTimeSecs)}
+		defer mockStub.MockTransactionEnd(txId)
+		return interopcc.LockAsset(ctx, base64.StdEncoding.EncodeToString(assetAgreementBytes), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+	}
+	lockFungible := func(txId string, numUnits uint64, expiryTimeSecs uint64) (string, error) {
+		assetAgreement := &common.FungibleAssetExchangeAgreement{
+			Type:      "cbdc",
+			NumUnits:  numUnits,
+			Recipient: "Bob",
+			Locker:    locker,
+		}
+		assetAgreementBytes, _ := proto.Marshal(assetAgreement)
+		mockStub.MockTransactionStart(txId)
+		mockStub.TxTimestamp = &timestamp.Timestamp{Seconds: int64(currentTimeSecs)}
+		defer mockStub.MockTransactionEnd(txId)
+		return interopcc.LockFungibleAsset(ctx, base64.StdEncoding.EncodeToString(assetAgreementBytes), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+	}
+
+	// Test failure with a lock expiring in the past, too soon or too late
+	_, err = lockBond("tx1", "bond", currentTimeSecs-1)
+	require.EqualError(t, err, fmt.Sprintf("lock policy violation: lock expiry time %d is not after the transaction time %d", currentTimeSecs-1, currentTimeSecs))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	_, err = lockBond("tx2", "bond", currentTimeSecs+30)
+	require.EqualError(t, err, "lock policy violation: lock duration of 30 secs is shorter than the minimum of 60 secs allowed by the lock policy")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	_, err = lockBond("tx3", "bond", currentTimeSecs+50*365*24*3600)
+	require.EqualError(t, err, fmt.Sprintf("lock policy violation: lock duration of %d secs is longer than the maximum of 3600 secs allowed by the lock policy", 50*365*24*3600))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with an asset type that is not allowed, or too many units
+	_, err = lockBond("tx4", "land", currentTimeSecs+defaultTimeLockSecs)
+	require.EqualError(t, err, "lock policy violation: asset type land is not allowed to be locked by the lock policy")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	_, err = lockFungible("tx5", 1001, currentTimeSecs+defaultTimeLockSecs)
+	require.EqualError(t, err, "lock policy violation: 1001 units exceed the maximum of 1000 units per lock allowed by the lock policy")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with locks within the limits of the policy
+	_, err = lockBond("tx6", "bond", currentTimeSecs+defaultTimeLockSecs)
+	require.NoError(t, err)
+	_, err = lockFungible("tx7", 1000, currentTimeSecs+3600)
+	require.NoError(t, err)
+
+	// Test failure with a lock extended beyond the maximum duration
+	mockStub.MockTransactionStart("tx8")
+	mockStub.TxTimestamp = &timestamp.Timestamp{Seconds: int64(currentTimeSecs)}
+	err = enforceLockPolicyOnExtension(ctx, currentTimeSecs+3601)
+	require.EqualError(t, err, "lock duration of 3601 secs is longer than the maximum of 3600 secs allowed by the lock policy")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	err = enforceLockPolicyOnExtension(ctx, currentTimeSecs+3600)
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx8")
+
+	// Test failure with an asset bundle holding an asset type that is not allowed, too many units, or expiring too late
+	lockBundle := func(txId string, assets []*common.AssetBundleItem, expiryTimeSecs uint64) (string, error) {
+		mockStub.MockTransactionStart(txId)
+		mockStub.TxTimestamp = &timestamp.Timestamp{Seconds: int64(currentTimeSecs)}
+		defer mockStub.MockTransactionEnd(txId)
+		return interopcc.LockAssetBundle(ctx, getAssetBundleAgreementBase64(assets, locker, "Bob"), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+	}
+	_, err = lockBundle("tx9", []*common.AssetBundleItem{{Type: "bond", Id: "A002"}, {Type: "land", Id: "L001"}}, currentTimeSecs+defaultTimeLockSecs)
+	require.EqualError(t, err, "lock policy violation: asset type land is not allowed to be locked by the lock policy")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	_, err = lockBundle("tx10", []*common.AssetBundleItem{{Type: "bond", Id: "A002"}, {Type: "cbdc", NumUnits: 1001}}, currentTimeSecs+defaultTimeLockSecs)
+	require.EqualError(t, err, "lock policy violation: 1001 units exceed the maximum of 1000 units per lock allowed by the lock policy")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	_, err = lockBundle("tx11", []*common.AssetBundleItem{{Type: "bond", Id: "A002"}}, currentTimeSecs+3601)
+	require.EqualError(t, err, "lock policy violation: lock duration of 3601 secs is longer than the maximum of 3600 secs allowed by the lock policy")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with an asset bundle within the limits of the policy
+	_, err = lockBundle("tx12", []*common.AssetBundleItem{{Type: "bond", Id: "A002"}, {Type: "cbdc", NumUnits: 1000}}, currentTimeSecs+3600)
+	require.NoError(t, err)
+
+	// Test failure with a lock expiring at the transaction time, even with a policy that sets no limits
+	mockStub.MockTransactionStart("tx13")
+	err = mockStub.PutState(lockPolicyKey, []byte("{}"))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx13")
+	_, err = lockBond("tx14", "land", currentTimeSecs)
+	require.EqualError(t, err, fmt.Sprintf("lock policy violation: lock expiry time %d is not after the transaction time %d", currentTimeSecs, currentTimeSecs))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	_, err = lockBond("tx15", "land", currentTimeSecs+50*365*24*3600)
+	require.NoError(t, err)
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// lock_recipients contains the functions supporting non-fungible asset locks for several recipients (e.g., the members
+// of a syndicate): a lock claimable by any one of the listed recipients, or only with the approval of k of them
+package main
+
+import (
+	"fmt"
+
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	log "github.com/sirupsen/logrus"
+)
+
+// function to check the recipients listed in an asset agreement: they need to be distinct, with the single recipient
+// of the agreement left empty, and the recipient threshold cannot exceed their number
+func validateRecipientsOfAssetAgreement(assetAgreement *common.AssetExchangeAgreement) error {
+	if len(assetAgreement.Recipients) == 0 {
+		if assetAgreement.RecipientThreshold > 1 {
+			return fmt.Errorf("recipient threshold %d is set without a list of recipients", assetAgreement.RecipientThreshold)
+		}
+		return nil
+	}
+	if len(assetAgreement.Recipient) > 0 {
+		return fmt.Errorf("both a recipient and a list of recipients are set in the asset agreement")
+	}
+	seenRecipients := map[string]bool{}
+	for _, recipient := range assetAgreement.Recipients {
+		if len(recipient) == 0 {
+			return fmt.Errorf("empty recipient in the list of recipients")
+		}
+		if seenRecipients[recipient] {
+			return fmt.Errorf("recipient %s is listed more than once", recipient)
+		}
+		seenRecipients[recipient] = true
+	}
+	if int(assetAgreement.RecipientThreshold) > len(assetAgreement.Recipients) {
+		return fmt.Errorf("recipient threshold %d exceeds the number of recipients %d", assetAgreement.RecipientThreshold, len(assetAgreement.Recipients))
+	}
+	return nil
+}
+
+// function to get the parties that a lock is meant for: the listed recipients, or the single recipient
+func getRecipientsOfLockedAsset(lockedAssetInfo LockedAssetInfo) []string {
+	if len(lockedAssetInfo.Recipients) > 0 {
+		return lockedAssetInfo.Recipients
+	}
+	return []string{lockedAssetInfo.Recipient}
+}
+
+// function to check if a party is the recipient, or one of the listed recipients, of a lock
+func isRecipientOfLock(recipient string, recipients []string, party string) bool {
+	if len(recipients) == 0 {
+		return recipient == party
+	}
+	for _, listedRecipient := range recipients {
+		if listedRecipient == party {
+			return true
+		}
+	}
+	return false
+}
+
+// function to check if the recipients of an asset agreement are those of a non-fungible asset lock; the listed
+// recipients can be in any order
+func isLockForRecipientsOfAssetAgreement(assetLockVal AssetLockValue, assetAgreement *common.AssetExchangeAgreement) bool {
+	if assetLockVal.Recipient != assetAgreement.Recipient || len(assetLockVal.Recipients) != len(assetAgreement.Recipients) ||
+		getRecipientThreshold(assetLockVal.RecipientThreshold) != getRecipientThreshold(assetAgreement.RecipientThreshold) {
+		return false
+	}
+	for _, recipient := range assetAgreement.Recipients {
+		if !isRecipientOfLock("", assetLockVal.Recipients, recipient) {
+			return false
+		}
+	}
+	return true
+}
+
+// function to get the number of recipients that need to approve a claim, given the recipient threshold of a lock
+// (at least one, the claimant)
+func getRecipientThreshold(recipientThreshold uint32) uint32 {
+	if recipientThreshold == 0 {
+		return 1
+	}
+	return recipientThreshold
+}
+
+// function to construct the message that a recipient of a lock signs to approve its claim by another recipient; the
+// message carries the hash (empty for a lock other than an HTLC) and the expiry time of the lock besides its contractId,
+// so that an approval cannot be replayed for a later lock under the same contractId (e.g., the default contractId of
+// the asset) nor after the expiry of the lock has been extended
+func getClaimApprovalMessage(contractId string, hashBase64 string, expiryTimeSecs uint64, claimant string) string {
+	return fmt.Sprintf("ClaimApproval:%s:%s:%d:%s", contractId, hashBase64, expiryTimeSecs, claimant)
+}
+
+/*
+ * Function to check that the claimant (the transaction creator) can claim a non-fungible asset lock: the claimant
+ * needs to be a recipient of the lock and, if the lock has a recipient threshold of k, the claim needs to carry the
+ * approvals of k-1 other recipients, i.e., their signatures over the claim approval message of the lock.
+ */
+func validateClaimantOfAssetLock(contractId string, assetLockVal AssetLockValue, claimInfo *common.AssetClaim, claimant string) error {
+	funName := "validateClaimantOfAssetLock"
+	if !isRecipientOfLock(assetLockVal.Recipient, assetLockVal.Recipients, claimant) {
+		return fmt.Errorf("asset is not locked for %s to claim", claimant)
+	}
+	recipientThreshold := getRecipientThreshold(assetLockVal.RecipientThreshold)
+	if recipientThreshold == 1 {
+		return nil
+	}
+
+	hashBase64 := ""
+	if hashLock, ok := normalizeLockInfo(assetLockVal.LockInfo).(HashLock); ok {
+		hashBase64 = hashLock.HashBase64
+	}
+	claimApprovalMessage := getClaimApprovalMessage(contractId, hashBase64, assetLockVal.ExpiryTimeSecs, claimant)
+	approvingRecipients := map[string]bool{claimant: true}
+	for _, approval := range claimInfo.RecipientApprovals {
+		if approvingRecipients[approval.Recipient] {
+			continue
+		}
+		if !isRecipientOfLock("", assetLockVal.Recipients, approval.Recipient) {
+			return fmt.Errorf("claim is approved by %s who is not a recipient of the lock", approval.Recipient)
+		}
+		recipientCert, err := parseLockPartyCert(approval.Recipient, "lock recipient")
+		if err != nil {
+			return err
+		}
+		err = validateSignature(claimApprovalMessage, recipientCert, string(approval.Signature))
+		if err != nil {
+			return fmt.Errorf("claim approval of recipient %s is not valid: %+v", approval.Recipient, err)
+		}
+		approvingRecipients[approval.Recipient] = true
+	}
+	if uint32(len(approvingRecipients)) < recipientThreshold {
+		return fmt.Errorf("claim is approved by %d recipients while the lock requires %d", len(approvingRecipients), recipientThreshold)
+	}
+	log.Infof("%s: claim of contractId %s is approved by %d recipients", funName, contractId, len(approvingRecipients))
+	return nil
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/stretchr/testify/require"
+)
+
+// function that builds an HTLC claim (in base64 form) carrying the given recipient approvals
+func getHTLCClaimInfoWithApprovalsBase64(preimage string, approvals []*common.RecipientApproval) string {
+	claimInfoHTLC := &common.AssetClaimHTLC{
+		HashPreimageBase64: []byte(base64.StdEncoding.EncodeToString([]byte(preimage))),
+	}
+	claimInfoHTLCBytes, _ := proto.Marshal(claimInfoHTLC)
+	claimInfo := &common.AssetClaim{
+		LockMechanism:      common.LockMechanism_HTLC,
+		ClaimInfo:          claimInfoHTLCBytes,
+		RecipientApprovals: approvals,
+	}
+	claimInfoBytes, _ := proto.Marshal(claimInfo)
+	return base64.StdEncoding.EncodeToString(claimInfoBytes)
+}
+
+// function that signs the claim approval message for a lock and claimant with the key of a recipient
+func signClaimApproval(t *testing.T, key *ecdsa.PrivateKey, recipient string, contractId string, hashBase64 string, expiryTimeSecs uint64,
+	claimant string) *common.RecipientApproval {
+	hashed, err := computeSHA2Hash([]byte(getClaimApprovalMessage(contractId, hashBase64, expiryTimeSecs, claimant)), key.PublicKey.Params().BitSize)
+	require.NoError(t, err)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hashed)
+	require.NoError(t, err)
+	return &common.RecipientApproval{Recipient: recipient, Signature: signature}
+}
+
+func TestLockForSeveralRecipients(t *testing.T) {
+	ctx, mockStub, interopcc := prepShimMockStub()
+
+	// the caller is the locker, and one of the recipients, of the assets
+	caller := getTxCreatorECertBase64()
+	newRecipient := func(commonName string) (string, *ecdsa.PrivateKey) {
+		template := x509.Certificate{
+			Subject:      pkix.Name{CommonName: commonName},
+			SerialNumber: big.NewInt(1337),
+		}
+		certBytes, key, err := createECDSACertAndKeyFromTemplate(template)
+		require.NoError(t, err)
+		return base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes})), key
+	}
+	bob, bobKey := newRecipient("bob.example.com")
+	charlie, charlieKey := newRecipient("charlie.example.com")
+	currentTimeSecs := uint64(time.Now().Unix())
+	hashBase64 := generateSHA256HashInBase64Form("abcd")
+	expiryTimeSecs := currentTimeSecs + defaultTimeLockSecs
+	lockInfo := getHTLCLockInfoBase64(hashBase64, expiryTimeSecs)
+	getAgreementBase64 := func(assetId string, recipient string, recipients []string, recipientThreshold uint32) string {
+		assetAgreement := &common.AssetExchangeAgreement{
+			Type:               "bond",
+			Id:                 assetId,
+			Locker:             caller,
+			Recipient:          recipient,
+			Recipients:         recipients,
+			RecipientThreshold: recipientThreshold,
+		}
+		assetAgreementBytes, _ := proto.Marshal(assetAgreement)
+		return base64.StdEncoding.EncodeToString(assetAgreementBytes)
+	}
+
+	// Test failure with invalid lists of recipients
+	_, err := interopcc.LockAsset(ctx, getAgreementBase64("A001", bob, []string{caller, bob}, 0), lockInfo)
+	require.EqualError(t, err, "error in recipient validation: both a recipient and a list of recipients are set in the asset agreement")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	_, err = interopcc.LockAsset(ctx, getAgreementBase64("A001", "", []string{bob, bob}, 0), lockInfo)
+	require.EqualError(t, err, fmt.Sprintf("error in recipient validation: recipient %s is listed more than once", bob))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	_, err = interopcc.LockAsset(ctx, getAgreementBase64("A001", "", []string{caller, bob}, 3), lockInfo)
+	require.EqualError(t, err, "error in recipient validation: recipient threshold 3 exceeds the number of recipients 2")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with an asset claimable by any one of the recipients, and listed for each of them
+	mockStub.MockTransactionStart("tx1")
+	anyOfContractId, err := interopcc.LockAsset(ctx, getAgreementBase64("A001", "", []string{caller, bob}, 0), lockInfo)
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx1")
+	for _, recipient := range []string{caller, bob} {
+		lockedAssets, err := interopcc.GetAllNonFungibleLockedAssets(ctx, recipient, "*")
+		require.NoError(t, err)
+		require.Len(t, lockedAssets, 1)
+		require.True(t, strings.Contains(lockedAssets[0], anyOfContractId))
+	}
+	lockedAssets, err := interopcc.GetAllNonFungibleLockedAssets(ctx, charlie, "*")
+	require.NoError(t, err)
+	require.Len(t, lockedAssets, 0)
+	isLocked, err := interopcc.IsAssetLocked(ctx, getAgreementBase64("A001", bob, nil, 0))
+	require.NoError(t, err)
+	require.True(t, isLocked)
+
+	// Test failure with a claim of the asset for a different set of recipients
+	mockStub.MockTransactionStart("tx2")
+	err = interopcc.ClaimAsset(ctx, getAgreementBase64("A001", "", []string{caller, charlie}, 0), getHTLCClaimInfoWithApprovalsBase64("abcd", nil))
+	require.Error(t, err)
+	require.True(t, strings.HasPrefix(err.Error(), "cannot claim asset of type bond and ID A001 as it is locked by"))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	mockStub.MockTransactionEnd("tx2")
+
+	// Test success with a claim of the asset by one of the recipients, listed in any order
+	mockStub.MockTransactionStart("tx3")
+	err = interopcc.ClaimAsset(ctx, getAgreementBase64("A001", "", []string{bob, caller}, 0), getHTLCClaimInfoWithApprovalsBase64("abcd", nil))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx3")
+	for _, recipient := range []string{caller, bob} {
+		lockedAssets, err := interopcc.GetAllNonFungibleLockedAssets(ctx, recipient, "*")
+		require.NoError(t, err)
+		require.Len(t, lockedAssets, 0)
+	}
+
+	// Test failure with a claim of an asset not locked for the caller
+	mockStub.MockTransactionStart("tx4")
+	otherContractId, err := interopcc.LockAsset(ctx, getAgreementBase64("A002", "", []string{bob, charlie}, 0), lockInfo)
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx4")
+	mockStub.MockTransactionStart("tx5")
+	err = interopcc.ClaimAssetUsingContractId(ctx, otherContractId, getHTLCClaimInfoWithApprovalsBase64("abcd", nil))
+	require.EqualError(t, err, fmt.Sprintf("asset is not locked for %s to claim", caller))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	mockStub.MockTransactionEnd("tx5")
+
+	// Test failure with a claim of a 2-of-3 lock without enough valid approvals
+	mockStub.MockTransactionStart("tx6")
+	thresholdContractId, err := interopcc.LockAsset(ctx, getAgreementBase64("A003", "", []string{caller, bob, charlie}, 2), lockInfo)
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx6")
+	mockStub.MockTransactionStart("tx7")
+	err = interopcc.ClaimAssetUsingContractId(ctx, thresholdContractId, getHTLCClaimInfoWithApprovalsBase64("abcd", nil))
+	require.EqualError(t, err, "claim is approved by 1 recipients while the lock requires 2")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	err = interopcc.ClaimAssetUsingContractId(ctx, thresholdContractId, getHTLCClaimInfoWithApprovalsBase64("abcd",
+		[]*common.RecipientApproval{signClaimApproval(t, charlieKey, bob, thresholdContractId, hashBase64, expiryTimeSecs, caller)}))
+	require.Error(t, err)
+	require.True(t, strings.HasPrefix(err.Error(), fmt.Sprintf("claim approval of recipient %s is not valid", bob)))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	err = interopcc.ClaimAssetUsingContractId(ctx, thresholdContractId, getHTLCClaimInfoWithApprovalsBase64("abcd",
+		[]*common.RecipientApproval{signClaimApproval(t, bobKey, bob, otherContractId, hashBase64, expiryTimeSecs, caller)}))
+	require.Error(t, err)
+	require.True(t, strings.HasPrefix(err.Error(), fmt.Sprintf("claim approval of recipient %s is not valid", bob)))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	mockStub.MockTransactionEnd("tx7")
+
+	// Test success with a claim of a 2-of-3 lock approved by another recipient
+	mockStub.MockTransactionStart("tx8")
+	err = interopcc.ClaimAssetUsingContractId(ctx, thresholdContractId, getHTLCClaimInfoWithApprovalsBase64("abcd",
+		[]*common.RecipientApproval{signClaimApproval(t, charlieKey, charlie, thresholdContractId, hashBase64, expiryTimeSecs, caller)}))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx8")
+	_, err = interopcc.IsAssetLockedQueryUsingContractId(ctx, thresholdContractId)
+	require.Error(t, err)
+
+	// Test failure with the approval of the claimed lock replayed for a new lock of the asset under the same contractId
+	newHashBase64 := generateSHA256HashInBase64Form("efgh")
+	mockStub.MockTransactionStart("tx9")
+	relockContractId, err := interopcc.LockAsset(ctx, getAgreementBase64("A003", "", []string{caller, bob, charlie}, 2),
+		getHTLCLockInfoBase64(newHashBase64, expiryTimeSecs))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx9")
+	require.Equal(t, thresholdContractId, relockContractId)
+	mockStub.MockTransactionStart("tx10")
+	err = interopcc.ClaimAssetUsingContractId(ctx, thresholdContractId, getHTLCClaimInfoWithApprovalsBase64("efgh",
+		[]*common.RecipientApproval{signClaimApproval(t, charlieKey, charlie, thresholdContractId, hashBase64, expiryTimeSecs, caller)}))
+	require.Error(t, err)
+	require.True(t, strings.HasPrefix(err.Error(), fmt.Sprintf("claim approval of recipient %s is not valid", charlie)))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	mockStub.MockTransactionEnd("tx10")
+
+	// Test success with a claim of the new lock approved for it
+	mockStub.MockTransactionStart("tx11")
+	err = interopcc.ClaimAssetUsingContractId(ctx, thresholdContractId, getHTLCClaimInfoWithApprovalsBase64("efgh",
+		[]*common.RecipientApproval{signClaimApproval(t, charlieKey, charlie, thresholdContractId, newHashBase64, expiryTimeSecs, caller)}))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx11")
+}
@@ -0,0 +1,334 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// lock_records contains the functions used to store asset lock records on the ledger as versioned protobuf messages
+// (common.AssetLockRecord), to read them back (along with records written in the legacy JSON form by earlier versions
+// of the chaincode), and to migrate the legacy records to the current schema
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+const lockRecordSchemaVersion uint32 = 1 // schema version of the lock records written by this chaincode
+
+// function to check whether a lock record was written in the legacy JSON form. A record in the current form always
+// starts with the tag of the (non-zero) schema version, so it can never be mistaken for a JSON object.
+func isLegacyLockRecord(recordBytes []byte) bool {
+	return len(recordBytes) > 0 && recordBytes[0] == '{'
+}
+
+// function to convert the lock information decoded from a legacy lock record, which is a generic map, to a HashLock
+// or an EscrowLock; lock information that is already typed is returned as is
+func normalizeLockInfo(lockInfo interface{}) interface{} {
+	switch lockInfoVal := lockInfo.(type) {
+	case HashLock, EscrowLock:
+		return lockInfoVal
+	case *HashLock:
+		return *lockInfoVal
+	case *EscrowLock:
+		return *lockInfoVal
+	case map[string]interface{}:
+		lockInfoBytes, err := json.Marshal(lockInfoVal)
+		if err != nil {
+			return nil
+		}
+		escrowLock := EscrowLock{}
+		if json.Unmarshal(lockInfoBytes, &escrowLock) == nil && escrowLock.Arbiter != "" {
+			return escrowLock
+		}
+		hashLock := HashLock{}
+		if json.Unmarshal(lockInfoBytes, &hashLock) == nil && hashLock.HashBase64 != "" {
+			return hashLock
+		}
+	}
+	return nil
+}
+
+// function to set the lock information (an HTLC or an escrow) of a lock record
+func setLockInfoOfLockRecord(record *common.AssetLockRecord, lockInfo interface{}) error {
+	switch lockInfoVal := normalizeLockInfo(lockInfo).(type) {
+	case HashLock:
+		record.LockInfo = &common.AssetLockRecord_HashLock{
+			HashLock: &common.HashLockRecord{HashBase64: lockInfoVal.HashBase64, HashMechanism: lockInfoVal.HashMechanism},
+		}
+	case EscrowLock:
+		record.LockInfo = &common.AssetLockRecord_EscrowLock{
+			EscrowLock: &common.EscrowLockRecord{Arbiter: lockInfoVal.Arbiter},
+		}
+	default:
+		return fmt.Errorf("unsupported lock information %+v", lockInfo)
+	}
+	return nil
+}
+
+// function to get the lock information (a HashLock or an EscrowLock) of a lock record
+func getLockInfoOfLockRecord(record *common.AssetLockRecord) (interface{}, error) {
+	switch lockInfo := record.LockInfo.(type) {
+	case *common.AssetLockRecord_HashLock:
+		return HashLock{HashBase64: lockInfo.HashLock.HashBase64, HashMechanism: lockInfo.HashLock.HashMechanism}, nil
+	case *common.AssetLockRecord_EscrowLock:
+		return EscrowLock{Arbiter: lockInfo.EscrowLock.Arbiter}, nil
+	}
+	return nil, fmt.Errorf("lock record has no lock information")
+}
+
+// function to serialize a lock record in the current schema
+func marshalLockRecord(record *common.AssetLockRecord) ([]byte, error) {
+	record.SchemaVersion = lockRecordSchemaVersion
+	recordBytes, err := proto.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %+v", err)
+	}
+	return recordBytes, nil
+}
+
+// function to deserialize a lock record, checking that it is in the current schema
+func unmarshalLockRecord(recordBytes []byte) (*common.AssetLockRecord, error) {
+	record := &common.AssetLockRecord{}
+	err := proto.Unmarshal(recordBytes, record)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal error: %+v", err)
+	}
+	if record.SchemaVersion != lockRecordSchemaVersion {
+		return nil, fmt.Errorf("unsupported lock record schema version %d", record.SchemaVersion)
+	}
+	return record, nil
+}
+
+// function to serialize the lock of a non-fungible asset as a lock record
+func marshalAssetLockValue(assetLockVal AssetLockValue) ([]byte, error) {
+	record := &common.AssetLockRecord{
+		Locker:             assetLockVal.Locker,
+		Recipient:          assetLockVal.Recipient,
+		ExpiryTimeSecs:     assetLockVal.ExpiryTimeSecs,
+		BundleContractId:   assetLockVal.BundleContractId,
+		Recipients:         assetLockVal.Recipients,
+		RecipientThreshold: assetLockVal.RecipientThreshold,
+		ContractId:         assetLockVal.ContractId,
+	}
+	err := setLockInfoOfLockRecord(record, assetLockVal.LockInfo)
+	if err != nil {
+		return nil, err
+	}
+	return marshalLockRecord(record)
+}
+
+// function to deserialize the lock of a non-fungible asset from a lock record (current or legacy)
+func unmarshalAssetLockValue(assetLockValBytes []byte) (AssetLockValue, error) {
+	assetLockVal := AssetLockValue{}
+	if isLegacyLockRecord(assetLockValBytes) {
+		err := json.Unmarshal(assetLockValBytes, &assetLockVal)
+		if err != nil {
+			return assetLockVal, fmt.Errorf("unmarshal error: %+v", err)
+		}
+		assetLockVal.LockInfo = normalizeLockInfo(assetLockVal.LockInfo)
+		return assetLockVal, nil
+	}
+	record, err := unmarshalLockRecord(assetLockValBytes)
+	if err != nil {
+		return assetLockVal, err
+	}
+	assetLockVal.Locker = record.Locker
+	assetLockVal.Recipient = record.Recipient
+	assetLockVal.LockInfo, err = getLockInfoOfLockRecord(record)
+	if err != nil {
+		return assetLockVal, err
+	}
+	assetLockVal.ExpiryTimeSecs = record.ExpiryTimeSecs
+	assetLockVal.BundleContractId = record.BundleContractId
+	assetLockVal.Recipients = record.Recipients
+	assetLockVal.RecipientThreshold = record.RecipientThreshold
+	assetLockVal.ContractId = record.ContractId
+	return assetLockVal, nil
+}
+
+// function to serialize the lock of fungible asset units as a lock record
+func marshalFungibleAssetLockValue(assetLockVal FungibleAssetLockValue) ([]byte, error) {
+	record := &common.AssetLockRecord{
+		Type:           assetLockVal.Type,
+		NumUnits:       assetLockVal.NumUnits,
+		Locker:         assetLockVal.Locker,
+		Recipient:      assetLockVal.Recipient,
+		ExpiryTimeSecs: assetLockVal.ExpiryTimeSecs,
+	}
+	err := setLockInfoOfLockRecord(record, assetLockVal.LockInfo)
+	if err != nil {
+		return nil, err
+	}
+	return marshalLockRecord(record)
+}
+
+// function to deserialize the lock of fungible asset units from a lock record (current or legacy)
+func unmarshalFungibleAssetLockValue(assetLockValBytes []byte) (FungibleAssetLockValue, error) {
+	assetLockVal := FungibleAssetLockValue{}
+	if isLegacyLockRecord(assetLockValBytes) {
+		err := json.Unmarshal(assetLockValBytes, &assetLockVal)
+		if err != nil {
+			return assetLockVal, fmt.Errorf("unmarshal error: %+v", err)
+		}
+		assetLockVal.LockInfo = normalizeLockInfo(assetLockVal.LockInfo)
+		return assetLockVal, nil
+	}
+	record, err := unmarshalLockRecord(assetLockValBytes)
+	if err != nil {
+		return assetLockVal, err
+	}
+	assetLockVal.Type = record.Type
+	assetLockVal.NumUnits = record.NumUnits
+	assetLockVal.Locker = record.Locker
+	assetLockVal.Recipient = record.Recipient
+	assetLockVal.LockInfo, err = getLockInfoOfLockRecord(record)
+	if err != nil {
+		return assetLockVal, err
+	}
+	assetLockVal.ExpiryTimeSecs = record.ExpiryTimeSecs
+	return assetLockVal, nil
+}
+
+// function to serialize the lock of an asset bundle as a lock record
+func marshalAssetBundleLockValue(bundleLockVal AssetBundleLockValue) ([]byte, error) {
+	record := &common.AssetLockRecord{
+		Locker:         bundleLockVal.Locker,
+		Recipient:      bundleLockVal.Recipient,
+		ExpiryTimeSecs: bundleLockVal.ExpiryTimeSecs,
+	}
+	for _, asset := range bundleLockVal.Assets {
+		record.Assets = append(record.Assets, &common.AssetBundleItemRecord{Type: asset.Type, Id: asset.Id, NumUnits: asset.NumUnits})
+	}
+	err := setLockInfoOfLockRecord(record, bundleLockVal.LockInfo)
+	if err != nil {
+		return nil, err
+	}
+	return marshalLockRecord(record)
+}
+
+// function to deserialize the lock of an asset bundle from a lock record (current or legacy)
+func unmarshalAssetBundleLockValue(bundleLockValBytes []byte) (AssetBundleLockValue, error) {
+	bundleLockVal := AssetBundleLockValue{}
+	if isLegacyLockRecord(bundleLockValBytes) {
+		err := json.Unmarshal(bundleLockValBytes, &bundleLockVal)
+		if err != nil {
+			return bundleLockVal, fmt.Errorf("unmarshal error: %+v", err)
+		}
+		bundleLockVal.LockInfo = normalizeLockInfo(bundleLockVal.LockInfo)
+		return bundleLockVal, nil
+	}
+	record, err := unmarshalLockRecord(bundleLockValBytes)
+	if err != nil {
+		return bundleLockVal, err
+	}
+	for _, asset := range record.Assets {
+		bundleLockVal.Assets = append(bundleLockVal.Assets, AssetBundleItem{Type: asset.Type, Id: asset.Id, NumUnits: asset.NumUnits})
+	}
+	bundleLockVal.Locker = record.Locker
+	bundleLockVal.Recipient = record.Recipient
+	bundleLockVal.LockInfo, err = getLockInfoOfLockRecord(record)
+	if err != nil {
+		return bundleLockVal, err
+	}
+	bundleLockVal.ExpiryTimeSecs = record.ExpiryTimeSecs
+	return bundleLockVal, nil
+}
+
+// function to rewrite, in the current schema, the legacy lock records returned by the iterator, up to a total of
+// maxRecords migrated records; it returns the updated count of migrated records
+func migrateLegacyLockRecords(ctx contractapi.TransactionContextInterface, iterator shim.StateQueryIteratorInterface,
+	rewrite func([]byte) ([]byte, error), migratedCount uint32, maxRecords uint32) (uint32, error) {
+	defer iterator.Close()
+	for migratedCount < maxRecords && iterator.HasNext() {
+		record, err := iterator.Next()
+		if err != nil {
+			return migratedCount, fmt.Errorf("failed to iterate over the lock records: %+v", err)
+		}
+		if !isLegacyLockRecord(record.Value) {
+			continue
+		}
+		recordBytes, err := rewrite(record.Value)
+		if err != nil {
+			return migratedCount, fmt.Errorf("cannot migrate the lock record with key %s: %+v", record.Key, err)
+		}
+		err = ctx.GetStub().PutState(record.Key, recordBytes)
+		if err != nil {
+			return migratedCount, fmt.Errorf("failed to write to the world state: %+v", err)
+		}
+		migratedCount++
+	}
+	return migratedCount, nil
+}
+
+/*
+ * MigrateLockRecords cc is used by an admin of the interop cc, after upgrading the chaincode, to rewrite the
+ * lock records stored in the legacy JSON form in the current schema. At most maxRecords records are rewritten in a
+ * transaction, and the number rewritten is returned; the function is invoked until it returns 0. Legacy records can
+ * still be read (and are rewritten when the lock is updated) until they are migrated, so open locks remain usable.
+ */
+func (s *SmartContract) MigrateLockRecords(ctx contractapi.TransactionContextInterface, maxRecords uint32) (uint32, error) {
+	err := checkCallerIsAdmin(ctx)
+	if err != nil {
+		return 0, logThenErrorf(err.Error())
+	}
+	if maxRecords == 0 {
+		return 0, logThenErrorf("invalid maximum number of records %d", maxRecords)
+	}
+
+	migratedCount := uint32(0)
+	// non-fungible asset locks are keyed by <asset-type, asset-id>
+	iterator, err := ctx.GetStub().GetStateByPartialCompositeKey("AssetExchangeContract", []string{})
+	if err != nil {
+		return migratedCount, logThenErrorf("failed to query the non-fungible asset locks: %+v", err)
+	}
+	migratedCount, err = migrateLegacyLockRecords(ctx, iterator, func(recordBytes []byte) ([]byte, error) {
+		assetLockVal, err := unmarshalAssetLockValue(recordBytes)
+		if err != nil {
+			return nil, err
+		}
+		return marshalAssetLockValue(assetLockVal)
+	}, migratedCount, maxRecords)
+	if err != nil {
+		return migratedCount, logThenErrorf(err.Error())
+	}
+
+	// fungible asset locks are keyed by contractId, along with the map of the contractIds of non-fungible asset
+	// locks to their keys (which are JSON strings, and hence never taken for legacy lock records)
+	iterator, err = ctx.GetStub().GetStateByRange(contractIdPrefix, contractIdPrefix+string(utf8.MaxRune))
+	if err != nil {
+		return migratedCount, logThenErrorf("failed to query the fungible asset locks: %+v", err)
+	}
+	migratedCount, err = migrateLegacyLockRecords(ctx, iterator, func(recordBytes []byte) ([]byte, error) {
+		assetLockVal, err := unmarshalFungibleAssetLockValue(recordBytes)
+		if err != nil {
+			return nil, err
+		}
+		return marshalFungibleAssetLockValue(assetLockVal)
+	}, migratedCount, maxRecords)
+	if err != nil {
+		return migratedCount, logThenErrorf(err.Error())
+	}
+
+	iterator, err = ctx.GetStub().GetStateByRange(bundleContractIdPrefix, bundleContractIdPrefix+string(utf8.MaxRune))
+	if err != nil {
+		return migratedCount, logThenErrorf("failed to query the asset bundle locks: %+v", err)
+	}
+	migratedCount, err = migrateLegacyLockRecords(ctx, iterator, func(recordBytes []byte) ([]byte, error) {
+		bundleLockVal, err := unmarshalAssetBundleLockValue(recordBytes)
+		if err != nil {
+			return nil, err
+		}
+		return marshalAssetBundleLockValue(bundleLockVal)
+	}, migratedCount, maxRecords)
+	if err != nil {
+		return migratedCount, logThenErrorf(err.Error())
+	}
+	return migratedCount, nil
+}
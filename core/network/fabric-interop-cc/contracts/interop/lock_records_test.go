@@ -0,0 +1,153 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/core/network/fabric-interop-cc/contracts/interop/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockRecordSchema(t *testing.T) {
+	hashLock := HashLock{HashBase64: generateSHA256HashInBase64Form("abcd"), HashMechanism: common.HashMechanism_KECCAK256}
+
+	// Test success with the locks round-tripped through lock records in the current schema
+	assetLockVal := AssetLockValue{Locker: "Alice", Recipient: "Bob", LockInfo: hashLock, ExpiryTimeSecs: 100, BundleContractId: "bundle-id"}
+	assetLockValBytes, err := marshalAssetLockValue(assetLockVal)
+	require.NoError(t, err)
+	require.False(t, isLegacyLockRecord(assetLockValBytes))
+	decodedAssetLockVal, err := unmarshalAssetLockValue(assetLockValBytes)
+	require.NoError(t, err)
+	require.Equal(t, assetLockVal, decodedAssetLockVal)
+
+	fungibleAssetLockVal := FungibleAssetLockValue{Type: "cbdc", NumUnits: 10, Locker: "Alice", Recipient: "Bob",
+		LockInfo: EscrowLock{Arbiter: "Charlie"}, ExpiryTimeSecs: 100}
+	fungibleAssetLockValBytes, err := marshalFungibleAssetLockValue(fungibleAssetLockVal)
+	require.NoError(t, err)
+	decodedFungibleAssetLockVal, err := unmarshalFungibleAssetLockValue(fungibleAssetLockValBytes)
+	require.NoError(t, err)
+	require.Equal(t, fungibleAssetLockVal, decodedFungibleAssetLockVal)
+
+	bundleLockVal := AssetBundleLockValue{Assets: []AssetBundleItem{{Type: "bond", Id: "A001"}, {Type: "cbdc", NumUnits: 10}},
+		Locker: "Alice", Recipient: "Bob", LockInfo: hashLock, ExpiryTimeSecs: 100}
+	bundleLockValBytes, err := marshalAssetBundleLockValue(bundleLockVal)
+	require.NoError(t, err)
+	decodedBundleLockVal, err := unmarshalAssetBundleLockValue(bundleLockValBytes)
+	require.NoError(t, err)
+	require.Equal(t, bundleLockVal, decodedBundleLockVal)
+
+	// Test success with the lock information recorded as the lock mechanism of the record
+	record := &common.AssetLockRecord{}
+	err = proto.Unmarshal(fungibleAssetLockValBytes, record)
+	require.NoError(t, err)
+	require.Equal(t, "Charlie", record.GetEscrowLock().GetArbiter())
+	require.Nil(t, record.GetHashLock())
+
+	// Test success with legacy JSON records, whose lock information is decoded to the typed form
+	legacyAssetLockValBytes, _ := json.Marshal(assetLockVal)
+	require.True(t, isLegacyLockRecord(legacyAssetLockValBytes))
+	decodedAssetLockVal, err = unmarshalAssetLockValue(legacyAssetLockValBytes)
+	require.NoError(t, err)
+	require.Equal(t, assetLockVal, decodedAssetLockVal)
+	legacyFungibleAssetLockValBytes, _ := json.Marshal(fungibleAssetLockVal)
+	decodedFungibleAssetLockVal, err = unmarshalFungibleAssetLockValue(legacyFungibleAssetLockValBytes)
+	require.NoError(t, err)
+	require.Equal(t, fungibleAssetLockVal, decodedFungibleAssetLockVal)
+
+	// Test failure with a record in an unknown schema
+	recordBytes, _ := proto.Marshal(&common.AssetLockRecord{SchemaVersion: lockRecordSchemaVersion + 1, Locker: "Alice"})
+	_, err = unmarshalAssetLockValue(recordBytes)
+	require.EqualError(t, err, fmt.Sprintf("unsupported lock record schema version %d", lockRecordSchemaVersion+1))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a lock missing its lock information, or a record holding none
+	_, err = marshalAssetLockValue(AssetLockValue{Locker: "Alice", Recipient: "Bob", ExpiryTimeSecs: 100})
+	require.EqualError(t, err, "unsupported lock information <nil>")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	recordBytes, _ = proto.Marshal(&common.AssetLockRecord{SchemaVersion: lockRecordSchemaVersion, Locker: "Alice"})
+	_, err = unmarshalAssetLockValue(recordBytes)
+	require.EqualError(t, err, "lock record has no lock information")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+}
+
+func TestMigrateLockRecords(t *testing.T) {
+	ctx, mockStub, interopcc := prepShimMockStub()
+	clientIdentity := ctx.GetClientIdentity().(*mocks.ClientIdentity)
+
+	// the caller locks the assets for itself, so that it can claim them too
+	party := getTxCreatorECertBase64()
+	preimage := "abcd"
+	hashLock := HashLock{HashBase64: generateSHA256HashInBase64Form(preimage)}
+	expiryTimeSecs := uint64(time.Now().Unix()) + defaultTimeLockSecs
+
+	// record locks in the legacy JSON form, as written by earlier versions of the chaincode
+	mockStub.MockTransactionStart("tx1")
+	assetLockKey, assetContractId, err := generateAssetLockKeyAndContractId(ctx, &common.AssetExchangeAgreement{Type: "bond", Id: "A001"})
+	require.NoError(t, err)
+	assetLockValBytes, _ := json.Marshal(AssetLockValue{Locker: party, Recipient: party, LockInfo: hashLock, ExpiryTimeSecs: expiryTimeSecs})
+	require.NoError(t, mockStub.PutState(assetLockKey, assetLockValBytes))
+	assetLockKeyBytes, _ := json.Marshal(assetLockKey)
+	require.NoError(t, mockStub.PutState(generateContractIdMapKey(assetContractId), assetLockKeyBytes))
+	fungibleContractId := "fungible-contract-id"
+	fungibleAssetLockValBytes, _ := json.Marshal(FungibleAssetLockValue{Type: "cbdc", NumUnits: 10, Locker: party, Recipient: party,
+		LockInfo: hashLock, ExpiryTimeSecs: expiryTimeSecs})
+	require.NoError(t, mockStub.PutState(generateContractIdMapKey(fungibleContractId), fungibleAssetLockValBytes))
+	bundleContractId := "bundle-contract-id"
+	bundleLockValBytes, _ := json.Marshal(AssetBundleLockValue{Assets: []AssetBundleItem{{Type: "cbdc", NumUnits: 5}},
+		Locker: party, Recipient: party, LockInfo: hashLock, ExpiryTimeSecs: expiryTimeSecs})
+	require.NoError(t, mockStub.PutState(generateBundleContractIdMapKey(bundleContractId), bundleLockValBytes))
+	mockStub.MockTransactionEnd("tx1")
+
+	// Test failure with the caller not being an admin, or an invalid batch size
+	mockStub.MockTransactionStart("tx2")
+	_, err = interopcc.MigrateLockRecords(ctx, 2)
+	require.EqualError(t, err, "caller is not an admin of the organization "+myOrg1Msp)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	clientIdentity.GetX509CertificateReturns(&x509.Certificate{Subject: pkix.Name{OrganizationalUnit: []string{"admin"}}}, nil)
+	_, err = interopcc.MigrateLockRecords(ctx, 0)
+	require.EqualError(t, err, "invalid maximum number of records 0")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	mockStub.MockTransactionEnd("tx2")
+
+	// Test success with the legacy records migrated in batches, until none is left
+	for i, expectedCount := range []uint32{2, 1, 0} {
+		txId := fmt.Sprintf("tx%d", i+3)
+		mockStub.MockTransactionStart(txId)
+		migratedCount, err := interopcc.MigrateLockRecords(ctx, 2)
+		require.NoError(t, err)
+		require.Equal(t, expectedCount, migratedCount)
+		mockStub.MockTransactionEnd(txId)
+	}
+	for _, key := range []string{assetLockKey, generateContractIdMapKey(fungibleContractId), generateBundleContractIdMapKey(bundleContractId)} {
+		recordBytes, err := mockStub.GetState(key)
+		require.NoError(t, err)
+		require.False(t, isLegacyLockRecord(recordBytes))
+	}
+	assetLockKeyBytesAfterMigration, _ := mockStub.GetState(generateContractIdMapKey(assetContractId))
+	require.Equal(t, assetLockKeyBytes, assetLockKeyBytesAfterMigration)
+
+	// Test success with the migrated locks claimed
+	claimInfoHTLC := &common.AssetClaimHTLC{HashPreimageBase64: []byte(base64.StdEncoding.EncodeToString([]byte(preimage)))}
+	claimInfoHTLCBytes, _ := proto.Marshal(claimInfoHTLC)
+	claimInfoBytes, _ := proto.Marshal(&common.AssetClaim{LockMechanism: common.LockMechanism_HTLC, ClaimInfo: claimInfoHTLCBytes})
+	claimInfoBase64 := base64.StdEncoding.EncodeToString(claimInfoBytes)
+	mockStub.MockTransactionStart("tx6")
+	err = interopcc.ClaimAssetUsingContractId(ctx, assetContractId, claimInfoBase64)
+	require.NoError(t, err)
+	_, err = interopcc.ClaimFungibleAsset(ctx, fungibleContractId, claimInfoBase64)
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx6")
+}
@@ -7,6 +7,7 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -17,6 +18,7 @@ import (
 )
 
 const applicationCCKey = "applicationccid"
+const adminMSPsKey = "InteropAdminMSPs" // ledger key for the MSP IDs of the organizations whose admins administer the interop cc
 
 // SmartContract provides functions for managing arbitrary key-value pairs
 type SmartContract struct {
@@ -33,18 +35,34 @@ func init() {
 }
 
 // InitLedger initilises ledger with data. Need the application chaincode id so the handleExtnernalRequest flow can
-// call the application chaincode.
+// call the application chaincode. Any further arguments are the MSP IDs of the organizations whose admins may
+// administer the interop cc; if there are none, it is the organization of the client initialising the ledger.
+// Once admin organizations are recorded, only an admin of one of them can initialise the ledger again; until then,
+// the client needs to be an admin of its own organization.
 func (s *SmartContract) InitLedger(ctx contractapi.TransactionContextInterface) error {
 	var err error
 
 	_, args := ctx.GetStub().GetFunctionAndParameters()
 
-	if len(args) != 1 {
-		err = fmt.Errorf("Incorrect number of arguments. Expecting 1: {APPLICATION Chaincode ID/hash}. Found %d", len(args))
+	if len(args) < 1 {
+		err = fmt.Errorf("Incorrect number of arguments. Expecting at least 1: {APPLICATION Chaincode ID/hash, [admin MSP IDs]}. Found %d", len(args))
 		fmt.Printf("Error %s", err.Error())
 		return err
 	}
 
+	adminMSPs := args[1:]
+	if len(adminMSPs) == 0 {
+		callerMSPID, err := ctx.GetClientIdentity().GetMSPID()
+		if err != nil {
+			return fmt.Errorf("Unable to get the MSP ID of the transaction creator: %s", err.Error())
+		}
+		adminMSPs = []string{callerMSPID}
+	}
+	err = checkCallerCanSetAdminMSPs(ctx)
+	if err != nil {
+		return err
+	}
+
 	err = ctx.GetStub().PutState(applicationCCKey, []byte(args[0]))
 	if err != nil {
 		errMsg := fmt.Sprintf("Error saving APPLICATION ID: %s", err.Error())
@@ -52,9 +70,129 @@ func (s *SmartContract) InitLedger(ctx contractapi.TransactionContextInterface)
 		return errors.New(errMsg)
 	}
 
+	return putAdminMSPs(ctx, adminMSPs)
+}
+
+// SetAdminMSPs cc is used to record the MSP IDs (a JSON array) of the organizations whose admins administer the
+// interop cc, replacing the ones recorded. Only an admin of one of the recorded organizations can change them; if
+// there are none, as on a ledger written by a version of the interop cc without admin organizations, the caller
+// needs to be an admin of its own organization.
+func (s *SmartContract) SetAdminMSPs(ctx contractapi.TransactionContextInterface, adminMSPsJSON string) error {
+	var adminMSPs []string
+	err := json.Unmarshal([]byte(adminMSPsJSON), &adminMSPs)
+	if err != nil {
+		return fmt.Errorf("Unable to unmarshal admin MSP IDs: %s", err.Error())
+	}
+	if len(adminMSPs) == 0 {
+		return errors.New("No admin MSP IDs supplied")
+	}
+	err = checkCallerCanSetAdminMSPs(ctx)
+	if err != nil {
+		return err
+	}
+	return putAdminMSPs(ctx, adminMSPs)
+}
+
+// function to record the MSP IDs of the organizations whose admins administer the interop cc
+func putAdminMSPs(ctx contractapi.TransactionContextInterface, adminMSPs []string) error {
+	for _, adminMSP := range adminMSPs {
+		if adminMSP == "" {
+			return errors.New("Admin MSP ID cannot be empty")
+		}
+	}
+	adminMSPsBytes, err := json.Marshal(adminMSPs)
+	if err != nil {
+		return fmt.Errorf("Unable to marshal admin MSP IDs: %s", err.Error())
+	}
+	err = ctx.GetStub().PutState(adminMSPsKey, adminMSPsBytes)
+	if err != nil {
+		errMsg := fmt.Sprintf("Error saving admin MSP IDs: %s", err.Error())
+		fmt.Printf(errMsg)
+		return errors.New(errMsg)
+	}
 	return nil
 }
 
+// function to check that the transaction creator may record the admin organizations of the interop cc: an admin of
+// one of the organizations recorded, or, while none are recorded, an admin of its own organization
+func checkCallerCanSetAdminMSPs(ctx contractapi.TransactionContextInterface) error {
+	adminMSPsBytes, err := ctx.GetStub().GetState(adminMSPsKey)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve from the world state: %+v", err)
+	}
+	if adminMSPsBytes != nil {
+		return checkCallerIsAdmin(ctx)
+	}
+	callerMSPID, err := ctx.GetClientIdentity().GetMSPID()
+	if err != nil {
+		return fmt.Errorf("unable to get the MSP ID of the transaction creator: %+v", err)
+	}
+	return checkCallerIsOrgAdmin(ctx, callerMSPID)
+}
+
+// GetAdminMSPs retrieves the MSP IDs of the organizations whose admins administer the interop cc from the ledger
+func (s *SmartContract) GetAdminMSPs(ctx contractapi.TransactionContextInterface) ([]string, error) {
+	return getAdminMSPs(ctx)
+}
+
+// function to fetch the MSP IDs of the organizations whose admins administer the interop cc, as recorded by InitLedger
+func getAdminMSPs(ctx contractapi.TransactionContextInterface) ([]string, error) {
+	adminMSPsBytes, err := ctx.GetStub().GetState(adminMSPsKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve from the world state: %+v", err)
+	}
+	if adminMSPsBytes == nil {
+		return nil, errors.New("no admin organizations are recorded on the ledger")
+	}
+	var adminMSPs []string
+	err = json.Unmarshal(adminMSPsBytes, &adminMSPs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid admin organizations recorded on the ledger: %+v", err)
+	}
+	return adminMSPs, nil
+}
+
+// function to check that the transaction creator is an admin of one of the organizations recorded on the ledger as
+// administering the interop cc. The check only depends on the ledger state and the creator, so that the peers of
+// every organization endorse it identically.
+func checkCallerIsAdmin(ctx contractapi.TransactionContextInterface) error {
+	callerMSPID, err := ctx.GetClientIdentity().GetMSPID()
+	if err != nil {
+		return fmt.Errorf("unable to get the MSP ID of the transaction creator: %+v", err)
+	}
+	adminMSPs, err := getAdminMSPs(ctx)
+	if err != nil {
+		return err
+	}
+	isAdminMSP := false
+	for _, adminMSP := range adminMSPs {
+		if callerMSPID == adminMSP {
+			isAdminMSP = true
+			break
+		}
+	}
+	if !isAdminMSP {
+		return fmt.Errorf("caller from MSP %s is not a member of an admin organization", callerMSPID)
+	}
+	return checkCallerIsOrgAdmin(ctx, callerMSPID)
+}
+
+// function to check that the transaction creator is an admin of its organization, i.e., has the admin OU
+func checkCallerIsOrgAdmin(ctx contractapi.TransactionContextInterface, callerMSPID string) error {
+	callerCert, err := ctx.GetClientIdentity().GetX509Certificate()
+	if err != nil {
+		return fmt.Errorf("unable to get the certificate of the transaction creator: %+v", err)
+	}
+	if callerCert != nil {
+		for _, ou := range callerCert.Subject.OrganizationalUnit {
+			if ou == "admin" {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("caller is not an admin of the organization %s", callerMSPID)
+}
+
 // GetApplicationID retrieves the app CC id from the ledger
 func (s *SmartContract) GetApplicationID(ctx contractapi.TransactionContextInterface) (string, error) {
 	bytes, err := ctx.GetStub().GetState(applicationCCKey)
@@ -65,7 +203,10 @@ func (s *SmartContract) GetApplicationID(ctx contractapi.TransactionContextInter
 }
 
 func main() {
-	chaincode, err := contractapi.NewChaincode(new(SmartContract))
+	interopcc := new(SmartContract)
+	// every transaction gets a fresh context, which gathers the lock notifications of that transaction
+	interopcc.TransactionContextHandler = new(interopTransactionContext)
+	chaincode, err := contractapi.NewChaincode(interopcc)
 
 	if err != nil {
 		fmt.Printf("Error creating Interop chaincode: %s", err.Error())
@@ -0,0 +1,129 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/core/network/fabric-interop-cc/contracts/interop/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitLedger(t *testing.T) {
+	ctx, chaincodeStub, interopcc := prepMockStub()
+	clientIdentity := ctx.GetClientIdentity().(*mocks.ClientIdentity)
+	config := ctx.GetStub().(*chaincodeStubWithConfig).config
+	delete(config, adminMSPsKey)
+
+	// Test failure with no admin organizations recorded yet and the client not being an admin of its organization
+	chaincodeStub.GetFunctionAndParametersReturns("InitLedger", []string{"simplestate"})
+	err := interopcc.InitLedger(ctx)
+	require.EqualError(t, err, "caller is not an admin of the organization "+myOrg1Msp)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	require.Equal(t, 0, chaincodeStub.PutStateCallCount())
+
+	// Test success with the organization of the admin initialising the ledger administering the interop cc
+	clientIdentity.GetX509CertificateReturns(&x509.Certificate{Subject: pkix.Name{OrganizationalUnit: []string{"admin"}}}, nil)
+	err = interopcc.InitLedger(ctx)
+	require.NoError(t, err)
+	key, value := chaincodeStub.PutStateArgsForCall(0)
+	require.Equal(t, applicationCCKey, key)
+	require.Equal(t, "simplestate", string(value))
+	key, value = chaincodeStub.PutStateArgsForCall(1)
+	require.Equal(t, adminMSPsKey, key)
+	require.JSONEq(t, `["`+myOrg1Msp+`"]`, string(value))
+	config[adminMSPsKey] = value
+
+	// Test failure with an admin of another organization initialising the ledger again to take over the interop cc
+	clientIdentity.GetMSPIDReturns("Org2Testmsp", nil)
+	chaincodeStub.GetFunctionAndParametersReturns("InitLedger", []string{"otherapp", "Org2Testmsp"})
+	err = interopcc.InitLedger(ctx)
+	require.EqualError(t, err, "caller from MSP Org2Testmsp is not a member of an admin organization")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	chaincodeStub.GetFunctionAndParametersReturns("InitLedger", []string{"otherapp"})
+	err = interopcc.InitLedger(ctx)
+	require.EqualError(t, err, "caller from MSP Org2Testmsp is not a member of an admin organization")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	require.Equal(t, 2, chaincodeStub.PutStateCallCount())
+
+	// Test success with an admin of an admin organization supplying the admin organizations as arguments
+	clientIdentity.GetMSPIDReturns(myOrg1Msp, nil)
+	chaincodeStub.GetFunctionAndParametersReturns("InitLedger", []string{"simplestate", "Org2Testmsp", "Org3Testmsp"})
+	err = interopcc.InitLedger(ctx)
+	require.NoError(t, err)
+	key, value = chaincodeStub.PutStateArgsForCall(3)
+	require.Equal(t, adminMSPsKey, key)
+	require.JSONEq(t, `["Org2Testmsp","Org3Testmsp"]`, string(value))
+
+	// Test success with the admin organizations read back from the ledger
+	config[adminMSPsKey] = value
+	adminMSPs, err := interopcc.GetAdminMSPs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Org2Testmsp", "Org3Testmsp"}, adminMSPs)
+
+	// Test failure with a missing application chaincode id, or an empty admin organization
+	clientIdentity.GetMSPIDReturns("Org2Testmsp", nil)
+	chaincodeStub.GetFunctionAndParametersReturns("InitLedger", []string{})
+	err = interopcc.InitLedger(ctx)
+	require.EqualError(t, err, "Incorrect number of arguments. Expecting at least 1: {APPLICATION Chaincode ID/hash, [admin MSP IDs]}. Found 0")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	chaincodeStub.GetFunctionAndParametersReturns("InitLedger", []string{"simplestate", ""})
+	err = interopcc.InitLedger(ctx)
+	require.EqualError(t, err, "Admin MSP ID cannot be empty")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+}
+
+func TestSetAdminMSPs(t *testing.T) {
+	ctx, chaincodeStub, interopcc := prepMockStub()
+	clientIdentity := ctx.GetClientIdentity().(*mocks.ClientIdentity)
+	config := ctx.GetStub().(*chaincodeStubWithConfig).config
+
+	// Test failure with the caller not being an admin
+	err := interopcc.SetAdminMSPs(ctx, `["Org2Testmsp"]`)
+	require.EqualError(t, err, "caller is not an admin of the organization "+myOrg1Msp)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with the caller being an admin of an organization that does not administer the interop cc
+	clientIdentity.GetX509CertificateReturns(&x509.Certificate{Subject: pkix.Name{OrganizationalUnit: []string{"admin"}}}, nil)
+	clientIdentity.GetMSPIDReturns("Org2Testmsp", nil)
+	err = interopcc.SetAdminMSPs(ctx, `["Org2Testmsp"]`)
+	require.EqualError(t, err, "caller from MSP Org2Testmsp is not a member of an admin organization")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with an admin of an admin organization changing the admin organizations
+	clientIdentity.GetMSPIDReturns(myOrg1Msp, nil)
+	err = interopcc.SetAdminMSPs(ctx, `["`+myOrg1Msp+`","Org2Testmsp"]`)
+	require.NoError(t, err)
+	key, value := chaincodeStub.PutStateArgsForCall(0)
+	require.Equal(t, adminMSPsKey, key)
+	require.JSONEq(t, `["`+myOrg1Msp+`","Org2Testmsp"]`, string(value))
+
+	// Test success with an admin of its organization recording the admin organizations on a ledger of an upgraded
+	// interop cc, which has none recorded
+	delete(config, adminMSPsKey)
+	clientIdentity.GetMSPIDReturns("Org2Testmsp", nil)
+	err = interopcc.SetAdminMSPs(ctx, `["Org2Testmsp"]`)
+	require.NoError(t, err)
+	key, value = chaincodeStub.PutStateArgsForCall(1)
+	require.Equal(t, adminMSPsKey, key)
+	require.JSONEq(t, `["Org2Testmsp"]`, string(value))
+
+	// Test failure with invalid admin organizations
+	config[adminMSPsKey] = value
+	for adminMSPsJSON, expectedErr := range map[string]string{
+		`[]`:                 "No admin MSP IDs supplied",
+		`["Org2Testmsp",""]`: "Admin MSP ID cannot be empty",
+		`"Org2Testmsp"`:      "Unable to unmarshal admin MSP IDs: json: cannot unmarshal string into Go value of type []string",
+	} {
+		err = interopcc.SetAdminMSPs(ctx, adminMSPsJSON)
+		require.EqualError(t, err, expectedErr)
+		fmt.Printf("Test failed as expected with error: %s\n", err)
+	}
+}
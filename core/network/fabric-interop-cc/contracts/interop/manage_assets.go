@@ -10,34 +10,52 @@ package main
 
 import (
 	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
-	"time"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 	mspProtobuf "github.com/hyperledger/fabric-protos-go/msp"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/sha3"
 )
 
 // Object used to capture the HashLock details used in Asset Locking
 type HashLock struct {
-	HashBase64 string `json:"hashBase64"`
+	HashBase64    string               `json:"hashBase64"`
+	HashMechanism common.HashMechanism `json:"hashMechanism"`
 }
 
-// Object used in the map, <asset-type, asset-id> --> <contractId, locker, recipient, ...> (for non-fungible assets)
+// Object used to capture the arbiter details used in Asset Locking using the escrow lock mechanism
+type EscrowLock struct {
+	Arbiter string `json:"arbiter"`
+}
+
+// Object used in the map, <asset-type, asset-id> --> <contractId, locker, recipient, ...> (for non-fungible assets);
+// BundleContractId is set if the asset is locked as part of an asset bundle, which settles it along with the bundle;
+// Recipients and RecipientThreshold are set, instead of Recipient, if the asset is locked for several recipients;
+// ContractId is set if the contractId of the lock was chosen by the client rather than derived from the asset-key
 type AssetLockValue struct {
-	Locker         string      `json:"locker"`
-	Recipient      string      `json:"recipient"`
-	LockInfo       interface{} `json:"lockInfo"`
-	ExpiryTimeSecs uint64      `json:"expiryTimeSecs"`
+	Locker             string      `json:"locker"`
+	Recipient          string      `json:"recipient"`
+	LockInfo           interface{} `json:"lockInfo"`
+	ExpiryTimeSecs     uint64      `json:"expiryTimeSecs"`
+	BundleContractId   string      `json:"bundleContractId,omitempty"`
+	Recipients         []string    `json:"recipients,omitempty"`
+	RecipientThreshold uint32      `json:"recipientThreshold,omitempty"`
+	ContractId         string      `json:"contractId,omitempty"`
 }
 
-// Object used in the map, contractId --> <asset-type, num-units, locker, ...> (for fungible assets)
+// Object used in the map, contractId --> <asset-type, num-units, locker, ...> (for fungible assets);
+// NumUnits is the number of units still locked, which partial claims reduce
 type FungibleAssetLockValue struct {
 	Type           string      `json:"type"`
 	NumUnits       uint64      `json:"numUnits"`
@@ -47,10 +65,54 @@ type FungibleAssetLockValue struct {
 	ExpiryTimeSecs uint64      `json:"expiryTimeSecs"`
 }
 
+// Object used in the map, contractId --> <hash-preimage, claimer, claim-time> (recorded when an HTLC is claimed)
+type ClaimedContractValue struct {
+	HashPreimageBase64 string `json:"hashPreimageBase64"`
+	Claimer            string `json:"claimer"`
+	ClaimTimeSecs      uint64 `json:"claimTimeSecs"`
+}
+
+// Object returned (in JSON form) for every lock reported by the lock listing queries
+type LockedAssetInfo struct {
+	ContractId         string   `json:"contractId"`
+	Type               string   `json:"type"`
+	Id                 string   `json:"id,omitempty"`
+	NumUnits           uint64   `json:"numUnits,omitempty"`
+	Locker             string   `json:"locker"`
+	Recipient          string   `json:"recipient"`
+	ExpiryTimeSecs     uint64   `json:"expiryTimeSecs"`
+	Recipients         []string `json:"recipients,omitempty"`
+	RecipientThreshold uint32   `json:"recipientThreshold,omitempty"`
+	// assets of an asset bundle lock, for which Type, Id and NumUnits are not set
+	Assets []AssetBundleItem `json:"assets,omitempty"`
+}
+
+// Object returned (in JSON form) by the paginated lock listing queries; Bookmark is passed to the next query to fetch
+// the following page, and is empty once the listing is exhausted. FetchedRecordsCount is the number of index entries
+// looked up for the page, which may exceed the number of locks listed when the query filters on a party.
+type LockedAssetsPage struct {
+	LockedAssets        []string `json:"lockedAssets"`
+	Bookmark            string   `json:"bookmark"`
+	FetchedRecordsCount int32    `json:"fetchedRecordsCount"`
+}
+
 const (
-	assetKeyPrefix    = "AssetKey_"   // prefix for the map, asset-key --> asset-object
-	assetKeyDelimiter = "_"           // delimiter for the asset-key
-	contractIdPrefix  = "ContractId_" // prefix for the map, contractId --> asset-key
+	assetKeyPrefix          = "AssetKey_"          // prefix for the map, asset-key --> asset-object
+	assetKeyDelimiter       = "_"                  // delimiter for the asset-key
+	contractIdPrefix        = "ContractId_"        // prefix for the map, contractId --> asset-key
+	claimedContractIdPrefix = "ClaimedContractId_" // prefix for the map, contractId --> claimed-contract-object
+)
+
+// Indexes maintained over the asset locks; each index entry ends with the lock kind and the contractId. The expiry
+// index uses simple keys rather than a composite key, so that it can be scanned by a range query bounded by time.
+const (
+	lockerIndexObjectType    = "AssetLockByLocker"    // <locker, lock-kind, contractId>
+	recipientIndexObjectType = "AssetLockByRecipient" // <recipient, lock-kind, contractId>, for each recipient of the lock
+	assetTypeIndexObjectType = "AssetLockByType"      // <lock-kind, asset-type, contractId>, for each asset type of the lock
+	expiryIndexObjectType    = "AssetLockByExpiry"    // AssetLockByExpiry_<zero-padded expiry-time>_<lock-kind>_<contractId>
+	nonFungibleLockKind      = "NonFungible"
+	fungibleLockKind         = "Fungible"
+	assetBundleLockKind      = "AssetBundle"
 )
 
 // helper functions to log and return errors
@@ -69,6 +131,30 @@ func generateSHA256HashInBase64Form(preimage string) string {
 	return shaHashBase64
 }
 
+// function to generate a hash in base64 format for a given preimage using the supplied hash mechanism
+func generateHashInBase64Form(preimage string, hashMechanism common.HashMechanism) (string, error) {
+	var hash []byte
+	switch hashMechanism {
+	case common.HashMechanism_SHA256:
+		shaHash := sha256.Sum256([]byte(preimage))
+		hash = shaHash[:]
+	case common.HashMechanism_SHA512:
+		shaHash := sha512.Sum512([]byte(preimage))
+		hash = shaHash[:]
+	case common.HashMechanism_KECCAK256:
+		hasher := sha3.NewLegacyKeccak256()
+		hasher.Write([]byte(preimage))
+		hash = hasher.Sum(nil)
+	case common.HashMechanism_DOUBLE_SHA256:
+		shaHash := sha256.Sum256([]byte(preimage))
+		doubleShaHash := sha256.Sum256(shaHash[:])
+		hash = doubleShaHash[:]
+	default:
+		return "", fmt.Errorf("hash mechanism %s is not supported", hashMechanism.String())
+	}
+	return base64.StdEncoding.EncodeToString(hash), nil
+}
+
 // function to return the key to fetch an element from the map using contractId
 func generateContractIdMapKey(contractId string) string {
 	return contractIdPrefix + contractId
@@ -93,12 +179,130 @@ func generateAssetLockKeyAndContractId(ctx contractapi.TransactionContextInterfa
  * a hash on the attributes of the fungible asset exchange agreement)
  */
 func generateFungibleAssetLockContractId(ctx contractapi.TransactionContextInterface, assetAgreement *common.FungibleAssetExchangeAgreement) string {
-	preimage := assetAgreement.Type + strconv.Itoa(int(assetAgreement.NumUnits)) +
+	preimage := assetAgreement.Type + strconv.FormatUint(assetAgreement.NumUnits, 10) +
 		assetAgreement.Locker + assetAgreement.Recipient + ctx.GetStub().GetTxID()
 	contractId := generateSHA256HashInBase64Form(preimage)
 	return contractId
 }
 
+// function to generate the keys of all the index entries associated with an asset lock
+func generateAssetLockIndexKeys(ctx contractapi.TransactionContextInterface, lockKind string, contractId string, lockInfo LockedAssetInfo) ([]string, error) {
+	objectTypes := []string{lockerIndexObjectType}
+	indexAttributes := [][]string{{lockInfo.Locker, lockKind, contractId}}
+	for _, recipient := range getRecipientsOfLockedAsset(lockInfo) {
+		objectTypes = append(objectTypes, recipientIndexObjectType)
+		indexAttributes = append(indexAttributes, []string{recipient, lockKind, contractId})
+	}
+	for _, assetType := range getAssetTypesOfLockedAsset(lockInfo) {
+		objectTypes = append(objectTypes, assetTypeIndexObjectType)
+		indexAttributes = append(indexAttributes, []string{lockKind, assetType, contractId})
+	}
+	indexKeys := []string{}
+	for i, objectType := range objectTypes {
+		indexKey, err := ctx.GetStub().CreateCompositeKey(objectType, indexAttributes[i])
+		if err != nil {
+			return indexKeys, logThenErrorf("error while creating composite key for index %s: %+v", objectType, err)
+		}
+		indexKeys = append(indexKeys, indexKey)
+	}
+	indexKeys = append(indexKeys, generateExpiryIndexKey(lockInfo.ExpiryTimeSecs, lockKind, contractId))
+	return indexKeys, nil
+}
+
+// function to generate the key of the expiry index entry of an asset lock; the zero-padded expiry time makes the
+// lexical order of the keys that of the expiry times
+func generateExpiryIndexKey(expiryTimeSecs uint64, lockKind string, contractId string) string {
+	return generateExpiryIndexKeyPrefix(expiryTimeSecs) + lockKind + assetKeyDelimiter + contractId
+}
+
+// function to generate the prefix shared by the keys of the expiry index entries of the locks expiring at a given time
+func generateExpiryIndexKeyPrefix(expiryTimeSecs uint64) string {
+	return expiryIndexObjectType + assetKeyDelimiter + fmt.Sprintf("%020d", expiryTimeSecs) + assetKeyDelimiter
+}
+
+// function to split the key of an expiry index entry into its zero-padded expiry time, lock kind and contractId
+func splitExpiryIndexKey(indexKey string) ([]string, error) {
+	indexAttributes := strings.SplitN(strings.TrimPrefix(indexKey, expiryIndexObjectType+assetKeyDelimiter), assetKeyDelimiter, 3)
+	if !strings.HasPrefix(indexKey, expiryIndexObjectType+assetKeyDelimiter) || len(indexAttributes) != 3 {
+		return nil, fmt.Errorf("malformed entry %s in index %s", indexKey, expiryIndexObjectType)
+	}
+	return indexAttributes, nil
+}
+
+// function to get the (exclusive) end key of a range query over the expiry index entries of the locks expiring at or
+// before the given time; the delimiter that follows the expiry time in the index keys sorts before '~'
+func getExpiryIndexRangeEndKey(lockExpiryTimeSecs uint64) string {
+	return expiryIndexObjectType + assetKeyDelimiter + fmt.Sprintf("%020d", lockExpiryTimeSecs) + "~"
+}
+
+// function to get the distinct asset types held in a lock: the types of the assets of an asset bundle, or the type of the asset otherwise
+func getAssetTypesOfLockedAsset(lockInfo LockedAssetInfo) []string {
+	if len(lockInfo.Assets) == 0 {
+		return []string{lockInfo.Type}
+	}
+	assetTypes := []string{}
+	isListed := map[string]bool{}
+	for _, asset := range lockInfo.Assets {
+		if !isListed[asset.Type] {
+			assetTypes = append(assetTypes, asset.Type)
+			isListed[asset.Type] = true
+		}
+	}
+	return assetTypes
+}
+
+// function to record an asset lock in the locker, recipient, asset-type and expiry indexes
+func addAssetLockIndexes(ctx contractapi.TransactionContextInterface, lockKind string, contractId string, lockInfo LockedAssetInfo) error {
+	indexKeys, err := generateAssetLockIndexKeys(ctx, lockKind, contractId, lockInfo)
+	if err != nil {
+		return err
+	}
+	for _, indexKey := range indexKeys {
+		// the index entries carry no value of their own; everything needed is in the composite key
+		err = ctx.GetStub().PutState(indexKey, []byte{0x00})
+		if err != nil {
+			return logThenErrorf("failed to write index entry for contractId %s: %+v", contractId, err)
+		}
+	}
+	return nil
+}
+
+// function to remove an asset lock from the locker, recipient, asset-type and expiry indexes
+func deleteAssetLockIndexes(ctx contractapi.TransactionContextInterface, lockKind string, contractId string, lockInfo LockedAssetInfo) error {
+	indexKeys, err := generateAssetLockIndexKeys(ctx, lockKind, contractId, lockInfo)
+	if err != nil {
+		return err
+	}
+	for _, indexKey := range indexKeys {
+		err = ctx.GetStub().DelState(indexKey)
+		if err != nil {
+			return logThenErrorf("failed to delete index entry for contractId %s: %+v", contractId, err)
+		}
+	}
+	return nil
+}
+
+// function to build the lock summary (used by the indexes and the listing queries) of a non-fungible asset lock
+func getNonFungibleLockedAssetInfo(ctx contractapi.TransactionContextInterface, contractId string, assetLockKey string, assetLockVal AssetLockValue) (LockedAssetInfo, error) {
+	lockedAssetInfo := LockedAssetInfo{ContractId: contractId, Locker: assetLockVal.Locker, Recipient: assetLockVal.Recipient,
+		ExpiryTimeSecs: assetLockVal.ExpiryTimeSecs, Recipients: assetLockVal.Recipients, RecipientThreshold: assetLockVal.RecipientThreshold}
+	_, assetLockKeyAttributes, err := ctx.GetStub().SplitCompositeKey(assetLockKey)
+	if err != nil {
+		return lockedAssetInfo, logThenErrorf("error while splitting composite key %s: %+v", assetLockKey, err)
+	}
+	if len(assetLockKeyAttributes) == 2 {
+		lockedAssetInfo.Type = assetLockKeyAttributes[0]
+		lockedAssetInfo.Id = assetLockKeyAttributes[1]
+	}
+	return lockedAssetInfo, nil
+}
+
+// function to build the lock summary (used by the indexes and the listing queries) of a fungible asset lock
+func getFungibleLockedAssetInfo(contractId string, assetLockVal FungibleAssetLockValue) LockedAssetInfo {
+	return LockedAssetInfo{ContractId: contractId, Type: assetLockVal.Type, NumUnits: assetLockVal.NumUnits,
+		Locker: assetLockVal.Locker, Recipient: assetLockVal.Recipient, ExpiryTimeSecs: assetLockVal.ExpiryTimeSecs}
+}
+
 // function to get the caller identity from the transaction context
 func getECertOfTxCreatorBase64(ctx contractapi.TransactionContextInterface) (string, error) {
 
@@ -160,7 +364,8 @@ func validateAndSetLockerOfFungibleAssetAgreement(ctx contractapi.TransactionCon
 
 /*
  * Function to validate the recipient in asset agreement.
- * If recipient is not set, it will be set to the caller.
+ * If the agreement lists several recipients, it ensures that the creator of the transaction is one of them.
+ * Otherwise, if recipient is not set, it will be set to the caller.
  * If the recipeint is set already, it ensures that the recipient is same as the creator of the transaction.
  */
 func validateAndSetRecipientOfAssetAgreement(ctx contractapi.TransactionContextInterface, assetAgreement *common.AssetExchangeAgreement) error {
@@ -168,7 +373,15 @@ func validateAndSetRecipientOfAssetAgreement(ctx contractapi.TransactionContextI
 	if err != nil {
 		return logThenErrorf(err.Error())
 	}
-	if len(assetAgreement.Recipient) == 0 {
+	if len(assetAgreement.Recipients) > 0 {
+		err = validateRecipientsOfAssetAgreement(assetAgreement)
+		if err != nil {
+			return logThenErrorf(err.Error())
+		}
+		if !isRecipientOfLock("", assetAgreement.Recipients, txCreatorECertBase64) {
+			return logThenErrorf("transaction creator %s is not one of the recipients in the asset agreement", txCreatorECertBase64)
+		}
+	} else if len(assetAgreement.Recipient) == 0 {
 		assetAgreement.Recipient = txCreatorECertBase64
 	} else if assetAgreement.Recipient != txCreatorECertBase64 {
 		return logThenErrorf("recipient %s in the asset agreement is not same as the transaction creator %s", assetAgreement.Recipient, txCreatorECertBase64)
@@ -177,7 +390,26 @@ func validateAndSetRecipientOfAssetAgreement(ctx contractapi.TransactionContextI
 	return nil
 }
 
-func getLockInfoAndExpiryTimeSecs(lockInfoBytesBase64 string) (interface{}, uint64, error) {
+// function to compute the absolute lock expiry time (in epoch seconds) from the time lock details;
+// a DURATION time spec is converted to an absolute expiry time relative to the transaction timestamp
+func getLockExpiryTimeSecs(ctx contractapi.TransactionContextInterface, timeSpec common.AssetLockHTLC_TimeSpec, expiryTimeSecs uint64) (uint64, error) {
+	if timeSpec == common.AssetLockHTLC_EPOCH {
+		return expiryTimeSecs, nil
+	} else if timeSpec == common.AssetLockHTLC_DURATION {
+		if expiryTimeSecs == 0 {
+			return 0, fmt.Errorf("lock duration must be greater than zero")
+		}
+		currentTimeSecs, err := getTxTimeSecs(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return currentTimeSecs + expiryTimeSecs, nil
+	}
+	return 0, fmt.Errorf("time spec %s is not supported", timeSpec.String())
+}
+
+// function to extract the lock details and the absolute lock expiry time (in epoch seconds) from the lock information
+func getLockInfoAndExpiryTimeSecs(ctx contractapi.TransactionContextInterface, lockInfoBytesBase64 string) (interface{}, uint64, error) {
 	var lockInfoVal interface{}
 	var expiryTimeSecs uint64
 
@@ -200,12 +432,32 @@ func getLockInfoAndExpiryTimeSecs(lockInfoBytesBase64 string) (interface{}, uint
 		}
 		//display the passed hash lock information
 		log.Infof("lockInfoHTLC: %+v", lockInfoHTLC)
-		lockInfoVal = HashLock{HashBase64: string(lockInfoHTLC.HashBase64)}
+		if _, ok := common.HashMechanism_name[int32(lockInfoHTLC.HashMechanism)]; !ok {
+			return lockInfoVal, 0, logThenErrorf("hash mechanism %s is not supported", lockInfoHTLC.HashMechanism.String())
+		}
+		lockInfoVal = HashLock{HashBase64: string(lockInfoHTLC.HashBase64), HashMechanism: lockInfoHTLC.HashMechanism}
 		// process time lock details here
-		if lockInfoHTLC.TimeSpec != common.AssetLockHTLC_EPOCH {
-			return lockInfoVal, 0, logThenErrorf("only EPOCH time is supported at present")
+		expiryTimeSecs, err = getLockExpiryTimeSecs(ctx, lockInfoHTLC.TimeSpec, lockInfoHTLC.ExpiryTimeSecs)
+		if err != nil {
+			return lockInfoVal, 0, logThenErrorf(err.Error())
+		}
+	} else if lockInfo.LockMechanism == common.LockMechanism_ESCROW {
+		lockInfoEscrow := &common.AssetLockEscrow{}
+		err := proto.Unmarshal(lockInfo.LockInfo, lockInfoEscrow)
+		if err != nil {
+			return lockInfoVal, 0, logThenErrorf("unmarshal error: %s", err)
+		}
+		//display the passed escrow lock information
+		log.Infof("lockInfoEscrow: %+v", lockInfoEscrow)
+		_, err = parseLockPartyCert(lockInfoEscrow.Arbiter, "escrow arbiter")
+		if err != nil {
+			return lockInfoVal, 0, logThenErrorf(err.Error())
+		}
+		lockInfoVal = EscrowLock{Arbiter: lockInfoEscrow.Arbiter}
+		expiryTimeSecs, err = getLockExpiryTimeSecs(ctx, lockInfoEscrow.TimeSpec, lockInfoEscrow.ExpiryTimeSecs)
+		if err != nil {
+			return lockInfoVal, 0, logThenErrorf(err.Error())
 		}
-		expiryTimeSecs = lockInfoHTLC.ExpiryTimeSecs
 	} else {
 		return lockInfoVal, 0, logThenErrorf("lock mechanism is not supported")
 	}
@@ -233,17 +485,40 @@ func (s *SmartContract) LockAsset(ctx contractapi.TransactionContextInterface, a
 		return "", logThenErrorf("error in locker validation: %+v", err)
 	}
 
-	lockInfo, expiryTimeSecs, err := getLockInfoAndExpiryTimeSecs(lockInfoBytesBase64)
+	return lockAssetOfAgreement(ctx, assetAgreement, lockInfoBytesBase64)
+}
+
+// function to record the lock of a non-fungible asset on the ledger, once the locker in the asset agreement is validated
+func lockAssetOfAgreement(ctx contractapi.TransactionContextInterface, assetAgreement *common.AssetExchangeAgreement, lockInfoBytesBase64 string) (string, error) {
+	lockInfo, expiryTimeSecs, err := getLockInfoAndExpiryTimeSecs(ctx, lockInfoBytesBase64)
 	if err != nil {
 		return "", logThenErrorf(err.Error())
 	}
+	err = enforceLockPolicy(ctx, assetAgreement.Type, 0, expiryTimeSecs)
+	if err != nil {
+		return "", logThenErrorf("lock policy violation: %+v", err)
+	}
 
-	assetLockKey, contractId, err := generateAssetLockKeyAndContractId(ctx, assetAgreement)
+	assetLockKey, defaultContractId, err := generateAssetLockKeyAndContractId(ctx, assetAgreement)
 	if err != nil {
 		return "", logThenErrorf(err.Error())
 	}
 
-	assetLockVal := AssetLockValue{Locker: assetAgreement.Locker, Recipient: assetAgreement.Recipient, LockInfo: lockInfo, ExpiryTimeSecs: expiryTimeSecs}
+	err = validateRecipientsOfAssetAgreement(assetAgreement)
+	if err != nil {
+		return "", logThenErrorf("error in recipient validation: %+v", err)
+	}
+
+	contractId, err := resolveContractIdOfNewLock(ctx, defaultContractId, assetAgreement.ContractId, assetAgreement.ContractIdNonce, assetAgreement.Locker)
+	if err != nil {
+		return "", logThenErrorf("error in contractId validation: %+v", err)
+	}
+
+	assetLockVal := AssetLockValue{Locker: assetAgreement.Locker, Recipient: assetAgreement.Recipient, LockInfo: lockInfo, ExpiryTimeSecs: expiryTimeSecs,
+		Recipients: assetAgreement.Recipients, RecipientThreshold: assetAgreement.RecipientThreshold}
+	if contractId != defaultContractId {
+		assetLockVal.ContractId = contractId
+	}
 
 	assetLockValBytes, err := ctx.GetStub().GetState(assetLockKey)
 	if err != nil {
@@ -253,8 +528,15 @@ func (s *SmartContract) LockAsset(ctx contractapi.TransactionContextInterface, a
 	if assetLockValBytes != nil {
 		return "", logThenErrorf("asset of type %s and ID %s is already locked", assetAgreement.Type, assetAgreement.Id)
 	}
+	contractIdMapValBytes, err := ctx.GetStub().GetState(generateContractIdMapKey(contractId))
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	if contractIdMapValBytes != nil {
+		return "", logThenErrorf("contractId %s is already in use", contractId)
+	}
 
-	assetLockValBytes, err = json.Marshal(assetLockVal)
+	assetLockValBytes, err = marshalAssetLockValue(assetLockVal)
 	if err != nil {
 		return "", logThenErrorf("marshal error: %+v", err)
 	}
@@ -273,6 +555,23 @@ func (s *SmartContract) LockAsset(ctx contractapi.TransactionContextInterface, a
 	if err != nil {
 		return "", logThenErrorf(err.Error())
 	}
+	err = recordTxIdOfContractId(ctx, nonFungibleLockKind, contractId)
+	if err != nil {
+		return "", err
+	}
+	err = deleteClaimedContract(ctx, contractId)
+	if err != nil {
+		return "", err
+	}
+
+	lockedAssetInfo := LockedAssetInfo{ContractId: contractId, Type: assetAgreement.Type, Id: assetAgreement.Id,
+		Locker: assetLockVal.Locker, Recipient: assetLockVal.Recipient, ExpiryTimeSecs: assetLockVal.ExpiryTimeSecs,
+		Recipients: assetLockVal.Recipients, RecipientThreshold: assetLockVal.RecipientThreshold}
+	err = addAssetLockIndexes(ctx, nonFungibleLockKind, contractId, lockedAssetInfo)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	emitAssetLockEvent(ctx, newAssetLockEvent(common.AssetLockEvent_LOCK, lockedAssetInfo, assetLockVal.LockInfo))
 	return contractId, nil
 }
 
@@ -311,19 +610,28 @@ func (s *SmartContract) UnlockAsset(ctx contractapi.TransactionContextInterface,
 		return logThenErrorf("no asset of type %s and ID %s is locked", assetAgreement.Type, assetAgreement.Id)
 	}
 
-	assetLockVal := AssetLockValue{}
-	err = json.Unmarshal(assetLockValBytes, &assetLockVal)
+	assetLockVal, err := unmarshalAssetLockValue(assetLockValBytes)
 	if err != nil {
 		return logThenErrorf("unmarshal error: %s", err)
 	}
+	// the lock may have a contractId chosen by the client, rather than the one derived from the asset-key
+	if assetLockVal.ContractId != "" {
+		contractId = assetLockVal.ContractId
+	}
 
-	if assetLockVal.Locker != assetAgreement.Locker || assetLockVal.Recipient != assetAgreement.Recipient {
+	if assetLockVal.Locker != assetAgreement.Locker || !isLockForRecipientsOfAssetAgreement(assetLockVal, assetAgreement) {
 		return logThenErrorf("cannot unlock asset of type %s and ID %s as it is locked by %s for %s", assetAgreement.Type, assetAgreement.Id, assetLockVal.Locker, assetLockVal.Recipient)
 	}
+	if assetLockVal.BundleContractId != "" {
+		return logThenErrorf("cannot unlock asset of type %s and ID %s as it is locked as part of the asset bundle %s", assetAgreement.Type, assetAgreement.Id, assetLockVal.BundleContractId)
+	}
 
 	// Check if expiry time is elapsed
-	currentTimeSecs := uint64(time.Now().Unix())
-	if currentTimeSecs < assetLockVal.ExpiryTimeSecs {
+	isExpired, err := isLockExpired(ctx, assetLockVal.ExpiryTimeSecs)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	if !isExpired {
 		return logThenErrorf("cannot unlock asset of type %s and ID %s as the expiry time is not yet elapsed", assetAgreement.Type, assetAgreement.Id)
 	}
 
@@ -336,6 +644,15 @@ func (s *SmartContract) UnlockAsset(ctx contractapi.TransactionContextInterface,
 		return logThenErrorf("failed to delete the contractId %s as part of asset unlock: %v", contractId, err)
 	}
 
+	lockedAssetInfo := LockedAssetInfo{ContractId: contractId, Type: assetAgreement.Type, Id: assetAgreement.Id,
+		Locker: assetLockVal.Locker, Recipient: assetLockVal.Recipient, ExpiryTimeSecs: assetLockVal.ExpiryTimeSecs,
+		Recipients: assetLockVal.Recipients, RecipientThreshold: assetLockVal.RecipientThreshold}
+	err = deleteAssetLockIndexes(ctx, nonFungibleLockKind, contractId, lockedAssetInfo)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	emitAssetLockEvent(ctx, newAssetLockEvent(common.AssetLockEvent_UNLOCK, lockedAssetInfo, assetLockVal.LockInfo))
+
 	return nil
 }
 
@@ -369,27 +686,32 @@ func (s *SmartContract) IsAssetLocked(ctx contractapi.TransactionContextInterfac
 		return false, logThenErrorf("no asset of type %s and ID %s is locked", assetAgreement.Type, assetAgreement.Id)
 	}
 
-	assetLockVal := AssetLockValue{}
-	err = json.Unmarshal(assetLockValBytes, &assetLockVal)
+	assetLockVal, err := unmarshalAssetLockValue(assetLockValBytes)
 	if err != nil {
 		return false, logThenErrorf("unmarshal error: %s", err)
 	}
 	log.Infof("assetLockVal: %+v", assetLockVal)
 
 	// Check if expiry time is elapsed
-	currentTimeSecs := uint64(time.Now().Unix())
-	if currentTimeSecs >= assetLockVal.ExpiryTimeSecs {
+	isExpired, err := isLockExpired(ctx, assetLockVal.ExpiryTimeSecs)
+	if err != nil {
+		return false, logThenErrorf(err.Error())
+	}
+	if isExpired {
 		return false, logThenErrorf("expiry time for asset of type %s and ID %s is already elapsed", assetAgreement.Type, assetAgreement.Id)
 	}
 
-	// '*' for recipient or locker in the query implies that the query seeks status for an arbitrary recipient or locker respectively
-	if (assetAgreement.Locker == "*" || assetLockVal.Locker == assetAgreement.Locker) && (assetAgreement.Recipient == "*" || assetLockVal.Recipient == assetAgreement.Recipient) {
+	// '*' for recipient or locker in the query implies that the query seeks status for an arbitrary recipient or locker respectively;
+	// a single recipient in the query matches a lock for several recipients that lists it
+	isLockForRecipient := assetAgreement.Recipient == "*" || isLockForRecipientsOfAssetAgreement(assetLockVal, assetAgreement) ||
+		(len(assetAgreement.Recipients) == 0 && isRecipientOfLock(assetLockVal.Recipient, assetLockVal.Recipients, assetAgreement.Recipient))
+	if (assetAgreement.Locker == "*" || assetLockVal.Locker == assetAgreement.Locker) && isLockForRecipient {
 		return true, nil
-	} else if assetAgreement.Locker == "*" && assetLockVal.Recipient != assetAgreement.Recipient {
+	} else if assetAgreement.Locker == "*" && !isLockForRecipient {
 		return false, logThenErrorf("asset of type %s and ID %s is not locked for %s", assetAgreement.Type, assetAgreement.Id, assetAgreement.Recipient)
 	} else if assetAgreement.Recipient == "*" && assetLockVal.Locker != assetAgreement.Locker {
 		return false, logThenErrorf("asset of type %s and ID %s is not locked by %s", assetAgreement.Type, assetAgreement.Id, assetAgreement.Locker)
-	} else if assetLockVal.Locker != assetAgreement.Locker || assetLockVal.Recipient != assetAgreement.Recipient {
+	} else if assetLockVal.Locker != assetAgreement.Locker || !isLockForRecipient {
 		return false, logThenErrorf("asset of type %s and ID %s is not locked by %s for %s", assetAgreement.Type, assetAgreement.Id, assetAgreement.Locker, assetAgreement.Recipient)
 	}
 
@@ -397,18 +719,21 @@ func (s *SmartContract) IsAssetLocked(ctx contractapi.TransactionContextInterfac
 }
 
 /*
- * Function to check if hashBase64 is the hash for the preimage preimageBase64.
+ * Function to check if hashBase64 is the hash for the preimage preimageBase64 under the hash mechanism hashMechanism.
  * Both the preimage and hash are passed in base64 form.
  */
-func checkIfCorrectPreimage(preimageBase64 string, hashBase64 string) (bool, error) {
+func checkIfCorrectPreimage(preimageBase64 string, hashBase64 string, hashMechanism common.HashMechanism) (bool, error) {
 	funName := "checkIfCorrectPreimage"
 	preimage, err := base64.StdEncoding.DecodeString(preimageBase64)
 	if err != nil {
 		return false, logThenErrorf("base64 decode preimage error: %s", err)
 	}
 
-	shaHashBase64 := generateSHA256HashInBase64Form(string(preimage))
-	if shaHashBase64 == hashBase64 {
+	hashOfPreimageBase64, err := generateHashInBase64Form(string(preimage), hashMechanism)
+	if err != nil {
+		return false, logThenErrorf(err.Error())
+	}
+	if hashOfPreimageBase64 == hashBase64 {
 		log.Infof("%s: preimage %s is passed correctly", funName, preimage)
 	} else {
 		log.Infof("%s: preimage %s is not passed correctly", funName, preimage)
@@ -425,19 +750,64 @@ func validateHashPreimage(claimInfo *common.AssetClaim, lockInfo interface{}) (b
 	}
 	//display the claim information
 	log.Infof("claimInfoHTLC: %+v\n", claimInfoHTLC)
-	lockInfoVal := HashLock{}
-	lockInfoBytes, err := json.Marshal(lockInfo)
+	lockInfoVal, ok := normalizeLockInfo(lockInfo).(HashLock)
+	log.Infof("HashLock: %+v\n", lockInfoVal)
+	if !ok || lockInfoVal.HashBase64 == "" {
+		return false, logThenErrorf("asset is not locked using the HTLC lock mechanism")
+	}
+
+	// match the hash passed during claim with the hash stored during asset locking
+	return checkIfCorrectPreimage(string(claimInfoHTLC.HashPreimageBase64), lockInfoVal.HashBase64, lockInfoVal.HashMechanism)
+}
+
+// function to parse the base64 encoded PEM certificate of a party to a lock (e.g., the arbiter of an escrow lock)
+func parseLockPartyCert(party string, partyRole string) (*x509.Certificate, error) {
+	if len(party) == 0 {
+		return nil, fmt.Errorf("empty %s", partyRole)
+	}
+	partyCertPEM, err := base64.StdEncoding.DecodeString(party)
 	if err != nil {
-		return false, logThenErrorf("marshal lockInfo error: %s", err)
+		return nil, fmt.Errorf("error in base64 decode of %s certificate: %+v", partyRole, err)
 	}
-	err = json.Unmarshal(lockInfoBytes, &lockInfoVal)
+	partyCert, err := parseCert(string(partyCertPEM))
 	if err != nil {
-		return false, logThenErrorf("unmarshal lockInfoBytes error: %s", err)
+		return nil, fmt.Errorf("invalid %s certificate: %+v", partyRole, err)
 	}
-	log.Infof("HashLock: %+v\n", lockInfoVal)
+	return partyCert, nil
+}
 
-	// match the hash passed during claim with the hash stored during asset locking
-	return checkIfCorrectPreimage(string(claimInfoHTLC.HashPreimageBase64), lockInfoVal.HashBase64)
+// function to construct the message that the arbiter of an escrow lock signs to release the asset to the recipient
+func getEscrowReleaseMessage(contractId string, expiryTimeSecs uint64) string {
+	return fmt.Sprintf("EscrowRelease:%s:%d", contractId, expiryTimeSecs)
+}
+
+/*
+ * Function to check if the claim carries the decision of the arbiter of the escrow lock to release the asset,
+ * i.e., a signature by the arbiter over the release message for the contract.
+ */
+func validateEscrowRelease(contractId string, claimInfo *common.AssetClaim, lockInfo interface{}, expiryTimeSecs uint64) (bool, error) {
+	funName := "validateEscrowRelease"
+	claimInfoEscrow := &common.AssetClaimEscrow{}
+	err := proto.Unmarshal(claimInfo.ClaimInfo, claimInfoEscrow)
+	if err != nil {
+		return false, logThenErrorf("unmarshal claimInfo.ClaimInfo error: %s", err)
+	}
+	lockInfoVal, ok := normalizeLockInfo(lockInfo).(EscrowLock)
+	if !ok || lockInfoVal.Arbiter == "" {
+		return false, logThenErrorf("asset is not locked using the escrow lock mechanism")
+	}
+	arbiterCert, err := parseLockPartyCert(lockInfoVal.Arbiter, "escrow arbiter")
+	if err != nil {
+		return false, logThenErrorf(err.Error())
+	}
+
+	err = validateSignature(getEscrowReleaseMessage(contractId, expiryTimeSecs), arbiterCert, string(claimInfoEscrow.ArbiterSignature))
+	if err != nil {
+		log.Infof("%s: release decision for contractId %s is not signed by the arbiter: %+v", funName, contractId, err)
+		return false, nil
+	}
+	log.Infof("%s: release decision for contractId %s is signed by the arbiter", funName, contractId)
+	return true, nil
 }
 
 // fetches common.AssetClaim from the input parameter and checks if the lock mechanism is valid or not
@@ -454,13 +824,51 @@ func getClaimInfo(claimInfoBytesBase64 string) (*common.AssetClaim, error) {
 		return claimInfo, logThenErrorf("unmarshal error: %s", err)
 	}
 	// check if a valid lock mechanism is provided
-	if claimInfo.LockMechanism != common.LockMechanism_HTLC {
+	if claimInfo.LockMechanism != common.LockMechanism_HTLC && claimInfo.LockMechanism != common.LockMechanism_ESCROW {
 		return claimInfo, logThenErrorf("lock mechanism is not supported")
 	}
 
 	return claimInfo, nil
 }
 
+// function to record the hash preimage revealed by a successful HTLC claim, along with the claimer and the claim time,
+// so that the counterparty can later fetch the preimage from the ledger using the contractId
+func recordClaimedContract(ctx contractapi.TransactionContextInterface, contractId string, claimInfo *common.AssetClaim, claimer string) error {
+	if claimInfo.LockMechanism != common.LockMechanism_HTLC {
+		return nil
+	}
+	claimInfoHTLC := &common.AssetClaimHTLC{}
+	err := proto.Unmarshal(claimInfo.ClaimInfo, claimInfoHTLC)
+	if err != nil {
+		return logThenErrorf("unmarshal claimInfo.ClaimInfo error: %s", err)
+	}
+	claimTimeSecs, err := getTxTimeSecs(ctx)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	claimedContractVal := ClaimedContractValue{HashPreimageBase64: string(claimInfoHTLC.HashPreimageBase64), Claimer: claimer, ClaimTimeSecs: claimTimeSecs}
+	claimedContractValBytes, err := json.Marshal(claimedContractVal)
+	if err != nil {
+		return logThenErrorf("marshal error: %s", err)
+	}
+	err = ctx.GetStub().PutState(claimedContractIdPrefix+contractId, claimedContractValBytes)
+	if err != nil {
+		return logThenErrorf("failed to record the claim of contractId %s: %+v", contractId, err)
+	}
+	return nil
+}
+
+// function to delete the claim recorded for an earlier lock associated with contractId, when a new lock reuses it (as
+// the default contractId of a non-fungible asset does every time the asset is locked), so that the preimage, claimer
+// and claim time of the earlier swap are not reported for the new one
+func deleteClaimedContract(ctx contractapi.TransactionContextInterface, contractId string) error {
+	err := ctx.GetStub().DelState(claimedContractIdPrefix + contractId)
+	if err != nil {
+		return logThenErrorf("failed to delete the claim of contractId %s: %+v", contractId, err)
+	}
+	return nil
+}
+
 // ClaimAsset cc is used to record claim of an asset on the ledger
 func (s *SmartContract) ClaimAsset(ctx contractapi.TransactionContextInterface, assetAgreementBytesBase64 string, claimInfoBytesBase64 string) error {
 
@@ -501,19 +909,36 @@ func (s *SmartContract) ClaimAsset(ctx contractapi.TransactionContextInterface,
 		return logThenErrorf("no asset of type %s and ID %s is locked", assetAgreement.Type, assetAgreement.Id)
 	}
 
-	assetLockVal := AssetLockValue{}
-	err = json.Unmarshal(assetLockValBytes, &assetLockVal)
+	assetLockVal, err := unmarshalAssetLockValue(assetLockValBytes)
 	if err != nil {
 		return logThenErrorf("unmarshal error: %s", err)
 	}
+	// the lock may have a contractId chosen by the client, rather than the one derived from the asset-key
+	if assetLockVal.ContractId != "" {
+		contractId = assetLockVal.ContractId
+	}
 
-	if assetLockVal.Locker != assetAgreement.Locker || assetLockVal.Recipient != assetAgreement.Recipient {
+	if assetLockVal.Locker != assetAgreement.Locker || !isLockForRecipientsOfAssetAgreement(assetLockVal, assetAgreement) {
 		return logThenErrorf("cannot claim asset of type %s and ID %s as it is locked by %s for %s", assetAgreement.Type, assetAgreement.Id, assetLockVal.Locker, assetLockVal.Recipient)
 	}
+	txCreatorECertBase64, err := getECertOfTxCreatorBase64(ctx)
+	if err != nil {
+		return logThenErrorf("unable to get the transaction creator information: %+v", err)
+	}
+	err = validateClaimantOfAssetLock(contractId, assetLockVal, claimInfo, txCreatorECertBase64)
+	if err != nil {
+		return logThenErrorf("cannot claim asset of type %s and ID %s: %+v", assetAgreement.Type, assetAgreement.Id, err)
+	}
+	if assetLockVal.BundleContractId != "" {
+		return logThenErrorf("cannot claim asset of type %s and ID %s as it is locked as part of the asset bundle %s", assetAgreement.Type, assetAgreement.Id, assetLockVal.BundleContractId)
+	}
 
 	// Check if expiry time is elapsed
-	currentTimeSecs := uint64(time.Now().Unix())
-	if currentTimeSecs >= assetLockVal.ExpiryTimeSecs {
+	isExpired, err := isLockExpired(ctx, assetLockVal.ExpiryTimeSecs)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	if isExpired {
 		return logThenErrorf("cannot claim asset of type %s and ID %s as the expiry time is already elapsed", assetAgreement.Type, assetAgreement.Id)
 	}
 
@@ -525,6 +950,14 @@ func (s *SmartContract) ClaimAsset(ctx contractapi.TransactionContextInterface,
 		if !isCorrectPreimage {
 			return logThenErrorf("cannot claim asset of type %s and ID %s as the hash preimage is not matching", assetAgreement.Type, assetAgreement.Id)
 		}
+	} else if claimInfo.LockMechanism == common.LockMechanism_ESCROW {
+		isReleased, err := validateEscrowRelease(contractId, claimInfo, assetLockVal.LockInfo, assetLockVal.ExpiryTimeSecs)
+		if err != nil {
+			return logThenErrorf("claim asset of type %s and ID %s error: %v", assetAgreement.Type, assetAgreement.Id, err)
+		}
+		if !isReleased {
+			return logThenErrorf("cannot claim asset of type %s and ID %s as it is not released by the escrow arbiter", assetAgreement.Type, assetAgreement.Id)
+		}
 	}
 
 	err = ctx.GetStub().DelState(assetLockKey)
@@ -536,6 +969,20 @@ func (s *SmartContract) ClaimAsset(ctx contractapi.TransactionContextInterface,
 		return logThenErrorf("failed to delete the contractId %s as part of asset claim: %v", contractId, err)
 	}
 
+	lockedAssetInfo := LockedAssetInfo{ContractId: contractId, Type: assetAgreement.Type, Id: assetAgreement.Id,
+		Locker: assetLockVal.Locker, Recipient: assetLockVal.Recipient, ExpiryTimeSecs: assetLockVal.ExpiryTimeSecs,
+		Recipients: assetLockVal.Recipients, RecipientThreshold: assetLockVal.RecipientThreshold}
+	err = deleteAssetLockIndexes(ctx, nonFungibleLockKind, contractId, lockedAssetInfo)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+
+	err = recordClaimedContract(ctx, contractId, claimInfo, txCreatorECertBase64)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	emitAssetLockEvent(ctx, newAssetLockEvent(common.AssetLockEvent_CLAIM, lockedAssetInfo, assetLockVal.LockInfo))
+
 	return nil
 }
 
@@ -567,7 +1014,7 @@ func fetchAssetLockedUsingContractId(ctx contractapi.TransactionContextInterface
 		return assetLockKey, assetLockVal, logThenErrorf("contractId %s is not associated with any currently locked asset", contractId)
 	}
 
-	err = json.Unmarshal(assetLockValBytes, &assetLockVal)
+	assetLockVal, err = unmarshalAssetLockValue(assetLockValBytes)
 	if err != nil {
 		return assetLockKey, assetLockVal, logThenErrorf("assetLockVal unmarshal error: %s", err)
 	}
@@ -593,8 +1040,11 @@ func (s *SmartContract) UnlockAssetUsingContractId(ctx contractapi.TransactionCo
 	}
 
 	// Check if expiry time is elapsed
-	currentTimeSecs := uint64(time.Now().Unix())
-	if currentTimeSecs < assetLockVal.ExpiryTimeSecs {
+	isExpired, err := isLockExpired(ctx, assetLockVal.ExpiryTimeSecs)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	if !isExpired {
 		return logThenErrorf("cannot unlock asset associated with the contractId %s as the expiry time is not yet elapsed", contractId)
 	}
 
@@ -608,6 +1058,16 @@ func (s *SmartContract) UnlockAssetUsingContractId(ctx contractapi.TransactionCo
 		return logThenErrorf("failed to delete the contractId %s as part of asset unlock: %v", contractId, err)
 	}
 
+	lockedAssetInfo, err := getNonFungibleLockedAssetInfo(ctx, contractId, assetLockKey, assetLockVal)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	err = deleteAssetLockIndexes(ctx, nonFungibleLockKind, contractId, lockedAssetInfo)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	emitAssetLockEvent(ctx, newAssetLockEvent(common.AssetLockEvent_UNLOCK, lockedAssetInfo, assetLockVal.LockInfo))
+
 	return nil
 }
 
@@ -624,18 +1084,22 @@ func (s *SmartContract) ClaimAssetUsingContractId(ctx contractapi.TransactionCon
 		return logThenErrorf("unable to get the transaction creator information: %+v", err)
 	}
 
-	if assetLockVal.Recipient != string(txCreatorECertBase64) {
-		return logThenErrorf("asset is not locked for %s to claim", string(txCreatorECertBase64))
+	claimInfo, err := getClaimInfo(claimInfoBytesBase64)
+	if err != nil {
+		return logThenErrorf(err.Error())
 	}
 
-	claimInfo, err := getClaimInfo(claimInfoBytesBase64)
+	err = validateClaimantOfAssetLock(contractId, assetLockVal, claimInfo, txCreatorECertBase64)
 	if err != nil {
 		return logThenErrorf(err.Error())
 	}
 
 	// Check if expiry time is elapsed
-	currentTimeSecs := uint64(time.Now().Unix())
-	if currentTimeSecs >= assetLockVal.ExpiryTimeSecs {
+	isExpired, err := isLockExpired(ctx, assetLockVal.ExpiryTimeSecs)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	if isExpired {
 		return logThenErrorf("cannot claim asset associated with contractId %s as the expiry time is already elapsed", contractId)
 	}
 
@@ -647,6 +1111,14 @@ func (s *SmartContract) ClaimAssetUsingContractId(ctx contractapi.TransactionCon
 		if !isCorrectPreimage {
 			return logThenErrorf("cannot claim asset associated with contractId %s as the hash preimage is not matching", contractId)
 		}
+	} else if claimInfo.LockMechanism == common.LockMechanism_ESCROW {
+		isReleased, err := validateEscrowRelease(contractId, claimInfo, assetLockVal.LockInfo, assetLockVal.ExpiryTimeSecs)
+		if err != nil {
+			return logThenErrorf("claim asset associated with contractId %s failed with error: %v", contractId, err)
+		}
+		if !isReleased {
+			return logThenErrorf("cannot claim asset associated with contractId %s as it is not released by the escrow arbiter", contractId)
+		}
 	}
 
 	err = ctx.GetStub().DelState(assetLockKey)
@@ -659,6 +1131,21 @@ func (s *SmartContract) ClaimAssetUsingContractId(ctx contractapi.TransactionCon
 		return logThenErrorf("failed to delete the contractId %s as part of asset claim: %+v", contractId, err)
 	}
 
+	lockedAssetInfo, err := getNonFungibleLockedAssetInfo(ctx, contractId, assetLockKey, assetLockVal)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	err = deleteAssetLockIndexes(ctx, nonFungibleLockKind, contractId, lockedAssetInfo)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+
+	err = recordClaimedContract(ctx, contractId, claimInfo, txCreatorECertBase64)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	emitAssetLockEvent(ctx, newAssetLockEvent(common.AssetLockEvent_CLAIM, lockedAssetInfo, assetLockVal.LockInfo))
+
 	return nil
 }
 
@@ -671,8 +1158,11 @@ func (s *SmartContract) IsAssetLockedQueryUsingContractId(ctx contractapi.Transa
 	}
 
 	// Check if expiry time is elapsed
-	currentTimeSecs := uint64(time.Now().Unix())
-	if currentTimeSecs >= assetLockVal.ExpiryTimeSecs {
+	isExpired, err := isLockExpired(ctx, assetLockVal.ExpiryTimeSecs)
+	if err != nil {
+		return false, logThenErrorf(err.Error())
+	}
+	if isExpired {
 		return false, logThenErrorf("expiry time for asset associated with contractId %s is already elapsed", contractId)
 	}
 
@@ -701,18 +1191,31 @@ func (s *SmartContract) LockFungibleAsset(ctx contractapi.TransactionContextInte
 		return "", logThenErrorf("error in locker validation: %+v", err)
 	}
 
-	lockInfo, expiryTimeSecs, err := getLockInfoAndExpiryTimeSecs(lockInfoBytesBase64)
+	return lockFungibleAssetOfAgreement(ctx, assetAgreement, lockInfoBytesBase64)
+}
+
+// function to record the lock of a fungible asset on the ledger, once the locker in the asset agreement is validated
+func lockFungibleAssetOfAgreement(ctx contractapi.TransactionContextInterface, assetAgreement *common.FungibleAssetExchangeAgreement, lockInfoBytesBase64 string) (string, error) {
+	lockInfo, expiryTimeSecs, err := getLockInfoAndExpiryTimeSecs(ctx, lockInfoBytesBase64)
 	if err != nil {
 		return "", logThenErrorf(err.Error())
 	}
+	err = enforceLockPolicy(ctx, assetAgreement.Type, assetAgreement.NumUnits, expiryTimeSecs)
+	if err != nil {
+		return "", logThenErrorf("lock policy violation: %+v", err)
+	}
 
-	// generate the contractId for the fungible asset lock agreement
-	contractId := generateFungibleAssetLockContractId(ctx, assetAgreement)
+	// generate the contractId for the fungible asset lock agreement, unless the client chose it
+	contractId, err := resolveContractIdOfNewLock(ctx, generateFungibleAssetLockContractId(ctx, assetAgreement), assetAgreement.ContractId,
+		assetAgreement.ContractIdNonce, assetAgreement.Locker)
+	if err != nil {
+		return "", logThenErrorf("error in contractId validation: %+v", err)
+	}
 
 	assetLockVal := FungibleAssetLockValue{Type: assetAgreement.Type, NumUnits: assetAgreement.NumUnits, Locker: assetAgreement.Locker,
 		Recipient: assetAgreement.Recipient, LockInfo: lockInfo, ExpiryTimeSecs: expiryTimeSecs}
 
-	assetLockValBytes, err := ctx.GetStub().GetState(contractId)
+	assetLockValBytes, err := ctx.GetStub().GetState(generateContractIdMapKey(contractId))
 	if err != nil {
 		return "", logThenErrorf("failed to retrieve from the world state: %+v", err)
 	}
@@ -721,7 +1224,7 @@ func (s *SmartContract) LockFungibleAsset(ctx contractapi.TransactionContextInte
 		return "", logThenErrorf("contractId %s already exists for the requested fungible asset agreement", contractId)
 	}
 
-	assetLockValBytes, err = json.Marshal(assetLockVal)
+	assetLockValBytes, err = marshalFungibleAssetLockValue(assetLockVal)
 	if err != nil {
 		return "", logThenErrorf("marshal error: %s", err)
 	}
@@ -730,6 +1233,16 @@ func (s *SmartContract) LockFungibleAsset(ctx contractapi.TransactionContextInte
 	if err != nil {
 		return "", logThenErrorf("failed to write to the world state: %+v", err)
 	}
+	err = recordTxIdOfContractId(ctx, fungibleLockKind, contractId)
+	if err != nil {
+		return "", err
+	}
+
+	err = addAssetLockIndexes(ctx, fungibleLockKind, contractId, getFungibleLockedAssetInfo(contractId, assetLockVal))
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	emitAssetLockEvent(ctx, newAssetLockEvent(common.AssetLockEvent_LOCK, getFungibleLockedAssetInfo(contractId, assetLockVal), assetLockVal.LockInfo))
 
 	return contractId, nil
 }
@@ -747,7 +1260,7 @@ func fetchFungibleAssetLocked(ctx contractapi.TransactionContextInterface, contr
 		return assetLockVal, logThenErrorf("contractId %s is not associated with any currently locked fungible asset", contractId)
 	}
 
-	err = json.Unmarshal(assetLockValBytes, &assetLockVal)
+	assetLockVal, err = unmarshalFungibleAssetLockValue(assetLockValBytes)
 	if err != nil {
 		return assetLockVal, logThenErrorf("unmarshal error: %s", err)
 	}
@@ -765,59 +1278,125 @@ func (s *SmartContract) IsFungibleAssetLocked(ctx contractapi.TransactionContext
 	}
 
 	// Check if expiry time is elapsed
-	currentTimeSecs := uint64(time.Now().Unix())
-	if currentTimeSecs >= assetLockVal.ExpiryTimeSecs {
+	isExpired, err := isLockExpired(ctx, assetLockVal.ExpiryTimeSecs)
+	if err != nil {
+		return false, logThenErrorf(err.Error())
+	}
+	if isExpired {
 		return false, logThenErrorf("expiry time for fungible asset associated with contractId %s is already elapsed", contractId)
 	}
 
 	return true, nil
 }
 
-// ClaimFungibleAsset cc is used to record claim of a fungible asset on the ledger
-func (s *SmartContract) ClaimFungibleAsset(ctx contractapi.TransactionContextInterface, contractId string, claimInfoBytesBase64 string) error {
+// function to fetch the number of units to be claimed from a fungible asset lock holding lockedUnits units;
+// an HTLC claim may ask for a subset of the locked units, whereas any other claim takes all of them
+func getClaimNumUnits(claimInfo *common.AssetClaim, lockedUnits uint64) (uint64, error) {
+	if claimInfo.LockMechanism != common.LockMechanism_HTLC {
+		return lockedUnits, nil
+	}
+	claimInfoHTLC := &common.AssetClaimHTLC{}
+	err := proto.Unmarshal(claimInfo.ClaimInfo, claimInfoHTLC)
+	if err != nil {
+		return 0, fmt.Errorf("unmarshal claimInfo.ClaimInfo error: %s", err)
+	}
+	if claimInfoHTLC.NumUnits == 0 {
+		return lockedUnits, nil
+	}
+	if claimInfoHTLC.NumUnits > lockedUnits {
+		return 0, fmt.Errorf("cannot claim %d units as only %d units are locked", claimInfoHTLC.NumUnits, lockedUnits)
+	}
+	return claimInfoHTLC.NumUnits, nil
+}
+
+// ClaimFungibleAsset cc is used to record claim of a fungible asset on the ledger; the recipient may claim a subset of
+// the locked units (using an HTLC), with the remaining units staying locked. It returns the number of units claimed.
+func (s *SmartContract) ClaimFungibleAsset(ctx contractapi.TransactionContextInterface, contractId string, claimInfoBytesBase64 string) (uint64, error) {
 
 	assetLockVal, err := fetchFungibleAssetLocked(ctx, contractId)
 	if err != nil {
-		return logThenErrorf(err.Error())
+		return 0, logThenErrorf(err.Error())
 	}
 
 	txCreatorECertBase64, err := getECertOfTxCreatorBase64(ctx)
 	if err != nil {
-		return logThenErrorf("unable to get the transaction creator information: %+v", err)
+		return 0, logThenErrorf("unable to get the transaction creator information: %+v", err)
 	}
 
 	// transaction creator needs to be the recipient of the locked fungible asset
 	if assetLockVal.Recipient != txCreatorECertBase64 {
-		return logThenErrorf("asset is not locked for %s to claim", txCreatorECertBase64)
+		return 0, logThenErrorf("asset is not locked for %s to claim", txCreatorECertBase64)
 	}
 
 	claimInfo, err := getClaimInfo(claimInfoBytesBase64)
 	if err != nil {
-		return logThenErrorf(err.Error())
+		return 0, logThenErrorf(err.Error())
 	}
 
 	// Check if expiry time is elapsed
-	currentTimeSecs := uint64(time.Now().Unix())
-	if currentTimeSecs >= assetLockVal.ExpiryTimeSecs {
-		return logThenErrorf("cannot claim fungible asset associated with contractId %s as the expiry time is already elapsed", contractId)
+	isExpired, err := isLockExpired(ctx, assetLockVal.ExpiryTimeSecs)
+	if err != nil {
+		return 0, logThenErrorf(err.Error())
+	}
+	if isExpired {
+		return 0, logThenErrorf("cannot claim fungible asset associated with contractId %s as the expiry time is already elapsed", contractId)
 	}
 
 	if claimInfo.LockMechanism == common.LockMechanism_HTLC {
 		isCorrectPreimage, err := validateHashPreimage(claimInfo, assetLockVal.LockInfo)
 		if err != nil {
-			return logThenErrorf("claim fungible asset associated with contractId %s failed with error: %v", contractId, err)
+			return 0, logThenErrorf("claim fungible asset associated with contractId %s failed with error: %v", contractId, err)
 		}
 		if !isCorrectPreimage {
-			return logThenErrorf("cannot claim fungible asset associated with contractId %s as the hash preimage is not matching", contractId)
+			return 0, logThenErrorf("cannot claim fungible asset associated with contractId %s as the hash preimage is not matching", contractId)
+		}
+	} else if claimInfo.LockMechanism == common.LockMechanism_ESCROW {
+		isReleased, err := validateEscrowRelease(contractId, claimInfo, assetLockVal.LockInfo, assetLockVal.ExpiryTimeSecs)
+		if err != nil {
+			return 0, logThenErrorf("claim fungible asset associated with contractId %s failed with error: %v", contractId, err)
+		}
+		if !isReleased {
+			return 0, logThenErrorf("cannot claim fungible asset associated with contractId %s as it is not released by the escrow arbiter", contractId)
 		}
 	}
 
-	err = ctx.GetStub().DelState(generateContractIdMapKey(contractId))
+	claimNumUnits, err := getClaimNumUnits(claimInfo, assetLockVal.NumUnits)
 	if err != nil {
-		return logThenErrorf("failed to delete the contractId %s as part of fungible asset claim: %+v", contractId, err)
+		return 0, logThenErrorf("cannot claim fungible asset associated with contractId %s: %+v", contractId, err)
 	}
 
-	return nil
+	if claimNumUnits < assetLockVal.NumUnits {
+		// the remaining units stay locked under the same contractId (the lock indexes do not depend on the units)
+		assetLockVal.NumUnits -= claimNumUnits
+		assetLockValBytes, err := marshalFungibleAssetLockValue(assetLockVal)
+		if err != nil {
+			return 0, logThenErrorf("marshal error: %s", err)
+		}
+		err = ctx.GetStub().PutState(generateContractIdMapKey(contractId), assetLockValBytes)
+		if err != nil {
+			return 0, logThenErrorf("failed to update the remaining units of contractId %s as part of fungible asset claim: %+v", contractId, err)
+		}
+	} else {
+		err = ctx.GetStub().DelState(generateContractIdMapKey(contractId))
+		if err != nil {
+			return 0, logThenErrorf("failed to delete the contractId %s as part of fungible asset claim: %+v", contractId, err)
+		}
+
+		err = deleteAssetLockIndexes(ctx, fungibleLockKind, contractId, getFungibleLockedAssetInfo(contractId, assetLockVal))
+		if err != nil {
+			return 0, logThenErrorf(err.Error())
+		}
+	}
+
+	err = recordClaimedContract(ctx, contractId, claimInfo, txCreatorECertBase64)
+	if err != nil {
+		return 0, logThenErrorf(err.Error())
+	}
+	claimedAssetInfo := getFungibleLockedAssetInfo(contractId, assetLockVal)
+	claimedAssetInfo.NumUnits = claimNumUnits
+	emitAssetLockEvent(ctx, newAssetLockEvent(common.AssetLockEvent_CLAIM, claimedAssetInfo, assetLockVal.LockInfo))
+
+	return claimNumUnits, nil
 }
 
 // UnlockFungibleAsset cc is used to record unlocking of a fungible asset on the ledger
@@ -839,8 +1418,11 @@ func (s *SmartContract) UnlockFungibleAsset(ctx contractapi.TransactionContextIn
 	}
 
 	// Check if expiry time is elapsed
-	currentTimeSecs := uint64(time.Now().Unix())
-	if currentTimeSecs < assetLockVal.ExpiryTimeSecs {
+	isExpired, err := isLockExpired(ctx, assetLockVal.ExpiryTimeSecs)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	if !isExpired {
 		return logThenErrorf("cannot unlock fungible asset associated with the contractId %s as the expiry time is not yet elapsed", contractId)
 	}
 
@@ -849,5 +1431,620 @@ func (s *SmartContract) UnlockFungibleAsset(ctx contractapi.TransactionContextIn
 		return logThenErrorf("failed to delete the contractId %s as part of fungible asset unlock: %v", contractId, err)
 	}
 
+	err = deleteAssetLockIndexes(ctx, fungibleLockKind, contractId, getFungibleLockedAssetInfo(contractId, assetLockVal))
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	emitAssetLockEvent(ctx, newAssetLockEvent(common.AssetLockEvent_UNLOCK, getFungibleLockedAssetInfo(contractId, assetLockVal), assetLockVal.LockInfo))
+
+	return nil
+}
+
+// function to resolve a locker or recipient passed to a lock query into an ECert in base64 form.
+// An empty value, or the serialized identity of the transaction creator (which is what the asset
+// management interface passes on behalf of the caller), resolves to the ECert of the transaction creator.
+func resolveLockPartyOfQuery(ctx contractapi.TransactionContextInterface, party string) (string, error) {
+	if party == "*" {
+		return party, nil
+	}
+	txCreatorBytes, err := ctx.GetStub().GetCreator()
+	if err != nil {
+		return "", logThenErrorf("unable to get the transaction creator information: %+v", err)
+	}
+	if len(party) == 0 || party == string(txCreatorBytes) {
+		return getECertOfTxCreatorBase64(ctx)
+	}
+	return party, nil
+}
+
+// function to read the attributes of all the index entries returned by an index query
+func readAssetLockIndexEntries(ctx contractapi.TransactionContextInterface, objectType string, resultsIterator shim.StateQueryIteratorInterface) ([][]string, error) {
+	indexEntries := [][]string{}
+	for resultsIterator.HasNext() {
+		indexEntry, err := resultsIterator.Next()
+		if err != nil {
+			return indexEntries, logThenErrorf("failed to iterate over index %s: %+v", objectType, err)
+		}
+		var indexAttributes []string
+		if objectType == expiryIndexObjectType {
+			indexAttributes, err = splitExpiryIndexKey(indexEntry.Key)
+		} else {
+			_, indexAttributes, err = ctx.GetStub().SplitCompositeKey(indexEntry.Key)
+		}
+		if err != nil {
+			return indexEntries, logThenErrorf("error while splitting index key %s: %+v", indexEntry.Key, err)
+		}
+		if len(indexAttributes) != 3 {
+			return indexEntries, logThenErrorf("malformed entry %s in index %s", indexEntry.Key, objectType)
+		}
+		indexEntries = append(indexEntries, indexAttributes)
+	}
+	return indexEntries, nil
+}
+
+// function to fetch the attributes of all the index entries matching a partial composite key
+func queryAssetLockIndex(ctx contractapi.TransactionContextInterface, objectType string, partialAttributes []string) ([][]string, error) {
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(objectType, partialAttributes)
+	if err != nil {
+		return [][]string{}, logThenErrorf("failed to query index %s: %+v", objectType, err)
+	}
+	defer resultsIterator.Close()
+
+	return readAssetLockIndexEntries(ctx, objectType, resultsIterator)
+}
+
+// function to fetch the attributes of one page of the index entries matching a partial composite key, along with the
+// bookmark of the next page (empty if there are no more entries) and the number of entries fetched.
+// Fabric only allows paginated queries in read-only transactions.
+func queryAssetLockIndexWithPagination(ctx contractapi.TransactionContextInterface, objectType string, partialAttributes []string,
+	pageSize int32, bookmark string) ([][]string, string, int32, error) {
+	if pageSize <= 0 {
+		return [][]string{}, "", 0, logThenErrorf("invalid page size %d", pageSize)
+	}
+	resultsIterator, responseMetadata, err := ctx.GetStub().GetStateByPartialCompositeKeyWithPagination(objectType, partialAttributes, pageSize, bookmark)
+	if err != nil {
+		return [][]string{}, "", 0, logThenErrorf("failed to query index %s: %+v", objectType, err)
+	}
+	if resultsIterator == nil || responseMetadata == nil {
+		return [][]string{}, "", 0, logThenErrorf("paginated query of index %s is not supported", objectType)
+	}
+	defer resultsIterator.Close()
+
+	indexEntries, err := readAssetLockIndexEntries(ctx, objectType, resultsIterator)
+	if err != nil {
+		return indexEntries, "", 0, err
+	}
+	nextBookmark := responseMetadata.Bookmark
+	if responseMetadata.FetchedRecordsCount < pageSize {
+		// a short page is the last one
+		nextBookmark = ""
+	}
+	return indexEntries, nextBookmark, responseMetadata.FetchedRecordsCount, nil
+}
+
+// function to fetch the attributes of all the expiry index entries of the locks expiring at or before the given time,
+// reading only that range of the index
+func queryExpiryIndexUntil(ctx contractapi.TransactionContextInterface, lockExpiryTimeSecs uint64) ([][]string, error) {
+	resultsIterator, err := ctx.GetStub().GetStateByRange(generateExpiryIndexKeyPrefix(0), getExpiryIndexRangeEndKey(lockExpiryTimeSecs))
+	if err != nil {
+		return [][]string{}, logThenErrorf("failed to query index %s: %+v", expiryIndexObjectType, err)
+	}
+	defer resultsIterator.Close()
+
+	return readAssetLockIndexEntries(ctx, expiryIndexObjectType, resultsIterator)
+}
+
+// function to fetch the attributes of one page of the expiry index entries of the locks expiring at or before the
+// given time, along with the bookmark of the next page (empty if there are no more entries) and the number of entries fetched
+func queryExpiryIndexUntilWithPagination(ctx contractapi.TransactionContextInterface, lockExpiryTimeSecs uint64,
+	pageSize int32, bookmark string) ([][]string, string, int32, error) {
+	if pageSize <= 0 {
+		return [][]string{}, "", 0, logThenErrorf("invalid page size %d", pageSize)
+	}
+	resultsIterator, responseMetadata, err := ctx.GetStub().GetStateByRangeWithPagination(generateExpiryIndexKeyPrefix(0),
+		getExpiryIndexRangeEndKey(lockExpiryTimeSecs), pageSize, bookmark)
+	if err != nil {
+		return [][]string{}, "", 0, logThenErrorf("failed to query index %s: %+v", expiryIndexObjectType, err)
+	}
+	if resultsIterator == nil || responseMetadata == nil {
+		return [][]string{}, "", 0, logThenErrorf("paginated query of index %s is not supported", expiryIndexObjectType)
+	}
+	defer resultsIterator.Close()
+
+	indexEntries, err := readAssetLockIndexEntries(ctx, expiryIndexObjectType, resultsIterator)
+	if err != nil {
+		return indexEntries, "", 0, err
+	}
+	nextBookmark := responseMetadata.Bookmark
+	if responseMetadata.FetchedRecordsCount < pageSize {
+		// a short page is the last one
+		nextBookmark = ""
+	}
+	return indexEntries, nextBookmark, responseMetadata.FetchedRecordsCount, nil
+}
+
+// function to find out whether contractId is associated with a non-fungible or a fungible asset lock
+func getLockKindOfContractId(ctx contractapi.TransactionContextInterface, contractId string) (string, error) {
+	contractIdMapValBytes, err := ctx.GetStub().GetState(generateContractIdMapKey(contractId))
+	if err != nil {
+		return "", logThenErrorf("failed to retrieve from the world state: %+v", err)
+	}
+	if contractIdMapValBytes == nil {
+		// the contractId of an asset bundle lock maps to the bundle lock under a key of its own
+		bundleLockValBytes, err := ctx.GetStub().GetState(generateBundleContractIdMapKey(contractId))
+		if err != nil {
+			return "", logThenErrorf("failed to retrieve from the world state: %+v", err)
+		}
+		if bundleLockValBytes != nil {
+			return assetBundleLockKind, nil
+		}
+		return "", logThenErrorf("no contractId %s exists on the ledger", contractId)
+	}
+
+	// the contractId of a non-fungible asset lock maps to the asset-lock key, and that of a fungible asset lock to the lock itself
+	var assetLockKey string
+	if json.Unmarshal(contractIdMapValBytes, &assetLockKey) == nil {
+		return nonFungibleLockKind, nil
+	}
+	return fungibleLockKind, nil
+}
+
+// function to fetch the summary of an asset lock of the given kind using contractId
+func fetchLockedAssetInfo(ctx contractapi.TransactionContextInterface, lockKind string, contractId string) (LockedAssetInfo, error) {
+	if lockKind == fungibleLockKind {
+		assetLockVal, err := fetchFungibleAssetLocked(ctx, contractId)
+		if err != nil {
+			return LockedAssetInfo{}, err
+		}
+		return getFungibleLockedAssetInfo(contractId, assetLockVal), nil
+	}
+	if lockKind == assetBundleLockKind {
+		bundleLockVal, err := fetchAssetBundleLocked(ctx, contractId)
+		if err != nil {
+			return LockedAssetInfo{}, err
+		}
+		return getAssetBundleLockedAssetInfo(contractId, bundleLockVal), nil
+	}
+	assetLockKey, assetLockVal, err := fetchAssetLockedUsingContractId(ctx, contractId)
+	if err != nil {
+		return LockedAssetInfo{}, err
+	}
+	return getNonFungibleLockedAssetInfo(ctx, contractId, assetLockKey, assetLockVal)
+}
+
+// function to resolve the locker and recipient of a lock listing query, and to pick the index to look up: the index of
+// whichever party is specified, with the other party being filtered on.
+// '*' for recipient or locker implies an arbitrary recipient or locker respectively.
+func resolveLockedAssetsQuery(ctx contractapi.TransactionContextInterface, lockKind string, lockRecipient string, locker string) (string, []string, string, string, error) {
+	lockRecipient, err := resolveLockPartyOfQuery(ctx, lockRecipient)
+	if err != nil {
+		return "", nil, "", "", err
+	}
+	locker, err = resolveLockPartyOfQuery(ctx, locker)
+	if err != nil {
+		return "", nil, "", "", err
+	}
+	if lockRecipient == "*" && locker == "*" {
+		return "", nil, "", "", logThenErrorf("invalid query: both locker and recipient are arbitrary")
+	}
+
+	indexObjectType, indexParty := lockerIndexObjectType, locker
+	if locker == "*" {
+		indexObjectType, indexParty = recipientIndexObjectType, lockRecipient
+	}
+	partialAttributes := []string{indexParty}
+	if len(lockKind) > 0 {
+		partialAttributes = append(partialAttributes, lockKind)
+	}
+	return indexObjectType, partialAttributes, lockRecipient, locker, nil
+}
+
+// function to build the listing (in JSON form) of the asset locks, among the given index entries, between a locker and a recipient
+func getLockedAssetsOfIndexEntries(ctx contractapi.TransactionContextInterface, indexEntries [][]string, lockRecipient string, locker string) ([]string, error) {
+	lockedAssets := []string{}
+	for _, indexEntry := range indexEntries {
+		lockedAssetInfo, err := fetchLockedAssetInfo(ctx, indexEntry[1], indexEntry[2])
+		if err != nil {
+			return lockedAssets, err
+		}
+		if (locker != "*" && lockedAssetInfo.Locker != locker) || (lockRecipient != "*" && !isRecipientOfLock(lockedAssetInfo.Recipient, lockedAssetInfo.Recipients, lockRecipient)) {
+			continue
+		}
+		lockedAssetInfoBytes, err := json.Marshal(lockedAssetInfo)
+		if err != nil {
+			return lockedAssets, logThenErrorf("marshal error: %+v", err)
+		}
+		lockedAssets = append(lockedAssets, string(lockedAssetInfoBytes))
+	}
+	return lockedAssets, nil
+}
+
+// function to list the asset locks of a given kind (all kinds if lockKind is empty) between a locker and a recipient.
+// '*' for recipient or locker implies an arbitrary recipient or locker respectively.
+func getAllLockedAssets(ctx contractapi.TransactionContextInterface, lockKind string, lockRecipient string, locker string) ([]string, error) {
+	indexObjectType, partialAttributes, lockRecipient, locker, err := resolveLockedAssetsQuery(ctx, lockKind, lockRecipient, locker)
+	if err != nil {
+		return []string{}, err
+	}
+	indexEntries, err := queryAssetLockIndex(ctx, indexObjectType, partialAttributes)
+	if err != nil {
+		return []string{}, err
+	}
+	return getLockedAssetsOfIndexEntries(ctx, indexEntries, lockRecipient, locker)
+}
+
+// function to list one page of the asset locks of a given kind (all kinds if lockKind is empty) between a locker and a recipient.
+// The page size bounds the number of index entries looked up, so a page may hold fewer locks once filtered on the other party.
+func getAllLockedAssetsWithPagination(ctx contractapi.TransactionContextInterface, lockKind string, lockRecipient string, locker string,
+	pageSize int32, bookmark string) (*LockedAssetsPage, error) {
+	indexObjectType, partialAttributes, lockRecipient, locker, err := resolveLockedAssetsQuery(ctx, lockKind, lockRecipient, locker)
+	if err != nil {
+		return nil, err
+	}
+	indexEntries, nextBookmark, fetchedRecordsCount, err := queryAssetLockIndexWithPagination(ctx, indexObjectType, partialAttributes, pageSize, bookmark)
+	if err != nil {
+		return nil, err
+	}
+	lockedAssets, err := getLockedAssetsOfIndexEntries(ctx, indexEntries, lockRecipient, locker)
+	if err != nil {
+		return nil, err
+	}
+	return &LockedAssetsPage{LockedAssets: lockedAssets, Bookmark: nextBookmark, FetchedRecordsCount: fetchedRecordsCount}, nil
+}
+
+// GetTotalFungibleLockedAssets cc is used to query the total number of units of a fungible asset type held in locks,
+// on their own or in asset bundles (including locks whose expiry time has elapsed but which are not yet unlocked)
+func (s *SmartContract) GetTotalFungibleLockedAssets(ctx contractapi.TransactionContextInterface, assetType string) (uint64, error) {
+	if len(assetType) == 0 {
+		return 0, logThenErrorf("empty asset type")
+	}
+	indexEntries, err := queryAssetLockIndex(ctx, assetTypeIndexObjectType, []string{fungibleLockKind, assetType})
+	if err != nil {
+		return 0, err
+	}
+	var numUnits uint64 = 0
+	for _, indexEntry := range indexEntries {
+		assetLockVal, err := fetchFungibleAssetLocked(ctx, indexEntry[2])
+		if err != nil {
+			return 0, err
+		}
+		numUnits += assetLockVal.NumUnits
+	}
+	indexEntries, err = queryAssetLockIndex(ctx, assetTypeIndexObjectType, []string{assetBundleLockKind, assetType})
+	if err != nil {
+		return 0, err
+	}
+	for _, indexEntry := range indexEntries {
+		bundleLockVal, err := fetchAssetBundleLocked(ctx, indexEntry[2])
+		if err != nil {
+			return 0, err
+		}
+		for _, asset := range bundleLockVal.Assets {
+			if asset.Type == assetType {
+				numUnits += asset.NumUnits
+			}
+		}
+	}
+	return numUnits, nil
+}
+
+// GetAllLockedAssets cc is used to list all the asset locks (fungible, non-fungible and asset bundles) between a locker and a recipient
+func (s *SmartContract) GetAllLockedAssets(ctx contractapi.TransactionContextInterface, lockRecipient string, locker string) ([]string, error) {
+	return getAllLockedAssets(ctx, "", lockRecipient, locker)
+}
+
+// GetAllNonFungibleLockedAssets cc is used to list all the non-fungible asset locks between a locker and a recipient
+func (s *SmartContract) GetAllNonFungibleLockedAssets(ctx contractapi.TransactionContextInterface, lockRecipient string, locker string) ([]string, error) {
+	return getAllLockedAssets(ctx, nonFungibleLockKind, lockRecipient, locker)
+}
+
+// GetAllFungibleLockedAssets cc is used to list all the fungible asset locks between a locker and a recipient
+func (s *SmartContract) GetAllFungibleLockedAssets(ctx contractapi.TransactionContextInterface, lockRecipient string, locker string) ([]string, error) {
+	return getAllLockedAssets(ctx, fungibleLockKind, lockRecipient, locker)
+}
+
+// GetAllLockedAssetsWithPagination cc is used to list one page of the asset locks (fungible, non-fungible and asset bundles) between a locker and a recipient
+func (s *SmartContract) GetAllLockedAssetsWithPagination(ctx contractapi.TransactionContextInterface, lockRecipient string, locker string,
+	pageSize int32, bookmark string) (*LockedAssetsPage, error) {
+	return getAllLockedAssetsWithPagination(ctx, "", lockRecipient, locker, pageSize, bookmark)
+}
+
+// GetAllNonFungibleLockedAssetsWithPagination cc is used to list one page of the non-fungible asset locks between a locker and a recipient
+func (s *SmartContract) GetAllNonFungibleLockedAssetsWithPagination(ctx contractapi.TransactionContextInterface, lockRecipient string, locker string,
+	pageSize int32, bookmark string) (*LockedAssetsPage, error) {
+	return getAllLockedAssetsWithPagination(ctx, nonFungibleLockKind, lockRecipient, locker, pageSize, bookmark)
+}
+
+// GetAllFungibleLockedAssetsWithPagination cc is used to list one page of the fungible asset locks between a locker and a recipient
+func (s *SmartContract) GetAllFungibleLockedAssetsWithPagination(ctx contractapi.TransactionContextInterface, lockRecipient string, locker string,
+	pageSize int32, bookmark string) (*LockedAssetsPage, error) {
+	return getAllLockedAssetsWithPagination(ctx, fungibleLockKind, lockRecipient, locker, pageSize, bookmark)
+}
+
+// GetAssetTimeToRelease cc is used to query the expiry time (in epoch seconds) of the lock on a non-fungible asset
+func (s *SmartContract) GetAssetTimeToRelease(ctx contractapi.TransactionContextInterface, assetType string, assetId string, lockRecipient string, locker string) (uint64, error) {
+	lockRecipient, err := resolveLockPartyOfQuery(ctx, lockRecipient)
+	if err != nil {
+		return 0, err
+	}
+	locker, err = resolveLockPartyOfQuery(ctx, locker)
+	if err != nil {
+		return 0, err
+	}
+
+	assetAgreement := &common.AssetExchangeAgreement{Type: assetType, Id: assetId}
+	assetLockKey, _, err := generateAssetLockKeyAndContractId(ctx, assetAgreement)
+	if err != nil {
+		return 0, logThenErrorf(err.Error())
+	}
+	assetLockValBytes, err := ctx.GetStub().GetState(assetLockKey)
+	if err != nil {
+		return 0, logThenErrorf(err.Error())
+	}
+	if assetLockValBytes == nil {
+		return 0, logThenErrorf("no asset of type %s and ID %s is locked", assetType, assetId)
+	}
+	assetLockVal, err := unmarshalAssetLockValue(assetLockValBytes)
+	if err != nil {
+		return 0, logThenErrorf("unmarshal error: %s", err)
+	}
+
+	if (locker != "*" && assetLockVal.Locker != locker) || (lockRecipient != "*" && !isRecipientOfLock(assetLockVal.Recipient, assetLockVal.Recipients, lockRecipient)) {
+		return 0, logThenErrorf("asset of type %s and ID %s is not locked by %s for %s", assetType, assetId, locker, lockRecipient)
+	}
+	return assetLockVal.ExpiryTimeSecs, nil
+}
+
+// GetFungibleAssetTimeToRelease cc is used to query the expiry time (in epoch seconds) of the lock on a fungible asset;
+// if several locks match the given asset type, number of units, locker and recipient, the earliest expiry is returned
+func (s *SmartContract) GetFungibleAssetTimeToRelease(ctx contractapi.TransactionContextInterface, assetType string, numUnits uint64, lockRecipient string, locker string) (uint64, error) {
+	lockedAssets, err := getAllLockedAssets(ctx, fungibleLockKind, lockRecipient, locker)
+	if err != nil {
+		return 0, err
+	}
+
+	var timeToReleaseSecs uint64 = 0
+	for _, lockedAsset := range lockedAssets {
+		lockedAssetInfo := LockedAssetInfo{}
+		err = json.Unmarshal([]byte(lockedAsset), &lockedAssetInfo)
+		if err != nil {
+			return 0, logThenErrorf("unmarshal error: %s", err)
+		}
+		if lockedAssetInfo.Type != assetType || lockedAssetInfo.NumUnits != numUnits {
+			continue
+		}
+		if timeToReleaseSecs == 0 || lockedAssetInfo.ExpiryTimeSecs < timeToReleaseSecs {
+			timeToReleaseSecs = lockedAssetInfo.ExpiryTimeSecs
+		}
+	}
+	if timeToReleaseSecs == 0 {
+		return 0, logThenErrorf("no %d units of fungible asset of type %s are locked by %s for %s", numUnits, assetType, locker, lockRecipient)
+	}
+	return timeToReleaseSecs, nil
+}
+
+// GetAssetTimeToReleaseUsingContractId cc is used to query the expiry time (in epoch seconds) of the lock on a non-fungible asset using contractId
+func (s *SmartContract) GetAssetTimeToReleaseUsingContractId(ctx contractapi.TransactionContextInterface, contractId string) (uint64, error) {
+	_, assetLockVal, err := fetchAssetLockedUsingContractId(ctx, contractId)
+	if err != nil {
+		return 0, logThenErrorf(err.Error())
+	}
+	return assetLockVal.ExpiryTimeSecs, nil
+}
+
+// GetFungibleAssetTimeToReleaseUsingContractId cc is used to query the expiry time (in epoch seconds) of the lock on a fungible asset using contractId
+func (s *SmartContract) GetFungibleAssetTimeToReleaseUsingContractId(ctx contractapi.TransactionContextInterface, contractId string) (uint64, error) {
+	assetLockVal, err := fetchFungibleAssetLocked(ctx, contractId)
+	if err != nil {
+		return 0, logThenErrorf(err.Error())
+	}
+	return assetLockVal.ExpiryTimeSecs, nil
+}
+
+// function to build the listing (in JSON form) of the asset locks, among the given entries of the expiry index, held by
+// or for the caller
+func getLockedAssetsOfCaller(ctx contractapi.TransactionContextInterface, indexEntries [][]string) ([]string, error) {
+	lockedAssets := []string{}
+	txCreatorECertBase64, err := getECertOfTxCreatorBase64(ctx)
+	if err != nil {
+		return lockedAssets, logThenErrorf("unable to get the transaction creator information: %+v", err)
+	}
+
+	for _, indexEntry := range indexEntries {
+		lockedAssetInfo, err := fetchLockedAssetInfo(ctx, indexEntry[1], indexEntry[2])
+		if err != nil {
+			return lockedAssets, err
+		}
+		if lockedAssetInfo.Locker != txCreatorECertBase64 && !isRecipientOfLock(lockedAssetInfo.Recipient, lockedAssetInfo.Recipients, txCreatorECertBase64) {
+			continue
+		}
+		lockedAssetInfoBytes, err := json.Marshal(lockedAssetInfo)
+		if err != nil {
+			return lockedAssets, logThenErrorf("marshal error: %+v", err)
+		}
+		lockedAssets = append(lockedAssets, string(lockedAssetInfoBytes))
+	}
+	return lockedAssets, nil
+}
+
+// GetAllAssetsLockedUntil cc is used to list all the asset locks, held by or for the caller, that expire at or before the given time
+func (s *SmartContract) GetAllAssetsLockedUntil(ctx contractapi.TransactionContextInterface, lockExpiryTimeSecs uint64) ([]string, error) {
+	indexEntries, err := queryExpiryIndexUntil(ctx, lockExpiryTimeSecs)
+	if err != nil {
+		return []string{}, err
+	}
+	return getLockedAssetsOfCaller(ctx, indexEntries)
+}
+
+// GetAllAssetsLockedUntilWithPagination cc is used to list one page of the asset locks, held by or for the caller, that
+// expire at or before the given time
+func (s *SmartContract) GetAllAssetsLockedUntilWithPagination(ctx contractapi.TransactionContextInterface, lockExpiryTimeSecs uint64,
+	pageSize int32, bookmark string) (*LockedAssetsPage, error) {
+	indexEntries, nextBookmark, fetchedRecordsCount, err := queryExpiryIndexUntilWithPagination(ctx, lockExpiryTimeSecs, pageSize, bookmark)
+	if err != nil {
+		return nil, err
+	}
+	lockedAssets, err := getLockedAssetsOfCaller(ctx, indexEntries)
+	if err != nil {
+		return nil, err
+	}
+	return &LockedAssetsPage{LockedAssets: lockedAssets, Bookmark: nextBookmark, FetchedRecordsCount: fetchedRecordsCount}, nil
+}
+
+// GetHTLCHashPreImage cc is used to fetch the hash preimage (in base64 form) revealed when the HTLC associated with contractId was claimed
+func (s *SmartContract) GetHTLCHashPreImage(ctx contractapi.TransactionContextInterface, contractId string) (string, error) {
+	claimedContractValBytes, err := ctx.GetStub().GetState(claimedContractIdPrefix + contractId)
+	if err != nil {
+		return "", logThenErrorf("failed to retrieve from the world state: %+v", err)
+	}
+	if claimedContractValBytes == nil {
+		return "", logThenErrorf("no claimed HTLC is associated with contractId %s", contractId)
+	}
+	claimedContractVal := ClaimedContractValue{}
+	err = json.Unmarshal(claimedContractValBytes, &claimedContractVal)
+	if err != nil {
+		return "", logThenErrorf("unmarshal error: %s", err)
+	}
+	return claimedContractVal.HashPreimageBase64, nil
+}
+
+// function to construct the message that the recipient of a lock signs to consent to the extension of its expiry time
+func getLockExtensionMessage(contractId string, expiryTimeSecs uint64, newExpiryTimeSecs uint64) string {
+	return fmt.Sprintf("LockExtension:%s:%d:%d", contractId, expiryTimeSecs, newExpiryTimeSecs)
+}
+
+// function to check that a lock can be extended by the transaction creator to extensionInfo.NewExpiryTimeSecs:
+// the creator must be the locker, the lock must not have expired, the new expiry time must be later than the
+// current one and the recipient must have signed the extension message
+func validateLockExtension(ctx contractapi.TransactionContextInterface, contractId string, locker string, recipient string,
+	expiryTimeSecs uint64, extensionInfo *common.AssetLockExtension) error {
+	txCreatorECertBase64, err := getECertOfTxCreatorBase64(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get the transaction creator information: %+v", err)
+	}
+	if locker != txCreatorECertBase64 {
+		return fmt.Errorf("asset is not locked by %s to extend the lock", txCreatorECertBase64)
+	}
+	isExpired, err := isLockExpired(ctx, expiryTimeSecs)
+	if err != nil {
+		return err
+	}
+	if isExpired {
+		return fmt.Errorf("cannot extend the lock associated with contractId %s as the expiry time is already elapsed", contractId)
+	}
+	if extensionInfo.NewExpiryTimeSecs <= expiryTimeSecs {
+		return fmt.Errorf("new expiry time %d is not later than the current expiry time %d", extensionInfo.NewExpiryTimeSecs, expiryTimeSecs)
+	}
+	recipientCert, err := parseLockPartyCert(recipient, "lock recipient")
+	if err != nil {
+		return err
+	}
+	err = validateSignature(getLockExtensionMessage(contractId, expiryTimeSecs, extensionInfo.NewExpiryTimeSecs), recipientCert, string(extensionInfo.RecipientSignature))
+	if err != nil {
+		return fmt.Errorf("lock extension is not signed by the recipient: %+v", err)
+	}
+	return nil
+}
+
+/*
+ * ExtendLockExpiry cc is used by the locker to push out the expiry time of an asset (or fungible asset) lock
+ * identified by contractId, with the consent of the recipient carried in extensionInfo as a signature over the
+ * extension message. An arbiter of an escrow lock needs to sign the release with the new expiry time thereafter.
+ */
+func (s *SmartContract) ExtendLockExpiry(ctx contractapi.TransactionContextInterface, contractId string, extensionInfoBytesBase64 string) error {
+	extensionInfoBytes, err := base64.StdEncoding.DecodeString(extensionInfoBytesBase64)
+	if err != nil {
+		return logThenErrorf("error in base64 decode of lock extension info: %+v", err)
+	}
+	if len(extensionInfoBytes) == 0 {
+		return logThenErrorf("empty lock extension info")
+	}
+	extensionInfo := &common.AssetLockExtension{}
+	err = proto.Unmarshal(extensionInfoBytes, extensionInfo)
+	if err != nil {
+		return logThenErrorf("unmarshal error: %s", err)
+	}
+	if extensionInfo.ContractId != "" && extensionInfo.ContractId != contractId {
+		return logThenErrorf("lock extension is meant for contractId %s and not %s", extensionInfo.ContractId, contractId)
+	}
+	err = enforceLockPolicyOnExtension(ctx, extensionInfo.NewExpiryTimeSecs)
+	if err != nil {
+		return logThenErrorf("lock policy violation: %+v", err)
+	}
+
+	lockKind, err := getLockKindOfContractId(ctx, contractId)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	if lockKind == assetBundleLockKind {
+		return logThenErrorf("cannot extend the lock associated with contractId %s as it is an asset bundle lock", contractId)
+	}
+	if lockKind == nonFungibleLockKind {
+		assetLockKey, assetLockVal, err := fetchAssetLockedUsingContractId(ctx, contractId)
+		if err != nil {
+			return logThenErrorf(err.Error())
+		}
+		if len(assetLockVal.Recipients) > 0 {
+			return logThenErrorf("cannot extend the lock associated with contractId %s as it is locked for several recipients", contractId)
+		}
+		err = validateLockExtension(ctx, contractId, assetLockVal.Locker, assetLockVal.Recipient, assetLockVal.ExpiryTimeSecs, extensionInfo)
+		if err != nil {
+			return logThenErrorf("cannot extend the lock associated with contractId %s: %+v", contractId, err)
+		}
+		lockedAssetInfo, err := getNonFungibleLockedAssetInfo(ctx, contractId, assetLockKey, assetLockVal)
+		if err != nil {
+			return logThenErrorf(err.Error())
+		}
+		err = deleteAssetLockIndexes(ctx, nonFungibleLockKind, contractId, lockedAssetInfo)
+		if err != nil {
+			return logThenErrorf(err.Error())
+		}
+
+		assetLockVal.ExpiryTimeSecs = extensionInfo.NewExpiryTimeSecs
+		assetLockValBytes, err := marshalAssetLockValue(assetLockVal)
+		if err != nil {
+			return logThenErrorf("marshal error: %s", err)
+		}
+		err = ctx.GetStub().PutState(assetLockKey, assetLockValBytes)
+		if err != nil {
+			return logThenErrorf(err.Error())
+		}
+		lockedAssetInfo.ExpiryTimeSecs = extensionInfo.NewExpiryTimeSecs
+		err = addAssetLockIndexes(ctx, nonFungibleLockKind, contractId, lockedAssetInfo)
+		if err != nil {
+			return logThenErrorf(err.Error())
+		}
+		emitAssetLockEvent(ctx, newAssetLockEvent(common.AssetLockEvent_EXTEND, lockedAssetInfo, assetLockVal.LockInfo))
+	} else {
+		assetLockVal, err := fetchFungibleAssetLocked(ctx, contractId)
+		if err != nil {
+			return logThenErrorf(err.Error())
+		}
+		err = validateLockExtension(ctx, contractId, assetLockVal.Locker, assetLockVal.Recipient, assetLockVal.ExpiryTimeSecs, extensionInfo)
+		if err != nil {
+			return logThenErrorf("cannot extend the lock associated with contractId %s: %+v", contractId, err)
+		}
+		err = deleteAssetLockIndexes(ctx, fungibleLockKind, contractId, getFungibleLockedAssetInfo(contractId, assetLockVal))
+		if err != nil {
+			return logThenErrorf(err.Error())
+		}
+
+		assetLockVal.ExpiryTimeSecs = extensionInfo.NewExpiryTimeSecs
+		assetLockValBytes, err := marshalFungibleAssetLockValue(assetLockVal)
+		if err != nil {
+			return logThenErrorf("marshal error: %s", err)
+		}
+		err = ctx.GetStub().PutState(generateContractIdMapKey(contractId), assetLockValBytes)
+		if err != nil {
+			return logThenErrorf(err.Error())
+		}
+		err = addAssetLockIndexes(ctx, fungibleLockKind, contractId, getFungibleLockedAssetInfo(contractId, assetLockVal))
+		if err != nil {
+			return logThenErrorf(err.Error())
+		}
+		emitAssetLockEvent(ctx, newAssetLockEvent(common.AssetLockEvent_EXTEND, getFungibleLockedAssetInfo(contractId, assetLockVal), assetLockVal.LockInfo))
+	}
+	log.Infof("lock associated with contractId %s extended until %d", contractId, extensionInfo.NewExpiryTimeSecs)
+
 	return nil
 }
@@ -7,7 +7,13 @@
 package main
 
 import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
+	"math/big"
 	"fmt"
 	"testing"
 	"encoding/base64"
@@ -19,6 +25,11 @@ import (
 	mspProtobuf "github.com/hyperledger/fabric-protos-go/msp"
 	log "github.com/sirupsen/logrus"
 	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/core/network/fabric-interop-cc/contracts/interop/mocks"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
 )
 
 const(
@@ -43,6 +54,36 @@ func getTxCreatorECertBase64() string {
 	return eCertBase64
 }
 
+// function that supplies a transaction context backed by an in-memory ledger (for tests spanning several transactions)
+func prepShimMockStub() (*mocks.TransactionContext, *shimtest.MockStub, SmartContract) {
+	transactionContext, _ := prepMocks(myOrg1Msp, myOrg1Clientid)
+	mockStub := shimtest.NewMockStub("interopcc", nil)
+	mockStub.Creator = []byte(getCreator())
+	adminMSPsBytes, _ := json.Marshal([]string{myOrg1Msp})
+	mockStub.MockTransactionStart("init")
+	mockStub.PutState(adminMSPsKey, adminMSPsBytes)
+	mockStub.MockTransactionEnd("init")
+	transactionContext.GetStubReturns(mockStub)
+	interopcc := SmartContract{}
+	return transactionContext, mockStub, interopcc
+}
+
+// function that supplies the base64 encoded lock information for an HTLC lock
+func getHTLCLockInfoBase64(hashBase64 string, expiryTimeSecs uint64) string {
+	lockInfoHTLC := &common.AssetLockHTLC {
+		HashBase64: []byte(hashBase64),
+		ExpiryTimeSecs: expiryTimeSecs,
+		TimeSpec: common.AssetLockHTLC_EPOCH,
+	}
+	lockInfoHTLCBytes, _ := proto.Marshal(lockInfoHTLC)
+	lockInfo := &common.AssetLock {
+		LockMechanism: common.LockMechanism_HTLC,
+		LockInfo: lockInfoHTLCBytes,
+	}
+	lockInfoBytes, _ := proto.Marshal(lockInfo)
+	return base64.StdEncoding.EncodeToString(lockInfoBytes)
+}
+
 func TestLockAsset(t *testing.T) {
 	ctx, chaincodeStub, interopcc := prepMockStub()
 
@@ -94,9 +135,8 @@ func TestLockAsset(t *testing.T) {
 	// no need to set chaincodeStub.GetStateReturns below since the error is hit before GetState() ledger access in LockAsset()
 	lockInfoHTLC = &common.AssetLockHTLC {
 		HashBase64: []byte(hashBase64),
-		// lock for next 5 mintues
-		ExpiryTimeSecs: currentTimeSecs + defaultTimeLockSecs,
-		// TimeSpec of AssetLockHTLC_DURATION is not currently supported
+		// a lock duration of zero is invalid
+		ExpiryTimeSecs: 0,
 		TimeSpec: common.AssetLockHTLC_DURATION,
 	}
 	lockInfoHTLCBytes, _ = proto.Marshal(lockInfoHTLC)
@@ -107,8 +147,104 @@ func TestLockAsset(t *testing.T) {
 	lockInfoBytes, _ = proto.Marshal(lockInfo)
 	// Test failure with lock information not specified properly
 	_, err = interopcc.LockAsset(ctx, base64.StdEncoding.EncodeToString(assetAgreementBytes), base64.StdEncoding.EncodeToString(lockInfoBytes))
-	require.Error(t, err)
+	require.EqualError(t, err, "lock duration must be greater than zero")
+	log.Info(fmt.Println("Test failed as expected with error:", err))
+
+	lockInfoHTLC = &common.AssetLockHTLC {
+		HashBase64: []byte(hashBase64),
+		// lock for 5 minutes from the transaction time
+		ExpiryTimeSecs: defaultTimeLockSecs,
+		TimeSpec: common.AssetLockHTLC_DURATION,
+	}
+	lockInfoHTLCBytes, _ = proto.Marshal(lockInfoHTLC)
+	lockInfo = &common.AssetLock {
+		LockMechanism: common.LockMechanism_HTLC,
+		LockInfo: lockInfoHTLCBytes,
+	}
+	lockInfoBytes, _ = proto.Marshal(lockInfo)
+	chaincodeStub.GetStateReturns(nil, nil)
+	putStateCallCount := chaincodeStub.PutStateCallCount()
+	// Test success with the expiry time computed from the lock duration and the transaction timestamp
+	_, err = interopcc.LockAsset(ctx, base64.StdEncoding.EncodeToString(assetAgreementBytes), base64.StdEncoding.EncodeToString(lockInfoBytes))
+	require.NoError(t, err)
+	txTimestamp, _ := chaincodeStub.GetTxTimestamp()
+	_, assetLockValBytes = chaincodeStub.PutStateArgsForCall(putStateCallCount)
+	assetLockVal, err = unmarshalAssetLockValue(assetLockValBytes)
+	require.NoError(t, err)
+	require.Equal(t, uint64(txTimestamp.Seconds) + defaultTimeLockSecs, assetLockVal.ExpiryTimeSecs)
+	fmt.Println("Test success as expected since the lock duration is specified properly")
+
+	lockInfoHTLC = &common.AssetLockHTLC {
+		HashBase64: []byte(hashBase64),
+		ExpiryTimeSecs: currentTimeSecs + defaultTimeLockSecs,
+		TimeSpec: common.AssetLockHTLC_EPOCH,
+		HashMechanism: common.HashMechanism(10),
+	}
+	lockInfoHTLCBytes, _ = proto.Marshal(lockInfoHTLC)
+	lockInfo = &common.AssetLock {
+		LockMechanism: common.LockMechanism_HTLC,
+		LockInfo: lockInfoHTLCBytes,
+	}
+	lockInfoBytes, _ = proto.Marshal(lockInfo)
+	// Test failure with an unsupported hash mechanism
+	_, err = interopcc.LockAsset(ctx, base64.StdEncoding.EncodeToString(assetAgreementBytes), base64.StdEncoding.EncodeToString(lockInfoBytes))
+	require.EqualError(t, err, "hash mechanism 10 is not supported")
 	log.Info(fmt.Println("Test failed as expected with error:", err))
+
+	keccakHashBase64, _ := generateHashInBase64Form(preimage, common.HashMechanism_KECCAK256)
+	lockInfoHTLC.HashBase64 = []byte(keccakHashBase64)
+	lockInfoHTLC.HashMechanism = common.HashMechanism_KECCAK256
+	lockInfoHTLCBytes, _ = proto.Marshal(lockInfoHTLC)
+	lockInfo.LockInfo = lockInfoHTLCBytes
+	lockInfoBytes, _ = proto.Marshal(lockInfo)
+	chaincodeStub.GetStateReturns(nil, nil)
+	putStateCallCount = chaincodeStub.PutStateCallCount()
+	// Test success with the hash mechanism recorded in the asset lock
+	_, err = interopcc.LockAsset(ctx, base64.StdEncoding.EncodeToString(assetAgreementBytes), base64.StdEncoding.EncodeToString(lockInfoBytes))
+	require.NoError(t, err)
+	_, assetLockValBytes = chaincodeStub.PutStateArgsForCall(putStateCallCount)
+	assetLockVal, err = unmarshalAssetLockValue(assetLockValBytes)
+	require.NoError(t, err)
+	require.Equal(t, HashLock{HashBase64: keccakHashBase64, HashMechanism: common.HashMechanism_KECCAK256}, assetLockVal.LockInfo)
+	fmt.Println("Test success as expected since the hash mechanism is specified properly")
+}
+
+func TestGenerateHashInBase64Form(t *testing.T) {
+	preimage := "abcd"
+
+	// Test success with each supported hash mechanism
+	hashBase64, err := generateHashInBase64Form(preimage, common.HashMechanism_SHA256)
+	require.NoError(t, err)
+	require.Equal(t, generateSHA256HashInBase64Form(preimage), hashBase64)
+	hashBase64, err = generateHashInBase64Form(preimage, common.HashMechanism_SHA512)
+	require.NoError(t, err)
+	require.Equal(t, "2AIvIGCtbv0perc9zFNVybIUBUsNF3ahNqZp0mp9OxT3OqDQ6/8Z7jMzaPAWS2QZqW2knj5IF1Pn6Wtxa9zLbw==", hashBase64)
+	hashBase64, err = generateHashInBase64Form(preimage, common.HashMechanism_DOUBLE_SHA256)
+	require.NoError(t, err)
+	require.Equal(t, "fpwVjs2Rn6Q5p6IUyfxYuFwxd/sWE72uQe5pUGDhG8Y=", hashBase64)
+	// Keccak-256 (as used by Ethereum) of the empty string differs from the standardized SHA3-256
+	hashBase64, err = generateHashInBase64Form("", common.HashMechanism_KECCAK256)
+	require.NoError(t, err)
+	require.Equal(t, "xdJGAYb3IzySfn2y3McDwOUAtlPKgic7e/rYBF2FpHA=", hashBase64)
+
+	// Test failure with an unsupported hash mechanism
+	_, err = generateHashInBase64Form(preimage, common.HashMechanism(10))
+	require.EqualError(t, err, "hash mechanism 10 is not supported")
+
+	// Test success with the preimage matched against the hash mechanism recorded in the lock
+	preimageBase64 := base64.StdEncoding.EncodeToString([]byte(preimage))
+	for _, hashMechanism := range []common.HashMechanism{common.HashMechanism_SHA256, common.HashMechanism_SHA512, common.HashMechanism_KECCAK256, common.HashMechanism_DOUBLE_SHA256} {
+		hashBase64, err = generateHashInBase64Form(preimage, hashMechanism)
+		require.NoError(t, err)
+		isCorrectPreimage, err := checkIfCorrectPreimage(preimageBase64, hashBase64, hashMechanism)
+		require.NoError(t, err)
+		require.True(t, isCorrectPreimage)
+	}
+
+	// Test failure with the hash checked under a different hash mechanism from the one used to generate it
+	isCorrectPreimage, err := checkIfCorrectPreimage(preimageBase64, generateSHA256HashInBase64Form(preimage), common.HashMechanism_KECCAK256)
+	require.NoError(t, err)
+	require.False(t, isCorrectPreimage)
 }
 
 func TestUnlockAsset(t *testing.T) {
@@ -125,8 +261,8 @@ func TestUnlockAsset(t *testing.T) {
 
 	lockInfoHTLC := &common.AssetLockHTLC {
 		HashBase64: []byte(hashBase64),
-		// lock for sometime in the past for testing UnlockAsset functionality
-		ExpiryTimeSecs: currentTimeSecs - defaultTimeLockSecs,
+		// a lock cannot expire before it is created; the lock records unlocked below carry their own expiry times
+		ExpiryTimeSecs: currentTimeSecs + defaultTimeLockSecs,
 		TimeSpec: common.AssetLockHTLC_EPOCH,
 	}
 	lockInfoHTLCBytes, _ := proto.Marshal(lockInfoHTLC)
@@ -395,6 +531,23 @@ func TestClaimAsset(t *testing.T) {
 	require.Error(t, err)
 	log.Info(fmt.Println("Test failed as expected with error:", err))
 
+	keccakHashBase64, _ := generateHashInBase64Form(preimage, common.HashMechanism_KECCAK256)
+	assetLockVal = AssetLockValue{Locker: locker, Recipient: recipient, LockInfo: HashLock{HashBase64: keccakHashBase64, HashMechanism: common.HashMechanism_KECCAK256}, ExpiryTimeSecs: currentTimeSecs + defaultTimeLockSecs}
+	assetLockValBytes, _ = json.Marshal(assetLockVal)
+	chaincodeStub.GetStateReturns(assetLockValBytes, nil)
+	// Test success with the preimage verified using the hash mechanism recorded in the lock
+	err = interopcc.ClaimAsset(ctx, base64.StdEncoding.EncodeToString(assetAgreementBytes), base64.StdEncoding.EncodeToString(claimInfoBytes))
+	require.NoError(t, err)
+	log.Info(fmt.Println("Test success as expected since the preimage matches the Keccak-256 hash lock."))
+
+	assetLockVal = AssetLockValue{Locker: locker, Recipient: recipient, LockInfo: HashLock{HashBase64: hashBase64, HashMechanism: common.HashMechanism_KECCAK256}, ExpiryTimeSecs: currentTimeSecs + defaultTimeLockSecs}
+	assetLockValBytes, _ = json.Marshal(assetLockVal)
+	chaincodeStub.GetStateReturns(assetLockValBytes, nil)
+	// Test failure with the hash lock generated using a different hash mechanism from the recorded one
+	err = interopcc.ClaimAsset(ctx, base64.StdEncoding.EncodeToString(assetAgreementBytes), base64.StdEncoding.EncodeToString(claimInfoBytes))
+	require.Error(t, err)
+	log.Info(fmt.Println("Test failed as expected with error:", err))
+
 	assetAgreement.Locker = "Charlie"
 	assetAgreementBytes, _ = proto.Marshal(assetAgreement)
 
@@ -743,13 +896,11 @@ func TestLockFungibleAsset(t *testing.T) {
 	contractId := generateFungibleAssetLockContractId(ctx, assetAgreement)
 	chaincodeStub.GetCreatorReturns([]byte(getCreator()), nil)
 
-	// Test failure with TimeSpec that is part of lock information not being currently supported
+	// Test failure with a lock duration of zero specified in the lock information
 	// no need to set chaincodeStub.GetStateReturns below since the error is hit before GetState() ledger access
 	lockInfoHTLC := &common.AssetLockHTLC {
 		HashBase64: []byte(hashBase64),
-		// lock for next 5 mintues
-		ExpiryTimeSecs: currentTimeSecs + defaultTimeLockSecs,
-		// TimeSpec of AssetLockHTLC_DURATION is not currently supported
+		ExpiryTimeSecs: 0,
 		TimeSpec: common.AssetLockHTLC_DURATION,
 	}
 	lockInfoHTLCBytes, _ := proto.Marshal(lockInfoHTLC)
@@ -760,7 +911,7 @@ func TestLockFungibleAsset(t *testing.T) {
 	lockInfoBytes, _ := proto.Marshal(lockInfo)
 	_, err := interopcc.LockFungibleAsset(ctx, base64.StdEncoding.EncodeToString(assetAgreementBytes), base64.StdEncoding.EncodeToString(lockInfoBytes))
 	require.Error(t, err)
-	require.EqualError(t, err, "only EPOCH time is supported at present")
+	require.EqualError(t, err, "lock duration must be greater than zero")
 	fmt.Printf("Test failed as expected with error: %s\n", err)
 
 	// Test failure with GetState(contractId) fail to read the world state
@@ -809,6 +960,29 @@ func TestLockFungibleAsset(t *testing.T) {
 	_, err = interopcc.LockFungibleAsset(ctx, base64.StdEncoding.EncodeToString(assetAgreementBytes), base64.StdEncoding.EncodeToString(lockInfoBytes))
 	require.NoError(t, err)
 	fmt.Println("Test success as expected since the fungible asset agreement is specified properly.")
+
+	// Test success with the expiry time computed from the lock duration and the transaction timestamp
+	lockInfoHTLC = &common.AssetLockHTLC {
+		HashBase64: []byte(hashBase64),
+		// lock for 5 minutes from the transaction time
+		ExpiryTimeSecs: defaultTimeLockSecs,
+		TimeSpec: common.AssetLockHTLC_DURATION,
+	}
+	lockInfoHTLCBytes, _ = proto.Marshal(lockInfoHTLC)
+	lockInfo = &common.AssetLock {
+		LockMechanism: common.LockMechanism_HTLC,
+		LockInfo: lockInfoHTLCBytes,
+	}
+	lockInfoBytes, _ = proto.Marshal(lockInfo)
+	putStateCallCount := chaincodeStub.PutStateCallCount()
+	_, err = interopcc.LockFungibleAsset(ctx, base64.StdEncoding.EncodeToString(assetAgreementBytes), base64.StdEncoding.EncodeToString(lockInfoBytes))
+	require.NoError(t, err)
+	txTimestamp, _ := chaincodeStub.GetTxTimestamp()
+	_, assetLockValBytes = chaincodeStub.PutStateArgsForCall(putStateCallCount)
+	assetLockVal, err = unmarshalFungibleAssetLockValue(assetLockValBytes)
+	require.NoError(t, err)
+	require.Equal(t, uint64(txTimestamp.Seconds) + defaultTimeLockSecs, assetLockVal.ExpiryTimeSecs)
+	fmt.Println("Test success as expected since the lock duration is specified properly.")
 }
 
 func TestIsFungibleAssetLocked(t *testing.T) {
@@ -906,14 +1080,14 @@ func TestClaimFungibleAsset(t *testing.T) {
 
 	// Test failure with GetState(contractId) fail to read the world state
 	chaincodeStub.GetStateReturnsOnCall(0, nil, fmt.Errorf("unable to retrieve contractId %s", contractId))
-	err := interopcc.ClaimFungibleAsset(ctx, contractId, base64.StdEncoding.EncodeToString(claimInfoBytes))
+	_, err := interopcc.ClaimFungibleAsset(ctx, contractId, base64.StdEncoding.EncodeToString(claimInfoBytes))
 	require.Error(t, err)
 	require.EqualError(t, err, "failed to retrieve from the world state: unable to retrieve contractId " + contractId)
 	fmt.Printf("Test failed as expected with error: %s\n", err)
 
 	// Test failure under the scenario that the contractId is not valid and there is no fungible asset locked with it
 	chaincodeStub.GetStateReturnsOnCall(1, nil, nil)
-	err = interopcc.ClaimFungibleAsset(ctx, contractId, base64.StdEncoding.EncodeToString(claimInfoBytes))
+	_, err = interopcc.ClaimFungibleAsset(ctx, contractId, base64.StdEncoding.EncodeToString(claimInfoBytes))
 	require.Error(t, err)
 	require.EqualError(t, err, "contractId " + contractId + " is not associated with any currently locked fungible asset")
 	fmt.Printf("Test failed as expected with error: %s\n", err)
@@ -926,7 +1100,7 @@ func TestClaimFungibleAsset(t *testing.T) {
 			LockInfo: lockInfo, ExpiryTimeSecs: currentTimeSecs - defaultTimeLockSecs}
 	assetLockValBytes, _ := json.Marshal(assetLockVal)
 	chaincodeStub.GetStateReturnsOnCall(2, assetLockValBytes, nil)
-	err = interopcc.ClaimFungibleAsset(ctx, contractId, base64.StdEncoding.EncodeToString(claimInfoBytes))
+	_, err = interopcc.ClaimFungibleAsset(ctx, contractId, base64.StdEncoding.EncodeToString(claimInfoBytes))
 	require.Error(t, err)
 	require.EqualError(t, err, "cannot claim fungible asset associated with contractId " + contractId + " as the expiry time is already elapsed")
 	fmt.Printf("Test failed as expected with error: %s\n", err)
@@ -942,7 +1116,7 @@ func TestClaimFungibleAsset(t *testing.T) {
 			LockInfo: lockInfo, ExpiryTimeSecs: currentTimeSecs + defaultTimeLockSecs}
 	assetLockValBytes, _ = json.Marshal(assetLockVal)
 	chaincodeStub.GetStateReturnsOnCall(3, assetLockValBytes, nil)
-	err = interopcc.ClaimFungibleAsset(ctx, contractId, base64.StdEncoding.EncodeToString(wrongClaimInfoBytes))
+	_, err = interopcc.ClaimFungibleAsset(ctx, contractId, base64.StdEncoding.EncodeToString(wrongClaimInfoBytes))
 	require.Error(t, err)
 	require.EqualError(t, err, "cannot claim fungible asset associated with contractId " + contractId + " as the hash preimage is not matching")
 	fmt.Printf("Test failed as expected with error: %s\n", err)
@@ -950,7 +1124,7 @@ func TestClaimFungibleAsset(t *testing.T) {
 	// Test failure with DelState failing on contractId
 	chaincodeStub.GetStateReturnsOnCall(4, assetLockValBytes, nil)
 	chaincodeStub.DelStateReturnsOnCall(0, fmt.Errorf("unable to delete contractId from world state"))
-	err = interopcc.ClaimFungibleAsset(ctx, contractId, base64.StdEncoding.EncodeToString(claimInfoBytes))
+	_, err = interopcc.ClaimFungibleAsset(ctx, contractId, base64.StdEncoding.EncodeToString(claimInfoBytes))
 	require.Error(t, err)
 	require.EqualError(t, err, "failed to delete the contractId " +
 		contractId + " as part of fungible asset claim: unable to delete contractId from world state")
@@ -959,12 +1133,12 @@ func TestClaimFungibleAsset(t *testing.T) {
 	// Test success with asset being claimed using contractId
 	chaincodeStub.GetStateReturnsOnCall(5, assetLockValBytes, nil)
 	chaincodeStub.DelStateReturnsOnCall(1, nil)
-	err = interopcc.ClaimFungibleAsset(ctx, contractId, base64.StdEncoding.EncodeToString(claimInfoBytes))
+	_, err = interopcc.ClaimFungibleAsset(ctx, contractId, base64.StdEncoding.EncodeToString(claimInfoBytes))
 	require.NoError(t, err)
 	fmt.Printf("Test success as expected since a valid contractId is specified.\n")
 
 	// Test failure with asset agreement specified not properly
-	err = interopcc.ClaimFungibleAsset(ctx, contractId, base64.StdEncoding.EncodeToString(claimInfoBytes))
+	_, err = interopcc.ClaimFungibleAsset(ctx, contractId, base64.StdEncoding.EncodeToString(claimInfoBytes))
 	require.Error(t, err)
 	log.Info(fmt.Println("Test failed as expected with error:", err))
 }
@@ -1036,3 +1210,733 @@ func TestUnlockFungibleAsset(t *testing.T) {
 	require.NoError(t, err)
 	fmt.Printf("Test success as expected since a valid contractId is specified.\n")
 }
+
+func TestLockedAssetQueries(t *testing.T) {
+	ctx, mockStub, interopcc := prepShimMockStub()
+
+	locker := getTxCreatorECertBase64()
+	hashBase64 := generateSHA256HashInBase64Form("abcd")
+	currentTimeSecs := uint64(time.Now().Unix())
+
+	// lock a bond for Bob and 10 (in two locks) and 20 units of cbdc for Bob and Alice respectively
+	bondAgreement := &common.AssetExchangeAgreement {
+		Type: "bond",
+		Id: "A001",
+		Recipient: "Bob",
+		Locker: locker,
+	}
+	bondAgreementBytes, _ := proto.Marshal(bondAgreement)
+	mockStub.MockTransactionStart("tx1")
+	bondContractId, err := interopcc.LockAsset(ctx, base64.StdEncoding.EncodeToString(bondAgreementBytes), getHTLCLockInfoBase64(hashBase64, currentTimeSecs + 2 * defaultTimeLockSecs))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx1")
+
+	lockFungible := func(txId string, recipient string, numUnits uint64, expiryTimeSecs uint64) string {
+		assetAgreement := &common.FungibleAssetExchangeAgreement {
+			Type: "cbdc",
+			NumUnits: numUnits,
+			Locker: locker,
+			Recipient: recipient,
+		}
+		assetAgreementBytes, _ := proto.Marshal(assetAgreement)
+		mockStub.MockTransactionStart(txId)
+		contractId, err := interopcc.LockFungibleAsset(ctx, base64.StdEncoding.EncodeToString(assetAgreementBytes), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+		require.NoError(t, err)
+		mockStub.MockTransactionEnd(txId)
+		return contractId
+	}
+	bobContractId := lockFungible("tx2", "Bob", 10, currentTimeSecs + 3 * defaultTimeLockSecs)
+	lockFungible("tx3", "Bob", 10, currentTimeSecs + defaultTimeLockSecs)
+	lockFungible("tx4", "Alice", 20, currentTimeSecs + 4 * defaultTimeLockSecs)
+
+	// Test success with the total units of cbdc locked
+	totalUnits, err := interopcc.GetTotalFungibleLockedAssets(ctx, "cbdc")
+	require.NoError(t, err)
+	require.Equal(t, uint64(40), totalUnits)
+	totalUnits, err = interopcc.GetTotalFungibleLockedAssets(ctx, "token")
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), totalUnits)
+
+	// Test success with listings of the locks held by the caller, for an explicit and an arbitrary recipient
+	lockedAssets, err := interopcc.GetAllLockedAssets(ctx, "Bob", "")
+	require.NoError(t, err)
+	require.Len(t, lockedAssets, 3)
+	lockedAssets, err = interopcc.GetAllLockedAssets(ctx, "*", getCreator())
+	require.NoError(t, err)
+	require.Len(t, lockedAssets, 4)
+	lockedAssets, err = interopcc.GetAllNonFungibleLockedAssets(ctx, "Bob", locker)
+	require.NoError(t, err)
+	require.Len(t, lockedAssets, 1)
+	lockedAssetInfo := LockedAssetInfo{}
+	err = json.Unmarshal([]byte(lockedAssets[0]), &lockedAssetInfo)
+	require.NoError(t, err)
+	require.Equal(t, LockedAssetInfo{ContractId: bondContractId, Type: "bond", Id: "A001", Locker: locker, Recipient: "Bob",
+		ExpiryTimeSecs: currentTimeSecs + 2 * defaultTimeLockSecs}, lockedAssetInfo)
+	lockedAssets, err = interopcc.GetAllFungibleLockedAssets(ctx, "Alice", "*")
+	require.NoError(t, err)
+	require.Len(t, lockedAssets, 1)
+	lockedAssets, err = interopcc.GetAllFungibleLockedAssets(ctx, "Carol", "*")
+	require.NoError(t, err)
+	require.Len(t, lockedAssets, 0)
+
+	// Test failure with both locker and recipient being arbitrary
+	_, err = interopcc.GetAllLockedAssets(ctx, "*", "*")
+	require.EqualError(t, err, "invalid query: both locker and recipient are arbitrary")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with the expiry times of the locks
+	timeToRelease, err := interopcc.GetAssetTimeToRelease(ctx, "bond", "A001", "Bob", "")
+	require.NoError(t, err)
+	require.Equal(t, currentTimeSecs + 2 * defaultTimeLockSecs, timeToRelease)
+	timeToRelease, err = interopcc.GetFungibleAssetTimeToRelease(ctx, "cbdc", 10, "Bob", "")
+	require.NoError(t, err)
+	require.Equal(t, currentTimeSecs + defaultTimeLockSecs, timeToRelease)
+
+	timeToRelease, err = interopcc.GetAssetTimeToReleaseUsingContractId(ctx, bondContractId)
+	require.NoError(t, err)
+	require.Equal(t, currentTimeSecs + 2 * defaultTimeLockSecs, timeToRelease)
+	timeToRelease, err = interopcc.GetFungibleAssetTimeToReleaseUsingContractId(ctx, bobContractId)
+	require.NoError(t, err)
+	require.Equal(t, currentTimeSecs + 3 * defaultTimeLockSecs, timeToRelease)
+
+	// Test failure with asset not locked, or locked for a different recipient
+	_, err = interopcc.GetAssetTimeToRelease(ctx, "bond", "A002", "Bob", "")
+	require.EqualError(t, err, "no asset of type bond and ID A002 is locked")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	_, err = interopcc.GetAssetTimeToRelease(ctx, "bond", "A001", "Alice", "")
+	require.Error(t, err)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	_, err = interopcc.GetFungibleAssetTimeToRelease(ctx, "cbdc", 20, "Bob", "")
+	require.Error(t, err)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with the locks expiring by a given time
+	lockedAssets, err = interopcc.GetAllAssetsLockedUntil(ctx, currentTimeSecs + 2 * defaultTimeLockSecs)
+	require.NoError(t, err)
+	require.Len(t, lockedAssets, 2)
+	lockedAssets, err = interopcc.GetAllAssetsLockedUntil(ctx, currentTimeSecs)
+	require.NoError(t, err)
+	require.Len(t, lockedAssets, 0)
+
+	// Test success with the indexes being cleaned up once a lock is claimed
+	selfContractId := lockFungible("tx5", locker, 5, currentTimeSecs + defaultTimeLockSecs)
+	totalUnits, err = interopcc.GetTotalFungibleLockedAssets(ctx, "cbdc")
+	require.NoError(t, err)
+	require.Equal(t, uint64(45), totalUnits)
+	claimInfoHTLC := &common.AssetClaimHTLC {
+		HashPreimageBase64: []byte(base64.StdEncoding.EncodeToString([]byte("abcd"))),
+	}
+	claimInfoHTLCBytes, _ := proto.Marshal(claimInfoHTLC)
+	claimInfo := &common.AssetClaim {
+		LockMechanism: common.LockMechanism_HTLC,
+		ClaimInfo: claimInfoHTLCBytes,
+	}
+	claimInfoBytes, _ := proto.Marshal(claimInfo)
+	mockStub.MockTransactionStart("tx6")
+	_, err = interopcc.ClaimFungibleAsset(ctx, selfContractId, base64.StdEncoding.EncodeToString(claimInfoBytes))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx6")
+	totalUnits, err = interopcc.GetTotalFungibleLockedAssets(ctx, "cbdc")
+	require.NoError(t, err)
+	require.Equal(t, uint64(40), totalUnits)
+	lockedAssets, err = interopcc.GetAllLockedAssets(ctx, "*", locker)
+	require.NoError(t, err)
+	require.Len(t, lockedAssets, 4)
+	lockedAssets, err = interopcc.GetAllLockedAssets(ctx, locker, "*")
+	require.NoError(t, err)
+	require.Len(t, lockedAssets, 0)
+}
+
+// MockStub that serves paginated queries of composite keys, which shimtest.MockStub does not implement; the bookmark
+// of a page is the key of the first entry of the next page
+type paginatedMockStub struct {
+	*shimtest.MockStub
+}
+
+func (stub *paginatedMockStub) GetStateByPartialCompositeKeyWithPagination(objectType string, keys []string,
+	pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	resultsIterator, err := stub.GetStateByPartialCompositeKey(objectType, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	return getResultsPage(resultsIterator, pageSize, bookmark)
+}
+
+func (stub *paginatedMockStub) GetStateByRangeWithPagination(startKey, endKey string,
+	pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	resultsIterator, err := stub.GetStateByRange(startKey, endKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return getResultsPage(resultsIterator, pageSize, bookmark)
+}
+
+// function that reads the page of the results starting at the bookmark, as the peer does for paginated queries
+func getResultsPage(resultsIterator shim.StateQueryIteratorInterface, pageSize int32,
+	bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	defer resultsIterator.Close()
+
+	page := []*queryresult.KV{}
+	nextBookmark := ""
+	for resultsIterator.HasNext() {
+		kv, err := resultsIterator.Next()
+		if err != nil {
+			return nil, nil, err
+		}
+		if kv.Key < bookmark {
+			continue
+		}
+		if len(page) == int(pageSize) {
+			nextBookmark = kv.Key
+			break
+		}
+		page = append(page, kv)
+	}
+
+	pageIterator := &mocks.StateQueryIterator{}
+	pageIterator.HasNextCalls(func() bool {
+		return len(page) > 0
+	})
+	pageIterator.NextCalls(func() (*queryresult.KV, error) {
+		kv := page[0]
+		page = page[1:]
+		return kv, nil
+	})
+	return pageIterator, &pb.QueryResponseMetadata{FetchedRecordsCount: int32(len(page)), Bookmark: nextBookmark}, nil
+}
+
+func TestLockedAssetQueriesWithPagination(t *testing.T) {
+	ctx, mockStub, interopcc := prepShimMockStub()
+
+	locker := getTxCreatorECertBase64()
+	hashBase64 := generateSHA256HashInBase64Form("abcd")
+	currentTimeSecs := uint64(time.Now().Unix())
+
+	// Test failure with the paginated queries not being supported by the stub
+	_, err := interopcc.GetAllLockedAssetsWithPagination(ctx, "Bob", "", 2, "")
+	require.EqualError(t, err, "paginated query of index AssetLockByLocker is not supported")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	ctx.GetStubReturns(&paginatedMockStub{mockStub})
+
+	// lock a bond and 5 lots of cbdc for Bob, and a lot of cbdc for Alice, with increasing expiry times
+	bondAgreement := &common.AssetExchangeAgreement {
+		Type: "bond",
+		Id: "A001",
+		Recipient: "Bob",
+		Locker: locker,
+	}
+	bondAgreementBytes, _ := proto.Marshal(bondAgreement)
+	mockStub.MockTransactionStart("tx0")
+	_, err = interopcc.LockAsset(ctx, base64.StdEncoding.EncodeToString(bondAgreementBytes), getHTLCLockInfoBase64(hashBase64, currentTimeSecs + defaultTimeLockSecs))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx0")
+	for i, recipient := range []string{"Bob", "Bob", "Bob", "Bob", "Bob", "Alice"} {
+		assetAgreement := &common.FungibleAssetExchangeAgreement {
+			Type: "cbdc",
+			NumUnits: uint64(10 * (i + 1)),
+			Locker: locker,
+			Recipient: recipient,
+		}
+		assetAgreementBytes, _ := proto.Marshal(assetAgreement)
+		txId := fmt.Sprintf("tx%d", i + 1)
+		mockStub.MockTransactionStart(txId)
+		_, err = interopcc.LockFungibleAsset(ctx, base64.StdEncoding.EncodeToString(assetAgreementBytes),
+			getHTLCLockInfoBase64(hashBase64, currentTimeSecs + uint64(i + 2) * defaultTimeLockSecs))
+		require.NoError(t, err)
+		mockStub.MockTransactionEnd(txId)
+	}
+
+	// function that follows the bookmarks of a paginated query to the end, and returns the sizes of the pages
+	fetchAllPages := func(query func(bookmark string) (*LockedAssetsPage, error)) ([]string, []int) {
+		lockedAssets := []string{}
+		pageSizes := []int{}
+		bookmark := ""
+		for {
+			page, err := query(bookmark)
+			require.NoError(t, err)
+			lockedAssets = append(lockedAssets, page.LockedAssets...)
+			pageSizes = append(pageSizes, len(page.LockedAssets))
+			if page.Bookmark == "" {
+				return lockedAssets, pageSizes
+			}
+			bookmark = page.Bookmark
+		}
+	}
+
+	// Test success with paginated listings matching the unpaginated ones
+	allLockedAssets, err := interopcc.GetAllLockedAssets(ctx, "Bob", "*")
+	require.NoError(t, err)
+	require.Len(t, allLockedAssets, 6)
+	lockedAssets, pageSizes := fetchAllPages(func(bookmark string) (*LockedAssetsPage, error) {
+		return interopcc.GetAllLockedAssetsWithPagination(ctx, "Bob", "*", 4, bookmark)
+	})
+	require.Equal(t, allLockedAssets, lockedAssets)
+	require.Equal(t, []int{4, 2}, pageSizes)
+	lockedAssets, pageSizes = fetchAllPages(func(bookmark string) (*LockedAssetsPage, error) {
+		return interopcc.GetAllFungibleLockedAssetsWithPagination(ctx, "Bob", locker, 2, bookmark)
+	})
+	require.Len(t, lockedAssets, 5)
+	require.Len(t, pageSizes, 3)
+	lockedAssets, _ = fetchAllPages(func(bookmark string) (*LockedAssetsPage, error) {
+		return interopcc.GetAllNonFungibleLockedAssetsWithPagination(ctx, "*", locker, 2, bookmark)
+	})
+	require.Len(t, lockedAssets, 1)
+
+	// Test success with a page holding fewer locks than index entries looked up, once filtered on the recipient
+	page, err := interopcc.GetAllLockedAssetsWithPagination(ctx, "Alice", locker, 7, "")
+	require.NoError(t, err)
+	require.Len(t, page.LockedAssets, 1)
+	require.Equal(t, int32(7), page.FetchedRecordsCount)
+
+	// Test success with the paginated listing of the locks expiring by a given time, which only reads the index up to that time
+	lockedAssets, pageSizes = fetchAllPages(func(bookmark string) (*LockedAssetsPage, error) {
+		return interopcc.GetAllAssetsLockedUntilWithPagination(ctx, currentTimeSecs + 3 * defaultTimeLockSecs, 2, bookmark)
+	})
+	require.Len(t, lockedAssets, 3)
+	require.Equal(t, []int{2, 1}, pageSizes)
+	page, err = interopcc.GetAllAssetsLockedUntilWithPagination(ctx, currentTimeSecs + 3 * defaultTimeLockSecs, 5, "")
+	require.NoError(t, err)
+	require.Len(t, page.LockedAssets, 3)
+	require.Equal(t, int32(3), page.FetchedRecordsCount)
+	require.Equal(t, "", page.Bookmark)
+
+	// Test failure with an invalid page size
+	_, err = interopcc.GetAllLockedAssetsWithPagination(ctx, "Bob", "", 0, "")
+	require.EqualError(t, err, "invalid page size 0")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+}
+
+func TestGetHTLCHashPreImage(t *testing.T) {
+	ctx, mockStub, interopcc := prepShimMockStub()
+
+	claimer := getTxCreatorECertBase64()
+	preimage := "abcd"
+	hashBase64 := generateSHA256HashInBase64Form(preimage)
+	preimageBase64 := base64.StdEncoding.EncodeToString([]byte(preimage))
+	currentTimeSecs := uint64(time.Now().Unix())
+	claimInfoHTLC := &common.AssetClaimHTLC {
+		HashPreimageBase64: []byte(preimageBase64),
+	}
+	claimInfoHTLCBytes, _ := proto.Marshal(claimInfoHTLC)
+	claimInfo := &common.AssetClaim {
+		LockMechanism: common.LockMechanism_HTLC,
+		ClaimInfo: claimInfoHTLCBytes,
+	}
+	claimInfoBytes, _ := proto.Marshal(claimInfo)
+
+	// lock a bond and 10 units of cbdc with the transaction creator being both the locker and the recipient
+	bondAgreement := &common.AssetExchangeAgreement {
+		Type: "bond",
+		Id: "A001",
+		Recipient: claimer,
+		Locker: claimer,
+	}
+	bondAgreementBytes, _ := proto.Marshal(bondAgreement)
+	mockStub.MockTransactionStart("tx1")
+	bondContractId, err := interopcc.LockAsset(ctx, base64.StdEncoding.EncodeToString(bondAgreementBytes), getHTLCLockInfoBase64(hashBase64, currentTimeSecs + defaultTimeLockSecs))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx1")
+	cbdcAgreement := &common.FungibleAssetExchangeAgreement {
+		Type: "cbdc",
+		NumUnits: 10,
+		Recipient: claimer,
+		Locker: claimer,
+	}
+	cbdcAgreementBytes, _ := proto.Marshal(cbdcAgreement)
+	mockStub.MockTransactionStart("tx2")
+	cbdcContractId, err := interopcc.LockFungibleAsset(ctx, base64.StdEncoding.EncodeToString(cbdcAgreementBytes), getHTLCLockInfoBase64(hashBase64, currentTimeSecs + defaultTimeLockSecs))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx2")
+
+	// Test failure with the HTLC not yet claimed
+	_, err = interopcc.GetHTLCHashPreImage(ctx, bondContractId)
+	require.EqualError(t, err, "no claimed HTLC is associated with contractId " + bondContractId)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with the preimage, claimer and claim time recorded on claim
+	mockStub.MockTransactionStart("tx3")
+	err = interopcc.ClaimAssetUsingContractId(ctx, bondContractId, base64.StdEncoding.EncodeToString(claimInfoBytes))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx3")
+	hashPreimageBase64, err := interopcc.GetHTLCHashPreImage(ctx, bondContractId)
+	require.NoError(t, err)
+	require.Equal(t, preimageBase64, hashPreimageBase64)
+	claimedContractValBytes, _ := mockStub.GetState(claimedContractIdPrefix + bondContractId)
+	claimedContractVal := ClaimedContractValue{}
+	err = json.Unmarshal(claimedContractValBytes, &claimedContractVal)
+	require.NoError(t, err)
+	require.Equal(t, claimer, claimedContractVal.Claimer)
+	require.LessOrEqual(t, currentTimeSecs, claimedContractVal.ClaimTimeSecs)
+
+	mockStub.MockTransactionStart("tx4")
+	_, err = interopcc.ClaimFungibleAsset(ctx, cbdcContractId, base64.StdEncoding.EncodeToString(claimInfoBytes))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx4")
+	hashPreimageBase64, err = interopcc.GetHTLCHashPreImage(ctx, cbdcContractId)
+	require.NoError(t, err)
+	require.Equal(t, preimageBase64, hashPreimageBase64)
+
+	// Test failure with the bond locked again (under the same default contractId) but not yet claimed: the claim of
+	// the earlier lock is no longer reported
+	newPreimage := "efgh"
+	newPreimageBase64 := base64.StdEncoding.EncodeToString([]byte(newPreimage))
+	mockStub.MockTransactionStart("tx5")
+	relockContractId, err := interopcc.LockAsset(ctx, base64.StdEncoding.EncodeToString(bondAgreementBytes), getHTLCLockInfoBase64(generateSHA256HashInBase64Form(newPreimage), currentTimeSecs + defaultTimeLockSecs))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx5")
+	require.Equal(t, bondContractId, relockContractId)
+	_, err = interopcc.GetHTLCHashPreImage(ctx, bondContractId)
+	require.EqualError(t, err, "no claimed HTLC is associated with contractId " + bondContractId)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with the preimage of the new lock reported once it is claimed
+	newClaimInfoHTLCBytes, _ := proto.Marshal(&common.AssetClaimHTLC{HashPreimageBase64: []byte(newPreimageBase64)})
+	newClaimInfoBytes, _ := proto.Marshal(&common.AssetClaim{LockMechanism: common.LockMechanism_HTLC, ClaimInfo: newClaimInfoHTLCBytes})
+	mockStub.MockTransactionStart("tx6")
+	err = interopcc.ClaimAssetUsingContractId(ctx, bondContractId, base64.StdEncoding.EncodeToString(newClaimInfoBytes))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx6")
+	hashPreimageBase64, err = interopcc.GetHTLCHashPreImage(ctx, bondContractId)
+	require.NoError(t, err)
+	require.Equal(t, newPreimageBase64, hashPreimageBase64)
+
+	// Test failure with an unknown contractId
+	_, err = interopcc.GetHTLCHashPreImage(ctx, "unknown-contract-id")
+	require.EqualError(t, err, "no claimed HTLC is associated with contractId unknown-contract-id")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+}
+
+// function that supplies the base64 encoded lock information for an escrow lock
+func getEscrowLockInfoBase64(arbiter string, expiryTimeSecs uint64) string {
+	lockInfoEscrow := &common.AssetLockEscrow {
+		Arbiter: arbiter,
+		ExpiryTimeSecs: expiryTimeSecs,
+		TimeSpec: common.AssetLockHTLC_EPOCH,
+	}
+	lockInfoEscrowBytes, _ := proto.Marshal(lockInfoEscrow)
+	lockInfo := &common.AssetLock {
+		LockMechanism: common.LockMechanism_ESCROW,
+		LockInfo: lockInfoEscrowBytes,
+	}
+	lockInfoBytes, _ := proto.Marshal(lockInfo)
+	return base64.StdEncoding.EncodeToString(lockInfoBytes)
+}
+
+// function that supplies the base64 encoded claim information carrying the signature of an escrow arbiter
+func getEscrowClaimInfoBase64(arbiterSignature []byte) string {
+	claimInfoEscrow := &common.AssetClaimEscrow {
+		ArbiterSignature: arbiterSignature,
+	}
+	claimInfoEscrowBytes, _ := proto.Marshal(claimInfoEscrow)
+	claimInfo := &common.AssetClaim {
+		LockMechanism: common.LockMechanism_ESCROW,
+		ClaimInfo: claimInfoEscrowBytes,
+	}
+	claimInfoBytes, _ := proto.Marshal(claimInfo)
+	return base64.StdEncoding.EncodeToString(claimInfoBytes)
+}
+
+// function that signs the escrow release message for a contract with the arbiter key
+func signEscrowRelease(t *testing.T, key *ecdsa.PrivateKey, contractId string, expiryTimeSecs uint64) []byte {
+	hashed, err := computeSHA2Hash([]byte(getEscrowReleaseMessage(contractId, expiryTimeSecs)), key.PublicKey.Params().BitSize)
+	require.NoError(t, err)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hashed)
+	require.NoError(t, err)
+	return signature
+}
+
+func TestEscrowLock(t *testing.T) {
+	ctx, mockStub, interopcc := prepShimMockStub()
+
+	template := x509.Certificate{
+		Subject: pkix.Name{
+			CommonName: "arbiter.example.com",
+		},
+		SerialNumber: big.NewInt(1337),
+	}
+	arbiterCertBytes, arbiterKey, err := createECDSACertAndKeyFromTemplate(template)
+	require.NoError(t, err)
+	arbiter := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: arbiterCertBytes}))
+	_, otherKey, err := createECDSACertAndKeyFromTemplate(template)
+	require.NoError(t, err)
+
+	locker := getTxCreatorECertBase64()
+	recipient := getTxCreatorECertBase64()
+	expiryTimeSecs := uint64(time.Now().Unix()) + defaultTimeLockSecs
+
+	bondAgreement := &common.AssetExchangeAgreement {
+		Type: "bond",
+		Id: "A001",
+		Recipient: recipient,
+		Locker: locker,
+	}
+	bondAgreementBytes, _ := proto.Marshal(bondAgreement)
+	bondAgreementBase64 := base64.StdEncoding.EncodeToString(bondAgreementBytes)
+
+	// Test failure with an empty arbiter
+	mockStub.MockTransactionStart("tx1")
+	_, err = interopcc.LockAsset(ctx, bondAgreementBase64, getEscrowLockInfoBase64("", expiryTimeSecs))
+	require.EqualError(t, err, "empty escrow arbiter")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with an arbiter that is not a valid certificate
+	_, err = interopcc.LockAsset(ctx, bondAgreementBase64, getEscrowLockInfoBase64(base64.StdEncoding.EncodeToString([]byte("not-a-cert")), expiryTimeSecs))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid escrow arbiter certificate")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with a valid arbiter
+	bondContractId, err := interopcc.LockAsset(ctx, bondAgreementBase64, getEscrowLockInfoBase64(arbiter, expiryTimeSecs))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx1")
+	isLocked, err := interopcc.IsAssetLockedQueryUsingContractId(ctx, bondContractId)
+	require.NoError(t, err)
+	require.True(t, isLocked)
+
+	// Test failure with an HTLC claim on an escrow lock
+	claimInfoHTLC := &common.AssetClaimHTLC {
+		HashPreimageBase64: []byte(base64.StdEncoding.EncodeToString([]byte("abcd"))),
+	}
+	claimInfoHTLCBytes, _ := proto.Marshal(claimInfoHTLC)
+	claimInfo := &common.AssetClaim {
+		LockMechanism: common.LockMechanism_HTLC,
+		ClaimInfo: claimInfoHTLCBytes,
+	}
+	claimInfoBytes, _ := proto.Marshal(claimInfo)
+	mockStub.MockTransactionStart("tx2")
+	err = interopcc.ClaimAssetUsingContractId(ctx, bondContractId, base64.StdEncoding.EncodeToString(claimInfoBytes))
+	require.EqualError(t, err, "claim asset associated with contractId " + bondContractId + " failed with error: asset is not locked using the HTLC lock mechanism")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with the release signed by a key other than the arbiter's
+	err = interopcc.ClaimAssetUsingContractId(ctx, bondContractId, getEscrowClaimInfoBase64(signEscrowRelease(t, otherKey, bondContractId, expiryTimeSecs)))
+	require.EqualError(t, err, "cannot claim asset associated with contractId " + bondContractId + " as it is not released by the escrow arbiter")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with the arbiter signature bound to a different contract
+	err = interopcc.ClaimAssetUsingContractId(ctx, bondContractId, getEscrowClaimInfoBase64(signEscrowRelease(t, arbiterKey, "other-contract-id", expiryTimeSecs)))
+	require.EqualError(t, err, "cannot claim asset associated with contractId " + bondContractId + " as it is not released by the escrow arbiter")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with the release signed by the arbiter
+	err = interopcc.ClaimAssetUsingContractId(ctx, bondContractId, getEscrowClaimInfoBase64(signEscrowRelease(t, arbiterKey, bondContractId, expiryTimeSecs)))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx2")
+	isLocked, err = interopcc.IsAssetLockedQueryUsingContractId(ctx, bondContractId)
+	require.EqualError(t, err, "no contractId " + bondContractId + " exists on the ledger")
+	require.False(t, isLocked)
+	// an escrow claim does not reveal any hash preimage
+	_, err = interopcc.GetHTLCHashPreImage(ctx, bondContractId)
+	require.EqualError(t, err, "no claimed HTLC is associated with contractId " + bondContractId)
+
+	// Test success with a fungible escrow lock released by the arbiter
+	cbdcAgreement := &common.FungibleAssetExchangeAgreement {
+		Type: "cbdc",
+		NumUnits: 10,
+		Recipient: recipient,
+		Locker: locker,
+	}
+	cbdcAgreementBytes, _ := proto.Marshal(cbdcAgreement)
+	mockStub.MockTransactionStart("tx3")
+	cbdcContractId, err := interopcc.LockFungibleAsset(ctx, base64.StdEncoding.EncodeToString(cbdcAgreementBytes), getEscrowLockInfoBase64(arbiter, expiryTimeSecs))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx3")
+	mockStub.MockTransactionStart("tx4")
+	_, err = interopcc.ClaimFungibleAsset(ctx, cbdcContractId, getEscrowClaimInfoBase64(signEscrowRelease(t, otherKey, cbdcContractId, expiryTimeSecs)))
+	require.EqualError(t, err, "cannot claim fungible asset associated with contractId " + cbdcContractId + " as it is not released by the escrow arbiter")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	_, err = interopcc.ClaimFungibleAsset(ctx, cbdcContractId, getEscrowClaimInfoBase64(signEscrowRelease(t, arbiterKey, cbdcContractId, expiryTimeSecs)))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx4")
+	isLocked, err = interopcc.IsFungibleAssetLocked(ctx, cbdcContractId)
+	require.Error(t, err)
+	require.False(t, isLocked)
+}
+
+func TestPartialClaimFungibleAsset(t *testing.T) {
+	ctx, mockStub, interopcc := prepShimMockStub()
+
+	claimer := getTxCreatorECertBase64()
+	preimage := "abcd"
+	hashBase64 := generateSHA256HashInBase64Form(preimage)
+	preimageBase64 := base64.StdEncoding.EncodeToString([]byte(preimage))
+	currentTimeSecs := uint64(time.Now().Unix())
+	getClaimInfoBase64 := func(numUnits uint64) string {
+		claimInfoHTLC := &common.AssetClaimHTLC {
+			HashPreimageBase64: []byte(preimageBase64),
+			NumUnits: numUnits,
+		}
+		claimInfoHTLCBytes, _ := proto.Marshal(claimInfoHTLC)
+		claimInfo := &common.AssetClaim {
+			LockMechanism: common.LockMechanism_HTLC,
+			ClaimInfo: claimInfoHTLCBytes,
+		}
+		claimInfoBytes, _ := proto.Marshal(claimInfo)
+		return base64.StdEncoding.EncodeToString(claimInfoBytes)
+	}
+
+	// lock 100 units of cbdc with the transaction creator being both the locker and the recipient
+	cbdcAgreement := &common.FungibleAssetExchangeAgreement {
+		Type: "cbdc",
+		NumUnits: 100,
+		Recipient: claimer,
+		Locker: claimer,
+	}
+	cbdcAgreementBytes, _ := proto.Marshal(cbdcAgreement)
+	mockStub.MockTransactionStart("tx1")
+	contractId, err := interopcc.LockFungibleAsset(ctx, base64.StdEncoding.EncodeToString(cbdcAgreementBytes), getHTLCLockInfoBase64(hashBase64, currentTimeSecs + defaultTimeLockSecs))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx1")
+
+	// Test failure with more units claimed than are locked
+	mockStub.MockTransactionStart("tx2")
+	claimedUnits, err := interopcc.ClaimFungibleAsset(ctx, contractId, getClaimInfoBase64(101))
+	require.EqualError(t, err, "cannot claim fungible asset associated with contractId " + contractId + ": cannot claim 101 units as only 100 units are locked")
+	require.Equal(t, uint64(0), claimedUnits)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with a partial claim leaving the remaining units locked
+	claimedUnits, err = interopcc.ClaimFungibleAsset(ctx, contractId, getClaimInfoBase64(30))
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), claimedUnits)
+	mockStub.MockTransactionEnd("tx2")
+	isLocked, err := interopcc.IsFungibleAssetLocked(ctx, contractId)
+	require.NoError(t, err)
+	require.True(t, isLocked)
+	totalUnits, err := interopcc.GetTotalFungibleLockedAssets(ctx, "cbdc")
+	require.NoError(t, err)
+	require.Equal(t, uint64(70), totalUnits)
+	hashPreimageBase64, err := interopcc.GetHTLCHashPreImage(ctx, contractId)
+	require.NoError(t, err)
+	require.Equal(t, preimageBase64, hashPreimageBase64)
+
+	// Test success with a claim of all the remaining units releasing the lock
+	mockStub.MockTransactionStart("tx3")
+	claimedUnits, err = interopcc.ClaimFungibleAsset(ctx, contractId, getClaimInfoBase64(0))
+	require.NoError(t, err)
+	require.Equal(t, uint64(70), claimedUnits)
+	mockStub.MockTransactionEnd("tx3")
+	isLocked, err = interopcc.IsFungibleAssetLocked(ctx, contractId)
+	require.Error(t, err)
+	require.False(t, isLocked)
+	totalUnits, err = interopcc.GetTotalFungibleLockedAssets(ctx, "cbdc")
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), totalUnits)
+}
+
+// function that supplies the base64 encoded lock extension info, signing the extension message with the given key
+func getLockExtensionInfoBase64(t *testing.T, key *ecdsa.PrivateKey, contractId string, expiryTimeSecs uint64, newExpiryTimeSecs uint64) string {
+	hashed, err := computeSHA2Hash([]byte(getLockExtensionMessage(contractId, expiryTimeSecs, newExpiryTimeSecs)), key.PublicKey.Params().BitSize)
+	require.NoError(t, err)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hashed)
+	require.NoError(t, err)
+	extensionInfo := &common.AssetLockExtension{
+		ContractId:         contractId,
+		NewExpiryTimeSecs:  newExpiryTimeSecs,
+		RecipientSignature: signature,
+	}
+	extensionInfoBytes, _ := proto.Marshal(extensionInfo)
+	return base64.StdEncoding.EncodeToString(extensionInfoBytes)
+}
+
+func TestExtendLockExpiry(t *testing.T) {
+	ctx, mockStub, interopcc := prepShimMockStub()
+
+	template := x509.Certificate{
+		Subject: pkix.Name{
+			CommonName: "recipient.example.com",
+		},
+		SerialNumber: big.NewInt(1337),
+	}
+	recipientCertBytes, recipientKey, err := createECDSACertAndKeyFromTemplate(template)
+	require.NoError(t, err)
+	recipient := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: recipientCertBytes}))
+	_, otherKey, err := createECDSACertAndKeyFromTemplate(template)
+	require.NoError(t, err)
+
+	locker := getTxCreatorECertBase64()
+	hashBase64 := generateSHA256HashInBase64Form("abcd")
+	currentTimeSecs := uint64(time.Now().Unix())
+	expiryTimeSecs := currentTimeSecs + defaultTimeLockSecs
+	newExpiryTimeSecs := expiryTimeSecs + defaultTimeLockSecs
+
+	bondAgreement := &common.AssetExchangeAgreement{
+		Type:      "bond",
+		Id:        "A001",
+		Recipient: recipient,
+		Locker:    locker,
+	}
+	bondAgreementBytes, _ := proto.Marshal(bondAgreement)
+	tokenAgreement := &common.FungibleAssetExchangeAgreement{
+		Type:      "cbdc",
+		NumUnits:  50,
+		Recipient: recipient,
+		Locker:    locker,
+	}
+	tokenAgreementBytes, _ := proto.Marshal(tokenAgreement)
+
+	mockStub.MockTransactionStart("tx1")
+	bondContractId, err := interopcc.LockAsset(ctx, base64.StdEncoding.EncodeToString(bondAgreementBytes), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+	require.NoError(t, err)
+	tokenContractId, err := interopcc.LockFungibleAsset(ctx, base64.StdEncoding.EncodeToString(tokenAgreementBytes), getHTLCLockInfoBase64(hashBase64, expiryTimeSecs))
+	require.NoError(t, err)
+	// the bundle expires after the other locks are extended, so that it stays out of the expiry queries below
+	bundleExpiryTimeSecs := newExpiryTimeSecs + defaultTimeLockSecs
+	bundleContractId, err := interopcc.LockAssetBundle(ctx, getAssetBundleAgreementBase64([]*common.AssetBundleItem{{Type: "bond", Id: "A002"}}, locker, recipient), getHTLCLockInfoBase64(hashBase64, bundleExpiryTimeSecs))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx1")
+
+	// Test failure with an unknown contractId
+	mockStub.MockTransactionStart("tx2")
+	err = interopcc.ExtendLockExpiry(ctx, "unknown-contract-id", getLockExtensionInfoBase64(t, recipientKey, "unknown-contract-id", expiryTimeSecs, newExpiryTimeSecs))
+	require.EqualError(t, err, "no contractId unknown-contract-id exists on the ledger")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with an asset bundle lock, which cannot be extended
+	err = interopcc.ExtendLockExpiry(ctx, bundleContractId, getLockExtensionInfoBase64(t, recipientKey, bundleContractId, bundleExpiryTimeSecs, bundleExpiryTimeSecs+defaultTimeLockSecs))
+	require.EqualError(t, err, "cannot extend the lock associated with contractId "+bundleContractId+" as it is an asset bundle lock")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with the extension signed by a key other than the recipient's
+	err = interopcc.ExtendLockExpiry(ctx, bondContractId, getLockExtensionInfoBase64(t, otherKey, bondContractId, expiryTimeSecs, newExpiryTimeSecs))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "lock extension is not signed by the recipient")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with the recipient signature bound to the other contract
+	err = interopcc.ExtendLockExpiry(ctx, bondContractId, getLockExtensionInfoBase64(t, recipientKey, tokenContractId, expiryTimeSecs, newExpiryTimeSecs))
+	require.EqualError(t, err, "lock extension is meant for contractId "+tokenContractId+" and not "+bondContractId)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a new expiry time earlier than the current one
+	err = interopcc.ExtendLockExpiry(ctx, bondContractId, getLockExtensionInfoBase64(t, recipientKey, bondContractId, expiryTimeSecs, expiryTimeSecs-1))
+	require.EqualError(t, err, fmt.Sprintf("cannot extend the lock associated with contractId %s: new expiry time %d is not later than the current expiry time %d", bondContractId, expiryTimeSecs-1, expiryTimeSecs))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with the expiry time of both locks pushed out, along with the expiry index
+	err = interopcc.ExtendLockExpiry(ctx, bondContractId, getLockExtensionInfoBase64(t, recipientKey, bondContractId, expiryTimeSecs, newExpiryTimeSecs))
+	require.NoError(t, err)
+	err = interopcc.ExtendLockExpiry(ctx, tokenContractId, getLockExtensionInfoBase64(t, recipientKey, tokenContractId, expiryTimeSecs, newExpiryTimeSecs))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx2")
+	bondExpiryTimeSecs, err := interopcc.GetAssetTimeToReleaseUsingContractId(ctx, bondContractId)
+	require.NoError(t, err)
+	require.Equal(t, newExpiryTimeSecs, bondExpiryTimeSecs)
+	tokenExpiryTimeSecs, err := interopcc.GetFungibleAssetTimeToReleaseUsingContractId(ctx, tokenContractId)
+	require.NoError(t, err)
+	require.Equal(t, newExpiryTimeSecs, tokenExpiryTimeSecs)
+	lockedAssets, err := interopcc.GetAllAssetsLockedUntil(ctx, expiryTimeSecs)
+	require.NoError(t, err)
+	require.Len(t, lockedAssets, 0)
+	lockedAssets, err = interopcc.GetAllAssetsLockedUntil(ctx, newExpiryTimeSecs)
+	require.NoError(t, err)
+	require.Len(t, lockedAssets, 2)
+
+	// Test failure with a replay of the earlier extension, which was signed over the previous expiry time
+	mockStub.MockTransactionStart("tx3")
+	err = interopcc.ExtendLockExpiry(ctx, bondContractId, getLockExtensionInfoBase64(t, recipientKey, bondContractId, expiryTimeSecs, newExpiryTimeSecs))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "new expiry time")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	mockStub.MockTransactionEnd("tx3")
+}
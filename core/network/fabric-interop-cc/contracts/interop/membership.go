@@ -97,7 +97,11 @@ func (s *SmartContract) GetMembershipBySecurityDomain(ctx contractapi.Transactio
 // verifyMemberInSecurityDomain function verifies the identity of the requester according to
 // the Membership for the external network the request originated from.
 func verifyMemberInSecurityDomain(s *SmartContract, ctx contractapi.TransactionContextInterface, cert *x509.Certificate, securityDomain string, requestingOrg string) error {
-	err := isCertificateWithinExpiry(cert)
+	txTime, clockSkewTolerance, err := getTxTimeAndClockSkewTolerance(ctx)
+	if err != nil {
+		return err
+	}
+	err = isCertificateWithinExpiry(cert, txTime, clockSkewTolerance)
 	if err != nil {
 		return err
 	}
@@ -116,7 +120,7 @@ func verifyMemberInSecurityDomain(s *SmartContract, ctx contractapi.TransactionC
 	switch member.Type {
 	case "ca":
 		// TODO: Add check for if cert and member.Value are the same verifyCaCertificate(cert, member.Value, true)
-		err := verifyCaCertificate(cert, member.Value)
+		err := verifyCaCertificate(cert, member.Value, txTime, clockSkewTolerance)
 		if err != nil {
 			return err
 		}
@@ -125,7 +129,7 @@ func verifyMemberInSecurityDomain(s *SmartContract, ctx contractapi.TransactionC
 		if len(chain) == 0 {
 			chain = []string{member.Value}
 		}
-		err := verifyCertificateChain(cert, chain)
+		err := verifyCertificateChain(cert, chain, txTime, clockSkewTolerance)
 		if err != nil {
 			return err
 		}
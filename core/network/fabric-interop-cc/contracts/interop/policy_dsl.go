@@ -0,0 +1,362 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// policy_dsl contains the parser and evaluator of the verification policy DSL (see rfcs/formats/policy-dsl.md and
+// common/policy-dsl/parser/Policy.g4), in which the criteria of a verification policy are expressed as boolean
+// combinations of signers and signer counts, e.g., "Org1 && Org2 || count > 4"
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+)
+
+// policyDSLType is the Policy.Type naming the DSL; the criteria of such a policy are DSL expressions, all of which
+// need to be satisfied. The criteria of a policy of any other type (e.g., "Signature") list signers that are all required.
+const policyDSLType = "PolicyDSL"
+
+const policyCountKeyword = "count"
+
+type policyTokenKind int
+
+const (
+	policyTokenID policyTokenKind = iota
+	policyTokenCount
+	policyTokenInt
+	policyTokenAnd
+	policyTokenOr
+	policyTokenComparison
+	policyTokenEnd
+)
+
+type policyToken struct {
+	kind     policyTokenKind
+	text     string
+	position int
+}
+
+// policyExpression is a node of the syntax tree of a DSL expression, evaluated against the set of signers of a view
+type policyExpression interface {
+	evaluate(signers map[string]bool) bool
+	String() string
+}
+
+type policyAndExpression struct {
+	left, right policyExpression
+}
+
+type policyOrExpression struct {
+	left, right policyExpression
+}
+
+type policyIDExpression struct {
+	id string
+}
+
+// policyCountExpression compares the number of signers with a threshold; "3 < count" is held as "count > 3"
+type policyCountExpression struct {
+	comparison string
+	threshold  int
+}
+
+func (e *policyAndExpression) evaluate(signers map[string]bool) bool {
+	return e.left.evaluate(signers) && e.right.evaluate(signers)
+}
+
+func (e *policyAndExpression) String() string {
+	return fmt.Sprintf("(%s && %s)", e.left, e.right)
+}
+
+func (e *policyOrExpression) evaluate(signers map[string]bool) bool {
+	return e.left.evaluate(signers) || e.right.evaluate(signers)
+}
+
+func (e *policyOrExpression) String() string {
+	return fmt.Sprintf("(%s || %s)", e.left, e.right)
+}
+
+func (e *policyIDExpression) evaluate(signers map[string]bool) bool {
+	return signers[e.id]
+}
+
+func (e *policyIDExpression) String() string {
+	return e.id
+}
+
+func (e *policyCountExpression) evaluate(signers map[string]bool) bool {
+	count := len(signers)
+	switch e.comparison {
+	case ">":
+		return count > e.threshold
+	case ">=":
+		return count >= e.threshold
+	case "<":
+		return count < e.threshold
+	default:
+		return count <= e.threshold
+	}
+}
+
+func (e *policyCountExpression) String() string {
+	return fmt.Sprintf("%s %s %d", policyCountKeyword, e.comparison, e.threshold)
+}
+
+func isPolicyIDLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isPolicyIDStart(c byte) bool {
+	return c == '_' || isPolicyIDLetter(c)
+}
+
+// identifiers may hold '.' and '-' after their first letter, as the MSP IDs of Fabric organizations (e.g.,
+// "org1.example.com") and the names of Corda parties commonly do
+func isPolicyIDPart(c byte) bool {
+	return isPolicyIDStart(c) || (c >= '0' && c <= '9') || c == '.' || c == '-'
+}
+
+/*
+ * Function to split a DSL expression into tokens. Unlike the antlr grammar, identifiers may start with an upper-case
+ * letter, as the MSP IDs of Fabric organizations and the names of Corda parties commonly do (and as the examples of
+ * the RFC do).
+ */
+func tokenizePolicyExpression(expression string) ([]policyToken, error) {
+	tokens := []policyToken{}
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case strings.HasPrefix(expression[i:], "&&"):
+			tokens = append(tokens, policyToken{kind: policyTokenAnd, text: "&&", position: i})
+			i += 2
+		case strings.HasPrefix(expression[i:], "||"):
+			tokens = append(tokens, policyToken{kind: policyTokenOr, text: "||", position: i})
+			i += 2
+		case strings.HasPrefix(expression[i:], ">=") || strings.HasPrefix(expression[i:], "<="):
+			tokens = append(tokens, policyToken{kind: policyTokenComparison, text: expression[i : i+2], position: i})
+			i += 2
+		case c == '>' || c == '<':
+			tokens = append(tokens, policyToken{kind: policyTokenComparison, text: expression[i : i+1], position: i})
+			i++
+		case c >= '0' && c <= '9':
+			start := i
+			for i < len(expression) && expression[i] >= '0' && expression[i] <= '9' {
+				i++
+			}
+			if expression[start] == '0' && i-start > 1 {
+				return nil, fmt.Errorf("integer literal %s at position %d has a leading zero", expression[start:i], start)
+			}
+			tokens = append(tokens, policyToken{kind: policyTokenInt, text: expression[start:i], position: start})
+		case isPolicyIDStart(c):
+			start := i
+			for i < len(expression) && isPolicyIDPart(expression[i]) {
+				i++
+			}
+			letters := strings.TrimLeft(expression[start:i], "_")
+			if letters == "" {
+				return nil, fmt.Errorf("identifier %s at position %d has no letter", expression[start:i], start)
+			}
+			if !isPolicyIDLetter(letters[0]) {
+				return nil, fmt.Errorf("identifier %s at position %d does not start with a letter", expression[start:i], start)
+			}
+			kind := policyTokenID
+			if expression[start:i] == policyCountKeyword {
+				kind = policyTokenCount
+			}
+			tokens = append(tokens, policyToken{kind: kind, text: expression[start:i], position: start})
+		default:
+			return nil, fmt.Errorf("unexpected character '%c' at position %d", c, i)
+		}
+	}
+	return append(tokens, policyToken{kind: policyTokenEnd, position: len(expression)}), nil
+}
+
+// policyParser is a recursive descent parser over the tokens of a DSL expression; && binds tighter than ||, and both
+// associate to the left, as with the antlr grammar
+type policyParser struct {
+	tokens []policyToken
+	next   int
+}
+
+func (p *policyParser) peek() policyToken {
+	return p.tokens[p.next]
+}
+
+func (p *policyParser) consume() policyToken {
+	token := p.tokens[p.next]
+	if token.kind != policyTokenEnd {
+		p.next++
+	}
+	return token
+}
+
+func unexpectedPolicyTokenError(token policyToken) error {
+	if token.kind == policyTokenEnd {
+		return fmt.Errorf("unexpected end of expression")
+	}
+	return fmt.Errorf("unexpected token '%s' at position %d", token.text, token.position)
+}
+
+// orExpression : andExpression (OR andExpression)*
+func (p *policyParser) parseOrExpression() (policyExpression, error) {
+	left, err := p.parseAndExpression()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == policyTokenOr {
+		p.consume()
+		right, err := p.parseAndExpression()
+		if err != nil {
+			return nil, err
+		}
+		left = &policyOrExpression{left: left, right: right}
+	}
+	return left, nil
+}
+
+// andExpression : primaryExpression (AND primaryExpression)*
+func (p *policyParser) parseAndExpression() (policyExpression, error) {
+	left, err := p.parsePrimaryExpression()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == policyTokenAnd {
+		p.consume()
+		right, err := p.parsePrimaryExpression()
+		if err != nil {
+			return nil, err
+		}
+		left = &policyAndExpression{left: left, right: right}
+	}
+	return left, nil
+}
+
+// primaryExpression : ID | COUNT comparison INTLIT | INTLIT comparison COUNT
+func (p *policyParser) parsePrimaryExpression() (policyExpression, error) {
+	token := p.consume()
+	switch token.kind {
+	case policyTokenID:
+		return &policyIDExpression{id: token.text}, nil
+	case policyTokenCount:
+		comparison := p.consume()
+		if comparison.kind != policyTokenComparison {
+			return nil, unexpectedPolicyTokenError(comparison)
+		}
+		threshold, err := p.parseThreshold()
+		if err != nil {
+			return nil, err
+		}
+		return &policyCountExpression{comparison: comparison.text, threshold: threshold}, nil
+	case policyTokenInt:
+		p.next--
+		threshold, err := p.parseThreshold()
+		if err != nil {
+			return nil, err
+		}
+		comparison := p.consume()
+		if comparison.kind != policyTokenComparison {
+			return nil, unexpectedPolicyTokenError(comparison)
+		}
+		count := p.consume()
+		if count.kind != policyTokenCount {
+			return nil, unexpectedPolicyTokenError(count)
+		}
+		// swap the operands so that the count is on the left of the comparison
+		mirrored := map[string]string{">": "<", ">=": "<=", "<": ">", "<=": ">="}
+		return &policyCountExpression{comparison: mirrored[comparison.text], threshold: threshold}, nil
+	default:
+		return nil, unexpectedPolicyTokenError(token)
+	}
+}
+
+func (p *policyParser) parseThreshold() (int, error) {
+	token := p.consume()
+	if token.kind != policyTokenInt {
+		return 0, unexpectedPolicyTokenError(token)
+	}
+	threshold, err := strconv.Atoi(token.text)
+	if err != nil {
+		return 0, fmt.Errorf("integer literal %s at position %d is out of range", token.text, token.position)
+	}
+	return threshold, nil
+}
+
+// function to parse a DSL expression into its syntax tree
+func parsePolicyExpression(expression string) (policyExpression, error) {
+	tokens, err := tokenizePolicyExpression(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy expression '%s': %s", expression, err.Error())
+	}
+	parser := &policyParser{tokens: tokens}
+	parsedExpression, err := parser.parseOrExpression()
+	if err == nil && parser.peek().kind != policyTokenEnd {
+		err = unexpectedPolicyTokenError(parser.peek())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy expression '%s': %s", expression, err.Error())
+	}
+	return parsedExpression, nil
+}
+
+// function to parse the criteria of a policy of the DSL type
+func parsePolicyCriteria(policy *common.Policy) ([]policyExpression, error) {
+	if len(policy.Criteria) == 0 {
+		return nil, fmt.Errorf("policy of type %s has no criteria", policyDSLType)
+	}
+	expressions := []policyExpression{}
+	for _, criterion := range policy.Criteria {
+		expression, err := parsePolicyExpression(criterion)
+		if err != nil {
+			return nil, err
+		}
+		expressions = append(expressions, expression)
+	}
+	return expressions, nil
+}
+
+// function to check that the criteria of each policy of the DSL type in a verification policy are valid expressions
+func validateVerificationPolicyCriteria(verificationPolicy *common.VerificationPolicy) error {
+	for _, identifier := range verificationPolicy.Identifiers {
+		if identifier.Policy == nil || identifier.Policy.Type != policyDSLType {
+			continue
+		}
+		_, err := parsePolicyCriteria(identifier.Policy)
+		if err != nil {
+			return fmt.Errorf("Invalid policy for pattern %s: %s", identifier.Pattern, err.Error())
+		}
+	}
+	return nil
+}
+
+// function to check that the signers of a view (the orgs or parties whose signatures were verified) satisfy a policy
+func verifyPolicySatisfiedBySigners(policy *common.Policy, signerList []string) error {
+	if policy.Type != policyDSLType {
+		for _, signer := range policy.Criteria {
+			if !Contains(signerList, signer) {
+				return fmt.Errorf("Notarizations missing signer: %s", signer)
+			}
+		}
+		return nil
+	}
+	expressions, err := parsePolicyCriteria(policy)
+	if err != nil {
+		return err
+	}
+	signers := map[string]bool{}
+	for _, signer := range signerList {
+		signers[signer] = true
+	}
+	for i, expression := range expressions {
+		if !expression.evaluate(signers) {
+			return fmt.Errorf("Notarizations by %v do not satisfy the policy criterion: %s", signerList, policy.Criteria[i])
+		}
+	}
+	return nil
+}
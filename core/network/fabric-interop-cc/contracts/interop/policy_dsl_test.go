@@ -0,0 +1,118 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePolicyExpression(t *testing.T) {
+	// Test success with the precedence of && over || and the normalization of counts
+	for expression, expected := range map[string]string{
+		"Org1":                      "Org1",
+		"Org1 && Org2":              "(Org1 && Org2)",
+		"Org1 && count >= 3":        "(Org1 && count >= 3)",
+		"Org1 && Org2 || count > 4": "((Org1 && Org2) || count > 4)",
+		"org1 || org2 && org3":      "(org1 || (org2 && org3))",
+		"2 <= count":                "count >= 2",
+		"\tOrg1MSP&&_org_2":         "(Org1MSP && _org_2)",
+		"org1.example.com||Org-2":   "(org1.example.com || Org-2)",
+	} {
+		parsedExpression, err := parsePolicyExpression(expression)
+		require.NoError(t, err)
+		require.Equal(t, expected, parsedExpression.String())
+	}
+
+	// Test failure with malformed expressions
+	for expression, expectedErr := range map[string]string{
+		"":                             "unexpected end of expression",
+		"Org1 &&":                      "unexpected end of expression",
+		"Org1 Org2":                    "unexpected token 'Org2' at position 5",
+		"(Org1 || Org2)":               "unexpected character '(' at position 0",
+		"Org1 & Org2":                  "unexpected character '&' at position 5",
+		"count":                        "unexpected end of expression",
+		"count == 2":                   "unexpected character '=' at position 6",
+		"count > Org1":                 "unexpected token 'Org1' at position 8",
+		"3 > Org1":                     "unexpected token 'Org1' at position 4",
+		"count > 03":                   "integer literal 03 at position 8 has a leading zero",
+		"count > 99999999999999999999": "integer literal 99999999999999999999 at position 8 is out of range",
+		"Org1 && __":                   "identifier __ at position 8 has no letter",
+		"Org1 && _-org2":               "identifier _-org2 at position 8 does not start with a letter",
+		"Org1 && .org2":                "unexpected character '.' at position 8",
+	} {
+		_, err := parsePolicyExpression(expression)
+		require.EqualError(t, err, fmt.Sprintf("invalid policy expression '%s': %s", expression, expectedErr))
+		fmt.Printf("Test failed as expected with error: %s\n", err)
+	}
+}
+
+func TestVerifyPolicySatisfiedBySigners(t *testing.T) {
+	dslPolicy := &common.Policy{Type: policyDSLType, Criteria: []string{"Org1 && Org2 || count > 2"}}
+
+	// Test success with either branch of the policy satisfied
+	require.NoError(t, verifyPolicySatisfiedBySigners(dslPolicy, []string{"Org1", "Org2"}))
+	require.NoError(t, verifyPolicySatisfiedBySigners(dslPolicy, []string{"Org2", "Org3", "Org4"}))
+
+	// Test failure with neither branch satisfied; a signer notarizing twice is only counted once
+	err := verifyPolicySatisfiedBySigners(dslPolicy, []string{"Org1", "Org3", "Org3"})
+	require.EqualError(t, err, "Notarizations by [Org1 Org3 Org3] do not satisfy the policy criterion: Org1 && Org2 || count > 2")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success and failure with several criteria, all of which need to be satisfied
+	dslPolicy.Criteria = []string{"Org1", "count >= 2"}
+	require.NoError(t, verifyPolicySatisfiedBySigners(dslPolicy, []string{"Org1", "Org3"}))
+	err = verifyPolicySatisfiedBySigners(dslPolicy, []string{"Org1"})
+	require.EqualError(t, err, "Notarizations by [Org1] do not satisfy the policy criterion: count >= 2")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a DSL policy without criteria
+	dslPolicy.Criteria = []string{}
+	err = verifyPolicySatisfiedBySigners(dslPolicy, []string{"Org1"})
+	require.EqualError(t, err, "policy of type PolicyDSL has no criteria")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success and failure with a policy of another type, whose criteria are all required signers
+	signaturePolicy := &common.Policy{Type: "Signature", Criteria: []string{"Org1", "Org2"}}
+	require.NoError(t, verifyPolicySatisfiedBySigners(signaturePolicy, []string{"Org2", "Org1"}))
+	err = verifyPolicySatisfiedBySigners(signaturePolicy, []string{"Org1"})
+	require.EqualError(t, err, "Notarizations missing signer: Org2")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+}
+
+func TestDSLVerificationPolicy(t *testing.T) {
+	ctx, chaincodeStub, interopcc := prepMockStub()
+	dslVerificationPolicy := common.VerificationPolicy{
+		SecurityDomain: "2345",
+		Identifiers: []*common.Identifier{
+			{Pattern: "localhost:9080/network1/*", Policy: &common.Policy{Type: policyDSLType, Criteria: []string{"Org1 || Org2"}}},
+			{Pattern: "localhost:9080/network1/mychannel:simplestate:Read:*", Policy: &common.Policy{Type: policyDSLType, Criteria: []string{"Org1 ||"}}},
+		},
+	}
+
+	// Test failure with the recording of a policy with an invalid criterion
+	verificationPolicyBytes, err := json.Marshal(&dslVerificationPolicy)
+	require.NoError(t, err)
+	err = interopcc.CreateVerificationPolicy(ctx, string(verificationPolicyBytes))
+	require.EqualError(t, err, "Invalid policy for pattern localhost:9080/network1/mychannel:simplestate:Read:*: invalid policy expression 'Org1 ||': unexpected end of expression")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with the resolution of an invalid policy already recorded on the ledger
+	chaincodeStub.GetStateReturns(verificationPolicyBytes, nil)
+	_, err = resolvePolicy(&interopcc, ctx, "2345", "localhost:9080/network1/mychannel:simplestate:Read:a")
+	require.EqualError(t, err, "Verification Policy Error: Invalid policy for pattern localhost:9080/network1/mychannel:simplestate:Read:*: invalid policy expression 'Org1 ||': unexpected end of expression")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with the resolution of a valid policy
+	resolvedPolicy, err := resolvePolicy(&interopcc, ctx, "2345", "localhost:9080/network1/mychannel:simplestate:Write:a")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Org1 || Org2"}, resolvedPolicy.Criteria)
+}
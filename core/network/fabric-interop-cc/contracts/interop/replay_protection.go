@@ -0,0 +1,235 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// replay_protection contains the functions used to reject replays of the queries that come from remote networks:
+// the nonce of each query is consumed by the first request that carries it, and the timestamp signed by the requestor
+// needs to be within a freshness window around the transaction time
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+const replayProtectionPolicyKey = "ReplayProtectionPolicy" // ledger key for the replay protection policy of external requests
+
+const requestNonceObjectType = "ExternalRequestNonce" // <requesting network, requesting org, nonce>
+
+/*
+ * Object used to capture the replay protection policy. A query needs to carry a timestamp within FreshnessWindowSecs
+ * of the transaction time, unless the window is 0. Consumed nonces are retained for NonceRetentionSecs after the
+ * query timestamp (forever if 0), which cannot be shorter than the freshness window: a query whose nonce is no longer
+ * retained is too old to be accepted anyway.
+ */
+type ReplayProtectionPolicy struct {
+	FreshnessWindowSecs uint64 `json:"freshnessWindowSecs"`
+	NonceRetentionSecs  uint64 `json:"nonceRetentionSecs"`
+}
+
+// function to check that the retention of consumed nonces covers the freshness window
+func validateReplayProtectionPolicy(policy *ReplayProtectionPolicy) error {
+	if policy.NonceRetentionSecs == 0 {
+		return nil
+	}
+	if policy.FreshnessWindowSecs == 0 {
+		return fmt.Errorf("nonces cannot be retained for a limited time without a freshness window")
+	}
+	if policy.NonceRetentionSecs < policy.FreshnessWindowSecs {
+		return fmt.Errorf("nonce retention of %d secs is shorter than the freshness window of %d secs",
+			policy.NonceRetentionSecs, policy.FreshnessWindowSecs)
+	}
+	return nil
+}
+
+// SetReplayProtectionPolicy cc is used by an admin of the interop cc to record the replay protection policy
+// (in JSON form) applied to the queries that come from remote networks, replacing any existing one
+func (s *SmartContract) SetReplayProtectionPolicy(ctx contractapi.TransactionContextInterface, policyJSON string) error {
+	err := checkCallerIsAdmin(ctx)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	policy := &ReplayProtectionPolicy{}
+	err = json.Unmarshal([]byte(policyJSON), policy)
+	if err != nil {
+		return logThenErrorf("unmarshal error: %+v", err)
+	}
+	err = validateReplayProtectionPolicy(policy)
+	if err != nil {
+		return logThenErrorf("invalid replay protection policy: %+v", err)
+	}
+	policyBytes, err := json.Marshal(policy)
+	if err != nil {
+		return logThenErrorf("marshal error: %+v", err)
+	}
+	err = ctx.GetStub().PutState(replayProtectionPolicyKey, policyBytes)
+	if err != nil {
+		return logThenErrorf("failed to write to the world state: %+v", err)
+	}
+	return nil
+}
+
+// GetReplayProtectionPolicy cc is used to query the replay protection policy in force (with no freshness window and
+// no limit on the retention of nonces if none has been recorded)
+func (s *SmartContract) GetReplayProtectionPolicy(ctx contractapi.TransactionContextInterface) (*ReplayProtectionPolicy, error) {
+	return getReplayProtectionPolicy(ctx)
+}
+
+// function to fetch the replay protection policy recorded on the ledger
+func getReplayProtectionPolicy(ctx contractapi.TransactionContextInterface) (*ReplayProtectionPolicy, error) {
+	policy := &ReplayProtectionPolicy{}
+	policyBytes, err := ctx.GetStub().GetState(replayProtectionPolicyKey)
+	if err != nil {
+		return policy, logThenErrorf("failed to retrieve from the world state: %+v", err)
+	}
+	if policyBytes == nil {
+		return policy, nil
+	}
+	err = json.Unmarshal(policyBytes, policy)
+	if err != nil {
+		return policy, logThenErrorf("invalid replay protection policy recorded on the ledger: %+v", err)
+	}
+	return policy, nil
+}
+
+/*
+ * Function to get the message signed by the requestor of a query. A query that carries a timestamp or is confidential is
+ * signed over its address, nonce and timestamp (in decimal form), followed by the field "confidential" for a confidential
+ * query, each field prefixed by its length in bytes and ':', so that no characters can be moved from one field to the
+ * next (e.g., from the nonce to the timestamp, to get a fresh nonce, or from the nonce to the confidentiality flag, to
+ * get a query that is not confidential) without invalidating the signature. A query with neither is signed over its
+ * address followed by its nonce, as issued by requestors that predate timestamps, which the replay protection policy
+ * rejects once it sets a freshness window.
+ */
+func getSignedQueryMessage(query *common.Query) string {
+	if query.Timestamp == 0 && !query.Confidential {
+		return query.Address + query.Nonce
+	}
+	fields := []string{query.Address, query.Nonce, strconv.FormatUint(query.Timestamp, 10)}
+	if query.Confidential {
+		fields = append(fields, confidentialQueryField)
+	}
+	message := ""
+	for _, field := range fields {
+		message += strconv.Itoa(len(field)) + ":" + field
+	}
+	return message
+}
+
+/*
+ * Function to check that a query is not a replay, and to consume its nonce: the query timestamp needs to be within the
+ * freshness window (extended by the clock skew tolerance) around the transaction time, and the nonce cannot have been
+ * used before by the requesting org of the requesting network.
+ */
+func checkAndConsumeQueryNonce(ctx contractapi.TransactionContextInterface, query *common.Query) error {
+	if query.Nonce == "" {
+		return fmt.Errorf("empty nonce")
+	}
+	policy, err := getReplayProtectionPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	if policy.FreshnessWindowSecs != 0 {
+		if query.Timestamp == 0 {
+			return fmt.Errorf("query timestamp is required by the replay protection policy")
+		}
+		txTime, tolerance, err := getTxTimeAndClockSkewTolerance(ctx)
+		if err != nil {
+			return err
+		}
+		window := time.Duration(policy.FreshnessWindowSecs)*time.Second + tolerance
+		queryTime := time.Unix(int64(query.Timestamp), 0)
+		if queryTime.Before(txTime.Add(-window)) || queryTime.After(txTime.Add(window)) {
+			return fmt.Errorf("query timestamp %d is outside the freshness window of %d secs around the transaction time %d",
+				query.Timestamp, policy.FreshnessWindowSecs, txTime.Unix())
+		}
+	}
+
+	nonceKey, err := ctx.GetStub().CreateCompositeKey(requestNonceObjectType, []string{query.RequestingNetwork, query.RequestingOrg, query.Nonce})
+	if err != nil {
+		return fmt.Errorf("error while creating composite key for index %s: %+v", requestNonceObjectType, err)
+	}
+	nonceBytes, err := ctx.GetStub().GetState(nonceKey)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve from the world state: %+v", err)
+	}
+	if nonceBytes != nil {
+		return fmt.Errorf("nonce %s has already been used by org %s of network %s", query.Nonce, query.RequestingOrg, query.RequestingNetwork)
+	}
+	// the nonce is retained from the query timestamp (or the transaction time, for a query without timestamp)
+	retainedFromSecs := query.Timestamp
+	if retainedFromSecs == 0 {
+		retainedFromSecs, err = getTxTimeSecs(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	err = ctx.GetStub().PutState(nonceKey, []byte(strconv.FormatUint(retainedFromSecs, 10)))
+	if err != nil {
+		return fmt.Errorf("failed to write to the world state: %+v", err)
+	}
+	return nil
+}
+
+// PurgeExpiredRequestNonces cc is used by an admin of the interop cc to delete the nonces consumed by the
+// requesting org of the requesting network that are past the retention period of the replay protection policy; it
+// returns the number of nonces deleted
+func (s *SmartContract) PurgeExpiredRequestNonces(ctx contractapi.TransactionContextInterface, requestingNetwork string, requestingOrg string) (int, error) {
+	err := checkCallerIsAdmin(ctx)
+	if err != nil {
+		return 0, logThenErrorf(err.Error())
+	}
+	if requestingNetwork == "" || requestingOrg == "" {
+		return 0, logThenErrorf("requesting network and org need to be specified")
+	}
+	policy, err := getReplayProtectionPolicy(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if policy.NonceRetentionSecs == 0 {
+		return 0, logThenErrorf("nonces are retained forever by the replay protection policy")
+	}
+	txTime, tolerance, err := getTxTimeAndClockSkewTolerance(ctx)
+	if err != nil {
+		return 0, err
+	}
+	// a nonce is only dropped once a query carrying it would fail the freshness check
+	retentionSecs := policy.NonceRetentionSecs
+	if minRetentionSecs := policy.FreshnessWindowSecs + uint64(tolerance/time.Second); retentionSecs < minRetentionSecs {
+		retentionSecs = minRetentionSecs
+	}
+	txTimeSecs := uint64(txTime.Unix())
+
+	iterator, err := ctx.GetStub().GetStateByPartialCompositeKey(requestNonceObjectType, []string{requestingNetwork, requestingOrg})
+	if err != nil {
+		return 0, logThenErrorf("failed to query index %s: %+v", requestNonceObjectType, err)
+	}
+	defer iterator.Close()
+	numPurged := 0
+	for iterator.HasNext() {
+		entry, err := iterator.Next()
+		if err != nil {
+			return numPurged, logThenErrorf("failed to iterate over index %s: %+v", requestNonceObjectType, err)
+		}
+		retainedFromSecs, err := strconv.ParseUint(string(entry.Value), 10, 64)
+		if err != nil {
+			return numPurged, logThenErrorf("invalid nonce record %s: %+v", entry.Key, err)
+		}
+		if retainedFromSecs+retentionSecs >= txTimeSecs {
+			continue
+		}
+		err = ctx.GetStub().DelState(entry.Key)
+		if err != nil {
+			return numPurged, logThenErrorf("failed to delete nonce record %s: %+v", entry.Key, err)
+		}
+		numPurged++
+	}
+	return numPurged, nil
+}
@@ -0,0 +1,158 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/core/network/fabric-interop-cc/contracts/interop/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetReplayProtectionPolicy(t *testing.T) {
+	ctx, chaincodeStub, interopcc := prepMockStub()
+	clientIdentity := ctx.GetClientIdentity().(*mocks.ClientIdentity)
+	policyJSON := `{"freshnessWindowSecs":300,"nonceRetentionSecs":3600}`
+
+	// Test failure with the caller not being an admin
+	err := interopcc.SetReplayProtectionPolicy(ctx, policyJSON)
+	require.EqualError(t, err, "caller is not an admin of the organization "+myOrg1Msp)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with a retention of nonces that does not cover the freshness window
+	clientIdentity.GetX509CertificateReturns(&x509.Certificate{Subject: pkix.Name{OrganizationalUnit: []string{"admin"}}}, nil)
+	err = interopcc.SetReplayProtectionPolicy(ctx, `{"freshnessWindowSecs":300,"nonceRetentionSecs":60}`)
+	require.EqualError(t, err, "invalid replay protection policy: nonce retention of 60 secs is shorter than the freshness window of 300 secs")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	err = interopcc.SetReplayProtectionPolicy(ctx, `{"nonceRetentionSecs":60}`)
+	require.EqualError(t, err, "invalid replay protection policy: nonces cannot be retained for a limited time without a freshness window")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	require.Equal(t, 0, chaincodeStub.PutStateCallCount())
+
+	// Test success with the caller being an admin of the local organization
+	err = interopcc.SetReplayProtectionPolicy(ctx, policyJSON)
+	require.NoError(t, err)
+	key, value := chaincodeStub.PutStateArgsForCall(0)
+	require.Equal(t, replayProtectionPolicyKey, key)
+	require.JSONEq(t, policyJSON, string(value))
+
+	// Test success with the policy read back from the ledger, and no restrictions if none is recorded
+	policy, err := interopcc.GetReplayProtectionPolicy(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &ReplayProtectionPolicy{}, policy)
+	ctx.GetStub().(*chaincodeStubWithConfig).config[replayProtectionPolicyKey] = value
+	policy, err = interopcc.GetReplayProtectionPolicy(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &ReplayProtectionPolicy{FreshnessWindowSecs: 300, NonceRetentionSecs: 3600}, policy)
+}
+
+func TestQueryNonceConsumption(t *testing.T) {
+	ctx, mockStub, interopcc := prepShimMockStub()
+	clientIdentity := ctx.GetClientIdentity().(*mocks.ClientIdentity)
+	currentTimeSecs := uint64(time.Now().Unix())
+	newQuery := func(network string, nonce string, timestampSecs uint64) *common.Query {
+		return &common.Query{
+			Address:           "localhost:9080/network1/mychannel:interop:Read:a",
+			RequestingNetwork: network,
+			RequestingOrg:     "Org1MSP",
+			Nonce:             nonce,
+			Timestamp:         timestampSecs,
+		}
+	}
+
+	// Test success with the nonce of a query consumed, and the signed message covering the timestamp
+	require.Equal(t, "localhost:9080/network1/mychannel:interop:Read:anonce-1", getSignedQueryMessage(newQuery("network1", "nonce-1", 0)))
+	require.Equal(t, fmt.Sprintf("48:localhost:9080/network1/mychannel:interop:Read:a7:nonce-1%d:%d", len(fmt.Sprint(currentTimeSecs)), currentTimeSecs),
+		getSignedQueryMessage(newQuery("network1", "nonce-1", currentTimeSecs)))
+	// moving digits from the nonce to the timestamp changes the signed message
+	require.NotEqual(t, getSignedQueryMessage(newQuery("network1", "nonce-1", 1700000000)),
+		getSignedQueryMessage(newQuery("network1", "nonce-", 11700000000)))
+	// the confidentiality flag is a separate field, and cannot be moved into (or out of) the nonce
+	confidentialQuery := newQuery("network1", "nonce-1", 0)
+	confidentialQuery.Confidential = true
+	require.Equal(t, "48:localhost:9080/network1/mychannel:interop:Read:a7:nonce-11:012:confidential", getSignedQueryMessage(confidentialQuery))
+	require.NotEqual(t, getSignedQueryMessage(confidentialQuery), getSignedQueryMessage(newQuery("network1", "nonce-1confidential", 0)))
+	require.NotEqual(t, getSignedQueryMessage(confidentialQuery), getSignedQueryMessage(newQuery("network1", "nonce-11:012:confidential", 0)))
+	mockStub.MockTransactionStart("tx1")
+	err := checkAndConsumeQueryNonce(ctx, newQuery("network1", "nonce-1", 0))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx1")
+
+	// Test failure with a replay of the nonce, or an empty nonce
+	mockStub.MockTransactionStart("tx2")
+	err = checkAndConsumeQueryNonce(ctx, newQuery("network1", "nonce-1", 0))
+	require.EqualError(t, err, "nonce nonce-1 has already been used by org Org1MSP of network network1")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	err = checkAndConsumeQueryNonce(ctx, newQuery("network1", "", 0))
+	require.EqualError(t, err, "empty nonce")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with the same nonce used by another network
+	err = checkAndConsumeQueryNonce(ctx, newQuery("network2", "nonce-1", 0))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx2")
+
+	// Test failure with queries lacking a timestamp, or carrying a stale one, under a freshness window
+	mockStub.MockTransactionStart("tx3")
+	err = mockStub.PutState(replayProtectionPolicyKey, []byte(`{"freshnessWindowSecs":60,"nonceRetentionSecs":120}`))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx3")
+	mockStub.MockTransactionStart("tx4")
+	mockStub.TxTimestamp = &timestamp.Timestamp{Seconds: int64(currentTimeSecs)}
+	err = checkAndConsumeQueryNonce(ctx, newQuery("network1", "nonce-2", 0))
+	require.EqualError(t, err, "query timestamp is required by the replay protection policy")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	err = checkAndConsumeQueryNonce(ctx, newQuery("network1", "nonce-2", currentTimeSecs-61))
+	require.EqualError(t, err, fmt.Sprintf("query timestamp %d is outside the freshness window of 60 secs around the transaction time %d",
+		currentTimeSecs-61, currentTimeSecs))
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	err = checkAndConsumeQueryNonce(ctx, newQuery("network1", "nonce-2", currentTimeSecs+61))
+	require.Error(t, err)
+
+	// Test success with queries carrying a fresh timestamp
+	err = checkAndConsumeQueryNonce(ctx, newQuery("network1", "nonce-2", currentTimeSecs-60))
+	require.NoError(t, err)
+	err = checkAndConsumeQueryNonce(ctx, newQuery("network1", "nonce-3", currentTimeSecs))
+	require.NoError(t, err)
+	mockStub.MockTransactionEnd("tx4")
+
+	// Test failure with a purge of nonces by a caller who is not an admin
+	mockStub.MockTransactionStart("tx5")
+	mockStub.TxTimestamp = &timestamp.Timestamp{Seconds: int64(currentTimeSecs + 60)}
+	_, err = interopcc.PurgeExpiredRequestNonces(ctx, "network1", "Org1MSP")
+	require.EqualError(t, err, "caller is not an admin of the organization "+myOrg1Msp)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with only the nonces past the retention period purged
+	clientIdentity.GetX509CertificateReturns(&x509.Certificate{Subject: pkix.Name{OrganizationalUnit: []string{"admin"}}}, nil)
+	numPurged, err := interopcc.PurgeExpiredRequestNonces(ctx, "network1", "Org1MSP")
+	require.NoError(t, err)
+	require.Equal(t, 0, numPurged)
+	mockStub.MockTransactionEnd("tx5")
+	mockStub.MockTransactionStart("tx6")
+	mockStub.TxTimestamp = &timestamp.Timestamp{Seconds: int64(currentTimeSecs + 100)}
+	numPurged, err = interopcc.PurgeExpiredRequestNonces(ctx, "network1", "Org1MSP")
+	require.NoError(t, err)
+	require.Equal(t, 1, numPurged)
+	mockStub.MockTransactionEnd("tx6")
+
+	// Test success with a purged nonce used again in a query signed afresh, and failure with a retained one
+	mockStub.MockTransactionStart("tx7")
+	mockStub.TxTimestamp = &timestamp.Timestamp{Seconds: int64(currentTimeSecs + 100)}
+	err = checkAndConsumeQueryNonce(ctx, newQuery("network1", "nonce-2", currentTimeSecs+100))
+	require.NoError(t, err)
+	err = checkAndConsumeQueryNonce(ctx, newQuery("network1", "nonce-3", currentTimeSecs+100))
+	require.EqualError(t, err, "nonce nonce-3 has already been used by org Org1MSP of network network1")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+	mockStub.MockTransactionEnd("tx7")
+}
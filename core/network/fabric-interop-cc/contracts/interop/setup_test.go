@@ -10,9 +10,12 @@
 package main
 
 import (
+	"encoding/json"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
@@ -55,7 +58,11 @@ func prepMockStub() (*mocks.TransactionContext, *mocks.ChaincodeStub, SmartContr
 func prepMocks(orgMSP, clientID string) (*mocks.TransactionContext, *mocks.ChaincodeStub) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
-	transactionContext.GetStubReturns(chaincodeStub)
+	// the organization of the client is the one recorded as administering the interop cc
+	adminMSPsBytes, _ := json.Marshal([]string{orgMSP})
+	transactionContext.GetStubReturns(&chaincodeStubWithConfig{ChaincodeStub: chaincodeStub, config: map[string][]byte{adminMSPsKey: adminMSPsBytes}})
+	// time-dependent checks are driven by the transaction timestamp, which tests pin to the current time
+	chaincodeStub.GetTxTimestampReturns(&timestamp.Timestamp{Seconds: time.Now().Unix()}, nil)
 
 	clientIdentity := &mocks.ClientIdentity{}
 	clientIdentity.GetMSPIDReturns(orgMSP, nil)
@@ -65,3 +72,17 @@ func prepMocks(orgMSP, clientID string) (*mocks.TransactionContext, *mocks.Chain
 	transactionContext.GetClientIdentityReturns(clientIdentity)
 	return transactionContext, chaincodeStub
 }
+
+// chaincodeStubWithConfig serves the interop cc configuration recorded on the ledger (e.g., the admin organizations, the clock skew tolerance, the lock policy, the local network id)
+// from a fixed map, so that tests can sequence the remaining ledger reads independently of configuration reads
+type chaincodeStubWithConfig struct {
+	*mocks.ChaincodeStub
+	config map[string][]byte
+}
+
+func (stub *chaincodeStubWithConfig) GetState(key string) ([]byte, error) {
+	if key == adminMSPsKey || key == clockSkewToleranceKey || key == lockPolicyKey || key == replayProtectionPolicyKey || key == localNetworkIdKey {
+		return stub.config[key], nil
+	}
+	return stub.ChaincodeStub.GetState(key)
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// tx_time contains the functions used to derive the current time from the transaction timestamp,
+// so that every endorsing peer evaluates time-dependent checks (lock expiry, certificate validity) identically
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+const clockSkewToleranceKey = "ClockSkewToleranceSecs" // ledger key for the tolerance (in seconds) applied to time-dependent checks
+
+// SetClockSkewTolerance cc is used by an admin of the interop cc to record the tolerance, in seconds,
+// allowed for skew between the clock of the transaction submitter and the actual time
+func (s *SmartContract) SetClockSkewTolerance(ctx contractapi.TransactionContextInterface, toleranceSecs uint64) error {
+	err := checkCallerIsAdmin(ctx)
+	if err != nil {
+		return logThenErrorf(err.Error())
+	}
+	err = ctx.GetStub().PutState(clockSkewToleranceKey, []byte(strconv.FormatUint(toleranceSecs, 10)))
+	if err != nil {
+		return logThenErrorf("failed to write to the world state: %+v", err)
+	}
+	return nil
+}
+
+// GetClockSkewTolerance cc is used to query the clock skew tolerance in seconds (0 if none has been set)
+func (s *SmartContract) GetClockSkewTolerance(ctx contractapi.TransactionContextInterface) (uint64, error) {
+	return getClockSkewToleranceSecs(ctx)
+}
+
+// function to fetch the clock skew tolerance (in seconds) recorded on the ledger
+func getClockSkewToleranceSecs(ctx contractapi.TransactionContextInterface) (uint64, error) {
+	toleranceBytes, err := ctx.GetStub().GetState(clockSkewToleranceKey)
+	if err != nil {
+		return 0, logThenErrorf("failed to retrieve from the world state: %+v", err)
+	}
+	if toleranceBytes == nil {
+		return 0, nil
+	}
+	toleranceSecs, err := strconv.ParseUint(string(toleranceBytes), 10, 64)
+	if err != nil {
+		return 0, logThenErrorf("invalid clock skew tolerance recorded on the ledger: %+v", err)
+	}
+	return toleranceSecs, nil
+}
+
+// function to get the time at which the transaction was created, as recorded in the transaction timestamp
+func getTxTime(ctx contractapi.TransactionContextInterface) (time.Time, error) {
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return time.Time{}, logThenErrorf("unable to get the transaction timestamp: %+v", err)
+	}
+	if txTimestamp == nil {
+		return time.Time{}, logThenErrorf("transaction timestamp is not available")
+	}
+	return time.Unix(txTimestamp.Seconds, int64(txTimestamp.Nanos)), nil
+}
+
+// function to get the time at which the transaction was created, in epoch seconds
+func getTxTimeSecs(ctx contractapi.TransactionContextInterface) (uint64, error) {
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(txTime.Unix()), nil
+}
+
+// function to get the transaction time along with the clock skew tolerance recorded on the ledger
+func getTxTimeAndClockSkewTolerance(ctx contractapi.TransactionContextInterface) (time.Time, time.Duration, error) {
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	toleranceSecs, err := getClockSkewToleranceSecs(ctx)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	return txTime, time.Duration(toleranceSecs) * time.Second, nil
+}
+
+// function to check whether the expiry time of a lock has elapsed as of the transaction time.
+// The clock skew tolerance extends the lock, so a claim submitted just before expiry by a client with a lagging clock
+// is still honoured, and claim and unlock can never both be valid at the same transaction time.
+func isLockExpired(ctx contractapi.TransactionContextInterface, expiryTimeSecs uint64) (bool, error) {
+	currentTimeSecs, err := getTxTimeSecs(ctx)
+	if err != nil {
+		return false, err
+	}
+	toleranceSecs, err := getClockSkewToleranceSecs(ctx)
+	if err != nil {
+		return false, err
+	}
+	return currentTimeSecs >= expiryTimeSecs+toleranceSecs, nil
+}
+
+// function to get the latest expiry time that has elapsed as of the transaction time, so that isLockExpired holds for
+// any expiry time up to it (0 if none has elapsed yet)
+func getLatestExpiredTimeSecs(ctx contractapi.TransactionContextInterface) (uint64, error) {
+	currentTimeSecs, err := getTxTimeSecs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	toleranceSecs, err := getClockSkewToleranceSecs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if currentTimeSecs < toleranceSecs {
+		return 0, nil
+	}
+	return currentTimeSecs - toleranceSecs, nil
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/core/network/fabric-interop-cc/contracts/interop/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetClockSkewTolerance(t *testing.T) {
+	ctx, chaincodeStub, interopcc := prepMockStub()
+	clientIdentity := ctx.GetClientIdentity().(*mocks.ClientIdentity)
+
+	// Test failure with the caller not being an admin
+	err := interopcc.SetClockSkewTolerance(ctx, 30)
+	require.EqualError(t, err, "caller is not an admin of the organization "+myOrg1Msp)
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with the caller being an admin of an organization that does not administer the interop cc
+	clientIdentity.GetX509CertificateReturns(&x509.Certificate{Subject: pkix.Name{OrganizationalUnit: []string{"admin"}}}, nil)
+	clientIdentity.GetMSPIDReturns("Org2Testmsp", nil)
+	err = interopcc.SetClockSkewTolerance(ctx, 30)
+	require.EqualError(t, err, "caller from MSP Org2Testmsp is not a member of an admin organization")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test failure with no admin organizations recorded on the ledger
+	config := ctx.GetStub().(*chaincodeStubWithConfig).config
+	adminMSPsBytes := config[adminMSPsKey]
+	delete(config, adminMSPsKey)
+	err = interopcc.SetClockSkewTolerance(ctx, 30)
+	require.EqualError(t, err, "no admin organizations are recorded on the ledger")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+
+	// Test success with the caller being an admin of an organization recorded as administering the interop cc,
+	// whichever the organization of the endorsing peer
+	config[adminMSPsKey] = []byte(`["Org2Testmsp"]`)
+	err = interopcc.SetClockSkewTolerance(ctx, 30)
+	require.NoError(t, err)
+	config[adminMSPsKey] = adminMSPsBytes
+	clientIdentity.GetMSPIDReturns(myOrg1Msp, nil)
+	err = interopcc.SetClockSkewTolerance(ctx, 30)
+	require.NoError(t, err)
+	key, value := chaincodeStub.PutStateArgsForCall(0)
+	require.Equal(t, clockSkewToleranceKey, key)
+	require.Equal(t, "30", string(value))
+
+	// Test success with the tolerance read back from the ledger
+	ctx.GetStub().(*chaincodeStubWithConfig).config[clockSkewToleranceKey] = value
+	toleranceSecs, err := interopcc.GetClockSkewTolerance(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), toleranceSecs)
+}
+
+func TestIsLockExpired(t *testing.T) {
+	ctx, chaincodeStub, _ := prepMockStub()
+	txTimeSecs := uint64(time.Now().Unix())
+	chaincodeStub.GetTxTimestampReturns(&timestamp.Timestamp{Seconds: int64(txTimeSecs)}, nil)
+
+	// Test success with expiry evaluated at the transaction time
+	isExpired, err := isLockExpired(ctx, txTimeSecs)
+	require.NoError(t, err)
+	require.True(t, isExpired)
+	isExpired, err = isLockExpired(ctx, txTimeSecs+1)
+	require.NoError(t, err)
+	require.False(t, isExpired)
+
+	// Test success with the clock skew tolerance extending the lock
+	ctx.GetStub().(*chaincodeStubWithConfig).config[clockSkewToleranceKey] = []byte("10")
+	isExpired, err = isLockExpired(ctx, txTimeSecs-5)
+	require.NoError(t, err)
+	require.False(t, isExpired)
+	isExpired, err = isLockExpired(ctx, txTimeSecs-10)
+	require.NoError(t, err)
+	require.True(t, isExpired)
+
+	// Test failure with the transaction timestamp not being available
+	chaincodeStub.GetTxTimestampReturns(nil, nil)
+	_, err = isLockExpired(ctx, txTimeSecs)
+	require.EqualError(t, err, "transaction timestamp is not available")
+	fmt.Printf("Test failed as expected with error: %s\n", err)
+}
@@ -24,6 +24,10 @@ func (s *SmartContract) CreateVerificationPolicy(ctx contractapi.TransactionCont
 	if err != nil {
 		return fmt.Errorf("Unmarshal error: %s", err)
 	}
+	err = validateVerificationPolicyCriteria(verificationPolicy)
+	if err != nil {
+		return err
+	}
 	verificationPolicyKey, err := ctx.GetStub().CreateCompositeKey(verificationPolicyObjectType, []string{verificationPolicy.SecurityDomain})
 	acp, getErr := ctx.GetStub().GetState(verificationPolicyKey)
 	if getErr != nil {
@@ -46,6 +50,10 @@ func (s *SmartContract) UpdateVerificationPolicy(ctx contractapi.TransactionCont
 	if err != nil {
 		return fmt.Errorf("Unmarshal error: %s", err)
 	}
+	err = validateVerificationPolicyCriteria(verificationPolicy)
+	if err != nil {
+		return err
+	}
 	verificationPolicyKey, err := ctx.GetStub().CreateCompositeKey(verificationPolicyObjectType, []string{verificationPolicy.SecurityDomain})
 	_, err = s.GetVerificationPolicyBySecurityDomain(ctx, verificationPolicy.SecurityDomain)
 	if err != nil {
@@ -112,7 +120,7 @@ func resolvePolicy(s *SmartContract, ctx contractapi.TransactionContextInterface
 	for _, identifier := range verificationPolicy.Identifiers {
 		// short circuit if there is an exact match
 		if identifier.Pattern == viewAddress {
-			return identifier.Policy, nil
+			return checkResolvedPolicy(identifier)
 		}
 
 		// check if the identifier pattern is valid, that it matches the address and it's longer (i.e. more specific) than the currentBestMatch
@@ -123,8 +131,20 @@ func resolvePolicy(s *SmartContract, ctx contractapi.TransactionContextInterface
 
 	// return the bestMatch if there was one
 	if currentBestMatch.Pattern != "" {
-		return currentBestMatch.Policy, nil
+		return checkResolvedPolicy(currentBestMatch)
 	}
 
 	return nil, fmt.Errorf("Verification Policy Error: Failed to find verification policy matching view address: %s", viewAddress)
 }
+
+// checkResolvedPolicy checks that the criteria of a policy of the DSL type are valid expressions before the policy is
+// applied, as a policy recorded before the DSL was evaluated by this chaincode may not have been validated
+func checkResolvedPolicy(identifier *common.Identifier) (*common.Policy, error) {
+	if identifier.Policy != nil && identifier.Policy.Type == policyDSLType {
+		_, err := parsePolicyCriteria(identifier.Policy)
+		if err != nil {
+			return nil, fmt.Errorf("Verification Policy Error: Invalid policy for pattern %s: %s", identifier.Pattern, err.Error())
+		}
+	}
+	return identifier.Policy, nil
+}
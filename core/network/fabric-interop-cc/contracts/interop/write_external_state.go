@@ -18,6 +18,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
 	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/corda"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/ethereum"
 	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/fabric"
 	protoV2 "google.golang.org/protobuf/proto"
 )
@@ -26,8 +27,9 @@ type interop interface {
 	WriteExternalState(state string) error
 }
 
-// Extract data (i.e., query response) from view
-func ExtractDataFromView(view *common.View) ([]byte, error) {
+// Extract data (i.e., query response) from view; the data of a confidential view is taken from its contents decrypted
+// by the requestor (b64ViewContents), once they are checked against the hash in the view
+func ExtractDataFromView(view *common.View, b64ViewContents string) ([]byte, error) {
 	var interopPayload common.InteropPayload
 	if view.Meta.Protocol == common.Meta_FABRIC {
 		var fabricViewData fabric.FabricView
@@ -49,14 +51,46 @@ func ExtractDataFromView(view *common.View) ([]byte, error) {
 		if err != nil {
 			return nil, fmt.Errorf("Unable to Unmarshal interopPayload: %s", err.Error())
 		}
+	} else if view.Meta.Protocol == common.Meta_ETHEREUM {
+		var ethereumViewData ethereum.ViewData
+		err := protoV2.Unmarshal(view.Data, &ethereumViewData)
+		if err != nil {
+			return nil, fmt.Errorf("EthereumView Unmarshal error: %s", err)
+		}
+		err = protoV2.Unmarshal(ethereumViewData.Payload, &interopPayload)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to Unmarshal interopPayload: %s", err.Error())
+		}
 	} else {
 		return nil, fmt.Errorf("Cannot extract data from view; unsupported DLT type: %+v", view.Meta.Protocol)
 	}
-	return interopPayload.Payload, nil
+	if !interopPayload.Confidential {
+		if b64ViewContents != "" {
+			return nil, fmt.Errorf("View contents supplied for a view that is not confidential")
+		}
+		return interopPayload.Payload, nil
+	}
+	if b64ViewContents == "" {
+		return nil, fmt.Errorf("Cannot extract data from a confidential view without its decrypted contents")
+	}
+	return verifyConfidentialPayloadContents(interopPayload.Payload, b64ViewContents)
 }
 
 // Validate view against address, and extract data (i.e., query response) from view
 func (s *SmartContract) ParseAndValidateView(ctx contractapi.TransactionContextInterface, address string, b64ViewProto string) ([]byte, error) {
+	return s.parseAndValidateView(ctx, address, b64ViewProto, "")
+}
+
+// Validate a confidential view against address, and extract data (i.e., query response) from the contents of the view
+// decrypted by the requestor (b64ViewContents), once they are checked against the hash in the view
+func (s *SmartContract) ParseAndValidateConfidentialView(ctx contractapi.TransactionContextInterface, address string, b64ViewProto string, b64ViewContents string) ([]byte, error) {
+	if b64ViewContents == "" {
+		return nil, fmt.Errorf("Contents of the confidential view not supplied")
+	}
+	return s.parseAndValidateView(ctx, address, b64ViewProto, b64ViewContents)
+}
+
+func (s *SmartContract) parseAndValidateView(ctx contractapi.TransactionContextInterface, address string, b64ViewProto string, b64ViewContents string) ([]byte, error) {
 	viewB64Bytes, err := base64.StdEncoding.DecodeString(b64ViewProto)
 	if err != nil {
 		return nil, fmt.Errorf("Unable to base64 decode data: %s", err.Error())
@@ -74,7 +108,7 @@ func (s *SmartContract) ParseAndValidateView(ctx contractapi.TransactionContextI
 	}
 
 	// 2. Extract response data for consumption by application chaincode
-	viewData, err := ExtractDataFromView(&view)
+	viewData, err := ExtractDataFromView(&view, b64ViewContents)
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +121,20 @@ func (s *SmartContract) ParseAndValidateView(ctx contractapi.TransactionContextI
 // 1. Verify Proofs that are returned
 // 2. Call application chaincode
 func (s *SmartContract) WriteExternalState(ctx contractapi.TransactionContextInterface, applicationID string, applicationChannel string, applicationFunction string, applicationArgs []string, argIndicesForSubstitution []int, addresses []string, b64ViewProtos []string) error {
+	return s.writeExternalState(ctx, applicationID, applicationChannel, applicationFunction, applicationArgs, argIndicesForSubstitution, addresses, b64ViewProtos, nil)
+}
+
+// WriteExternalStateWithViewContents is the WriteExternalState flow for responses that include confidential views,
+// whose contents decrypted by the requestor are passed in b64ViewContents, with an entry (empty for a view that is not
+// confidential) for each view.
+func (s *SmartContract) WriteExternalStateWithViewContents(ctx contractapi.TransactionContextInterface, applicationID string, applicationChannel string, applicationFunction string, applicationArgs []string, argIndicesForSubstitution []int, addresses []string, b64ViewProtos []string, b64ViewContents []string) error {
+	if len(b64ViewContents) != len(b64ViewProtos) {
+		return fmt.Errorf("Number of view contents (%d) does not match number of views (%d)", len(b64ViewContents), len(b64ViewProtos))
+	}
+	return s.writeExternalState(ctx, applicationID, applicationChannel, applicationFunction, applicationArgs, argIndicesForSubstitution, addresses, b64ViewProtos, b64ViewContents)
+}
+
+func (s *SmartContract) writeExternalState(ctx contractapi.TransactionContextInterface, applicationID string, applicationChannel string, applicationFunction string, applicationArgs []string, argIndicesForSubstitution []int, addresses []string, b64ViewProtos []string, b64ViewContents []string) error {
 	if len(argIndicesForSubstitution) != len(addresses) {
 		return fmt.Errorf("Number of argument indices for substitution (%d) does not match number of addresses (%d)", len(argIndicesForSubstitution), len(addresses))
 	}
@@ -103,7 +151,11 @@ func (s *SmartContract) WriteExternalState(ctx contractapi.TransactionContextInt
 			return fmt.Errorf("Index %d out of bounds of array (length %d)", argIndex, len(applicationArgs))
 		}
 		// Validate proof and extract view data
-		viewData, err := s.ParseAndValidateView(ctx, addresses[i], b64ViewProtos[i])
+		viewContents := ""
+		if b64ViewContents != nil {
+			viewContents = b64ViewContents[i]
+		}
+		viewData, err := s.parseAndValidateView(ctx, addresses[i], b64ViewProtos[i], viewContents)
 		if err != nil {
 			return err
 		}
@@ -149,7 +201,7 @@ func (s *SmartContract) VerifyView(ctx contractapi.TransactionContextInterface,
 	case common.Meta_CORDA:
 		switch view.Meta.ProofType {
 		case "Notarization":
-			return verifyCordaNotarization(s, ctx, view.Data, verificationPolicy, addressStruct.LedgerSegment)
+			return verifyCordaNotarization(s, ctx, view.Data, verificationPolicy, addressStruct.LedgerSegment, address)
 		default:
 			return fmt.Errorf("Proof type not supported: %s", view.Meta.ProofType)
 		}
@@ -166,6 +218,13 @@ func (s *SmartContract) VerifyView(ctx contractapi.TransactionContextInterface,
 		default:
 			return fmt.Errorf("Proof type not supported: %s", view.Meta.ProofType)
 		}
+	case common.Meta_ETHEREUM:
+		switch view.Meta.ProofType {
+		case ethereumStateProofType:
+			return verifyEthereumStateProof(s, ctx, view.Data, verificationPolicy, addressStruct.LedgerSegment, address)
+		default:
+			return fmt.Errorf("Proof type not supported: %s", view.Meta.ProofType)
+		}
 	default:
 		return fmt.Errorf("Verification Error: Unrecognised protocol %s", view.Meta.Protocol)
 	}
@@ -176,53 +235,30 @@ func (s *SmartContract) VerifyView(ctx contractapi.TransactionContextInterface,
 //
 // Verification requires the following steps:
 // 1. Create [CordaViewData] from the view.
-// 2. TODO: Verify address in payload is the same as original address
-// 3. Verify each of the signatures in the Notarization array according to the data bytes and certificate.
+// 2. Verify address in payload is the same as original address
+// 3. Verify each of the signatures in the Notarization array according to the data bytes and certificate, and that
+// the Notarization Id matches the X.500 name in the certificate.
 // 4. Check the certificates are valid according to the Membership.
 // 5. Check the notarizations fulfill the verification policy of the request.
-func verifyCordaNotarization(s *SmartContract, ctx contractapi.TransactionContextInterface, data []byte, verificationPolicy *common.Policy, securityDomain string) error {
-	var cordaViewData corda.ViewData
-	err := protoV2.Unmarshal(data, &cordaViewData)
+func verifyCordaNotarization(s *SmartContract, ctx contractapi.TransactionContextInterface, data []byte, verificationPolicy *common.Policy, securityDomain string, address string) error {
+	// 1-3. Verify the notarization proof of the view data, which needs no ledger state.
+	_, notarizations, err := verifyCordaProof(data, address)
 	if err != nil {
-		return fmt.Errorf("Unable to decode corda view data: %s", err.Error())
+		return err
 	}
 
 	signerList := []string{}
-	// 3. Verify each of the signatures in the Notarization array according to the data bytes and certificate.
-	for _, value := range cordaViewData.Notarizations {
-		x509Cert, err := parseCert(value.Certificate)
-		if err != nil {
-			return fmt.Errorf("Unable to parse certificate: %s", err.Error())
-		}
-		var interopPayload common.InteropPayload
-		err = protoV2.Unmarshal(cordaViewData.Payload, &interopPayload)
-		if err != nil {
-			return fmt.Errorf("Unable to decode corda view data: %s", err.Error())
-		}
-		decodedSignature, err := base64.StdEncoding.DecodeString(value.Signature)
-		if err != nil {
-			return fmt.Errorf("Corda signature could not be decoded from base64: %s", err.Error())
-		}
-		err = validateSignature(string(cordaViewData.Payload), x509Cert, string(decodedSignature))
-		if err != nil {
-			return fmt.Errorf("Unable to Validate Signature: %s", err.Error())
-		}
-		signerList = append(signerList, value.Id)
+	for _, notarization := range notarizations {
+		signerList = append(signerList, notarization.id)
 		// 4. Check the certificates are valid according to the Membership.
-		err = verifyMemberInSecurityDomain(s, ctx, x509Cert, securityDomain, value.Id)
+		err = verifyMemberInSecurityDomain(s, ctx, notarization.certificate, securityDomain, notarization.id)
 		if err != nil {
 			return fmt.Errorf("Verify membership failed. Certificate not valid: %s", err.Error())
 		}
 	}
 
 	// 5. Check the notarizations fulfill the verification policy of the request.
-	requiredSigners := verificationPolicy.Criteria
-	for _, signer := range requiredSigners {
-		if !Contains(signerList, signer) {
-			return fmt.Errorf("Notarizations missing signer: %s", signer)
-		}
-	}
-	return nil
+	return verifyPolicySatisfiedBySigners(verificationPolicy, signerList)
 }
 
 // The verifyFabricNotarization function is used to verify views that come from a Fabric network
@@ -288,11 +324,5 @@ func verifyFabricNotarization(s *SmartContract, ctx contractapi.TransactionConte
 		return fmt.Errorf("Response in fabric view does not match response in proposal response")
 	}
 	// 6. Check the notarizations fulfill the verification policy of the request.
-	requiredSigners := verificationPolicy.Criteria
-	for _, signer := range requiredSigners {
-		if !Contains(signerList, signer) {
-			return fmt.Errorf("Notarizations missing signer: %s", signer)
-		}
-	}
-	return nil
+	return verifyPolicySatisfiedBySigners(verificationPolicy, signerList)
 }
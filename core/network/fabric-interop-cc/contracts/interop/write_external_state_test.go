@@ -11,7 +11,9 @@ package main
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/stretchr/testify/require"
 	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
@@ -65,6 +67,9 @@ var network1VerificationPolicy = common.VerificationPolicy{
 	}},
 }
 
+// transaction time within the validity period (2020-07-29 to 2021-07-29) of the endorser certificate in the Fabric view
+var fabricViewTxTimestamp = &timestamp.Timestamp{Seconds: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC).Unix()}
+
 func TestWriteExternalState(t *testing.T) {
 	// Happy case: Fabric
 	ctx, chaincodeStub, interopcc := prepMockStub()
@@ -75,6 +80,7 @@ func TestWriteExternalState(t *testing.T) {
 	require.NoError(t, err)
 	chaincodeStub.GetStateReturnsOnCall(0, network1VerificationPolicyBytes, nil)
 	chaincodeStub.GetStateReturnsOnCall(1, network1MembershipBytes, nil)
+	chaincodeStub.GetTxTimestampReturns(fabricViewTxTimestamp, nil)
 	chaincodeStub.InvokeChaincodeReturns(peer.Response{
 		Status:  200,
 		Message: "",
@@ -94,6 +100,23 @@ func TestWriteExternalState(t *testing.T) {
 	err = interopcc.WriteExternalState(ctx, "network1", "mychannel", "Write", []string{"test-key", ""}, []int{1}, []string{"localhost:9080/network1/mychannel:simplestate:Read:Arcturus"}, []string{})
 	require.EqualError(t, err, "Number of addresses (1) does not match number of views (0)")
 
+	// Test with view contents, which are empty for a view that is not confidential
+	for i := chaincodeStub.GetStateCallCount(); i < chaincodeStub.GetStateCallCount() + 4; i += 2 {
+		chaincodeStub.GetStateReturnsOnCall(i, network1VerificationPolicyBytes, nil)
+		chaincodeStub.GetStateReturnsOnCall(i + 1, network1MembershipBytes, nil)
+	}
+	err = interopcc.WriteExternalStateWithViewContents(ctx, "network1", "mychannel", "Write", []string{"test-key", ""}, []int{1}, []string{"localhost:9080/network1/mychannel:simplestate:Read:Arcturus"}, []string{b64View}, []string{""})
+	require.NoError(t, err)
+
+	err = interopcc.WriteExternalStateWithViewContents(ctx, "network1", "mychannel", "Write", []string{"test-key", ""}, []int{1}, []string{"localhost:9080/network1/mychannel:simplestate:Read:Arcturus"}, []string{b64View}, []string{"", ""})
+	require.EqualError(t, err, "Number of view contents (2) does not match number of views (1)")
+
+	err = interopcc.WriteExternalStateWithViewContents(ctx, "network1", "mychannel", "Write", []string{"test-key", ""}, []int{1}, []string{"localhost:9080/network1/mychannel:simplestate:Read:Arcturus"}, []string{b64View}, []string{"Y29udGVudHM="})
+	require.EqualError(t, err, "View contents supplied for a view that is not confidential")
+
+	_, err = interopcc.ParseAndValidateConfidentialView(ctx, "localhost:9080/network1/mychannel:simplestate:Read:Arcturus", b64View, "")
+	require.EqualError(t, err, "Contents of the confidential view not supplied")
+
 	// Happy case: Corda
 	ctx, chaincodeStub, interopcc = prepMockStub()
 	// mock all the calls to the chaincode stub
@@ -108,7 +131,7 @@ func TestWriteExternalState(t *testing.T) {
 		Message: "",
 		Payload: []byte("I am a result"),
 	})
-	err = interopcc.WriteExternalState(ctx, "network1", "mychannel", "Write", []string{"test-key", ""}, []int{1}, []string{"localhost:9081/Corda_Network/localhost:10006#com.cordaSimpleApplication.flow.GetStateByKey:H"}, []string{cordaB64View})
+	err = interopcc.WriteExternalState(ctx, "network1", "mychannel", "Write", []string{"test-key", ""}, []int{1}, []string{"localhost:9080/Corda_Network/localhost:10006#com.cordaSimpleApplication.flow.GetStateByKey:H"}, []string{cordaB64View})
 	require.NoError(t, err)
 
 	// Test case: Invalid cert in Membership
@@ -118,6 +141,7 @@ func TestWriteExternalState(t *testing.T) {
 	require.NoError(t, err)
 	chaincodeStub.GetStateReturnsOnCall(0, network1VerificationPolicyBytes, nil)
 	chaincodeStub.GetStateReturnsOnCall(1, invalidMembershipBytes, nil)
+	chaincodeStub.GetTxTimestampReturns(fabricViewTxTimestamp, nil)
 	err = interopcc.WriteExternalState(ctx, "network1", "mychannel", "Write", []string{"test-key", ""}, []int{1}, []string{"localhost:9080/network1/mychannel:simplestate:Read:Arcturus"}, []string{b64View})
 	require.EqualError(t, err, "VerifyView error: Verify membership failed. Certificate not valid: Client cert not in a known PEM format")
 
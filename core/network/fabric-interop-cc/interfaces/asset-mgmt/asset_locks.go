@@ -28,6 +28,15 @@ type AssetManagement struct {
 }
 
 
+// LockedAssetsPage is one page of a paginated lock listing; 'Bookmark' is passed to the next query to fetch the
+// following page, and is empty once the listing is exhausted
+type LockedAssetsPage struct {
+    LockedAssets        []string `json:"lockedAssets"`
+    Bookmark            string   `json:"bookmark"`
+    FetchedRecordsCount int32    `json:"fetchedRecordsCount"`
+}
+
+
 // Utility functions
 func (am *AssetManagement) Configure(interopChaincodeId string) {
     am.interopChaincodeId = interopChaincodeId
@@ -78,8 +87,31 @@ func (am *AssetManagement) validateLockInfo(lockInfo *common.AssetLock) error {
         if len(lockInfoHTLC.HashBase64) == 0 {
             return logThenErrorf("empty lock hash value")
         }
-        if lockInfoHTLC.TimeSpec != common.AssetLockHTLC_EPOCH {
-            return logThenErrorf("only EPOCH time is supported at present")
+        if lockInfoHTLC.TimeSpec == common.AssetLockHTLC_DURATION {
+            if lockInfoHTLC.ExpiryTimeSecs == 0 {
+                return logThenErrorf("lock duration must be greater than zero")
+            }
+        } else if lockInfoHTLC.TimeSpec != common.AssetLockHTLC_EPOCH {
+            return logThenErrorf("unsupported time spec: %+v", lockInfoHTLC.TimeSpec)
+        }
+        if _, ok := common.HashMechanism_name[int32(lockInfoHTLC.HashMechanism)]; !ok {
+            return logThenErrorf("unsupported hash mechanism: %+v", lockInfoHTLC.HashMechanism)
+        }
+    } else if (lockInfo.LockMechanism == common.LockMechanism_ESCROW) {
+        lockInfoEscrow := &common.AssetLockEscrow{}
+        err := proto.Unmarshal(lockInfo.LockInfo, lockInfoEscrow)
+        if err != nil {
+            return logThenErrorf(err.Error())
+        }
+        if len(lockInfoEscrow.Arbiter) == 0 {
+            return logThenErrorf("empty escrow arbiter")
+        }
+        if lockInfoEscrow.TimeSpec == common.AssetLockHTLC_DURATION {
+            if lockInfoEscrow.ExpiryTimeSecs == 0 {
+                return logThenErrorf("lock duration must be greater than zero")
+            }
+        } else if lockInfoEscrow.TimeSpec != common.AssetLockHTLC_EPOCH {
+            return logThenErrorf("unsupported time spec: %+v", lockInfoEscrow.TimeSpec)
         }
     } else {
         return logThenErrorf("unsupported lock mechanism: %+v", lockInfo.LockMechanism)
@@ -101,6 +133,15 @@ func (am *AssetManagement) validateClaimInfo(claimInfo *common.AssetClaim) error
         if len(claimInfoHTLC.HashPreimageBase64) == 0 {
             return logThenErrorf("empty lock hash preimage")
         }
+    } else if (claimInfo.LockMechanism == common.LockMechanism_ESCROW) {
+        claimInfoEscrow := &common.AssetClaimEscrow{}
+        err := proto.Unmarshal(claimInfo.ClaimInfo, claimInfoEscrow)
+        if err != nil {
+            return logThenErrorf(err.Error())
+        }
+        if len(claimInfoEscrow.ArbiterSignature) == 0 {
+            return logThenErrorf("empty escrow arbiter signature")
+        }
     } else {
         return logThenErrorf("unsupported lock mechanism: %+v", claimInfo.LockMechanism)
     }
@@ -115,7 +156,7 @@ func (am *AssetManagement) LockAsset(stub shim.ChaincodeStubInterface, assetAgre
     if err != nil {
 	return "", err
     }
-    if len(assetAgreement.Recipient) == 0 {
+    if len(assetAgreement.Recipient) == 0 && len(assetAgreement.Recipients) == 0 {
         return "", logThenErrorf("empty lock recipient")
     }
 
@@ -185,7 +226,107 @@ func (am *AssetManagement) LockFungibleAsset(stub shim.ChaincodeStubInterface, a
     return contractId, nil
 }
 
-// If 'assetAgreement.Locker' or 'assetAgreement.Recipient' is blank, assume it's the caller
+// Lock an asset on behalf of its owner (assetAgreement.Locker), who authorizes the caller in delegation by signing
+// over the base64 encodings of the serialized assetAgreement and lockInfo
+func (am *AssetManagement) LockAssetAsDelegate(stub shim.ChaincodeStubInterface, assetAgreement *common.AssetExchangeAgreement, lockInfo *common.AssetLock, delegation *common.AssetLockDelegation) (string, error) {
+    _, err := am.validateInteropccAssetTypeAssetId(assetAgreement)
+    if err != nil {
+        return "", err
+    }
+    if len(assetAgreement.Recipient) == 0 && len(assetAgreement.Recipients) == 0 {
+        return "", logThenErrorf("empty lock recipient")
+    }
+    if len(assetAgreement.Locker) == 0 {
+        return "", logThenErrorf("empty asset owner")
+    }
+    err = am.validateLockInfo(lockInfo)
+    if err != nil {
+        return "", err
+    }
+    if len(delegation.OwnerSignature) == 0 {
+        return "", logThenErrorf("empty owner signature")
+    }
+
+    assetAgreementBytes, err := proto.Marshal(assetAgreement)
+    if err != nil {
+        return "", logThenErrorf(err.Error())
+    }
+    lockInfoBytes, err := proto.Marshal(lockInfo)
+    if err != nil {
+        return "", logThenErrorf(err.Error())
+    }
+    delegationBytes, err := proto.Marshal(delegation)
+    if err != nil {
+        return "", logThenErrorf(err.Error())
+    }
+    assetAgreementBytes64 := base64.StdEncoding.EncodeToString(assetAgreementBytes)
+    lockInfoBytes64 := base64.StdEncoding.EncodeToString(lockInfoBytes)
+    delegationBytes64 := base64.StdEncoding.EncodeToString(delegationBytes)
+
+    iccResp := stub.InvokeChaincode(am.interopChaincodeId, [][]byte{[]byte("LockAssetAsDelegate"), []byte(assetAgreementBytes64), []byte(lockInfoBytes64), []byte(delegationBytes64)}, "")
+    fmt.Printf("Response from Interop CC: %+v\n", iccResp)
+    if iccResp.GetStatus() != shim.OK {
+        return "", logThenErrorf(string(iccResp.GetMessage()))
+    }
+    contractId := string(iccResp.GetPayload())
+    fmt.Printf("Asset %s of type %s locked on behalf of its owner for %s using contractId %s\n", assetAgreement.Id, assetAgreement.Type, assetAgreement.Recipient, contractId)
+    return contractId, nil
+}
+
+// Lock units of a fungible asset on behalf of their owner (assetAgreement.Locker), who authorizes the caller in
+// delegation by signing over the base64 encodings of the serialized assetAgreement and lockInfo
+func (am *AssetManagement) LockFungibleAssetAsDelegate(stub shim.ChaincodeStubInterface, assetAgreement *common.FungibleAssetExchangeAgreement, lockInfo *common.AssetLock, delegation *common.AssetLockDelegation) (string, error) {
+    if len(am.interopChaincodeId) == 0 {
+        return "", logThenErrorf("interoperation chaincode ID not set. Run the 'Configure(...)' function first.")
+    }
+
+    if len(assetAgreement.Type) == 0 {
+        return "", logThenErrorf("empty asset type")
+    }
+    if assetAgreement.NumUnits <= 0 {
+        return "", logThenErrorf("invalid number of asset units")
+    }
+    if len(assetAgreement.Recipient) == 0 {
+        return "", logThenErrorf("empty lock recipient")
+    }
+    if len(assetAgreement.Locker) == 0 {
+        return "", logThenErrorf("empty asset owner")
+    }
+    err := am.validateLockInfo(lockInfo)
+    if err != nil {
+        return "", err
+    }
+    if len(delegation.OwnerSignature) == 0 {
+        return "", logThenErrorf("empty owner signature")
+    }
+
+    assetAgreementBytes, err := proto.Marshal(assetAgreement)
+    if err != nil {
+        return "", logThenErrorf(err.Error())
+    }
+    lockInfoBytes, err := proto.Marshal(lockInfo)
+    if err != nil {
+        return "", logThenErrorf(err.Error())
+    }
+    delegationBytes, err := proto.Marshal(delegation)
+    if err != nil {
+        return "", logThenErrorf(err.Error())
+    }
+    assetAgreementBytes64 := base64.StdEncoding.EncodeToString(assetAgreementBytes)
+    lockInfoBytes64 := base64.StdEncoding.EncodeToString(lockInfoBytes)
+    delegationBytes64 := base64.StdEncoding.EncodeToString(delegationBytes)
+
+    iccResp := stub.InvokeChaincode(am.interopChaincodeId, [][]byte{[]byte("LockFungibleAssetAsDelegate"), []byte(assetAgreementBytes64), []byte(lockInfoBytes64), []byte(delegationBytes64)}, "")
+    fmt.Printf("Response from Interop CC: %+v\n", iccResp)
+    if iccResp.GetStatus() != shim.OK {
+        return "", logThenErrorf(string(iccResp.GetMessage()))
+    }
+    contractId := string(iccResp.GetPayload())
+    fmt.Printf("%d units of asset type %s locked on behalf of their owner for %s using contractId %s\n", assetAgreement.NumUnits, assetAgreement.Type, assetAgreement.Recipient, contractId)
+    return contractId, nil
+}
+
+// If 'assetAgreement.Locker' or 'assetAgreement.Recipient' is blank, assume it's the caller (unless the agreement lists several recipients)
 func (am *AssetManagement) IsAssetLocked(stub shim.ChaincodeStubInterface, assetAgreement *common.AssetExchangeAgreement) (bool, error) {
     _, err := am.validateInteropccAssetTypeAssetId(assetAgreement)
     if err != nil {
@@ -198,7 +339,7 @@ func (am *AssetManagement) IsAssetLocked(stub shim.ChaincodeStubInterface, asset
         return false, err
     }
     myself := string(myselfBytes)
-    if len(assetAgreement.Recipient) == 0 {
+    if len(assetAgreement.Recipient) == 0 && len(assetAgreement.Recipients) == 0 {
         log.Info("empty lock recipient; assuming caller")
         assetAgreement.Recipient = myself
     }
@@ -303,28 +444,42 @@ func (am *AssetManagement) ClaimAsset(stub shim.ChaincodeStubInterface, assetAgr
 }
 
 func (am *AssetManagement) ClaimFungibleAsset(stub shim.ChaincodeStubInterface, contractId string, claimInfo *common.AssetClaim) (bool, error) {
+    _, err := am.ClaimFungibleAssetUnits(stub, contractId, claimInfo)
+    if err != nil {
+        return false, err
+    }
+    return true, nil
+}
+
+// Claim the units of a fungible asset locked using contractId, returning the number of units claimed;
+// an HTLC claim may ask for a subset of the locked units, in which case the remaining units stay locked
+func (am *AssetManagement) ClaimFungibleAssetUnits(stub shim.ChaincodeStubInterface, contractId string, claimInfo *common.AssetClaim) (uint64, error) {
     _, err := am.validateInteropccContractId(contractId)
     if err != nil {
-	return false, err
+	return 0, err
     }
 
     err = am.validateClaimInfo(claimInfo)
     if err != nil {
-	return false, err
+	return 0, err
     }
 
     claimInfoBytes, err := proto.Marshal(claimInfo)
     if err != nil {
-        return false, logThenErrorf(err.Error())
+        return 0, logThenErrorf(err.Error())
     }
     claimInfoBytes64 := base64.StdEncoding.EncodeToString(claimInfoBytes)
     iccResp := stub.InvokeChaincode(am.interopChaincodeId, [][]byte{[]byte("ClaimFungibleAsset"), []byte(contractId), []byte(claimInfoBytes64)}, "")
     fmt.Printf("Response from Interop CC: %+v\n", iccResp)
     if iccResp.GetStatus() != shim.OK {
-        return false, logThenErrorf(string(iccResp.GetMessage()))
+        return 0, logThenErrorf(string(iccResp.GetMessage()))
     }
-    fmt.Printf("Fungible asset locked using contractId %s is claimed\n", contractId)
-    return true, nil
+    numUnits, err := strconv.ParseUint(string(iccResp.Payload), 10, 64)
+    if err != nil {
+        return 0, logThenErrorf(err.Error())
+    }
+    fmt.Printf("%d units of fungible asset locked using contractId %s are claimed\n", numUnits, contractId)
+    return numUnits, nil
 }
 
 func (am *AssetManagement) ClaimAssetUsingContractId(stub shim.ChaincodeStubInterface, contractId string, claimInfo *common.AssetClaim) (bool, error) {
@@ -352,12 +507,49 @@ func (am *AssetManagement) ClaimAssetUsingContractId(stub shim.ChaincodeStubInte
     return true, nil
 }
 
+// Claim atomically the HTLCs associated with 'contractIds', which are all locked with the hash whose preimage is in 'claimInfo'
+func (am *AssetManagement) ClaimAssetsUsingContractIds(stub shim.ChaincodeStubInterface, contractIds []string, claimInfo *common.AssetClaim) (bool, error) {
+    if len(contractIds) == 0 {
+        return false, logThenErrorf("empty list of contract ids")
+    }
+    for _, contractId := range contractIds {
+        _, err := am.validateInteropccContractId(contractId)
+        if err != nil {
+            return false, err
+        }
+    }
+    if claimInfo.LockMechanism != common.LockMechanism_HTLC {
+        return false, logThenErrorf("batch claims are only supported for the HTLC lock mechanism")
+    }
+    err := am.validateClaimInfo(claimInfo)
+    if err != nil {
+        return false, err
+    }
+
+    contractIdsBytes, err := json.Marshal(contractIds)
+    if err != nil {
+        return false, logThenErrorf(err.Error())
+    }
+    claimInfoBytes, err := proto.Marshal(claimInfo)
+    if err != nil {
+        return false, logThenErrorf(err.Error())
+    }
+    claimInfoBytes64 := base64.StdEncoding.EncodeToString(claimInfoBytes)
+    iccResp := stub.InvokeChaincode(am.interopChaincodeId, [][]byte{[]byte("ClaimAssetsUsingContractIds"), contractIdsBytes, []byte(claimInfoBytes64)}, "")
+    fmt.Printf("Response from Interop CC: %+v\n", iccResp)
+    if iccResp.GetStatus() != shim.OK {
+        return false, logThenErrorf(string(iccResp.GetMessage()))
+    }
+    fmt.Printf("assets locked using contractIds %v are claimed\n", contractIds)
+    return true, nil
+}
+
 func (am *AssetManagement) UnlockAsset(stub shim.ChaincodeStubInterface, assetAgreement *common.AssetExchangeAgreement) (bool, error) {
     _, err := am.validateInteropccAssetTypeAssetId(assetAgreement)
     if err != nil {
 	return false, err
     }
-    if len(assetAgreement.Recipient) == 0 {
+    if len(assetAgreement.Recipient) == 0 && len(assetAgreement.Recipients) == 0 {
         return false, logThenErrorf("empty lock recipient")
     }
 
@@ -406,6 +598,102 @@ func (am *AssetManagement) UnlockAssetUsingContractId(stub shim.ChaincodeStubInt
 }
 
 
+// Push out the expiry time of the lock associated with extensionInfo.ContractId, with the recipient's consent carried in extensionInfo
+func (am *AssetManagement) ExtendLockExpiry(stub shim.ChaincodeStubInterface, extensionInfo *common.AssetLockExtension) (bool, error) {
+    _, err := am.validateInteropccContractId(extensionInfo.ContractId)
+    if err != nil {
+        return false, err
+    }
+    if extensionInfo.NewExpiryTimeSecs == 0 {
+        return false, logThenErrorf("invalid new expiry time")
+    }
+    if len(extensionInfo.RecipientSignature) == 0 {
+        return false, logThenErrorf("empty recipient signature")
+    }
+
+    extensionInfoBytes, err := proto.Marshal(extensionInfo)
+    if err != nil {
+        return false, logThenErrorf(err.Error())
+    }
+    extensionInfoBytes64 := base64.StdEncoding.EncodeToString(extensionInfoBytes)
+    iccResp := stub.InvokeChaincode(am.interopChaincodeId, [][]byte{[]byte("ExtendLockExpiry"), []byte(extensionInfo.ContractId), []byte(extensionInfoBytes64)}, "")
+    fmt.Printf("Response from Interop CC: %+v\n", iccResp)
+    if iccResp.GetStatus() != shim.OK {
+        return false, logThenErrorf(string(iccResp.GetMessage()))
+    }
+    fmt.Printf("Lock associated with contractId %s extended until %+v\n", extensionInfo.ContractId, time.Unix(int64(extensionInfo.NewExpiryTimeSecs), 0))
+    return true, nil
+}
+
+// invoke a batch unlock function of the interop CC and decode the outcome of the release of each lock, which the
+// interop CC returns as a serialized 'AssetBatchUnlockOutcomes' in base64 form
+func (am *AssetManagement) unlockExpiredAssetsFunc(stub shim.ChaincodeStubInterface, funcName string, args ...[]byte) (*common.AssetBatchUnlockOutcomes, error) {
+    iccResp := stub.InvokeChaincode(am.interopChaincodeId, append([][]byte{[]byte(funcName)}, args...), "")
+    fmt.Printf("Response from Interop CC: %+v\n", iccResp)
+    if iccResp.GetStatus() != shim.OK {
+        return nil, logThenErrorf(string(iccResp.GetMessage()))
+    }
+    outcomesBytes, err := base64.StdEncoding.DecodeString(string(iccResp.GetPayload()))
+    if err != nil {
+        return nil, logThenErrorf(err.Error())
+    }
+    outcomes := &common.AssetBatchUnlockOutcomes{}
+    err = proto.Unmarshal(outcomesBytes, outcomes)
+    if err != nil {
+        return nil, logThenErrorf(err.Error())
+    }
+    numUnlocked := 0
+    for _, outcome := range outcomes.Outcomes {
+        if outcome.Unlocked {
+            numUnlocked++
+        }
+    }
+    fmt.Printf("Unlocked %d of %d expired locks\n", numUnlocked, len(outcomes.Outcomes))
+    return outcomes, nil
+}
+
+// Release the expired locks associated with 'contractIds' in one transaction, reporting the outcome for each lock
+func (am *AssetManagement) UnlockExpiredAssetsUsingContractIds(stub shim.ChaincodeStubInterface, contractIds []string) (*common.AssetBatchUnlockOutcomes, error) {
+    if len(am.interopChaincodeId) == 0 {
+        return nil, logThenErrorf("interoperation chaincode ID not set. Run the 'Configure(...)' function first.")
+    }
+    if len(contractIds) == 0 {
+        return nil, logThenErrorf("empty list of contract ids")
+    }
+    for _, contractId := range contractIds {
+        if len(contractId) == 0 {
+            return nil, logThenErrorf("empty contract id")
+        }
+    }
+
+    contractIdsBytes, err := json.Marshal(contractIds)
+    if err != nil {
+        return nil, logThenErrorf(err.Error())
+    }
+    return am.unlockExpiredAssetsFunc(stub, "UnlockExpiredAssetsUsingContractIds", contractIdsBytes)
+}
+
+// Release, in one transaction, all the locks held by 'locker' that expire at or before 'expiryCutoffSecs' and have expired
+// 'locker': if blank, assume caller
+func (am *AssetManagement) UnlockExpiredAssetsOfLocker(stub shim.ChaincodeStubInterface, locker string, expiryCutoffSecs uint64) (*common.AssetBatchUnlockOutcomes, error) {
+    if len(am.interopChaincodeId) == 0 {
+        return nil, logThenErrorf("interoperation chaincode ID not set. Run the 'Configure(...)' function first.")
+    }
+    if expiryCutoffSecs == 0 {
+        return nil, logThenErrorf("invalid expiry cutoff time")
+    }
+    if len(locker) == 0 {
+        myselfBytes, err := stub.GetCreator()
+        if err != nil {
+            return nil, logThenErrorf(err.Error())
+        }
+        log.Info("empty locker; assuming caller")
+        locker = string(myselfBytes)
+    }
+
+    return am.unlockExpiredAssetsFunc(stub, "UnlockExpiredAssetsOfLocker", []byte(locker), []byte(strconv.FormatUint(expiryCutoffSecs, 10)))
+}
+
 // Ledger query functions
 
 func (am *AssetManagement) GetTotalFungibleLockedAssets(stub shim.ChaincodeStubInterface, assetType string) (uint64, error) {
@@ -434,16 +722,10 @@ func (am *AssetManagement) GetTotalFungibleLockedAssets(stub shim.ChaincodeStubI
 
 // 'lockRecipient': if blank, assume caller
 // 'locker': if blank, assume caller
-func (am *AssetManagement) GetAllLockedAssetsFunc(stub shim.ChaincodeStubInterface, funcName string, lockRecipient string, locker string) ([]string, error) {
-    var assets []string
-
-    if len(am.interopChaincodeId) == 0 {
-        return []string{}, logThenErrorf("interoperation chaincode ID not set. Run the 'Configure(...)' function first.")
-    }
-
+func resolveLockedAssetsQueryParties(stub shim.ChaincodeStubInterface, lockRecipient string, locker string) (string, string, error) {
     myselfBytes, err := stub.GetCreator()
     if err != nil {
-        return []string{}, logThenErrorf(err.Error())
+        return "", "", logThenErrorf(err.Error())
     }
     myself := string(myselfBytes)
     if len(lockRecipient) == 0 {
@@ -455,7 +737,23 @@ func (am *AssetManagement) GetAllLockedAssetsFunc(stub shim.ChaincodeStubInterfa
         locker = myself
     }
     if lockRecipient == locker {
-        return []string{}, logThenErrorf("invalid query: locker identical to recipient")
+        return "", "", logThenErrorf("invalid query: locker identical to recipient")
+    }
+    return lockRecipient, locker, nil
+}
+
+// 'lockRecipient': if blank, assume caller
+// 'locker': if blank, assume caller
+func (am *AssetManagement) GetAllLockedAssetsFunc(stub shim.ChaincodeStubInterface, funcName string, lockRecipient string, locker string) ([]string, error) {
+    var assets []string
+
+    if len(am.interopChaincodeId) == 0 {
+        return []string{}, logThenErrorf("interoperation chaincode ID not set. Run the 'Configure(...)' function first.")
+    }
+
+    lockRecipient, locker, err := resolveLockedAssetsQueryParties(stub, lockRecipient, locker)
+    if err != nil {
+        return []string{}, err
     }
     iccResp := stub.InvokeChaincode(am.interopChaincodeId, [][]byte{[]byte(funcName), []byte(lockRecipient), []byte(locker)}, "")
     fmt.Printf("Response from Interop CC: %+v\n", iccResp)
@@ -470,6 +768,49 @@ func (am *AssetManagement) GetAllLockedAssetsFunc(stub shim.ChaincodeStubInterfa
     return assets, nil
 }
 
+// invoke a paginated lock listing function of the interop CC, passing 'args' ahead of the page size and bookmark
+func (am *AssetManagement) getLockedAssetsPage(stub shim.ChaincodeStubInterface, funcName string, args []string, pageSize int32, bookmark string) (*LockedAssetsPage, error) {
+    if pageSize <= 0 {
+        return nil, logThenErrorf("invalid page size %d", pageSize)
+    }
+    iccArgs := [][]byte{[]byte(funcName)}
+    for _, arg := range args {
+        iccArgs = append(iccArgs, []byte(arg))
+    }
+    iccArgs = append(iccArgs, []byte(strconv.FormatInt(int64(pageSize), 10)), []byte(bookmark))
+    iccResp := stub.InvokeChaincode(am.interopChaincodeId, iccArgs, "")
+    fmt.Printf("Response from Interop CC: %+v\n", iccResp)
+    if iccResp.GetStatus() != shim.OK {
+        return nil, logThenErrorf(string(iccResp.GetMessage()))
+    }
+    page := &LockedAssetsPage{}
+    err := json.Unmarshal(iccResp.Payload, page)
+    if err != nil {
+        return nil, logThenErrorf(err.Error())
+    }
+    return page, nil
+}
+
+// 'lockRecipient': if blank, assume caller
+// 'locker': if blank, assume caller
+// 'bookmark': if blank, fetch the first page
+func (am *AssetManagement) GetAllLockedAssetsWithPaginationFunc(stub shim.ChaincodeStubInterface, funcName string, lockRecipient string, locker string, pageSize int32, bookmark string) (*LockedAssetsPage, error) {
+    if len(am.interopChaincodeId) == 0 {
+        return nil, logThenErrorf("interoperation chaincode ID not set. Run the 'Configure(...)' function first.")
+    }
+
+    lockRecipient, locker, err := resolveLockedAssetsQueryParties(stub, lockRecipient, locker)
+    if err != nil {
+        return nil, err
+    }
+    page, err := am.getLockedAssetsPage(stub, funcName, []string{lockRecipient, locker}, pageSize, bookmark)
+    if err != nil {
+        return nil, err
+    }
+    fmt.Printf("Obtained a page of info for %d assets locked by %s for %s\n", len(page.LockedAssets), locker, lockRecipient)
+    return page, nil
+}
+
 func (am *AssetManagement) GetAllLockedAssets(stub shim.ChaincodeStubInterface, lockRecipient string, locker string) ([]string, error) {
     return am.GetAllLockedAssetsFunc(stub, "GetAllLockedAssets", lockRecipient, locker)
 }
@@ -482,6 +823,18 @@ func (am *AssetManagement) GetAllFungibleLockedAssets(stub shim.ChaincodeStubInt
     return am.GetAllLockedAssetsFunc(stub, "GetAllFungibleLockedAssets", lockRecipient, locker)
 }
 
+func (am *AssetManagement) GetAllLockedAssetsWithPagination(stub shim.ChaincodeStubInterface, lockRecipient string, locker string, pageSize int32, bookmark string) (*LockedAssetsPage, error) {
+    return am.GetAllLockedAssetsWithPaginationFunc(stub, "GetAllLockedAssetsWithPagination", lockRecipient, locker, pageSize, bookmark)
+}
+
+func (am *AssetManagement) GetAllNonFungibleLockedAssetsWithPagination(stub shim.ChaincodeStubInterface, lockRecipient string, locker string, pageSize int32, bookmark string) (*LockedAssetsPage, error) {
+    return am.GetAllLockedAssetsWithPaginationFunc(stub, "GetAllNonFungibleLockedAssetsWithPagination", lockRecipient, locker, pageSize, bookmark)
+}
+
+func (am *AssetManagement) GetAllFungibleLockedAssetsWithPagination(stub shim.ChaincodeStubInterface, lockRecipient string, locker string, pageSize int32, bookmark string) (*LockedAssetsPage, error) {
+    return am.GetAllLockedAssetsWithPaginationFunc(stub, "GetAllFungibleLockedAssetsWithPagination", lockRecipient, locker, pageSize, bookmark)
+}
+
 // 'lockRecipient': if blank, assume caller
 // 'locker': if blank, assume caller
 func (am *AssetManagement) GetAssetTimeToRelease(stub shim.ChaincodeStubInterface, assetAgreement *common.AssetExchangeAgreement) (uint64, error) {
@@ -572,6 +925,76 @@ func (am *AssetManagement) GetFungibleAssetTimeToRelease(stub shim.ChaincodeStub
     return uint64(timeToReleaseSecs), nil
 }
 
+func (am *AssetManagement) GetAssetTimeToReleaseUsingContractIdFunc(stub shim.ChaincodeStubInterface, funcName string, contractId string) (uint64, error) {
+    _, err := am.validateInteropccContractId(contractId)
+    if err != nil {
+        return 0, err
+    }
+
+    iccResp := stub.InvokeChaincode(am.interopChaincodeId, [][]byte{[]byte(funcName), []byte(contractId)}, "")
+    fmt.Printf("Response from Interop CC: %+v\n", iccResp)
+    if iccResp.GetStatus() != shim.OK {
+        return 0, logThenErrorf(string(iccResp.GetMessage()))
+    }
+    timeToReleaseSecs, err := strconv.ParseInt(string(iccResp.Payload), 10, 64)
+    if err != nil {
+        return 0, logThenErrorf(err.Error())
+    }
+    if timeToReleaseSecs < 0 {
+        return 0, logThenErrorf("asset time to release must be a positive integer; found " + string(iccResp.Payload) + " instead")
+    }
+    fmt.Printf("Asset locked using contractId %s locked until %+v\n", contractId, time.Unix(timeToReleaseSecs, 0))
+    return uint64(timeToReleaseSecs), nil
+}
+
+func (am *AssetManagement) GetAssetTimeToReleaseUsingContractId(stub shim.ChaincodeStubInterface, contractId string) (uint64, error) {
+    return am.GetAssetTimeToReleaseUsingContractIdFunc(stub, "GetAssetTimeToReleaseUsingContractId", contractId)
+}
+
+func (am *AssetManagement) GetFungibleAssetTimeToReleaseUsingContractId(stub shim.ChaincodeStubInterface, contractId string) (uint64, error) {
+    return am.GetAssetTimeToReleaseUsingContractIdFunc(stub, "GetFungibleAssetTimeToReleaseUsingContractId", contractId)
+}
+
+// Fetch the hash preimage (in base64 form) revealed when the HTLC associated with contractId was claimed
+func (am *AssetManagement) GetHTLCHashPreImage(stub shim.ChaincodeStubInterface, contractId string) (string, error) {
+    _, err := am.validateInteropccContractId(contractId)
+    if err != nil {
+        return "", err
+    }
+
+    iccResp := stub.InvokeChaincode(am.interopChaincodeId, [][]byte{[]byte("GetHTLCHashPreImage"), []byte(contractId)}, "")
+    fmt.Printf("Response from Interop CC: %+v\n", iccResp)
+    if iccResp.GetStatus() != shim.OK {
+        return "", logThenErrorf(string(iccResp.GetMessage()))
+    }
+    fmt.Printf("Obtained the hash preimage revealed by the claim of contractId %s\n", contractId)
+    return string(iccResp.Payload), nil
+}
+
+// Fetch the contractIds of the asset locks created by the transaction txId
+func (am *AssetManagement) GetContractIdsOfTxId(stub shim.ChaincodeStubInterface, txId string) ([]string, error) {
+    var contractIds []string
+
+    if len(am.interopChaincodeId) == 0 {
+        return []string{}, logThenErrorf("interoperation chaincode ID not set. Run the 'Configure(...)' function first.")
+    }
+    if len(txId) == 0 {
+        return []string{}, logThenErrorf("empty transaction id")
+    }
+
+    iccResp := stub.InvokeChaincode(am.interopChaincodeId, [][]byte{[]byte("GetContractIdsOfTxId"), []byte(txId)}, "")
+    fmt.Printf("Response from Interop CC: %+v\n", iccResp)
+    if iccResp.GetStatus() != shim.OK {
+        return []string{}, logThenErrorf(string(iccResp.GetMessage()))
+    }
+    err := json.Unmarshal(iccResp.Payload, &contractIds)
+    if err != nil {
+        return []string{}, logThenErrorf(err.Error())
+    }
+    fmt.Printf("Obtained %d contractIds of the asset locks created by transaction %s\n", len(contractIds), txId)
+    return contractIds, nil
+}
+
 // Assumption is that the caller is either the recipient or the locker in each element in the list, but we will let the interop CC take care of it
 func (am *AssetManagement) GetAllAssetsLockedUntil(stub shim.ChaincodeStubInterface, lockExpiryTimeSecs uint64) ([]string, error) {
     var assets []string
@@ -595,3 +1018,138 @@ func (am *AssetManagement) GetAllAssetsLockedUntil(stub shim.ChaincodeStubInterf
     fmt.Printf("Obtained info for %d assets locked until %+v\n", len(assets), time.Unix(int64(lockExpiryTimeSecs), 0))
     return assets, nil
 }
+
+// Assumption is that the caller is either the recipient or the locker in each element in the list, but we will let the interop CC take care of it
+func (am *AssetManagement) GetAllAssetsLockedUntilWithPagination(stub shim.ChaincodeStubInterface, lockExpiryTimeSecs uint64, pageSize int32, bookmark string) (*LockedAssetsPage, error) {
+    if len(am.interopChaincodeId) == 0 {
+        return nil, logThenErrorf("interoperation chaincode ID not set. Run the 'Configure(...)' function first.")
+    }
+
+    if lockExpiryTimeSecs <= 0 {
+        return nil, logThenErrorf("invalid expiry time")
+    }
+    page, err := am.getLockedAssetsPage(stub, "GetAllAssetsLockedUntilWithPagination", []string{strconv.FormatInt(int64(lockExpiryTimeSecs), 10)}, pageSize, bookmark)
+    if err != nil {
+        return nil, err
+    }
+    fmt.Printf("Obtained a page of info for %d assets locked until %+v\n", len(page.LockedAssets), time.Unix(int64(lockExpiryTimeSecs), 0))
+    return page, nil
+}
+
+// Asset bundle functions
+
+func (am *AssetManagement) LockAssetBundle(stub shim.ChaincodeStubInterface, bundleAgreement *common.AssetBundleExchangeAgreement, lockInfo *common.AssetLock) (string, error) {
+    if len(am.interopChaincodeId) == 0 {
+        return "", logThenErrorf("interoperation chaincode ID not set. Run the 'Configure(...)' function first.")
+    }
+    if len(bundleAgreement.Assets) == 0 {
+        return "", logThenErrorf("empty asset bundle")
+    }
+    if len(bundleAgreement.Recipient) == 0 {
+        return "", logThenErrorf("empty lock recipient")
+    }
+
+    bundleAgreementBytes, err := proto.Marshal(bundleAgreement)
+    if err != nil {
+        return "", logThenErrorf(err.Error())
+    }
+    err = am.validateLockInfo(lockInfo)
+    if err != nil {
+        return "", err
+    }
+    lockInfoBytes, err := proto.Marshal(lockInfo)
+    if err != nil {
+        return "", logThenErrorf(err.Error())
+    }
+    bundleAgreementBytes64 := base64.StdEncoding.EncodeToString(bundleAgreementBytes)
+    lockInfoBytes64 := base64.StdEncoding.EncodeToString(lockInfoBytes)
+
+    iccResp := stub.InvokeChaincode(am.interopChaincodeId, [][]byte{[]byte("LockAssetBundle"), []byte(bundleAgreementBytes64), []byte(lockInfoBytes64)}, "")
+    fmt.Printf("Response from Interop CC: %+v\n", iccResp)
+    if iccResp.GetStatus() != shim.OK {
+        return "", logThenErrorf(string(iccResp.GetMessage()))
+    }
+    contractId := string(iccResp.GetPayload())
+    fmt.Printf("Bundle of %d assets locked for %s using contractId %s\n", len(bundleAgreement.Assets), bundleAgreement.Recipient, contractId)
+    return contractId, nil
+}
+
+func (am *AssetManagement) IsAssetBundleLocked(stub shim.ChaincodeStubInterface, contractId string) (bool, error) {
+    _, err := am.validateInteropccContractId(contractId)
+    if err != nil {
+        return false, err
+    }
+
+    iccResp := stub.InvokeChaincode(am.interopChaincodeId, [][]byte{[]byte("IsAssetBundleLocked"), []byte(contractId)}, "")
+    fmt.Printf("Response from Interop CC: %+v\n", iccResp)
+    if iccResp.GetStatus() != shim.OK {
+        return false, logThenErrorf(string(iccResp.GetMessage()))
+    }
+    isLocked := (string(iccResp.Payload) == fmt.Sprintf("%t", true))
+    if isLocked {
+        fmt.Printf("contractId %s is associated with a locked asset bundle\n", contractId)
+    } else {
+        fmt.Printf("contractId %s is not associated with a locked asset bundle\n", contractId)
+    }
+    return isLocked, nil
+}
+
+func (am *AssetManagement) ClaimAssetBundle(stub shim.ChaincodeStubInterface, contractId string, claimInfo *common.AssetClaim) (bool, error) {
+    _, err := am.validateInteropccContractId(contractId)
+    if err != nil {
+        return false, err
+    }
+
+    err = am.validateClaimInfo(claimInfo)
+    if err != nil {
+        return false, err
+    }
+
+    claimInfoBytes, err := proto.Marshal(claimInfo)
+    if err != nil {
+        return false, logThenErrorf(err.Error())
+    }
+    claimInfoBytes64 := base64.StdEncoding.EncodeToString(claimInfoBytes)
+    iccResp := stub.InvokeChaincode(am.interopChaincodeId, [][]byte{[]byte("ClaimAssetBundle"), []byte(contractId), []byte(claimInfoBytes64)}, "")
+    fmt.Printf("Response from Interop CC: %+v\n", iccResp)
+    if iccResp.GetStatus() != shim.OK {
+        return false, logThenErrorf(string(iccResp.GetMessage()))
+    }
+    fmt.Printf("Claimed asset bundle locked using contractId %s\n", contractId)
+    return true, nil
+}
+
+func (am *AssetManagement) UnlockAssetBundle(stub shim.ChaincodeStubInterface, contractId string) (bool, error) {
+    _, err := am.validateInteropccContractId(contractId)
+    if err != nil {
+        return false, err
+    }
+
+    iccResp := stub.InvokeChaincode(am.interopChaincodeId, [][]byte{[]byte("UnlockAssetBundle"), []byte(contractId)}, "")
+    fmt.Printf("Response from Interop CC: %+v\n", iccResp)
+    if iccResp.GetStatus() != shim.OK {
+        return false, logThenErrorf(string(iccResp.GetMessage()))
+    }
+    fmt.Printf("Asset bundle locked using contractId %s is unlocked\n", contractId)
+    return true, nil
+}
+
+// Returns the assets of the bundle as a JSON array of '{"type": ..., "id": ..., "numUnits": ...}' elements
+func (am *AssetManagement) GetAssetBundleUsingContractId(stub shim.ChaincodeStubInterface, contractId string) (string, error) {
+    _, err := am.validateInteropccContractId(contractId)
+    if err != nil {
+        return "", err
+    }
+
+    iccResp := stub.InvokeChaincode(am.interopChaincodeId, [][]byte{[]byte("GetAssetBundleUsingContractId"), []byte(contractId)}, "")
+    fmt.Printf("Response from Interop CC: %+v\n", iccResp)
+    if iccResp.GetStatus() != shim.OK {
+        return "", logThenErrorf(string(iccResp.GetMessage()))
+    }
+    fmt.Printf("Obtained the asset bundle locked using contractId %s\n", contractId)
+    return string(iccResp.Payload), nil
+}
+
+func (am *AssetManagement) GetAssetBundleTimeToReleaseUsingContractId(stub shim.ChaincodeStubInterface, contractId string) (uint64, error) {
+    return am.GetAssetTimeToReleaseUsingContractIdFunc(stub, "GetAssetBundleTimeToReleaseUsingContractId", contractId)
+}
@@ -9,6 +9,7 @@ package assetmgmt
 import (
     "encoding/base64"
     "encoding/json"
+    "errors"
 
     "github.com/golang/protobuf/proto"
     "github.com/hyperledger/fabric-contract-api-go/contractapi"
@@ -21,6 +22,8 @@ import (
 type AssetManagementContract struct {
     contractapi.Contract
     assetManagement AssetManagement
+    // Set by the application chaincode to take part in asset transfers across networks (see asset_transfer_contract.go)
+    AssetTransferHooks AssetTransferHooks
 }
 
 // Utility functions
@@ -46,6 +49,24 @@ type ContractedFungibleAsset struct {
     NumUnits	uint64	`json:"id"`
 }
 
+// Convert a lock duration into the absolute expiry time recorded by the interop CC for the lock.
+// The interop CC computes it from the timestamp of this same transaction, so both arrive at the same value.
+func setLockExpiryTimeFromDuration(ctx contractapi.TransactionContextInterface, timeSpec *common.AssetLockHTLC_TimeSpec, expiryTimeSecs *uint64) error {
+    if *timeSpec != common.AssetLockHTLC_DURATION {
+        return nil
+    }
+    txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+    if err != nil {
+        return err
+    }
+    if txTimestamp == nil {
+        return errors.New("transaction timestamp is not available")
+    }
+    *expiryTimeSecs = uint64(txTimestamp.Seconds) + *expiryTimeSecs
+    *timeSpec = common.AssetLockHTLC_EPOCH
+    return nil
+}
+
 func getAssetLockLookupMapKey(ctx contractapi.TransactionContextInterface, Type, Id string) (string, error) {
     assetLockKey, err := ctx.GetStub().CreateCompositeKey("AssetExchangeContract", []string{Type, Id})
     if err != nil {
@@ -289,6 +310,51 @@ func (amc *AssetManagementContract) ValidateAndExtractClaimInfo(claimInfoSeriali
 
 // Ledger transaction (invocation) functions
 
+// emit the event recording the lock of a non-fungible asset, with the details of the contract set up for the lock
+func setLockAssetEvent(ctx contractapi.TransactionContextInterface, contractId string, assetAgreement *common.AssetExchangeAgreement, lockInfo *common.AssetLock) error {
+    var err error
+    var contractInfoBytes []byte
+    eventName := "LockAsset"
+    if lockInfo.LockMechanism == common.LockMechanism_HTLC {
+        lockInfoVal := &common.AssetLockHTLC{}
+        err = proto.Unmarshal(lockInfo.LockInfo, lockInfoVal)
+        if err == nil {
+            err = setLockExpiryTimeFromDuration(ctx, &lockInfoVal.TimeSpec, &lockInfoVal.ExpiryTimeSecs)
+        }
+        if err == nil {
+            contractInfo := &common.AssetContractHTLC {
+                ContractId: contractId,
+                Agreement: assetAgreement,
+                Lock: lockInfoVal,
+            }
+            contractInfoBytes, err = proto.Marshal(contractInfo)
+        }
+    } else if lockInfo.LockMechanism == common.LockMechanism_ESCROW {
+        eventName = "LockAssetEscrow"
+        lockInfoVal := &common.AssetLockEscrow{}
+        err = proto.Unmarshal(lockInfo.LockInfo, lockInfoVal)
+        if err == nil {
+            err = setLockExpiryTimeFromDuration(ctx, &lockInfoVal.TimeSpec, &lockInfoVal.ExpiryTimeSecs)
+        }
+        if err == nil {
+            contractInfo := &common.AssetContractEscrow {
+                ContractId: contractId,
+                Agreement: assetAgreement,
+                Lock: lockInfoVal,
+            }
+            contractInfoBytes, err = proto.Marshal(contractInfo)
+        }
+    } else {
+        logWarnings("lock mechanism is not supported")
+    }
+    if err == nil {
+        err = ctx.GetStub().SetEvent(eventName, contractInfoBytes)
+    } else {
+        logWarnings("Unable to set '" + eventName + "' event", err.Error())
+    }
+    return err
+}
+
 func (amc *AssetManagementContract) LockAsset(ctx contractapi.TransactionContextInterface, assetAgreementSerializedProto64 string, lockInfoSerializedProto64 string) (string, error) {
     assetAgreement, err := amc.ValidateAndExtractAssetAgreement(assetAgreementSerializedProto64)
     if err != nil {
@@ -302,29 +368,55 @@ func (amc *AssetManagementContract) LockAsset(ctx contractapi.TransactionContext
     // The below 'SetEvent' should be the last in a given transaction (if this function is being called by another), otherwise it will be overridden
     contractId, err := amc.assetManagement.LockAsset(ctx.GetStub(), assetAgreement, lockInfo)
     if err == nil {
-	var contractInfoBytes []byte
-        if lockInfo.LockMechanism == common.LockMechanism_HTLC {
-            lockInfoVal := &common.AssetLockHTLC{}
-            err = proto.Unmarshal(lockInfo.LockInfo, lockInfoVal)
-            if err == nil {
-                contractInfo := &common.AssetContractHTLC {
-                    ContractId: contractId,
-                    Agreement: assetAgreement,
-                    Lock: lockInfoVal,
-                }
-                contractInfoBytes, err = proto.Marshal(contractInfo)
+        err = setLockAssetEvent(ctx, contractId, assetAgreement, lockInfo)
+    }
+
+    return contractId, err
+}
+
+// emit the event recording the lock of fungible asset units, with the details of the contract set up for the lock
+func setLockFungibleAssetEvent(ctx contractapi.TransactionContextInterface, contractId string, assetAgreement *common.FungibleAssetExchangeAgreement, lockInfo *common.AssetLock) error {
+    var err error
+    var contractInfoBytes []byte
+    eventName := "LockFungibleAsset"
+    if lockInfo.LockMechanism == common.LockMechanism_HTLC {
+        lockInfoVal := &common.AssetLockHTLC{}
+        err = proto.Unmarshal(lockInfo.LockInfo, lockInfoVal)
+        if err == nil {
+            err = setLockExpiryTimeFromDuration(ctx, &lockInfoVal.TimeSpec, &lockInfoVal.ExpiryTimeSecs)
+        }
+        if err == nil {
+            contractInfo := &common.FungibleAssetContractHTLC {
+                ContractId: contractId,
+                Agreement: assetAgreement,
+                Lock: lockInfoVal,
             }
-        } else {
-            logWarnings("lock mechanism is not supported")
+            contractInfoBytes, err = proto.Marshal(contractInfo)
         }
+    } else if lockInfo.LockMechanism == common.LockMechanism_ESCROW {
+        eventName = "LockFungibleAssetEscrow"
+        lockInfoVal := &common.AssetLockEscrow{}
+        err = proto.Unmarshal(lockInfo.LockInfo, lockInfoVal)
         if err == nil {
-            err = ctx.GetStub().SetEvent("LockAsset", contractInfoBytes)
-        } else {
-            logWarnings("Unable to set 'LockAsset' event", err.Error())
-	}
+            err = setLockExpiryTimeFromDuration(ctx, &lockInfoVal.TimeSpec, &lockInfoVal.ExpiryTimeSecs)
+        }
+        if err == nil {
+            contractInfo := &common.FungibleAssetContractEscrow {
+                ContractId: contractId,
+                Agreement: assetAgreement,
+                Lock: lockInfoVal,
+            }
+            contractInfoBytes, err = proto.Marshal(contractInfo)
+        }
+    } else {
+        logWarnings("lock mechanism is not supported")
     }
-
-    return contractId, err
+    if err == nil {
+        err = ctx.GetStub().SetEvent(eventName, contractInfoBytes)
+    } else {
+        logWarnings("Unable to set '" + eventName + "' event", err.Error())
+    }
+    return err
 }
 
 func (amc *AssetManagementContract) LockFungibleAsset(ctx contractapi.TransactionContextInterface, fungibleAssetExchangeAgreementSerializedProto64 string, lockInfoSerializedProto64 string) (string, error) {
@@ -340,26 +432,76 @@ func (amc *AssetManagementContract) LockFungibleAsset(ctx contractapi.Transactio
     // The below 'SetEvent' should be the last in a given transaction (if this function is being called by another), otherwise it will be overridden
     contractId, err := amc.assetManagement.LockFungibleAsset(ctx.GetStub(), assetAgreement, lockInfo)
     if err == nil {
-	var contractInfoBytes []byte
-        if lockInfo.LockMechanism == common.LockMechanism_HTLC {
-            lockInfoVal := &common.AssetLockHTLC{}
-            err = proto.Unmarshal(lockInfo.LockInfo, lockInfoVal)
-            if err == nil {
-                contractInfo := &common.FungibleAssetContractHTLC {
-                    ContractId: contractId,
-                    Agreement: assetAgreement,
-                    Lock: lockInfoVal,
-                }
-                contractInfoBytes, err = proto.Marshal(contractInfo)
-            }
-        } else {
-            logWarnings("lock mechanism is not supported")
-        }
-	if err == nil {
-            err = ctx.GetStub().SetEvent("LockFungibleAsset", contractInfoBytes)
-        } else {
-            logWarnings("Unable to set 'LockFungibleAsset' event", err.Error())
-        }
+        err = setLockFungibleAssetEvent(ctx, contractId, assetAgreement, lockInfo)
+    }
+
+    return contractId, err
+}
+
+func (amc *AssetManagementContract) ValidateAndExtractLockDelegation(delegationSerializedProto64 string) (*common.AssetLockDelegation, error) {
+    delegation := &common.AssetLockDelegation{}
+    // Decode from base64
+    delegationSerializedProto, err := base64.StdEncoding.DecodeString(delegationSerializedProto64)
+    if err != nil {
+        return delegation, logThenErrorf(err.Error())
+    }
+    if len(delegationSerializedProto) == 0 {
+        return delegation, logThenErrorf("empty lock delegation")
+    }
+    err = proto.Unmarshal([]byte(delegationSerializedProto), delegation)
+    if err != nil {
+        return delegation, logThenErrorf(err.Error())
+    }
+
+    return delegation, nil
+}
+
+// LockAssetAsDelegate locks an asset on behalf of its owner (the locker in the agreement), who has authorized the caller
+// in the delegation; the same 'LockAsset' (or 'LockAssetEscrow') event as that of a lock by the owner is emitted
+func (amc *AssetManagementContract) LockAssetAsDelegate(ctx contractapi.TransactionContextInterface, assetAgreementSerializedProto64 string, lockInfoSerializedProto64 string, delegationSerializedProto64 string) (string, error) {
+    assetAgreement, err := amc.ValidateAndExtractAssetAgreement(assetAgreementSerializedProto64)
+    if err != nil {
+        return "", err
+    }
+    lockInfo, err := amc.ValidateAndExtractLockInfo(lockInfoSerializedProto64)
+    if err != nil {
+        return "", err
+    }
+    delegation, err := amc.ValidateAndExtractLockDelegation(delegationSerializedProto64)
+    if err != nil {
+        return "", err
+    }
+
+    // The below 'SetEvent' should be the last in a given transaction (if this function is being called by another), otherwise it will be overridden
+    contractId, err := amc.assetManagement.LockAssetAsDelegate(ctx.GetStub(), assetAgreement, lockInfo, delegation)
+    if err == nil {
+        err = setLockAssetEvent(ctx, contractId, assetAgreement, lockInfo)
+    }
+
+    return contractId, err
+}
+
+// LockFungibleAssetAsDelegate locks fungible asset units on behalf of their owner (the locker in the agreement), who has
+// authorized the caller in the delegation; the same 'LockFungibleAsset' (or 'LockFungibleAssetEscrow') event as that
+// of a lock by the owner is emitted
+func (amc *AssetManagementContract) LockFungibleAssetAsDelegate(ctx contractapi.TransactionContextInterface, fungibleAssetExchangeAgreementSerializedProto64 string, lockInfoSerializedProto64 string, delegationSerializedProto64 string) (string, error) {
+    assetAgreement, err := amc.ValidateAndExtractFungibleAssetAgreement(fungibleAssetExchangeAgreementSerializedProto64)
+    if err != nil {
+        return "", err
+    }
+    lockInfo, err := amc.ValidateAndExtractLockInfo(lockInfoSerializedProto64)
+    if err != nil {
+        return "", err
+    }
+    delegation, err := amc.ValidateAndExtractLockDelegation(delegationSerializedProto64)
+    if err != nil {
+        return "", err
+    }
+
+    // The below 'SetEvent' should be the last in a given transaction (if this function is being called by another), otherwise it will be overridden
+    contractId, err := amc.assetManagement.LockFungibleAssetAsDelegate(ctx.GetStub(), assetAgreement, lockInfo, delegation)
+    if err == nil {
+        err = setLockFungibleAssetEvent(ctx, contractId, assetAgreement, lockInfo)
     }
 
     return contractId, err
@@ -402,6 +544,7 @@ func (amc *AssetManagementContract) ClaimAsset(ctx contractapi.TransactionContex
     retVal, err := amc.assetManagement.ClaimAsset(ctx.GetStub(), assetAgreement, claimInfo)
     if retVal && err == nil {
 	var contractInfoBytes []byte
+        eventName := "ClaimAsset"
         if claimInfo.LockMechanism == common.LockMechanism_HTLC {
             claimInfoVal := &common.AssetClaimHTLC{}
             err = proto.Unmarshal(claimInfo.ClaimInfo, claimInfoVal)
@@ -412,51 +555,86 @@ func (amc *AssetManagementContract) ClaimAsset(ctx contractapi.TransactionContex
                 }
                 contractInfoBytes, err = proto.Marshal(contractInfo)
             }
+        } else if claimInfo.LockMechanism == common.LockMechanism_ESCROW {
+            eventName = "ClaimAssetEscrow"
+            claimInfoVal := &common.AssetClaimEscrow{}
+            err = proto.Unmarshal(claimInfo.ClaimInfo, claimInfoVal)
+            if err == nil {
+                contractInfo := &common.AssetContractEscrow {
+                    Agreement: assetAgreement,
+                    Claim: claimInfoVal,
+                }
+                contractInfoBytes, err = proto.Marshal(contractInfo)
+            }
         } else {
             logWarnings("lock mechanism is not supported")
         }
         if err == nil {
-           err = ctx.GetStub().SetEvent("ClaimAsset", contractInfoBytes)
+           err = ctx.GetStub().SetEvent(eventName, contractInfoBytes)
         } else {
-	    logWarnings("Unable to set 'ClaimAsset' event", err.Error())
+	    logWarnings("Unable to set '" + eventName + "' event", err.Error())
         }
     }
     return retVal, err
 }
 
 func (amc *AssetManagementContract) ClaimFungibleAsset(ctx contractapi.TransactionContextInterface, contractId, claimInfoSerializedProto64 string) (bool, error) {
+    _, err := amc.ClaimFungibleAssetUnits(ctx, contractId, claimInfoSerializedProto64)
+    if err != nil {
+        return false, err
+    }
+    return true, nil
+}
+
+// Claim the units of a fungible asset locked using contractId, returning the number of units claimed (which the
+// claim event also carries); an HTLC claim may ask for a subset of the locked units, the rest staying locked
+func (amc *AssetManagementContract) ClaimFungibleAssetUnits(ctx contractapi.TransactionContextInterface, contractId, claimInfoSerializedProto64 string) (uint64, error) {
     if len(contractId) == 0 {
-        return false, logThenErrorf("empty contract id")
+        return 0, logThenErrorf("empty contract id")
     }
     claimInfo, err := amc.ValidateAndExtractClaimInfo(claimInfoSerializedProto64)
     if err != nil {
-        return false, err
+        return 0, err
     }
 
     // The below 'SetEvent' should be the last in a given transaction (if this function is being called by another), otherwise it will be overridden
-    retVal, err := amc.assetManagement.ClaimFungibleAsset(ctx.GetStub(), contractId, claimInfo)
-    if retVal && err == nil {
+    numUnits, err := amc.assetManagement.ClaimFungibleAssetUnits(ctx.GetStub(), contractId, claimInfo)
+    if err == nil {
 	var contractInfoBytes []byte
+        eventName := "ClaimFungibleAsset"
         if claimInfo.LockMechanism == common.LockMechanism_HTLC {
             claimInfoVal := &common.AssetClaimHTLC{}
             err = proto.Unmarshal(claimInfo.ClaimInfo, claimInfoVal)
             if err == nil {
+                // report the units actually claimed (the claim may have asked for all the locked units)
+                claimInfoVal.NumUnits = numUnits
                 contractInfo := &common.FungibleAssetContractHTLC {
                     ContractId: contractId,
                     Claim: claimInfoVal,
                 }
                 contractInfoBytes, err = proto.Marshal(contractInfo)
             }
+        } else if claimInfo.LockMechanism == common.LockMechanism_ESCROW {
+            eventName = "ClaimFungibleAssetEscrow"
+            claimInfoVal := &common.AssetClaimEscrow{}
+            err = proto.Unmarshal(claimInfo.ClaimInfo, claimInfoVal)
+            if err == nil {
+                contractInfo := &common.FungibleAssetContractEscrow {
+                    ContractId: contractId,
+                    Claim: claimInfoVal,
+                }
+                contractInfoBytes, err = proto.Marshal(contractInfo)
+            }
         } else {
             logWarnings("lock mechanism is not supported")
         }
         if err == nil {
-            err = ctx.GetStub().SetEvent("ClaimFungibleAsset", contractInfoBytes)
+            err = ctx.GetStub().SetEvent(eventName, contractInfoBytes)
         } else {
-	    logWarnings("Unable to set 'ClaimFungibleAsset' event", err.Error())
+	    logWarnings("Unable to set '" + eventName + "' event", err.Error())
         }
     }
-    return retVal, err
+    return numUnits, err
 }
 
 func (amc *AssetManagementContract) ClaimAssetUsingContractId(ctx contractapi.TransactionContextInterface, contractId, claimInfoSerializedProto64 string) (bool, error) {
@@ -472,6 +650,7 @@ func (amc *AssetManagementContract) ClaimAssetUsingContractId(ctx contractapi.Tr
     retVal, err := amc.assetManagement.ClaimAssetUsingContractId(ctx.GetStub(), contractId, claimInfo)
     if retVal && err == nil {
 	var contractInfoBytes []byte
+        eventName := "ClaimAsset"
         if claimInfo.LockMechanism == common.LockMechanism_HTLC {
             claimInfoVal := &common.AssetClaimHTLC{}
             err = proto.Unmarshal(claimInfo.ClaimInfo, claimInfoVal)
@@ -482,19 +661,59 @@ func (amc *AssetManagementContract) ClaimAssetUsingContractId(ctx contractapi.Tr
                 }
                 contractInfoBytes, err = proto.Marshal(contractInfo)
             }
+        } else if claimInfo.LockMechanism == common.LockMechanism_ESCROW {
+            eventName = "ClaimAssetEscrow"
+            claimInfoVal := &common.AssetClaimEscrow{}
+            err = proto.Unmarshal(claimInfo.ClaimInfo, claimInfoVal)
+            if err == nil {
+                contractInfo := &common.AssetContractEscrow {
+                    ContractId: contractId,
+                    Claim: claimInfoVal,
+                }
+                contractInfoBytes, err = proto.Marshal(contractInfo)
+            }
         } else {
             logWarnings("lock mechanism is not supported")
         }
         if err == nil {
-            err = ctx.GetStub().SetEvent("ClaimAsset", contractInfoBytes)
+            err = ctx.GetStub().SetEvent(eventName, contractInfoBytes)
         } else {
-	    logWarnings("Unable to set 'ClaimAsset' event", err.Error())
+	    logWarnings("Unable to set '" + eventName + "' event", err.Error())
 	}
     }
 
     return retVal, err
 }
 
+func (amc *AssetManagementContract) ClaimAssetsUsingContractIds(ctx contractapi.TransactionContextInterface, contractIds []string, claimInfoSerializedProto64 string) (bool, error) {
+    claimInfo, err := amc.ValidateAndExtractClaimInfo(claimInfoSerializedProto64)
+    if err != nil {
+        return false, err
+    }
+
+    // The below 'SetEvent' should be the last in a given transaction (if this function is being called by another), otherwise it will be overridden
+    retVal, err := amc.assetManagement.ClaimAssetsUsingContractIds(ctx.GetStub(), contractIds, claimInfo)
+    if retVal && err == nil {
+        claimInfoVal := &common.AssetClaimHTLC{}
+        err = proto.Unmarshal(claimInfo.ClaimInfo, claimInfoVal)
+        var contractInfoBytes []byte
+        if err == nil {
+            contractInfo := &common.AssetBatchContractHTLC {
+                ContractIds: contractIds,
+                Claim: claimInfoVal,
+            }
+            contractInfoBytes, err = proto.Marshal(contractInfo)
+        }
+        if err == nil {
+            err = ctx.GetStub().SetEvent("ClaimAssetsInBatch", contractInfoBytes)
+        }
+        if err != nil {
+            logWarnings("Unable to set 'ClaimAssetsInBatch' event", err.Error())
+        }
+    }
+    return retVal, err
+}
+
 func (amc *AssetManagementContract) UnlockAsset(ctx contractapi.TransactionContextInterface, assetAgreementSerializedProto64 string) (bool, error) {
     assetAgreement, err := amc.ValidateAndExtractAssetAgreement(assetAgreementSerializedProto64)
     if err != nil {
@@ -563,6 +782,86 @@ func (amc *AssetManagementContract) UnlockAssetUsingContractId(ctx contractapi.T
 }
 
 
+func (amc *AssetManagementContract) ValidateAndExtractLockExtensionInfo(extensionInfoSerializedProto64 string) (*common.AssetLockExtension, error) {
+    extensionInfo := &common.AssetLockExtension{}
+    // Decode from base64
+    extensionInfoSerializedProto, err := base64.StdEncoding.DecodeString(extensionInfoSerializedProto64)
+    if err != nil {
+        return extensionInfo, logThenErrorf(err.Error())
+    }
+    if len(extensionInfoSerializedProto) == 0 {
+        return extensionInfo, logThenErrorf("empty lock extension info")
+    }
+    err = proto.Unmarshal([]byte(extensionInfoSerializedProto), extensionInfo)
+    if err != nil {
+        return extensionInfo, logThenErrorf(err.Error())
+    }
+
+    return extensionInfo, nil
+}
+
+// ExtendLockExpiry pushes out the expiry time of an asset (or fungible asset) lock, emitting an event carrying the
+// new expiry time so that the other leg of the exchange can adjust its own timeout
+func (amc *AssetManagementContract) ExtendLockExpiry(ctx contractapi.TransactionContextInterface, contractId, extensionInfoSerializedProto64 string) (bool, error) {
+    if len(contractId) == 0 {
+        return false, logThenErrorf("empty contract id")
+    }
+    extensionInfo, err := amc.ValidateAndExtractLockExtensionInfo(extensionInfoSerializedProto64)
+    if err != nil {
+        return false, err
+    }
+    if len(extensionInfo.ContractId) == 0 {
+        extensionInfo.ContractId = contractId
+    } else if extensionInfo.ContractId != contractId {
+        return false, logThenErrorf("lock extension is meant for contractId %s and not %s", extensionInfo.ContractId, contractId)
+    }
+
+    // The below 'SetEvent' should be the last in a given transaction (if this function is being called by another), otherwise it will be overridden
+    retVal, err := amc.assetManagement.ExtendLockExpiry(ctx.GetStub(), extensionInfo)
+    if retVal && err == nil {
+        extensionInfoBytes, err := proto.Marshal(extensionInfo)
+        if err == nil {
+            err = ctx.GetStub().SetEvent("ExtendLockExpiry", extensionInfoBytes)
+        }
+        if err != nil {
+            logWarnings("Unable to set 'ExtendLockExpiry' event", err.Error())
+        }
+    }
+    return retVal, err
+}
+
+// Set an 'UnlockExpiredAssets' event listing the outcome of the release of each lock in a batch, and return the
+// outcomes serialized in base64 form
+func setUnlockExpiredAssetsEvent(ctx contractapi.TransactionContextInterface, outcomes *common.AssetBatchUnlockOutcomes) (string, error) {
+    outcomesBytes, err := proto.Marshal(outcomes)
+    if err != nil {
+        return "", logThenErrorf(err.Error())
+    }
+    err = ctx.GetStub().SetEvent("UnlockExpiredAssets", outcomesBytes)
+    if err != nil {
+        logWarnings("Unable to set 'UnlockExpiredAssets' event", err.Error())
+    }
+    return base64.StdEncoding.EncodeToString(outcomesBytes), nil
+}
+
+func (amc *AssetManagementContract) UnlockExpiredAssetsUsingContractIds(ctx contractapi.TransactionContextInterface, contractIds []string) (string, error) {
+    // The below 'SetEvent' should be the last in a given transaction (if this function is being called by another), otherwise it will be overridden
+    outcomes, err := amc.assetManagement.UnlockExpiredAssetsUsingContractIds(ctx.GetStub(), contractIds)
+    if err != nil {
+        return "", err
+    }
+    return setUnlockExpiredAssetsEvent(ctx, outcomes)
+}
+
+func (amc *AssetManagementContract) UnlockExpiredAssetsOfLocker(ctx contractapi.TransactionContextInterface, locker string, expiryCutoffSecs uint64) (string, error) {
+    // The below 'SetEvent' should be the last in a given transaction (if this function is being called by another), otherwise it will be overridden
+    outcomes, err := amc.assetManagement.UnlockExpiredAssetsOfLocker(ctx.GetStub(), locker, expiryCutoffSecs)
+    if err != nil {
+        return "", err
+    }
+    return setUnlockExpiredAssetsEvent(ctx, outcomes)
+}
+
 // Ledger query functions
 
 func (amc *AssetManagementContract) GetTotalFungibleLockedAssets(ctx contractapi.TransactionContextInterface, assetType string) (uint64, error) {
@@ -581,6 +880,18 @@ func (amc *AssetManagementContract) GetAllFungibleLockedAssets(ctx contractapi.T
     return amc.assetManagement.GetAllFungibleLockedAssets(ctx.GetStub(), lockRecipient, locker)
 }
 
+func (amc *AssetManagementContract) GetAllLockedAssetsWithPagination(ctx contractapi.TransactionContextInterface, lockRecipient string, locker string, pageSize int32, bookmark string) (*LockedAssetsPage, error) {
+    return amc.assetManagement.GetAllLockedAssetsWithPagination(ctx.GetStub(), lockRecipient, locker, pageSize, bookmark)
+}
+
+func (amc *AssetManagementContract) GetAllNonFungibleLockedAssetsWithPagination(ctx contractapi.TransactionContextInterface, lockRecipient string, locker string, pageSize int32, bookmark string) (*LockedAssetsPage, error) {
+    return amc.assetManagement.GetAllNonFungibleLockedAssetsWithPagination(ctx.GetStub(), lockRecipient, locker, pageSize, bookmark)
+}
+
+func (amc *AssetManagementContract) GetAllFungibleLockedAssetsWithPagination(ctx contractapi.TransactionContextInterface, lockRecipient string, locker string, pageSize int32, bookmark string) (*LockedAssetsPage, error) {
+    return amc.assetManagement.GetAllFungibleLockedAssetsWithPagination(ctx.GetStub(), lockRecipient, locker, pageSize, bookmark)
+}
+
 func (amc *AssetManagementContract) GetAssetTimeToRelease(ctx contractapi.TransactionContextInterface, assetAgreementSerializedProto64 string) (uint64, error) {
     assetAgreement, err := amc.ValidateAndExtractAssetAgreement(assetAgreementSerializedProto64)
     if err != nil {
@@ -599,6 +910,160 @@ func (amc *AssetManagementContract) GetFungibleAssetTimeToRelease(ctx contractap
     return amc.assetManagement.GetFungibleAssetTimeToRelease(ctx.GetStub(), assetAgreement)
 }
 
+func (amc *AssetManagementContract) GetAssetTimeToReleaseUsingContractId(ctx contractapi.TransactionContextInterface, contractId string) (uint64, error) {
+    return amc.assetManagement.GetAssetTimeToReleaseUsingContractId(ctx.GetStub(), contractId)
+}
+
+func (amc *AssetManagementContract) GetFungibleAssetTimeToReleaseUsingContractId(ctx contractapi.TransactionContextInterface, contractId string) (uint64, error) {
+    return amc.assetManagement.GetFungibleAssetTimeToReleaseUsingContractId(ctx.GetStub(), contractId)
+}
+
+func (amc *AssetManagementContract) GetHTLCHashPreImage(ctx contractapi.TransactionContextInterface, contractId string) (string, error) {
+    return amc.assetManagement.GetHTLCHashPreImage(ctx.GetStub(), contractId)
+}
+
+func (amc *AssetManagementContract) GetContractIdsOfTxId(ctx contractapi.TransactionContextInterface, txId string) ([]string, error) {
+    return amc.assetManagement.GetContractIdsOfTxId(ctx.GetStub(), txId)
+}
+
 func (amc *AssetManagementContract) GetAllAssetsLockedUntil(ctx contractapi.TransactionContextInterface, lockExpiryTimeSecs uint64) ([]string, error) {
     return amc.assetManagement.GetAllAssetsLockedUntil(ctx.GetStub(), lockExpiryTimeSecs)
 }
+
+func (amc *AssetManagementContract) GetAllAssetsLockedUntilWithPagination(ctx contractapi.TransactionContextInterface, lockExpiryTimeSecs uint64, pageSize int32, bookmark string) (*LockedAssetsPage, error) {
+    return amc.assetManagement.GetAllAssetsLockedUntilWithPagination(ctx.GetStub(), lockExpiryTimeSecs, pageSize, bookmark)
+}
+
+// Asset bundle functions
+
+func (amc *AssetManagementContract) ValidateAndExtractAssetBundleAgreement(bundleAgreementSerializedProto64 string) (*common.AssetBundleExchangeAgreement, error) {
+    bundleAgreement := &common.AssetBundleExchangeAgreement{}
+    // Decoding from base64
+    bundleAgreementSerializedProto, err := base64.StdEncoding.DecodeString(bundleAgreementSerializedProto64)
+    if err != nil {
+        return bundleAgreement, logThenErrorf(err.Error())
+    }
+    if len(bundleAgreementSerializedProto) == 0 {
+        return bundleAgreement, logThenErrorf("empty asset bundle agreement")
+    }
+    err = proto.Unmarshal([]byte(bundleAgreementSerializedProto), bundleAgreement)
+    if err != nil {
+        return bundleAgreement, logThenErrorf(err.Error())
+    }
+
+    return bundleAgreement, nil
+}
+
+func (amc *AssetManagementContract) LockAssetBundle(ctx contractapi.TransactionContextInterface, bundleAgreementSerializedProto64 string, lockInfoSerializedProto64 string) (string, error) {
+    bundleAgreement, err := amc.ValidateAndExtractAssetBundleAgreement(bundleAgreementSerializedProto64)
+    if err != nil {
+        return "", err
+    }
+    lockInfo, err := amc.ValidateAndExtractLockInfo(lockInfoSerializedProto64)
+    if err != nil {
+        return "", err
+    }
+
+    // The below 'SetEvent' should be the last in a given transaction (if this function is being called by another), otherwise it will be overridden
+    contractId, err := amc.assetManagement.LockAssetBundle(ctx.GetStub(), bundleAgreement, lockInfo)
+    if err == nil {
+        if lockInfo.LockMechanism == common.LockMechanism_HTLC {
+            var contractInfoBytes []byte
+            lockInfoVal := &common.AssetLockHTLC{}
+            err = proto.Unmarshal(lockInfo.LockInfo, lockInfoVal)
+            if err == nil {
+                err = setLockExpiryTimeFromDuration(ctx, &lockInfoVal.TimeSpec, &lockInfoVal.ExpiryTimeSecs)
+            }
+            if err == nil {
+                contractInfo := &common.AssetBundleContractHTLC {
+                    ContractId: contractId,
+                    Agreement: bundleAgreement,
+                    Lock: lockInfoVal,
+                }
+                contractInfoBytes, err = proto.Marshal(contractInfo)
+            }
+            if err == nil {
+                err = ctx.GetStub().SetEvent("LockAssetBundle", contractInfoBytes)
+            } else {
+                logWarnings("Unable to set 'LockAssetBundle' event", err.Error())
+            }
+        } else {
+            logWarnings("lock mechanism is not supported for asset bundle events")
+        }
+    }
+
+    return contractId, err
+}
+
+func (amc *AssetManagementContract) IsAssetBundleLocked(ctx contractapi.TransactionContextInterface, contractId string) (bool, error) {
+    if len(contractId) == 0 {
+        return false, logThenErrorf("empty contract id")
+    }
+    return amc.assetManagement.IsAssetBundleLocked(ctx.GetStub(), contractId)
+}
+
+func (amc *AssetManagementContract) ClaimAssetBundle(ctx contractapi.TransactionContextInterface, contractId, claimInfoSerializedProto64 string) (bool, error) {
+    if len(contractId) == 0 {
+        return false, logThenErrorf("empty contract id")
+    }
+    claimInfo, err := amc.ValidateAndExtractClaimInfo(claimInfoSerializedProto64)
+    if err != nil {
+        return false, err
+    }
+
+    // The below 'SetEvent' should be the last in a given transaction (if this function is being called by another), otherwise it will be overridden
+    retVal, err := amc.assetManagement.ClaimAssetBundle(ctx.GetStub(), contractId, claimInfo)
+    if retVal && err == nil {
+        if claimInfo.LockMechanism == common.LockMechanism_HTLC {
+            var contractInfoBytes []byte
+            claimInfoVal := &common.AssetClaimHTLC{}
+            err = proto.Unmarshal(claimInfo.ClaimInfo, claimInfoVal)
+            if err == nil {
+                contractInfo := &common.AssetBundleContractHTLC {
+                    ContractId: contractId,
+                    Claim: claimInfoVal,
+                }
+                contractInfoBytes, err = proto.Marshal(contractInfo)
+            }
+            if err == nil {
+                err = ctx.GetStub().SetEvent("ClaimAssetBundle", contractInfoBytes)
+            } else {
+                logWarnings("Unable to set 'ClaimAssetBundle' event", err.Error())
+            }
+        } else {
+            logWarnings("lock mechanism is not supported for asset bundle events")
+        }
+    }
+
+    return retVal, err
+}
+
+func (amc *AssetManagementContract) UnlockAssetBundle(ctx contractapi.TransactionContextInterface, contractId string) (bool, error) {
+    if len(contractId) == 0 {
+        return false, logThenErrorf("empty contract id")
+    }
+
+    // The below 'SetEvent' should be the last in a given transaction (if this function is being called by another), otherwise it will be overridden
+    retVal, err := amc.assetManagement.UnlockAssetBundle(ctx.GetStub(), contractId)
+    if retVal && err == nil {
+        contractInfo := &common.AssetBundleContractHTLC{
+            ContractId: contractId,
+        }
+        contractInfoBytes, err := proto.Marshal(contractInfo)
+        if err == nil {
+            err = ctx.GetStub().SetEvent("UnlockAssetBundle", contractInfoBytes)
+        }
+        if err != nil {
+            logWarnings("Unable to set 'UnlockAssetBundle' event", err.Error())
+        }
+    }
+    return retVal, err
+}
+
+func (amc *AssetManagementContract) GetAssetBundleUsingContractId(ctx contractapi.TransactionContextInterface, contractId string) (string, error) {
+    return amc.assetManagement.GetAssetBundleUsingContractId(ctx.GetStub(), contractId)
+}
+
+func (amc *AssetManagementContract) GetAssetBundleTimeToReleaseUsingContractId(ctx contractapi.TransactionContextInterface, contractId string) (uint64, error) {
+    return amc.assetManagement.GetAssetBundleTimeToReleaseUsingContractId(ctx.GetStub(), contractId)
+}
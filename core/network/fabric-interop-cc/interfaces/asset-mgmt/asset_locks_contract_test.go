@@ -1,9 +1,15 @@
 package assetmgmt_test
 
 import (
+	"encoding/base64"
 	"fmt"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"github.com/stretchr/testify/require"
 )
@@ -27,3 +33,550 @@ func TestContractIsFungibleAssetLocked(t *testing.T) {
 	require.False(t, isAssetLocked)
 	fmt.Printf("Test failed as expected with error: %+v\n", err)
 }
+
+func TestContractLockAssetWithDuration(t *testing.T) {
+	ctx, chaincodeStub, amc := prepMockStub()
+
+	txTimeSecs := time.Now().Unix()
+	lockDurationSecs := uint64(300)
+	chaincodeStub.GetTxTimestampReturns(&timestamp.Timestamp{Seconds: txTimeSecs}, nil)
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte("contract-id")))
+
+	assetAgreement := &common.AssetExchangeAgreement{
+		Type:      "bond",
+		Id:        "A001",
+		Recipient: "Bob",
+	}
+	assetAgreementBytes, _ := proto.Marshal(assetAgreement)
+	lockInfoHTLC := &common.AssetLockHTLC{
+		HashBase64:     []byte(generateSHA256HashInBase64Form("abcd")),
+		ExpiryTimeSecs: lockDurationSecs,
+		TimeSpec:       common.AssetLockHTLC_DURATION,
+	}
+	lockInfoHTLCBytes, _ := proto.Marshal(lockInfoHTLC)
+	lockInfo := &common.AssetLock{
+		LockMechanism: common.LockMechanism_HTLC,
+		LockInfo:      lockInfoHTLCBytes,
+	}
+	lockInfoBytes, _ := proto.Marshal(lockInfo)
+
+	// Test success with the lock event carrying the absolute expiry time computed from the lock duration
+	contractId, err := amc.LockAsset(ctx, base64.StdEncoding.EncodeToString(assetAgreementBytes), base64.StdEncoding.EncodeToString(lockInfoBytes))
+	require.NoError(t, err)
+	require.Equal(t, "contract-id", contractId)
+	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(0)
+	require.Equal(t, "LockAsset", eventName)
+	contractInfo := &common.AssetContractHTLC{}
+	err = proto.Unmarshal(eventPayload, contractInfo)
+	require.NoError(t, err)
+	require.Equal(t, common.AssetLockHTLC_EPOCH, contractInfo.Lock.TimeSpec)
+	require.Equal(t, uint64(txTimeSecs)+lockDurationSecs, contractInfo.Lock.ExpiryTimeSecs)
+
+	// Test success with the fungible lock event carrying the absolute expiry time computed from the lock duration
+	fungibleAssetAgreement := &common.FungibleAssetExchangeAgreement{
+		Type:      "cbdc",
+		NumUnits:  10,
+		Recipient: "Bob",
+	}
+	fungibleAssetAgreementBytes, _ := proto.Marshal(fungibleAssetAgreement)
+	_, err = amc.LockFungibleAsset(ctx, base64.StdEncoding.EncodeToString(fungibleAssetAgreementBytes), base64.StdEncoding.EncodeToString(lockInfoBytes))
+	require.NoError(t, err)
+	eventName, eventPayload = chaincodeStub.SetEventArgsForCall(1)
+	require.Equal(t, "LockFungibleAsset", eventName)
+	fungibleContractInfo := &common.FungibleAssetContractHTLC{}
+	err = proto.Unmarshal(eventPayload, fungibleContractInfo)
+	require.NoError(t, err)
+	require.Equal(t, common.AssetLockHTLC_EPOCH, fungibleContractInfo.Lock.TimeSpec)
+	require.Equal(t, uint64(txTimeSecs)+lockDurationSecs, fungibleContractInfo.Lock.ExpiryTimeSecs)
+
+	// Test failure with a lock duration of zero
+	lockInfoHTLC.ExpiryTimeSecs = 0
+	lockInfoHTLCBytes, _ = proto.Marshal(lockInfoHTLC)
+	lockInfo.LockInfo = lockInfoHTLCBytes
+	lockInfoBytes, _ = proto.Marshal(lockInfo)
+	_, err = amc.LockAsset(ctx, base64.StdEncoding.EncodeToString(assetAgreementBytes), base64.StdEncoding.EncodeToString(lockInfoBytes))
+	require.EqualError(t, err, "lock duration must be greater than zero")
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+}
+
+func TestContractGetHTLCHashPreImage(t *testing.T) {
+	ctx, chaincodeStub, amc := prepMockStub()
+
+	// Test success with the hash preimage returned by the interop chaincode
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte("cHJlaW1hZ2U=")))
+	hashPreimageBase64, err := amc.GetHTLCHashPreImage(ctx, "contract-id")
+	require.NoError(t, err)
+	require.Equal(t, "cHJlaW1hZ2U=", hashPreimageBase64)
+	chaincodeName, args, _ := chaincodeStub.InvokeChaincodeArgsForCall(0)
+	require.Equal(t, "interopcc", chaincodeName)
+	require.Equal(t, [][]byte{[]byte("GetHTLCHashPreImage"), []byte("contract-id")}, args)
+
+	// Test failure under the scenario that the HTLC associated with the contractId is not claimed
+	chaincodeStub.InvokeChaincodeReturns(shim.Error("no claimed HTLC is associated with contractId contract-id"))
+	hashPreimageBase64, err = amc.GetHTLCHashPreImage(ctx, "contract-id")
+	require.EqualError(t, err, "no claimed HTLC is associated with contractId contract-id")
+	require.Empty(t, hashPreimageBase64)
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+}
+
+func TestContractGetContractIdsOfTxId(t *testing.T) {
+	ctx, chaincodeStub, amc := prepMockStub()
+
+	// Test success with the contractIds returned by the interop chaincode
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte(`["leg-1","leg-2"]`)))
+	contractIds, err := amc.GetContractIdsOfTxId(ctx, "tx-id")
+	require.NoError(t, err)
+	require.Equal(t, []string{"leg-1", "leg-2"}, contractIds)
+	_, args, _ := chaincodeStub.InvokeChaincodeArgsForCall(0)
+	require.Equal(t, [][]byte{[]byte("GetContractIdsOfTxId"), []byte("tx-id")}, args)
+
+	// Test failure with an empty transaction id
+	_, err = amc.GetContractIdsOfTxId(ctx, "")
+	require.EqualError(t, err, "empty transaction id")
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+	require.Equal(t, 1, chaincodeStub.InvokeChaincodeCallCount())
+}
+
+func TestContractEscrowLockEvents(t *testing.T) {
+	ctx, chaincodeStub, amc := prepMockStub()
+
+	txTimeSecs := time.Now().Unix()
+	lockDurationSecs := uint64(300)
+	chaincodeStub.GetTxTimestampReturns(&timestamp.Timestamp{Seconds: txTimeSecs}, nil)
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte("contract-id")))
+
+	assetAgreement := &common.AssetExchangeAgreement{
+		Type:      "bond",
+		Id:        "A001",
+		Recipient: "Bob",
+	}
+	assetAgreementBytes, _ := proto.Marshal(assetAgreement)
+	lockInfoEscrow := &common.AssetLockEscrow{
+		Arbiter:        "Carol",
+		ExpiryTimeSecs: lockDurationSecs,
+		TimeSpec:       common.AssetLockHTLC_DURATION,
+	}
+	lockInfoEscrowBytes, _ := proto.Marshal(lockInfoEscrow)
+	lockInfo := &common.AssetLock{
+		LockMechanism: common.LockMechanism_ESCROW,
+		LockInfo:      lockInfoEscrowBytes,
+	}
+	lockInfoBytes, _ := proto.Marshal(lockInfo)
+
+	// Test success with the escrow lock event carrying the arbiter and the absolute expiry time
+	contractId, err := amc.LockAsset(ctx, base64.StdEncoding.EncodeToString(assetAgreementBytes), base64.StdEncoding.EncodeToString(lockInfoBytes))
+	require.NoError(t, err)
+	require.Equal(t, "contract-id", contractId)
+	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(0)
+	require.Equal(t, "LockAssetEscrow", eventName)
+	contractInfo := &common.AssetContractEscrow{}
+	err = proto.Unmarshal(eventPayload, contractInfo)
+	require.NoError(t, err)
+	require.Equal(t, "contract-id", contractInfo.ContractId)
+	require.Equal(t, "Carol", contractInfo.Lock.Arbiter)
+	require.Equal(t, common.AssetLockHTLC_EPOCH, contractInfo.Lock.TimeSpec)
+	require.Equal(t, uint64(txTimeSecs)+lockDurationSecs, contractInfo.Lock.ExpiryTimeSecs)
+
+	// Test success with the escrow claim event carrying the arbiter signature
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte("10")))
+	claimInfoEscrow := &common.AssetClaimEscrow{
+		ArbiterSignature: []byte("arbiter-signature"),
+	}
+	claimInfoEscrowBytes, _ := proto.Marshal(claimInfoEscrow)
+	claimInfo := &common.AssetClaim{
+		LockMechanism: common.LockMechanism_ESCROW,
+		ClaimInfo:     claimInfoEscrowBytes,
+	}
+	claimInfoBytes, _ := proto.Marshal(claimInfo)
+	claimed, err := amc.ClaimFungibleAsset(ctx, "contract-id", base64.StdEncoding.EncodeToString(claimInfoBytes))
+	require.NoError(t, err)
+	require.True(t, claimed)
+	eventName, eventPayload = chaincodeStub.SetEventArgsForCall(1)
+	require.Equal(t, "ClaimFungibleAssetEscrow", eventName)
+	fungibleContractInfo := &common.FungibleAssetContractEscrow{}
+	err = proto.Unmarshal(eventPayload, fungibleContractInfo)
+	require.NoError(t, err)
+	require.Equal(t, "contract-id", fungibleContractInfo.ContractId)
+	require.Equal(t, []byte("arbiter-signature"), fungibleContractInfo.Claim.ArbiterSignature)
+
+	// Test failure with an empty arbiter signature
+	claimInfoEscrow.ArbiterSignature = nil
+	claimInfoEscrowBytes, _ = proto.Marshal(claimInfoEscrow)
+	claimInfo.ClaimInfo = claimInfoEscrowBytes
+	claimInfoBytes, _ = proto.Marshal(claimInfo)
+	_, err = amc.ClaimFungibleAsset(ctx, "contract-id", base64.StdEncoding.EncodeToString(claimInfoBytes))
+	require.EqualError(t, err, "empty claim info")
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+}
+
+func TestContractClaimFungibleAssetUnits(t *testing.T) {
+	ctx, chaincodeStub, amc := prepMockStub()
+
+	claimInfoHTLC := &common.AssetClaimHTLC{
+		HashPreimageBase64: []byte(base64.StdEncoding.EncodeToString([]byte("abcd"))),
+		NumUnits:           30,
+	}
+	claimInfoHTLCBytes, _ := proto.Marshal(claimInfoHTLC)
+	claimInfo := &common.AssetClaim{
+		LockMechanism: common.LockMechanism_HTLC,
+		ClaimInfo:     claimInfoHTLCBytes,
+	}
+	claimInfoBytes, _ := proto.Marshal(claimInfo)
+
+	// Test success with a partial claim, the claim event carrying the number of units claimed
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte("30")))
+	numUnits, err := amc.ClaimFungibleAssetUnits(ctx, "contract-id", base64.StdEncoding.EncodeToString(claimInfoBytes))
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), numUnits)
+	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(0)
+	require.Equal(t, "ClaimFungibleAsset", eventName)
+	contractInfo := &common.FungibleAssetContractHTLC{}
+	err = proto.Unmarshal(eventPayload, contractInfo)
+	require.NoError(t, err)
+	require.Equal(t, "contract-id", contractInfo.ContractId)
+	require.Equal(t, uint64(30), contractInfo.Claim.NumUnits)
+
+	// Test success with a claim of all the locked units, the claim event carrying the units reported by the interop chaincode
+	claimInfoHTLC.NumUnits = 0
+	claimInfoHTLCBytes, _ = proto.Marshal(claimInfoHTLC)
+	claimInfo.ClaimInfo = claimInfoHTLCBytes
+	claimInfoBytes, _ = proto.Marshal(claimInfo)
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte("70")))
+	claimed, err := amc.ClaimFungibleAsset(ctx, "contract-id", base64.StdEncoding.EncodeToString(claimInfoBytes))
+	require.NoError(t, err)
+	require.True(t, claimed)
+	_, eventPayload = chaincodeStub.SetEventArgsForCall(1)
+	err = proto.Unmarshal(eventPayload, contractInfo)
+	require.NoError(t, err)
+	require.Equal(t, uint64(70), contractInfo.Claim.NumUnits)
+
+	// Test failure with more units claimed than are locked
+	chaincodeStub.InvokeChaincodeReturns(shim.Error("cannot claim fungible asset associated with contractId contract-id: cannot claim 101 units as only 70 units are locked"))
+	numUnits, err = amc.ClaimFungibleAssetUnits(ctx, "contract-id", base64.StdEncoding.EncodeToString(claimInfoBytes))
+	require.EqualError(t, err, "cannot claim fungible asset associated with contractId contract-id: cannot claim 101 units as only 70 units are locked")
+	require.Equal(t, uint64(0), numUnits)
+	require.Equal(t, 2, chaincodeStub.SetEventCallCount())
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+}
+
+func TestContractAssetBundleEvents(t *testing.T) {
+	ctx, chaincodeStub, amc := prepMockStub()
+
+	bundleAgreement := &common.AssetBundleExchangeAgreement{
+		Assets: []*common.AssetBundleItem{
+			{Type: "bond", Id: "A001"},
+			{Type: "cbdc", NumUnits: 50},
+		},
+		Recipient: "Bob",
+	}
+	bundleAgreementBytes, _ := proto.Marshal(bundleAgreement)
+	lockInfoHTLC := &common.AssetLockHTLC{
+		HashBase64:     []byte(generateSHA256HashInBase64Form("abcd")),
+		ExpiryTimeSecs: uint64(time.Now().Unix()) + 300,
+	}
+	lockInfoHTLCBytes, _ := proto.Marshal(lockInfoHTLC)
+	lockInfo := &common.AssetLock{
+		LockMechanism: common.LockMechanism_HTLC,
+		LockInfo:      lockInfoHTLCBytes,
+	}
+	lockInfoBytes, _ := proto.Marshal(lockInfo)
+
+	// Test failure with an empty asset bundle
+	emptyBundleAgreementBytes, _ := proto.Marshal(&common.AssetBundleExchangeAgreement{Recipient: "Bob"})
+	_, err := amc.LockAssetBundle(ctx, base64.StdEncoding.EncodeToString(emptyBundleAgreementBytes), base64.StdEncoding.EncodeToString(lockInfoBytes))
+	require.EqualError(t, err, "empty asset bundle")
+	require.Equal(t, 0, chaincodeStub.InvokeChaincodeCallCount())
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+
+	// Test success with the lock event carrying the whole bundle
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte("bundle-contract-id")))
+	contractId, err := amc.LockAssetBundle(ctx, base64.StdEncoding.EncodeToString(bundleAgreementBytes), base64.StdEncoding.EncodeToString(lockInfoBytes))
+	require.NoError(t, err)
+	require.Equal(t, "bundle-contract-id", contractId)
+	_, args, _ := chaincodeStub.InvokeChaincodeArgsForCall(0)
+	require.Equal(t, []byte("LockAssetBundle"), args[0])
+	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(0)
+	require.Equal(t, "LockAssetBundle", eventName)
+	contractInfo := &common.AssetBundleContractHTLC{}
+	err = proto.Unmarshal(eventPayload, contractInfo)
+	require.NoError(t, err)
+	require.Equal(t, contractId, contractInfo.ContractId)
+	require.Len(t, contractInfo.Agreement.Assets, 2)
+	require.Equal(t, uint64(50), contractInfo.Agreement.Assets[1].NumUnits)
+
+	// Test success with the claim event carrying the hash preimage
+	claimInfoHTLC := &common.AssetClaimHTLC{
+		HashPreimageBase64: []byte(base64.StdEncoding.EncodeToString([]byte("abcd"))),
+	}
+	claimInfoHTLCBytes, _ := proto.Marshal(claimInfoHTLC)
+	claimInfo := &common.AssetClaim{
+		LockMechanism: common.LockMechanism_HTLC,
+		ClaimInfo:     claimInfoHTLCBytes,
+	}
+	claimInfoBytes, _ := proto.Marshal(claimInfo)
+	chaincodeStub.InvokeChaincodeReturns(shim.Success(nil))
+	claimed, err := amc.ClaimAssetBundle(ctx, contractId, base64.StdEncoding.EncodeToString(claimInfoBytes))
+	require.NoError(t, err)
+	require.True(t, claimed)
+	eventName, eventPayload = chaincodeStub.SetEventArgsForCall(1)
+	require.Equal(t, "ClaimAssetBundle", eventName)
+	err = proto.Unmarshal(eventPayload, contractInfo)
+	require.NoError(t, err)
+	require.Equal(t, claimInfoHTLC.HashPreimageBase64, contractInfo.Claim.HashPreimageBase64)
+
+	// Test failure with the bundle unlock rejected by the interop chaincode
+	chaincodeStub.InvokeChaincodeReturns(shim.Error("contractId bundle-contract-id is not associated with any currently locked asset bundle"))
+	unlocked, err := amc.UnlockAssetBundle(ctx, contractId)
+	require.EqualError(t, err, "contractId bundle-contract-id is not associated with any currently locked asset bundle")
+	require.False(t, unlocked)
+	require.Equal(t, 2, chaincodeStub.SetEventCallCount())
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+}
+
+func TestContractExtendLockExpiry(t *testing.T) {
+	ctx, chaincodeStub, amc := prepMockStub()
+
+	newExpiryTimeSecs := uint64(time.Now().Unix()) + 600
+	extensionInfo := &common.AssetLockExtension{
+		NewExpiryTimeSecs:  newExpiryTimeSecs,
+		RecipientSignature: []byte("recipient-signature"),
+	}
+	extensionInfoBytes, _ := proto.Marshal(extensionInfo)
+
+	// Test failure with an empty lock extension
+	_, err := amc.ExtendLockExpiry(ctx, "contract-id", "")
+	require.EqualError(t, err, "empty lock extension info")
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+
+	// Test success with the extension event carrying the contractId and the new expiry time
+	chaincodeStub.InvokeChaincodeReturns(shim.Success(nil))
+	extended, err := amc.ExtendLockExpiry(ctx, "contract-id", base64.StdEncoding.EncodeToString(extensionInfoBytes))
+	require.NoError(t, err)
+	require.True(t, extended)
+	_, args, _ := chaincodeStub.InvokeChaincodeArgsForCall(0)
+	require.Equal(t, []byte("ExtendLockExpiry"), args[0])
+	require.Equal(t, []byte("contract-id"), args[1])
+	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(0)
+	require.Equal(t, "ExtendLockExpiry", eventName)
+	eventInfo := &common.AssetLockExtension{}
+	err = proto.Unmarshal(eventPayload, eventInfo)
+	require.NoError(t, err)
+	require.Equal(t, "contract-id", eventInfo.ContractId)
+	require.Equal(t, newExpiryTimeSecs, eventInfo.NewExpiryTimeSecs)
+
+	// Test failure with the extension rejected by the interop chaincode
+	chaincodeStub.InvokeChaincodeReturns(shim.Error("cannot extend the lock associated with contractId contract-id: lock extension is not signed by the recipient"))
+	extended, err = amc.ExtendLockExpiry(ctx, "contract-id", base64.StdEncoding.EncodeToString(extensionInfoBytes))
+	require.EqualError(t, err, "cannot extend the lock associated with contractId contract-id: lock extension is not signed by the recipient")
+	require.False(t, extended)
+	require.Equal(t, 1, chaincodeStub.SetEventCallCount())
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+}
+
+func TestContractUnlockExpiredAssets(t *testing.T) {
+	ctx, chaincodeStub, amc := prepMockStub()
+	chaincodeStub.GetCreatorReturns([]byte("locker"), nil)
+
+	// Test failure with invalid arguments
+	_, err := amc.UnlockExpiredAssetsUsingContractIds(ctx, []string{})
+	require.EqualError(t, err, "empty list of contract ids")
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+	_, err = amc.UnlockExpiredAssetsUsingContractIds(ctx, []string{"contract-id-1", ""})
+	require.EqualError(t, err, "empty contract id")
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+	_, err = amc.UnlockExpiredAssetsOfLocker(ctx, "", 0)
+	require.EqualError(t, err, "invalid expiry cutoff time")
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+	require.Equal(t, 0, chaincodeStub.InvokeChaincodeCallCount())
+
+	// Test success with the outcomes of the batch returned and carried in the event
+	batchOutcomes := &common.AssetBatchUnlockOutcomes{Outcomes: []*common.AssetUnlockOutcome{
+		{ContractId: "contract-id-1", Unlocked: true},
+		{ContractId: "contract-id-2", Error: "expiry time is not yet elapsed"},
+	}}
+	batchOutcomesBytes, _ := proto.Marshal(batchOutcomes)
+	batchOutcomesBase64 := base64.StdEncoding.EncodeToString(batchOutcomesBytes)
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte(batchOutcomesBase64)))
+	outcomesBase64, err := amc.UnlockExpiredAssetsUsingContractIds(ctx, []string{"contract-id-1", "contract-id-2"})
+	require.NoError(t, err)
+	require.Equal(t, batchOutcomesBase64, outcomesBase64)
+	_, args, _ := chaincodeStub.InvokeChaincodeArgsForCall(0)
+	require.Equal(t, []byte("UnlockExpiredAssetsUsingContractIds"), args[0])
+	require.Equal(t, []byte(`["contract-id-1","contract-id-2"]`), args[1])
+	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(0)
+	require.Equal(t, "UnlockExpiredAssets", eventName)
+	eventInfo := &common.AssetBatchUnlockOutcomes{}
+	err = proto.Unmarshal(eventPayload, eventInfo)
+	require.NoError(t, err)
+	require.Len(t, eventInfo.Outcomes, 2)
+	require.True(t, eventInfo.Outcomes[0].Unlocked)
+	require.Equal(t, "expiry time is not yet elapsed", eventInfo.Outcomes[1].Error)
+
+	// Test failure with outcomes that are not serialized in base64 form
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte(`[{"contractId":"contract-id-1","unlocked":true}]`)))
+	_, err = amc.UnlockExpiredAssetsUsingContractIds(ctx, []string{"contract-id-1"})
+	require.Error(t, err)
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte(batchOutcomesBase64)))
+
+	// Test success with the locker defaulting to the caller
+	cutoffSecs := uint64(time.Now().Unix())
+	outcomesBase64, err = amc.UnlockExpiredAssetsOfLocker(ctx, "", cutoffSecs)
+	require.NoError(t, err)
+	require.Equal(t, batchOutcomesBase64, outcomesBase64)
+	_, args, _ = chaincodeStub.InvokeChaincodeArgsForCall(2)
+	require.Equal(t, [][]byte{[]byte("UnlockExpiredAssetsOfLocker"), []byte("locker"), []byte(strconv.FormatUint(cutoffSecs, 10))}, args)
+	require.Equal(t, 2, chaincodeStub.SetEventCallCount())
+
+	// Test failure with the batch rejected by the interop chaincode
+	chaincodeStub.InvokeChaincodeReturns(shim.Error("locks held by Bob cannot be unlocked by locker"))
+	_, err = amc.UnlockExpiredAssetsOfLocker(ctx, "Bob", cutoffSecs)
+	require.EqualError(t, err, "locks held by Bob cannot be unlocked by locker")
+	require.Equal(t, 2, chaincodeStub.SetEventCallCount())
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+}
+
+func TestContractClaimAssetsUsingContractIds(t *testing.T) {
+	ctx, chaincodeStub, amc := prepMockStub()
+
+	claimInfoHTLC := &common.AssetClaimHTLC{
+		HashPreimageBase64: []byte(base64.StdEncoding.EncodeToString([]byte("abcd"))),
+	}
+	claimInfoHTLCBytes, _ := proto.Marshal(claimInfoHTLC)
+	claimInfo := &common.AssetClaim{
+		LockMechanism: common.LockMechanism_HTLC,
+		ClaimInfo:     claimInfoHTLCBytes,
+	}
+	claimInfoBytes, _ := proto.Marshal(claimInfo)
+	claimInfoBase64 := base64.StdEncoding.EncodeToString(claimInfoBytes)
+
+	// Test failure with invalid arguments
+	_, err := amc.ClaimAssetsUsingContractIds(ctx, []string{}, claimInfoBase64)
+	require.EqualError(t, err, "empty list of contract ids")
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+	_, err = amc.ClaimAssetsUsingContractIds(ctx, []string{"contract-id-1", ""}, claimInfoBase64)
+	require.EqualError(t, err, "contractId cannot be empty")
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+	escrowClaimInfo := &common.AssetClaim{
+		LockMechanism: common.LockMechanism_ESCROW,
+		ClaimInfo:     []byte("escrow-claim"),
+	}
+	escrowClaimInfoBytes, _ := proto.Marshal(escrowClaimInfo)
+	_, err = amc.ClaimAssetsUsingContractIds(ctx, []string{"contract-id-1"}, base64.StdEncoding.EncodeToString(escrowClaimInfoBytes))
+	require.EqualError(t, err, "batch claims are only supported for the HTLC lock mechanism")
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+	require.Equal(t, 0, chaincodeStub.InvokeChaincodeCallCount())
+
+	// Test success with the batch event carrying the contractIds and the hash preimage
+	chaincodeStub.InvokeChaincodeReturns(shim.Success(nil))
+	claimed, err := amc.ClaimAssetsUsingContractIds(ctx, []string{"contract-id-1", "contract-id-2"}, claimInfoBase64)
+	require.NoError(t, err)
+	require.True(t, claimed)
+	_, args, _ := chaincodeStub.InvokeChaincodeArgsForCall(0)
+	require.Equal(t, []byte("ClaimAssetsUsingContractIds"), args[0])
+	require.Equal(t, []byte(`["contract-id-1","contract-id-2"]`), args[1])
+	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(0)
+	require.Equal(t, "ClaimAssetsInBatch", eventName)
+	eventInfo := &common.AssetBatchContractHTLC{}
+	err = proto.Unmarshal(eventPayload, eventInfo)
+	require.NoError(t, err)
+	require.Equal(t, []string{"contract-id-1", "contract-id-2"}, eventInfo.ContractIds)
+	require.Equal(t, claimInfoHTLC.HashPreimageBase64, eventInfo.Claim.HashPreimageBase64)
+
+	// Test failure with the batch rejected by the interop chaincode
+	chaincodeStub.InvokeChaincodeReturns(shim.Error("cannot claim the batch of assets as the claim of contractId contract-id-2 failed"))
+	claimed, err = amc.ClaimAssetsUsingContractIds(ctx, []string{"contract-id-1", "contract-id-2"}, claimInfoBase64)
+	require.EqualError(t, err, "cannot claim the batch of assets as the claim of contractId contract-id-2 failed")
+	require.False(t, claimed)
+	require.Equal(t, 1, chaincodeStub.SetEventCallCount())
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+}
+
+func TestContractLockAssetAsDelegate(t *testing.T) {
+	ctx, chaincodeStub, amc := prepMockStub()
+
+	currentTimeSecs := uint64(time.Now().Unix())
+	lockInfoHTLC := &common.AssetLockHTLC{
+		HashBase64:     []byte("ivHErp1x4bJDKuRo6L5bApO/DdoyD/dG0mAZrzLZEIs="),
+		ExpiryTimeSecs: currentTimeSecs + 300,
+		TimeSpec:       common.AssetLockHTLC_EPOCH,
+	}
+	lockInfoHTLCBytes, _ := proto.Marshal(lockInfoHTLC)
+	lockInfo := &common.AssetLock{
+		LockMechanism: common.LockMechanism_HTLC,
+		LockInfo:      lockInfoHTLCBytes,
+	}
+	lockInfoBytes, _ := proto.Marshal(lockInfo)
+	lockInfoBase64 := base64.StdEncoding.EncodeToString(lockInfoBytes)
+	assetAgreement := &common.AssetExchangeAgreement{
+		Type:      "bond",
+		Id:        "A001",
+		Recipient: "Bob",
+		Locker:    "Alice",
+	}
+	assetAgreementBytes, _ := proto.Marshal(assetAgreement)
+	assetAgreementBase64 := base64.StdEncoding.EncodeToString(assetAgreementBytes)
+	delegation := &common.AssetLockDelegation{
+		Delegate:       "Custodian",
+		OwnerSignature: []byte("owner-signature"),
+	}
+	delegationBytes, _ := proto.Marshal(delegation)
+	delegationBase64 := base64.StdEncoding.EncodeToString(delegationBytes)
+
+	// Test failure with an empty delegation or a missing owner
+	_, err := amc.LockAssetAsDelegate(ctx, assetAgreementBase64, lockInfoBase64, "")
+	require.EqualError(t, err, "empty lock delegation")
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+	ownerlessAgreement := &common.AssetExchangeAgreement{
+		Type:      "bond",
+		Id:        "A001",
+		Recipient: "Bob",
+	}
+	ownerlessAgreementBytes, _ := proto.Marshal(ownerlessAgreement)
+	_, err = amc.LockAssetAsDelegate(ctx, base64.StdEncoding.EncodeToString(ownerlessAgreementBytes), lockInfoBase64, delegationBase64)
+	require.EqualError(t, err, "empty asset owner")
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+	require.Equal(t, 0, chaincodeStub.InvokeChaincodeCallCount())
+
+	// Test success with the agreement, lock and delegation passed through to the interop chaincode
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte("contract-id-1")))
+	contractId, err := amc.LockAssetAsDelegate(ctx, assetAgreementBase64, lockInfoBase64, delegationBase64)
+	require.NoError(t, err)
+	require.Equal(t, "contract-id-1", contractId)
+	_, args, _ := chaincodeStub.InvokeChaincodeArgsForCall(0)
+	require.Equal(t, []byte("LockAssetAsDelegate"), args[0])
+	require.Equal(t, []byte(assetAgreementBase64), args[1])
+	require.Equal(t, []byte(lockInfoBase64), args[2])
+	require.Equal(t, []byte(delegationBase64), args[3])
+	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(0)
+	require.Equal(t, "LockAsset", eventName)
+	contractInfo := &common.AssetContractHTLC{}
+	err = proto.Unmarshal(eventPayload, contractInfo)
+	require.NoError(t, err)
+	require.Equal(t, "contract-id-1", contractInfo.ContractId)
+	require.Equal(t, "Alice", contractInfo.Agreement.Locker)
+
+	// Test success with fungible asset units
+	fungibleAssetAgreement := &common.FungibleAssetExchangeAgreement{
+		Type:      "cbdc",
+		NumUnits:  10,
+		Recipient: "Bob",
+		Locker:    "Alice",
+	}
+	fungibleAssetAgreementBytes, _ := proto.Marshal(fungibleAssetAgreement)
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte("contract-id-2")))
+	contractId, err = amc.LockFungibleAssetAsDelegate(ctx, base64.StdEncoding.EncodeToString(fungibleAssetAgreementBytes), lockInfoBase64, delegationBase64)
+	require.NoError(t, err)
+	require.Equal(t, "contract-id-2", contractId)
+	_, args, _ = chaincodeStub.InvokeChaincodeArgsForCall(1)
+	require.Equal(t, []byte("LockFungibleAssetAsDelegate"), args[0])
+	eventName, _ = chaincodeStub.SetEventArgsForCall(1)
+	require.Equal(t, "LockFungibleAsset", eventName)
+
+	// Test failure with the delegation rejected by the interop chaincode
+	chaincodeStub.InvokeChaincodeReturns(shim.Error("error in lock delegation validation: lock delegation is not signed by the asset owner"))
+	_, err = amc.LockAssetAsDelegate(ctx, assetAgreementBase64, lockInfoBase64, delegationBase64)
+	require.EqualError(t, err, "error in lock delegation validation: lock delegation is not signed by the asset owner")
+	require.Equal(t, 2, chaincodeStub.SetEventCallCount())
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+}
@@ -13,6 +13,7 @@ import (
     "strconv"
     "encoding/json"
     "strings"
+    "sort"
     "crypto/sha256"
     "encoding/base64"
 
@@ -36,6 +37,7 @@ type InteropCC struct {
     assetLockMap map[string]string
     fungibleAssetLockMap map[string]string
     fungibleAssetLockedCount map[string]int
+    claimedContractMap map[string]string
 }
 
 func (cc *InteropCC) Init(stub shim.ChaincodeStubInterface) pb.Response {
@@ -43,6 +45,7 @@ func (cc *InteropCC) Init(stub shim.ChaincodeStubInterface) pb.Response {
     cc.assetLockMap = make(map[string]string)
     cc.fungibleAssetLockMap = make(map[string]string)
     cc.fungibleAssetLockedCount = make(map[string]int)
+    cc.claimedContractMap = make(map[string]string)
     return shim.Success(nil)
 }
 
@@ -195,7 +198,7 @@ func (cc *InteropCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 			return shim.Error(fmt.Sprintf("cannot claim fungible asset using contractId %s as caller is different from recipient", contractId))
 		}
 		delete(cc.fungibleAssetLockMap, contractId)
-		return shim.Success(nil)
+		return shim.Success([]byte(assetLockValSplit[1]))
 	} else {
             return shim.Error(fmt.Sprintf("No fungible asset is locked associated with contractId %s", contractId))
 	}
@@ -209,11 +212,23 @@ func (cc *InteropCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 			return shim.Error(fmt.Sprintf("cannot claim asset using contractId %s as caller is different from recipient", contractId))
 		}
 		delete(cc.assetLockMap, contractId)
+		claimInfo := &common.AssetClaim{}
+		arg1, _ := base64.StdEncoding.DecodeString(args[1])
+		_ = proto.Unmarshal([]byte(arg1), claimInfo)
+		claimInfoHTLC := &common.AssetClaimHTLC{}
+		_ = proto.Unmarshal(claimInfo.ClaimInfo, claimInfoHTLC)
+		cc.claimedContractMap[contractId] = string(claimInfoHTLC.HashPreimageBase64)
 		return shim.Success(nil)
 	} else {
             return shim.Error(fmt.Sprintf("No asset is locked associated with contractId %s", contractId))
 	}
     }
+    if function == "GetHTLCHashPreImage" {
+        if hashPreimageBase64, contractExists := cc.claimedContractMap[args[0]]; contractExists {
+            return shim.Success([]byte(hashPreimageBase64))
+        }
+        return shim.Error(fmt.Sprintf("no claimed HTLC is associated with contractId %s", args[0]))
+    }
     if function == "GetAllLockedAssets" || function == "GetAllAssetsLockedUntil" {
         assets := []string{}
         for key, val := range cc.assetLockMap {
@@ -241,12 +256,51 @@ func (cc *InteropCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
         assetsBytes, _ := json.Marshal(assets)
         return shim.Success(assetsBytes)
     }
+    if strings.HasSuffix(function, "WithPagination") {
+        // list the locks in key order, using the offset of the next page as the bookmark
+        assets := []string{}
+        if function != "GetAllFungibleLockedAssetsWithPagination" {
+            for key, val := range cc.assetLockMap {
+                assets = append(assets, key + ":" + val)
+            }
+        }
+        if function != "GetAllNonFungibleLockedAssetsWithPagination" {
+            for key, val := range cc.fungibleAssetLockMap {
+                assets = append(assets, key + ":" + val)
+            }
+        }
+        sort.Strings(assets)
+        pageSize, _ := strconv.Atoi(args[len(args) - 2])
+        offset, _ := strconv.Atoi(args[len(args) - 1])
+        page := am.LockedAssetsPage{ LockedAssets: []string{} }
+        for i := offset; i < len(assets) && i < offset + pageSize; i++ {
+            page.LockedAssets = append(page.LockedAssets, assets[i])
+        }
+        page.FetchedRecordsCount = int32(len(page.LockedAssets))
+        if offset + pageSize < len(assets) {
+            page.Bookmark = strconv.Itoa(offset + pageSize)
+        }
+        pageBytes, _ := json.Marshal(page)
+        return shim.Success(pageBytes)
+    }
     if function == "GetAssetTimeToRelease" {
         return shim.Success([]byte(strconv.Itoa(len(cc.assetLockMap))))
     }
     if function == "GetFungibleAssetTimeToRelease" {
         return shim.Success([]byte(strconv.Itoa(len(cc.fungibleAssetLockMap))))
     }
+    if function == "GetAssetTimeToReleaseUsingContractId" {
+        if _, contractExists := cc.assetLockMap[args[0]]; !contractExists {
+            return shim.Error(fmt.Sprintf("No asset is locked associated with contractId %s", args[0]))
+        }
+        return shim.Success([]byte(strconv.Itoa(len(cc.assetLockMap))))
+    }
+    if function == "GetFungibleAssetTimeToReleaseUsingContractId" {
+        if _, contractExists := cc.fungibleAssetLockMap[args[0]]; !contractExists {
+            return shim.Error(fmt.Sprintf("No fungible asset is locked associated with contractId %s", args[0]))
+        }
+        return shim.Success([]byte(strconv.Itoa(len(cc.fungibleAssetLockMap))))
+    }
     return shim.Error(fmt.Sprintf("Invalid invoke function name: %s", function))
 }
 
@@ -397,6 +451,81 @@ func TestAssetLock(t *testing.T) {
     lockSuccess, err = amcc.IsAssetLockedQueryUsingContractId(amstub, contractId)
     require.NoError(t, err)
     require.True(t, lockSuccess)
+
+    // Test failure when the lock duration is zero
+    assetAgreement.Id = "A003"
+    lockInfoHTLC.TimeSpec = common.AssetLockHTLC_DURATION
+    lockInfoHTLC.ExpiryTimeSecs = 0
+    lockInfoBytes, _ = proto.Marshal(lockInfoHTLC)
+    lockInfo.LockInfo = lockInfoBytes
+    contractId, err = amcc.LockAsset(amstub, assetAgreement, lockInfo)
+    require.EqualError(t, err, "lock duration must be greater than zero")
+    require.Empty(t, contractId)
+
+    // Test success with a lock duration
+    lockInfoHTLC.ExpiryTimeSecs = 60     // expires in 1 minute
+    lockInfoBytes, _ = proto.Marshal(lockInfoHTLC)
+    lockInfo.LockInfo = lockInfoBytes
+    contractId, err = amcc.LockAsset(amstub, assetAgreement, lockInfo)
+    require.NoError(t, err)
+    require.NotEmpty(t, contractId)
+
+    // Test failure when the hash mechanism is not supported
+    assetAgreement.Id = "A004"
+    lockInfoHTLC.HashMechanism = common.HashMechanism(10)
+    lockInfoBytes, _ = proto.Marshal(lockInfoHTLC)
+    lockInfo.LockInfo = lockInfoBytes
+    contractId, err = amcc.LockAsset(amstub, assetAgreement, lockInfo)
+    require.EqualError(t, err, "unsupported hash mechanism: 10")
+    require.Empty(t, contractId)
+
+    // Test success with a Keccak-256 hash lock
+    lockInfoHTLC.HashMechanism = common.HashMechanism_KECCAK256
+    lockInfoBytes, _ = proto.Marshal(lockInfoHTLC)
+    lockInfo.LockInfo = lockInfoBytes
+    contractId, err = amcc.LockAsset(amstub, assetAgreement, lockInfo)
+    require.NoError(t, err)
+    require.NotEmpty(t, contractId)
+
+    // Test failure when the escrow arbiter is not supplied
+    assetAgreement.Id = "A005"
+    lockInfoEscrow := &common.AssetLockEscrow {
+        Arbiter: "",
+        ExpiryTimeSecs: uint64(expiryTime.Unix()),
+    }
+    lockInfoBytes, _ = proto.Marshal(lockInfoEscrow)
+    lockInfo.LockMechanism = common.LockMechanism_ESCROW
+    lockInfo.LockInfo = lockInfoBytes
+    contractId, err = amcc.LockAsset(amstub, assetAgreement, lockInfo)
+    require.EqualError(t, err, "empty escrow arbiter")
+    require.Empty(t, contractId)
+
+    // Test failure when the escrow lock duration is zero
+    lockInfoEscrow.Arbiter = "Carol"
+    lockInfoEscrow.TimeSpec = common.AssetLockHTLC_DURATION
+    lockInfoEscrow.ExpiryTimeSecs = 0
+    lockInfoBytes, _ = proto.Marshal(lockInfoEscrow)
+    lockInfo.LockInfo = lockInfoBytes
+    contractId, err = amcc.LockAsset(amstub, assetAgreement, lockInfo)
+    require.EqualError(t, err, "lock duration must be greater than zero")
+    require.Empty(t, contractId)
+
+    // Test success with an escrow lock
+    lockInfoEscrow.ExpiryTimeSecs = 60     // expires in 1 minute
+    lockInfoBytes, _ = proto.Marshal(lockInfoEscrow)
+    lockInfo.LockInfo = lockInfoBytes
+    contractId, err = amcc.LockAsset(amstub, assetAgreement, lockInfo)
+    require.NoError(t, err)
+    require.NotEmpty(t, contractId)
+
+    // Test success with a lock for several recipients, of whom 2 need to approve a claim
+    assetAgreement.Id = "A006"
+    assetAgreement.Recipient = ""
+    assetAgreement.Recipients = []string{"Bob", "Charlie", "Dave"}
+    assetAgreement.RecipientThreshold = 2
+    contractId, err = amcc.LockAsset(amstub, assetAgreement, lockInfo)
+    require.NoError(t, err)
+    require.NotEmpty(t, contractId)
 }
 
 func TestFungibleAssetLock(t *testing.T) {
@@ -1169,6 +1298,11 @@ func TestAssetClaimUsingContractId(t *testing.T) {
     require.Error(t, err)
     require.False(t, claimSuccess)
 
+    // Test failure when the asset is not yet claimed
+    revealedPreimage, err := amcc.GetHTLCHashPreImage(amstub, contractId)
+    require.Error(t, err)
+    require.Empty(t, revealedPreimage)
+
     // Now claim the asset
     setCreator(amstub, recipient)
     setCreator(istub, recipient)
@@ -1178,6 +1312,16 @@ func TestAssetClaimUsingContractId(t *testing.T) {
     setCreator(amstub, locker)
     setCreator(istub, locker)
 
+    // Confirm that the hash preimage revealed by the claim can be fetched by the locker
+    revealedPreimage, err = amcc.GetHTLCHashPreImage(amstub, contractId)
+    require.NoError(t, err)
+    require.Equal(t, string(hashPreimage), revealedPreimage)
+
+    // Test failure when contractId is not supplied
+    revealedPreimage, err = amcc.GetHTLCHashPreImage(amstub, "")
+    require.Error(t, err)
+    require.Empty(t, revealedPreimage)
+
     // Confirm that asset is not locked
     lockSuccess, err = amcc.IsAssetLockedQueryUsingContractId(amstub, contractId)
     require.NoError(t, err)
@@ -1440,6 +1584,104 @@ func TestAssetListFunctions(t *testing.T) {
     require.Equal(t, 2, len(getSuccess))
 }
 
+func TestAssetListFunctionsWithPagination(t *testing.T) {
+    amcc, amstub := createAssetMgmtCCInstance()
+    recipient := "Bob"
+    locker := clientId
+    hash := []byte("MBQGA1UEBxMNU2FuIEZyYW5jaXNjbzEPMA0GA1UECxMGY2xpZW50MSQwIgYDVQQD")
+    lockInfoHTLC := &common.AssetLockHTLC {
+        HashBase64: hash,
+        ExpiryTimeSecs: uint64(time.Now().Add(time.Minute).Unix()),
+    }
+    lockInfoBytes, _ := proto.Marshal(lockInfoHTLC)
+    lockInfo := &common.AssetLock {
+        LockMechanism: common.LockMechanism_HTLC,
+        LockInfo: lockInfoBytes,
+    }
+
+    // Test failure when interop CC is not set
+    page, err := amcc.GetAllLockedAssetsWithPagination(amstub, recipient, locker, 2, "")
+    require.Error(t, err)
+    require.Nil(t, page)
+
+    page, err = amcc.GetAllAssetsLockedUntilWithPagination(amstub, uint64(time.Now().Unix()), 2, "")
+    require.Error(t, err)
+    require.Nil(t, page)
+
+    associateInteropCCInstance(amcc, amstub)
+
+    // Test failures when parameters are invalid
+    page, err = amcc.GetAllLockedAssetsWithPagination(amstub, "", "", 2, "")
+    require.Error(t, err)
+    require.Nil(t, page)
+
+    page, err = amcc.GetAllFungibleLockedAssetsWithPagination(amstub, recipient, locker, 0, "")
+    require.EqualError(t, err, "invalid page size 0")
+    require.Nil(t, page)
+
+    page, err = amcc.GetAllAssetsLockedUntilWithPagination(amstub, 0, 2, "")
+    require.Error(t, err)
+    require.Nil(t, page)
+
+    // Lock 3 non-fungible and 2 fungible assets
+    for _, assetId := range []string{"A001", "A002", "A003"} {
+        assetAgreement := &common.AssetExchangeAgreement {
+            Type: "bond",
+            Id: assetId,
+            Recipient: recipient,
+            Locker: locker,
+        }
+        _, err = amcc.LockAsset(amstub, assetAgreement, lockInfo)
+        require.NoError(t, err)
+    }
+    for _, numUnits := range []uint64{10, 20} {
+        fungibleAssetExchangeAgreement := &common.FungibleAssetExchangeAgreement {
+            Type: "cbdc",
+            NumUnits: numUnits,
+            Recipient: recipient,
+            Locker: locker,
+        }
+        _, err = amcc.LockFungibleAsset(amstub, fungibleAssetExchangeAgreement, lockInfo)
+        require.NoError(t, err)
+    }
+
+    // Test success by following the bookmarks to the end of the listings
+    allAssets, err := amcc.GetAllLockedAssets(amstub, recipient, locker)
+    require.NoError(t, err)
+    pagedAssets := []string{}
+    bookmark := ""
+    for {
+        page, err = amcc.GetAllLockedAssetsWithPagination(amstub, recipient, locker, 2, bookmark)
+        require.NoError(t, err)
+        require.LessOrEqual(t, len(page.LockedAssets), 2)
+        pagedAssets = append(pagedAssets, page.LockedAssets...)
+        if page.Bookmark == "" {
+            break
+        }
+        bookmark = page.Bookmark
+    }
+    require.ElementsMatch(t, allAssets, pagedAssets)
+
+    page, err = amcc.GetAllNonFungibleLockedAssetsWithPagination(amstub, recipient, locker, 2, "")
+    require.NoError(t, err)
+    require.Equal(t, 2, len(page.LockedAssets))
+    require.NotEmpty(t, page.Bookmark)
+    page, err = amcc.GetAllNonFungibleLockedAssetsWithPagination(amstub, recipient, locker, 2, page.Bookmark)
+    require.NoError(t, err)
+    require.Equal(t, 1, len(page.LockedAssets))
+    require.Empty(t, page.Bookmark)
+
+    page, err = amcc.GetAllFungibleLockedAssetsWithPagination(amstub, recipient, locker, 2, "")
+    require.NoError(t, err)
+    require.Equal(t, 2, len(page.LockedAssets))
+    require.Empty(t, page.Bookmark)
+
+    page, err = amcc.GetAllAssetsLockedUntilWithPagination(amstub, uint64(time.Now().Add(time.Minute).Unix()), 4, "")
+    require.NoError(t, err)
+    require.Equal(t, 4, len(page.LockedAssets))
+    require.NotEmpty(t, page.Bookmark)
+}
+
 func TestAssetTimeFunctions(t *testing.T) {
     amcc, amstub := createAssetMgmtCCInstance()
     assetType := "bond"
@@ -1566,10 +1808,28 @@ func TestAssetTimeFunctions(t *testing.T) {
     require.NoError(t, err)
     require.Less(t, uint64(0), getSuccess)
 
+    getSuccess, err = amcc.GetAssetTimeToReleaseUsingContractId(amstub, contractId)
+    require.NoError(t, err)
+    require.Less(t, uint64(0), getSuccess)
+
+    // Test failures when the contractId is empty or not associated with a lock
+    getSuccess, err = amcc.GetAssetTimeToReleaseUsingContractId(amstub, "")
+    require.Error(t, err)
+    require.Equal(t, uint64(0), getSuccess)
+
+    getSuccess, err = amcc.GetAssetTimeToReleaseUsingContractId(amstub, "non-existing-contract-id")
+    require.Error(t, err)
+    require.Equal(t, uint64(0), getSuccess)
+
     // Lock a fungible asset
-    _, err = amcc.LockFungibleAsset(amstub, fungibleAssetExchangeAgreement, lockInfo)
+    fungibleContractId, err := amcc.LockFungibleAsset(amstub, fungibleAssetExchangeAgreement, lockInfo)
     require.NoError(t, err)
 
+    // Test success
+    getSuccess, err = amcc.GetFungibleAssetTimeToReleaseUsingContractId(amstub, fungibleContractId)
+    require.NoError(t, err)
+    require.Less(t, uint64(0), getSuccess)
+
     // Test success
     fungibleAssetExchangeAgreement.Recipient = recipient
     fungibleAssetExchangeAgreement.Locker = locker
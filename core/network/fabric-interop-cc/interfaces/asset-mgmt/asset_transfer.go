@@ -0,0 +1,146 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package assetmgmt
+
+import (
+    "fmt"
+    "encoding/base64"
+
+    "github.com/golang/protobuf/proto"
+    "github.com/hyperledger/fabric-chaincode-go/shim"
+    "github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+)
+
+
+func (am *AssetManagement) validateInteropccPledgeId(pledgeId string) error {
+    if len(am.interopChaincodeId) == 0 {
+        return logThenErrorf("empty interop chaincode id")
+    }
+    if len(pledgeId) == 0 {
+        return logThenErrorf("empty pledge id")
+    }
+    return nil
+}
+
+// function to decode the serialized pledge (in base64 form) returned by the interop chaincode
+func decodeAssetPledge(assetPledgeBytes64 []byte) (*common.AssetPledge, error) {
+    assetPledgeBytes, err := base64.StdEncoding.DecodeString(string(assetPledgeBytes64))
+    if err != nil {
+        return nil, logThenErrorf(err.Error())
+    }
+    assetPledge := &common.AssetPledge{}
+    err = proto.Unmarshal(assetPledgeBytes, assetPledge)
+    if err != nil {
+        return nil, logThenErrorf(err.Error())
+    }
+    return assetPledge, nil
+}
+
+// Pledge an asset for its transfer to a recipient on a remote network; returns the pledge id
+func (am *AssetManagement) PledgeAsset(stub shim.ChaincodeStubInterface, assetPledge *common.AssetPledge) (string, error) {
+    if len(am.interopChaincodeId) == 0 {
+        return "", logThenErrorf("empty interop chaincode id")
+    }
+    if len(assetPledge.AssetType) == 0 || len(assetPledge.AssetDetails) == 0 {
+        return "", logThenErrorf("empty asset type or details")
+    }
+    if len(assetPledge.RemoteNetworkID) == 0 {
+        return "", logThenErrorf("empty remote network id")
+    }
+    if len(assetPledge.Recipient) == 0 {
+        return "", logThenErrorf("empty pledge recipient")
+    }
+
+    assetPledgeBytes, err := proto.Marshal(assetPledge)
+    if err != nil {
+        return "", logThenErrorf(err.Error())
+    }
+    assetPledgeBytes64 := base64.StdEncoding.EncodeToString(assetPledgeBytes)
+
+    iccResp := stub.InvokeChaincode(am.interopChaincodeId, [][]byte{[]byte("PledgeAsset"), []byte(assetPledgeBytes64)}, "")
+    fmt.Printf("Response from Interop CC: %+v\n", iccResp)
+    if iccResp.GetStatus() != shim.OK {
+        return "", logThenErrorf(string(iccResp.GetMessage()))
+    }
+    pledgeId := string(iccResp.GetPayload())
+    fmt.Printf("Asset of type %s pledged for %s in network %s using pledgeId %s\n", assetPledge.AssetType, assetPledge.Recipient, assetPledge.RemoteNetworkID, pledgeId)
+    return pledgeId, nil
+}
+
+// Returns the serialized pledge (in base64 form), which is empty if no asset is pledged using pledgeId
+func (am *AssetManagement) GetAssetPledgeStatus(stub shim.ChaincodeStubInterface, pledgeId string) (string, error) {
+    err := am.validateInteropccPledgeId(pledgeId)
+    if err != nil {
+        return "", err
+    }
+
+    iccResp := stub.InvokeChaincode(am.interopChaincodeId, [][]byte{[]byte("GetAssetPledgeStatus"), []byte(pledgeId)}, "")
+    fmt.Printf("Response from Interop CC: %+v\n", iccResp)
+    if iccResp.GetStatus() != shim.OK {
+        return "", logThenErrorf(string(iccResp.GetMessage()))
+    }
+    return string(iccResp.GetPayload()), nil
+}
+
+// Claim an asset pledged on a remote network, using a view of the pledge (the result of 'GetAssetPledgeStatus' for
+// pledgeId on that network) obtained from pledgeViewAddress; returns the pledge, carrying the details of the asset
+func (am *AssetManagement) ClaimRemoteAsset(stub shim.ChaincodeStubInterface, pledgeId, remoteNetworkId, pledgeViewAddress, pledgeViewB64 string) (*common.AssetPledge, error) {
+    err := am.validateInteropccPledgeId(pledgeId)
+    if err != nil {
+        return nil, err
+    }
+    if len(remoteNetworkId) == 0 {
+        return nil, logThenErrorf("empty remote network id")
+    }
+    if len(pledgeViewAddress) == 0 || len(pledgeViewB64) == 0 {
+        return nil, logThenErrorf("empty view address or view of the asset pledge")
+    }
+
+    iccResp := stub.InvokeChaincode(am.interopChaincodeId, [][]byte{[]byte("ClaimRemoteAsset"), []byte(pledgeId), []byte(remoteNetworkId), []byte(pledgeViewAddress), []byte(pledgeViewB64)}, "")
+    fmt.Printf("Response from Interop CC: %+v\n", iccResp)
+    if iccResp.GetStatus() != shim.OK {
+        return nil, logThenErrorf(string(iccResp.GetMessage()))
+    }
+    fmt.Printf("Asset pledged in network %s using pledgeId %s is claimed\n", remoteNetworkId, pledgeId)
+    return decodeAssetPledge(iccResp.GetPayload())
+}
+
+// Returns the serialized status (in base64 form) of the claim of an asset pledged on a remote network using pledgeId;
+// the status is built by the interop chaincode from its ledger alone
+func (am *AssetManagement) GetAssetClaimStatus(stub shim.ChaincodeStubInterface, pledgeId, remoteNetworkId string) (string, error) {
+    err := am.validateInteropccPledgeId(pledgeId)
+    if err != nil {
+        return "", err
+    }
+
+    iccResp := stub.InvokeChaincode(am.interopChaincodeId, [][]byte{[]byte("GetAssetClaimStatus"), []byte(pledgeId), []byte(remoteNetworkId)}, "")
+    fmt.Printf("Response from Interop CC: %+v\n", iccResp)
+    if iccResp.GetStatus() != shim.OK {
+        return "", logThenErrorf(string(iccResp.GetMessage()))
+    }
+    return string(iccResp.GetPayload()), nil
+}
+
+// Reclaim an asset whose pledge has expired without being claimed, using a view of the claim status (the result of
+// 'GetAssetClaimStatus' for pledgeId on the remote network) obtained from claimStatusViewAddress; returns the pledge
+func (am *AssetManagement) ReclaimAsset(stub shim.ChaincodeStubInterface, pledgeId, claimStatusViewAddress, claimStatusViewB64 string) (*common.AssetPledge, error) {
+    err := am.validateInteropccPledgeId(pledgeId)
+    if err != nil {
+        return nil, err
+    }
+    if len(claimStatusViewAddress) == 0 || len(claimStatusViewB64) == 0 {
+        return nil, logThenErrorf("empty view address or view of the asset claim status")
+    }
+
+    iccResp := stub.InvokeChaincode(am.interopChaincodeId, [][]byte{[]byte("ReclaimAsset"), []byte(pledgeId), []byte(claimStatusViewAddress), []byte(claimStatusViewB64)}, "")
+    fmt.Printf("Response from Interop CC: %+v\n", iccResp)
+    if iccResp.GetStatus() != shim.OK {
+        return nil, logThenErrorf(string(iccResp.GetMessage()))
+    }
+    fmt.Printf("Asset pledged using pledgeId %s is reclaimed\n", pledgeId)
+    return decodeAssetPledge(iccResp.GetPayload())
+}
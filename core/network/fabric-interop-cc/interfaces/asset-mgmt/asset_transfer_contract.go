@@ -0,0 +1,145 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package assetmgmt
+
+import (
+    "encoding/base64"
+
+    "github.com/golang/protobuf/proto"
+    "github.com/hyperledger/fabric-contract-api-go/contractapi"
+    "github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+)
+
+
+// AssetTransferHooks is implemented by the application chaincode to remove an asset from its ledger when the asset is
+// pledged for transfer to another network (burn), and to recreate it from its details when it is claimed from another
+// network, or reclaimed after an unclaimed pledge expires (mint); the owner is the certificate (in base64 form) of the
+// recipient or the pledger respectively
+type AssetTransferHooks interface {
+    BurnAsset(ctx contractapi.TransactionContextInterface, assetType string, assetDetails []byte) error
+    MintAsset(ctx contractapi.TransactionContextInterface, assetType string, assetDetails []byte, owner string) error
+}
+
+func (amc *AssetManagementContract) validateAssetTransferConfig() error {
+    if amc.AssetTransferHooks == nil {
+        return logThenErrorf("asset transfer hooks are not configured")
+    }
+    return nil
+}
+
+func (amc *AssetManagementContract) ValidateAndExtractAssetPledge(assetPledgeSerializedProto64 string) (*common.AssetPledge, error) {
+    assetPledge := &common.AssetPledge{}
+    // Decode from base64
+    assetPledgeSerializedProto, err := base64.StdEncoding.DecodeString(assetPledgeSerializedProto64)
+    if err != nil {
+        return assetPledge, logThenErrorf(err.Error())
+    }
+    if len(assetPledgeSerializedProto) == 0 {
+        return assetPledge, logThenErrorf("empty asset pledge")
+    }
+    err = proto.Unmarshal([]byte(assetPledgeSerializedProto), assetPledge)
+    if err != nil {
+        return assetPledge, logThenErrorf(err.Error())
+    }
+
+    return assetPledge, nil
+}
+
+// emit the event recording the pledge, claim or reclaim of an asset, with the pledge that it refers to
+func setAssetTransferEvent(ctx contractapi.TransactionContextInterface, eventName string, pledgeId string, assetPledge *common.AssetPledge) error {
+    eventInfo := &common.AssetTransferEvent {
+        PledgeId: pledgeId,
+        Pledge: assetPledge,
+    }
+    eventInfoBytes, err := proto.Marshal(eventInfo)
+    if err == nil {
+        err = ctx.GetStub().SetEvent(eventName, eventInfoBytes)
+    } else {
+        logWarnings("Unable to set '" + eventName + "' event", err.Error())
+    }
+    return err
+}
+
+// PledgeAsset pledges an asset for its transfer to a recipient on another network, and burns the asset on this network;
+// the local network id of the pledge defaults to the one recorded on the ledger by the interop chaincode
+func (amc *AssetManagementContract) PledgeAsset(ctx contractapi.TransactionContextInterface, assetPledgeSerializedProto64 string) (string, error) {
+    err := amc.validateAssetTransferConfig()
+    if err != nil {
+        return "", err
+    }
+    assetPledge, err := amc.ValidateAndExtractAssetPledge(assetPledgeSerializedProto64)
+    if err != nil {
+        return "", err
+    }
+    pledgeId, err := amc.assetManagement.PledgeAsset(ctx.GetStub(), assetPledge)
+    if err != nil {
+        return "", err
+    }
+    err = amc.AssetTransferHooks.BurnAsset(ctx, assetPledge.AssetType, assetPledge.AssetDetails)
+    if err != nil {
+        return "", logThenErrorf("failed to burn the pledged asset: %+v", err)
+    }
+
+    // The below 'SetEvent' should be the last in a given transaction (if this function is being called by another), otherwise it will be overridden
+    err = setAssetTransferEvent(ctx, "PledgeAsset", pledgeId, assetPledge)
+    return pledgeId, err
+}
+
+// GetAssetPledgeStatus is queried by another network, for a view of the pledge to be used in a claim of the asset
+func (amc *AssetManagementContract) GetAssetPledgeStatus(ctx contractapi.TransactionContextInterface, pledgeId string) (string, error) {
+    return amc.assetManagement.GetAssetPledgeStatus(ctx.GetStub(), pledgeId)
+}
+
+// ClaimRemoteAsset claims an asset pledged on another network (remoteNetworkId), using a view of its pledge, and mints
+// the asset on this network for the recipient
+func (amc *AssetManagementContract) ClaimRemoteAsset(ctx contractapi.TransactionContextInterface, pledgeId, remoteNetworkId, pledgeViewAddress, pledgeViewB64 string) (bool, error) {
+    err := amc.validateAssetTransferConfig()
+    if err != nil {
+        return false, err
+    }
+
+    assetPledge, err := amc.assetManagement.ClaimRemoteAsset(ctx.GetStub(), pledgeId, remoteNetworkId, pledgeViewAddress, pledgeViewB64)
+    if err != nil {
+        return false, err
+    }
+    err = amc.AssetTransferHooks.MintAsset(ctx, assetPledge.AssetType, assetPledge.AssetDetails, assetPledge.Recipient)
+    if err != nil {
+        return false, logThenErrorf("failed to mint the claimed asset: %+v", err)
+    }
+
+    // The below 'SetEvent' should be the last in a given transaction (if this function is being called by another), otherwise it will be overridden
+    err = setAssetTransferEvent(ctx, "ClaimRemoteAsset", pledgeId, assetPledge)
+    return err == nil, err
+}
+
+// GetAssetClaimStatus is queried by another network (remoteNetworkId), for a view of the claim status to be used in a
+// reclaim of the asset pledged there
+func (amc *AssetManagementContract) GetAssetClaimStatus(ctx contractapi.TransactionContextInterface, pledgeId, remoteNetworkId string) (string, error) {
+    return amc.assetManagement.GetAssetClaimStatus(ctx.GetStub(), pledgeId, remoteNetworkId)
+}
+
+// ReclaimAsset reclaims an asset whose pledge has expired without being claimed, using a view of its claim status on
+// the network it was pledged to, and mints the asset again on this network for the pledger
+func (amc *AssetManagementContract) ReclaimAsset(ctx contractapi.TransactionContextInterface, pledgeId, claimStatusViewAddress, claimStatusViewB64 string) (bool, error) {
+    err := amc.validateAssetTransferConfig()
+    if err != nil {
+        return false, err
+    }
+
+    assetPledge, err := amc.assetManagement.ReclaimAsset(ctx.GetStub(), pledgeId, claimStatusViewAddress, claimStatusViewB64)
+    if err != nil {
+        return false, err
+    }
+    err = amc.AssetTransferHooks.MintAsset(ctx, assetPledge.AssetType, assetPledge.AssetDetails, assetPledge.Pledger)
+    if err != nil {
+        return false, logThenErrorf("failed to mint the reclaimed asset: %+v", err)
+    }
+
+    // The below 'SetEvent' should be the last in a given transaction (if this function is being called by another), otherwise it will be overridden
+    err = setAssetTransferEvent(ctx, "ReclaimAsset", pledgeId, assetPledge)
+    return err == nil, err
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package assetmgmt_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/stretchr/testify/require"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+)
+
+// Mock application hooks that record the assets burnt and minted, as '<type>:<details>[:<owner>]'
+type assetTransferHooks struct {
+	burnt  []string
+	minted []string
+	err    error
+}
+
+func (hooks *assetTransferHooks) BurnAsset(ctx contractapi.TransactionContextInterface, assetType string, assetDetails []byte) error {
+	if hooks.err != nil {
+		return hooks.err
+	}
+	hooks.burnt = append(hooks.burnt, assetType+":"+string(assetDetails))
+	return nil
+}
+
+func (hooks *assetTransferHooks) MintAsset(ctx contractapi.TransactionContextInterface, assetType string, assetDetails []byte, owner string) error {
+	if hooks.err != nil {
+		return hooks.err
+	}
+	hooks.minted = append(hooks.minted, assetType+":"+string(assetDetails)+":"+owner)
+	return nil
+}
+
+func TestContractAssetTransfer(t *testing.T) {
+	ctx, chaincodeStub, amc := prepMockStub()
+
+	assetPledge := &common.AssetPledge{
+		AssetType:       "bond",
+		AssetDetails:    []byte("A001"),
+		RemoteNetworkID: "network2",
+		Recipient:       "Bob",
+		ExpiryTimeSecs:  1000,
+	}
+	assetPledgeBytes, _ := proto.Marshal(assetPledge)
+	assetPledgeBase64 := base64.StdEncoding.EncodeToString(assetPledgeBytes)
+
+	// Test failure with asset transfers not configured
+	_, err := amc.PledgeAsset(ctx, assetPledgeBase64)
+	require.EqualError(t, err, "asset transfer hooks are not configured")
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+	require.Equal(t, 0, chaincodeStub.InvokeChaincodeCallCount())
+	hooks := &assetTransferHooks{}
+	amc.AssetTransferHooks = hooks
+
+	// Test success with the asset pledged, its local network id being left to the interop chaincode, and burnt
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte("pledge-id-1")))
+	pledgeId, err := amc.PledgeAsset(ctx, assetPledgeBase64)
+	require.NoError(t, err)
+	require.Equal(t, "pledge-id-1", pledgeId)
+	_, args, _ := chaincodeStub.InvokeChaincodeArgsForCall(0)
+	require.Equal(t, []byte("PledgeAsset"), args[0])
+	require.Equal(t, []byte(base64.StdEncoding.EncodeToString(assetPledgeBytes)), args[1])
+	require.Equal(t, []string{"bond:A001"}, hooks.burnt)
+	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(0)
+	require.Equal(t, "PledgeAsset", eventName)
+	eventInfo := &common.AssetTransferEvent{}
+	err = proto.Unmarshal(eventPayload, eventInfo)
+	require.NoError(t, err)
+	require.Equal(t, "pledge-id-1", eventInfo.PledgeId)
+	require.True(t, proto.Equal(assetPledge, eventInfo.Pledge))
+
+	// Test success with a remote asset claimed, and minted for the recipient
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte(base64.StdEncoding.EncodeToString(assetPledgeBytes))))
+	claimed, err := amc.ClaimRemoteAsset(ctx, "pledge-id-1", "network2", "view-address", "view")
+	require.NoError(t, err)
+	require.True(t, claimed)
+	_, args, _ = chaincodeStub.InvokeChaincodeArgsForCall(1)
+	require.Equal(t, [][]byte{[]byte("ClaimRemoteAsset"), []byte("pledge-id-1"), []byte("network2"),
+		[]byte("view-address"), []byte("view")}, args)
+	require.Equal(t, []string{"bond:A001:Bob"}, hooks.minted)
+	eventName, _ = chaincodeStub.SetEventArgsForCall(1)
+	require.Equal(t, "ClaimRemoteAsset", eventName)
+
+	// Test success with the claim status queried
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte("claim-status")))
+	claimStatus, err := amc.GetAssetClaimStatus(ctx, "pledge-id-1", "network2")
+	require.NoError(t, err)
+	require.Equal(t, "claim-status", claimStatus)
+	_, args, _ = chaincodeStub.InvokeChaincodeArgsForCall(2)
+	require.Equal(t, [][]byte{[]byte("GetAssetClaimStatus"), []byte("pledge-id-1"), []byte("network2")}, args)
+
+	// Test failure with a reclaim for which the asset cannot be minted
+	hooks.err = errors.New("asset A001 already exists")
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte(base64.StdEncoding.EncodeToString(assetPledgeBytes))))
+	_, err = amc.ReclaimAsset(ctx, "pledge-id-1", "view-address", "view")
+	require.EqualError(t, err, "failed to mint the reclaimed asset: asset A001 already exists")
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+
+	// Test failure with a reclaim rejected by the interop chaincode
+	hooks.err = nil
+	chaincodeStub.InvokeChaincodeReturns(shim.Error("cannot reclaim asset pledged with pledgeId pledge-id-1 as the pledge has not expired yet"))
+	_, err = amc.ReclaimAsset(ctx, "pledge-id-1", "view-address", "view")
+	require.EqualError(t, err, "cannot reclaim asset pledged with pledgeId pledge-id-1 as the pledge has not expired yet")
+	fmt.Printf("Test failed as expected with error: %+v\n", err)
+	require.Equal(t, []string{"bond:A001:Bob"}, hooks.minted)
+}
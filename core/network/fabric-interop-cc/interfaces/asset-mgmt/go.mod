@@ -4,10 +4,10 @@ go 1.16
 
 require (
 	github.com/golang/protobuf v1.5.0
-	github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go v1.2.1
+	github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go v1.3.0
 	github.com/hyperledger/fabric-chaincode-go v0.0.0-20200424173110-d7076418f212
 	github.com/hyperledger/fabric-contract-api-go v1.1.1
-	github.com/hyperledger/fabric-protos-go v0.0.0-20210528200356-82833ecdac31
+	github.com/hyperledger/fabric-protos-go v0.0.0-20210720123151-f0dc3e2a0871
 	github.com/sirupsen/logrus v1.8.1
 	github.com/stretchr/testify v1.7.0
 )
@@ -98,7 +98,12 @@ func (s *SmartContract) LockFungibleAsset(ctx contractapi.TransactionContextInte
 		return "", logThenErrorf(err.Error())
 	}
 
-	err = s.DeleteTokenAssets(ctx, assetAgreement.Type, assetAgreement.NumUnits)
+	// Move the locked tokens out of the available balance of the locker into the lock
+	lockerECertBase64, err := getECertOfTxCreatorBase64(ctx)
+	if err != nil {
+		return contractId, logThenErrorf(err.Error())
+	}
+	err = lockTokenAssetsHelper(ctx, assetAgreement.Type, assetAgreement.NumUnits, contractId, lockerECertBase64, assetAgreement.Recipient)
 	if err != nil {
 		// not performing the operation UnlockFungibleAsset and let the TxCreator take care of it
 		return contractId, logThenErrorf(err.Error())
@@ -127,6 +132,11 @@ func (s *SmartContract) IsFungibleAssetLocked(ctx contractapi.TransactionContext
 	return s.amc.IsFungibleAssetLocked(ctx, contractId)
 }
 
+// Fetch the hash preimage revealed when the HTLC associated with contractId was claimed
+func (s *SmartContract) GetHTLCHashPreImage(ctx contractapi.TransactionContextInterface, contractId string) (string, error) {
+	return s.amc.GetHTLCHashPreImage(ctx, contractId)
+}
+
 func (s *SmartContract) ClaimAsset(ctx contractapi.TransactionContextInterface, assetAgreementSerializedProto64 string, claimInfoSerializedProto64 string) (bool, error) {
 	assetAgreement, err := s.amc.ValidateAndExtractAssetAgreement(assetAgreementSerializedProto64)
 	if err != nil {
@@ -193,11 +203,11 @@ func (s *SmartContract) ClaimAssetUsingContractId(ctx contractapi.TransactionCon
 }
 
 func (s *SmartContract) ClaimFungibleAsset(ctx contractapi.TransactionContextInterface, contractId, claimInfoSerializedProto64 string) (bool, error) {
-	claimed, err := s.amc.ClaimFungibleAsset(ctx, contractId, claimInfoSerializedProto64)
+	claimedUnits, err := s.amc.ClaimFungibleAssetUnits(ctx, contractId, claimInfoSerializedProto64)
 	if err != nil {
 		return false, logThenErrorf(err.Error())
 	}
-	if claimed {
+	if claimedUnits > 0 {
 		// Add the claimed tokens into the wallet of the claimant
 		recipientECertBase64, err := getECertOfTxCreatorBase64(ctx)
 		if err != nil {
@@ -210,11 +220,16 @@ func (s *SmartContract) ClaimFungibleAsset(ctx contractapi.TransactionContextInt
 			return false, logThenErrorf(err.Error())
 		}
 
-		err = s.IssueTokenAssets(ctx, assetType, numUnits, recipientECertBase64)
+		err = s.releaseTokenAssets(ctx, assetType, claimedUnits, contractId, recipientECertBase64, false)
 		if err != nil {
 			return false, logThenErrorf(err.Error())
 		}
-		err = s.amc.DeleteFungibleAssetLookupMap(ctx, contractId)
+		if claimedUnits < numUnits {
+			// a partial claim leaves the remaining tokens locked under the same contractId
+			err = s.amc.ContractIdFungibleAssetsLookupMap(ctx, assetType, numUnits-claimedUnits, contractId)
+		} else {
+			err = s.amc.DeleteFungibleAssetLookupMap(ctx, contractId)
+		}
 		if err != nil {
 			return false, logThenErrorf(err.Error())
 		}
@@ -281,7 +296,7 @@ func (s *SmartContract) UnlockFungibleAsset(ctx contractapi.TransactionContextIn
 			return false, logThenErrorf(err.Error())
 		}
 
-		err = s.IssueTokenAssets(ctx, assetType, numUnits, lockerECertBase64)
+		err = s.releaseTokenAssets(ctx, assetType, numUnits, contractId, lockerECertBase64, true)
 		if err != nil {
 			return false, logThenErrorf(err.Error())
 		}
@@ -294,3 +309,16 @@ func (s *SmartContract) UnlockFungibleAsset(ctx contractapi.TransactionContextIn
 		return false, logThenErrorf("unlock on token asset using contractId %s failed", contractId)
 	}
 }
+
+// Move the tokens released from the lock associated with contractId into the wallet of the claimant (or of the locker, upon unlock)
+func (s *SmartContract) releaseTokenAssets(ctx contractapi.TransactionContextInterface, assetType string, numUnits uint64, contractId string, claimant string, unlock bool) error {
+	tokenLock, err := readTokenLock(ctx, contractId)
+	if err != nil {
+		return err
+	}
+	if tokenLock == nil {
+		// the lock is not tracked by the wallets, so the tokens are simply issued to the claimant (or locker)
+		return s.IssueTokenAssets(ctx, assetType, numUnits, claimant)
+	}
+	return releaseTokenAssetsHelper(ctx, assetType, numUnits, contractId, tokenLock, claimant, unlock)
+}
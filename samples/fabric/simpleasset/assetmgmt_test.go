@@ -175,11 +175,36 @@ func TestExchangeBondAssetWithTokenAsset(t *testing.T) {
 	chaincodeStub.GetStateReturnsOnCall(6, tokensWalletBytes, nil)
 	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte(tokensContractId)))
 	chaincodeStub.GetCreatorReturnsOnCall(2, []byte(getCreatorInContext("recipient")), nil)
-	chaincodeStub.GetStateReturnsOnCall(7, tokenAssetTypeBytes, nil)
-	chaincodeStub.GetStateReturnsOnCall(8, tokensWalletBytes, nil)
+	// the wallet of the locker, as the recipient sees the locked units through the record of the lock
+	chaincodeStub.GetStateReturnsOnCall(7, tokensWalletBytes, nil)
+	putStateCallCount := chaincodeStub.PutStateCallCount()
 	tokensContractId, err = sc.LockFungibleAsset(ctx, base64.StdEncoding.EncodeToString(tokensAgreementBytes), base64.StdEncoding.EncodeToString(lockInfoBytes))
 	require.NoError(t, err)
 	require.NotEmpty(t, tokensContractId)
+	lockedTokens := &sa.LockedTokenAssets{TokenAssetType: tokenType, NumUnits: numTokens}
+	lockerWallet := &sa.TokenWallet{WalletMap: map[string]uint64{}, LockedMap: map[string]*sa.LockedTokenAssets{tokensContractId: lockedTokens}}
+	lockerWalletBytes, _ := json.Marshal(lockerWallet)
+	// the locker is recorded with the ECert of the transaction creator in its canonical base64 form
+	tokensLockerECertBytes, _ := base64.StdEncoding.DecodeString(tokensLocker)
+	tokensLockerECertBase64 := base64.StdEncoding.EncodeToString(tokensLockerECertBytes)
+	tokenLockBytes, _ := json.Marshal(&sa.TokenLock{Locker: tokensLockerECertBase64, Recipient: tokensRecipient, TokenAssetType: tokenType, NumUnits: numTokens})
+	// the lock is listed in the indexes of its recipient and of its token asset type
+	recipientIndexKey, _ := chaincodeStub.CreateCompositeKey("TokenLockByRecipient", []string{tokensRecipient, tokensContractId})
+	typeIndexKey, _ := chaincodeStub.CreateCompositeKey("TokenLockByType", []string{tokenType, tokensContractId})
+	writtenKeys := map[string]bool{}
+	for i := putStateCallCount; i < chaincodeStub.PutStateCallCount(); i++ {
+		key, value := chaincodeStub.PutStateArgsForCall(i)
+		if key == "W_" + tokensLockerECertBase64 {
+			require.Equal(t, lockerWalletBytes, value)
+		} else if key == "TL_" + tokensContractId {
+			require.Equal(t, tokenLockBytes, value)
+		}
+		require.NotEqual(t, "W_" + tokensRecipient, key)
+		writtenKeys[key] = true
+	}
+	require.True(t, writtenKeys["TL_" + tokensContractId])
+	require.True(t, writtenKeys[recipientIndexKey])
+	require.True(t, writtenKeys[typeIndexKey])
 
 
 	// Claim token asset in network2 by Alice
@@ -194,7 +219,8 @@ func TestExchangeBondAssetWithTokenAsset(t *testing.T) {
 		LockMechanism: common.LockMechanism_HTLC,
 	}
 	claimInfoBytes, _ := proto.Marshal(claimInfo)
-	chaincodeStub.InvokeChaincodeReturns(shim.Success(nil))
+	// the interop chaincode reports the number of units claimed
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte(fmt.Sprintf("%d", numTokens))))
 	chaincodeStub.GetCreatorReturnsOnCall(3, []byte(getCreatorInContext("locker")), nil)
 	tokenAssetType = sa.TokenAssetType {
 		Issuer: tokenIssuer,
@@ -207,11 +233,32 @@ func TestExchangeBondAssetWithTokenAsset(t *testing.T) {
 	}
 
 	contractedTokenAssetBytes, _ := json.Marshal(contractedTokenAsset)
-	chaincodeStub.GetStateReturnsOnCall(9, tokenAssetTypeBytes, nil)
-	chaincodeStub.GetStateReturnsOnCall(10, contractedTokenAssetBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(8, contractedTokenAssetBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(9, tokenLockBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(10, lockerWalletBytes, nil)
 	chaincodeStub.GetStateReturnsOnCall(11, nil, nil)
-	_, err = sc.ClaimFungibleAsset(ctx, base64.StdEncoding.EncodeToString(tokensAgreementBytes), base64.StdEncoding.EncodeToString(claimInfoBytes))
+	putStateCallCount = chaincodeStub.PutStateCallCount()
+	delStateCallCount := chaincodeStub.DelStateCallCount()
+	_, err = sc.ClaimFungibleAsset(ctx, tokensContractId, base64.StdEncoding.EncodeToString(claimInfoBytes))
         require.NoError(t, err)
+	// the emptied lock is dropped from the indexes along with its record
+	deletedKeys := map[string]bool{}
+	for i := delStateCallCount; i < chaincodeStub.DelStateCallCount(); i++ {
+		deletedKeys[chaincodeStub.DelStateArgsForCall(i)] = true
+	}
+	require.True(t, deletedKeys["TL_" + tokensContractId])
+	require.True(t, deletedKeys[recipientIndexKey])
+	require.True(t, deletedKeys[typeIndexKey])
+	// the claimed tokens are available to Alice, while the emptied wallet of Bob and the emptied lock are deleted
+	claimantWalletBytes, _ := json.Marshal(&sa.TokenWallet{WalletMap: map[string]uint64{tokenType: numTokens}})
+	for i := putStateCallCount; i < chaincodeStub.PutStateCallCount(); i++ {
+		key, value := chaincodeStub.PutStateArgsForCall(i)
+		if key == "W_" + tokensRecipient {
+			require.Equal(t, claimantWalletBytes, value)
+		}
+		require.NotEqual(t, "W_" + tokensLocker, key)
+		require.NotEqual(t, "TL_" + tokensContractId, key)
+	}
 
 
 	// Claim bond asset in network1 by Bob
@@ -4,11 +4,17 @@ go 1.15
 
 require (
 	github.com/golang/protobuf v1.5.2
-	github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go v1.2.1
-	github.com/hyperledger-labs/weaver-dlt-interoperability/core/network/fabric-interop-cc/interfaces/asset-mgmt v1.2.1
+	github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go v1.3.0
+	github.com/hyperledger-labs/weaver-dlt-interoperability/core/network/fabric-interop-cc/interfaces/asset-mgmt v1.3.0
 	github.com/hyperledger/fabric-chaincode-go v0.0.0-20210718160520-38d29fabecb9
 	github.com/hyperledger/fabric-contract-api-go v1.1.1
 	github.com/hyperledger/fabric-protos-go v0.0.0-20210720123151-f0dc3e2a0871
 	github.com/sirupsen/logrus v1.8.1
 	github.com/stretchr/testify v1.7.0
 )
+
+// build against the protos and interfaces in this repository, which may be ahead of their last release
+replace (
+	github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go => ../../../common/protos-go
+	github.com/hyperledger-labs/weaver-dlt-interoperability/core/network/fabric-interop-cc/interfaces/asset-mgmt => ../../../core/network/fabric-interop-cc/interfaces/asset-mgmt
+)
@@ -8,6 +8,7 @@ package main_test
 
 import (
 	"os"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"github.com/hyperledger-labs/weaver-dlt-interoperability/core/network/fabric-interop-cc/interfaces/asset-mgmt/mocks"
 	sa "github.com/hyperledger-labs/weaver-dlt-interoperability/samples/fabric/simpleasset"
 )
@@ -27,6 +28,9 @@ func prepMockStub() (*mocks.TransactionContext, *mocks.ChaincodeStub, sa.SmartCo
 
 func prepMocks(orgMSP, clientID string) (*mocks.TransactionContext, *mocks.ChaincodeStub) {
 	chaincodeStub := &mocks.ChaincodeStub{}
+	// composite keys are built and split as the peer does
+	chaincodeStub.CreateCompositeKeyStub = (&shim.ChaincodeStub{}).CreateCompositeKey
+	chaincodeStub.SplitCompositeKeyStub = (&shim.ChaincodeStub{}).SplitCompositeKey
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
 
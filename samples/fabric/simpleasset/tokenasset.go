@@ -3,16 +3,54 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	log "github.com/sirupsen/logrus"
 )
 
 type TokenAssetType struct {
 	Issuer            string            `json:"issuer"`
 	Value             int               `json:"value"`
 }
+// TokenWallet holds the available units of each token asset type owned by an owner (WalletMap), along with the units
+// the owner has locked (LockedMap) by contractId
 type TokenWallet struct {
 	WalletMap            map[string]uint64    `json:"walletlist"`
+	LockedMap            map[string]*LockedTokenAssets `json:"lockedlist,omitempty"`
+}
+
+// LockedTokenAssets is the number of units of a token asset type held in the lock associated with a contractId
+type LockedTokenAssets struct {
+	TokenAssetType    string            `json:"tokenassettype"`
+	NumUnits          uint64            `json:"numunits"`
+}
+
+// TokenLock records the parties of the lock associated with a contractId and the units it still holds; the units
+// pending claim by a recipient and the units locked across all wallets are derived from these records at query time,
+// so that a lock writes neither the wallet of the recipient nor a key shared by all the locks of a token asset type.
+// The records are found through composite-key indexes per recipient and per token asset type, whose entries carry
+// no value and are written when the lock is created and deleted along with the record
+type TokenLock struct {
+	Locker            string            `json:"locker"`
+	Recipient         string            `json:"recipient"`
+	TokenAssetType    string            `json:"tokenassettype"`
+	NumUnits          uint64            `json:"numunits"`
+}
+
+// TokenBalances reports the units of a token asset type available to an owner, locked by the owner, and locked by
+// others for the owner to claim; the total is what the owner possesses (available and locked units)
+const (
+	tokenLockByRecipientObjectType = "TokenLockByRecipient" // <recipient, contractId>
+	tokenLockByTypeObjectType      = "TokenLockByType"      // <token-asset-type, contractId>
+)
+
+type TokenBalances struct {
+	TokenAssetType    string            `json:"tokenassettype"`
+	Available         uint64            `json:"available"`
+	Locked            uint64            `json:"locked"`
+	PendingClaim      uint64            `json:"pendingclaim"`
+	Total             uint64            `json:"total"`
 }
 
 
@@ -287,17 +325,361 @@ func subTokenAssetsHelper(ctx contractapi.TransactionContextInterface, tokenAsse
 		delete(wallet.WalletMap, tokenAssetType)
 	}
 
-	if len(wallet.WalletMap) == 0 {
-		// Delete the entry from State if wallet becomes empty
-		return ctx.GetStub().DelState(id)
-	} else {
-		// Update the new wallet object otherwise
-		walletNewJSON, err := json.Marshal(wallet)
+	return writeWallet(ctx, id, &wallet)
+}
+
+// GetTokenBalances returns the available, locked, pending claim and total amounts of given token asset type of an owner.
+func (s *SmartContract) GetTokenBalances(ctx contractapi.TransactionContextInterface, tokenAssetType string, owner string) (*TokenBalances, error) {
+	exists, err := s.TokenAssetTypeExists(ctx, tokenAssetType)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("the token asset type %s does not exist", tokenAssetType)
+	}
+
+	wallet, err := readWallet(ctx, getWalletId(owner))
+	if err != nil {
+		return nil, err
+	}
+	pendingClaims, err := readPendingClaims(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	return getTokenBalancesOfWallet(wallet, pendingClaims, tokenAssetType), nil
+}
+
+// GetMyTokenBalances returns the available, locked, pending claim and total amounts of each token asset type in the wallet of the caller.
+func (s *SmartContract) GetMyTokenBalances(ctx contractapi.TransactionContextInterface) ([]*TokenBalances, error) {
+	owner, err := getECertOfTxCreatorBase64(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := readWallet(ctx, getWalletId(owner))
+	if err != nil {
+		return nil, err
+	}
+	pendingClaims, err := readPendingClaims(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	if isWalletEmpty(wallet) && len(pendingClaims) == 0 {
+		return nil, fmt.Errorf("owner does not have a wallet")
+	}
+	tokenAssetTypes := []string{}
+	for tokenAssetType := range wallet.WalletMap {
+		tokenAssetTypes = append(tokenAssetTypes, tokenAssetType)
+	}
+	for _, lockedMap := range []map[string]*LockedTokenAssets{wallet.LockedMap, pendingClaims} {
+		for _, lockedTokens := range lockedMap {
+			tokenAssetTypes = append(tokenAssetTypes, lockedTokens.TokenAssetType)
+		}
+	}
+	sort.Strings(tokenAssetTypes)
+
+	balances := []*TokenBalances{}
+	for i, tokenAssetType := range tokenAssetTypes {
+		if i > 0 && tokenAssetTypes[i-1] == tokenAssetType {
+			continue
+		}
+		balances = append(balances, getTokenBalancesOfWallet(wallet, pendingClaims, tokenAssetType))
+	}
+	return balances, nil
+}
+
+// CheckLockedTokenAssets returns true when the units of given token asset type locked in the wallets match the units
+// held in the locks of the interop chaincode, and false otherwise.
+func (s *SmartContract) CheckLockedTokenAssets(ctx contractapi.TransactionContextInterface, tokenAssetType string) (bool, error) {
+	walletsLockedUnits, err := readLockedTokenAssetsTotal(ctx, tokenAssetType)
+	if err != nil {
+		return false, err
+	}
+	interopLockedUnits, err := s.amc.GetTotalFungibleLockedAssets(ctx, tokenAssetType)
+	if err != nil {
+		return false, err
+	}
+	if walletsLockedUnits != interopLockedUnits {
+		log.Warnf("%d units of token asset type %s are locked in the wallets while %d are held in locks", walletsLockedUnits, tokenAssetType, interopLockedUnits)
+		return false, nil
+	}
+	return true, nil
+}
+
+func getTokenBalancesOfWallet(wallet *TokenWallet, pendingClaims map[string]*LockedTokenAssets, tokenAssetType string) *TokenBalances {
+	balances := &TokenBalances{
+		TokenAssetType: tokenAssetType,
+		Available: wallet.WalletMap[tokenAssetType],
+	}
+	for _, lockedTokens := range wallet.LockedMap {
+		if lockedTokens.TokenAssetType == tokenAssetType {
+			balances.Locked += lockedTokens.NumUnits
+		}
+	}
+	for _, lockedTokens := range pendingClaims {
+		if lockedTokens.TokenAssetType == tokenAssetType {
+			balances.PendingClaim += lockedTokens.NumUnits
+		}
+	}
+	balances.Total = balances.Available + balances.Locked
+	return balances
+}
+
+// Helper Functions for the locks of token assets
+
+// lockTokenAssetsHelper moves numUnits of the locker's available units into the lock associated with contractId, which
+// the recipient (if known) sees as pending claim through the record of the lock
+func lockTokenAssetsHelper(ctx contractapi.TransactionContextInterface, tokenAssetType string, numUnits uint64, contractId string, locker string, recipient string) error {
+	lockerId := getWalletId(locker)
+	lockerWallet, err := readWallet(ctx, lockerId)
+	if err != nil {
+		return err
+	}
+	if lockerWallet.WalletMap[tokenAssetType] < numUnits {
+		return fmt.Errorf("the owner does not possess enough units of the token asset type %s", tokenAssetType)
+	}
+	if _, exists := lockerWallet.LockedMap[contractId]; exists {
+		return fmt.Errorf("tokens are already locked in the wallet with contractId %s", contractId)
+	}
+	lockerWallet.WalletMap[tokenAssetType] -= numUnits
+	if lockerWallet.WalletMap[tokenAssetType] == 0 {
+		delete(lockerWallet.WalletMap, tokenAssetType)
+	}
+	lockerWallet.LockedMap[contractId] = &LockedTokenAssets{TokenAssetType: tokenAssetType, NumUnits: numUnits}
+
+	err = writeWallet(ctx, lockerId, lockerWallet)
+	if err != nil {
+		return err
+	}
+	tokenLock := &TokenLock{Locker: locker, Recipient: recipient, TokenAssetType: tokenAssetType, NumUnits: numUnits}
+	err = writeTokenLock(ctx, contractId, tokenLock)
+	if err != nil {
+		return err
+	}
+	indexKeys, err := getTokenLockIndexKeys(ctx, contractId, tokenLock)
+	if err != nil {
+		return err
+	}
+	for _, indexKey := range indexKeys {
+		err = ctx.GetStub().PutState(indexKey, []byte{0x00})
 		if err != nil {
 			return err
 		}
-		return ctx.GetStub().PutState(id, walletNewJSON)
 	}
+	return nil
+}
+
+// releaseTokenAssetsHelper takes numUnits out of the lock associated with contractId into the available units of the
+// claimant (or of the locker, upon unlock); the lock is dropped from the wallets once it holds no more units
+func releaseTokenAssetsHelper(ctx contractapi.TransactionContextInterface, tokenAssetType string, numUnits uint64, contractId string, tokenLock *TokenLock, claimant string, unlock bool) error {
+	wallets := map[string]*TokenWallet{}
+	getWallet := func(owner string) (*TokenWallet, error) {
+		id := getWalletId(owner)
+		if wallet, exists := wallets[id]; exists {
+			return wallet, nil
+		}
+		wallet, err := readWallet(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		wallets[id] = wallet
+		return wallet, nil
+	}
+	takeOutOfLock := func(lockedMap map[string]*LockedTokenAssets) {
+		lockedTokens, exists := lockedMap[contractId]
+		if !exists {
+			return
+		}
+		if lockedTokens.NumUnits > numUnits {
+			lockedTokens.NumUnits -= numUnits
+		} else {
+			delete(lockedMap, contractId)
+		}
+	}
+
+	lockerWallet, err := getWallet(tokenLock.Locker)
+	if err != nil {
+		return err
+	}
+	takeOutOfLock(lockerWallet.LockedMap)
+	receiver := claimant
+	if unlock {
+		receiver = tokenLock.Locker
+	}
+	receiverWallet, err := getWallet(receiver)
+	if err != nil {
+		return err
+	}
+	receiverWallet.WalletMap[tokenAssetType] += numUnits
+
+	for id, wallet := range wallets {
+		err = writeWallet(ctx, id, wallet)
+		if err != nil {
+			return err
+		}
+	}
+	// an unlock releases all the remaining units, which are then no longer pending claim
+	if unlock || tokenLock.NumUnits <= numUnits {
+		indexKeys, err := getTokenLockIndexKeys(ctx, contractId, tokenLock)
+		if err != nil {
+			return err
+		}
+		for _, indexKey := range indexKeys {
+			err = ctx.GetStub().DelState(indexKey)
+			if err != nil {
+				return err
+			}
+		}
+		return ctx.GetStub().DelState(getTokenLockId(contractId))
+	}
+	tokenLock.NumUnits -= numUnits
+	return writeTokenLock(ctx, contractId, tokenLock)
+}
+
+// readWallet returns the wallet with given id, or an empty wallet if there is none
+func readWallet(ctx contractapi.TransactionContextInterface, id string) (*TokenWallet, error) {
+	walletJSON, err := ctx.GetStub().GetState(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read owner's wallet from world state: %v", err)
+	}
+	wallet := &TokenWallet{}
+	if walletJSON != nil {
+		err = json.Unmarshal(walletJSON, wallet)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if wallet.WalletMap == nil {
+		wallet.WalletMap = make(map[string]uint64)
+	}
+	if wallet.LockedMap == nil {
+		wallet.LockedMap = make(map[string]*LockedTokenAssets)
+	}
+	return wallet, nil
+}
+
+func isWalletEmpty(wallet *TokenWallet) bool {
+	return len(wallet.WalletMap) == 0 && len(wallet.LockedMap) == 0
+}
+
+// writeWallet updates the wallet with given id, or deletes it from the world state if it becomes empty
+func writeWallet(ctx contractapi.TransactionContextInterface, id string, wallet *TokenWallet) error {
+	if isWalletEmpty(wallet) {
+		return ctx.GetStub().DelState(id)
+	}
+	walletJSON, err := json.Marshal(wallet)
+	if err != nil {
+		return err
+	}
+	return ctx.GetStub().PutState(id, walletJSON)
+}
+
+// readTokenLock returns the parties of the lock associated with contractId, or nil if the lock is not tracked by the
+// wallets (e.g., because it was created before the wallets started tracking locked units)
+func readTokenLock(ctx contractapi.TransactionContextInterface, contractId string) (*TokenLock, error) {
+	tokenLockJSON, err := ctx.GetStub().GetState(getTokenLockId(contractId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token lock %s from world state: %v", contractId, err)
+	}
+	if tokenLockJSON == nil {
+		return nil, nil
+	}
+	var tokenLock TokenLock
+	err = json.Unmarshal(tokenLockJSON, &tokenLock)
+	if err != nil {
+		return nil, err
+	}
+	return &tokenLock, nil
+}
+
+func writeTokenLock(ctx contractapi.TransactionContextInterface, contractId string, tokenLock *TokenLock) error {
+	tokenLockJSON, err := json.Marshal(tokenLock)
+	if err != nil {
+		return err
+	}
+	return ctx.GetStub().PutState(getTokenLockId(contractId), tokenLockJSON)
+}
+
+// getTokenLockIndexKeys returns the keys of the index entries of the lock associated with contractId: one per
+// recipient (if the recipient is known) and one per token asset type
+func getTokenLockIndexKeys(ctx contractapi.TransactionContextInterface, contractId string, tokenLock *TokenLock) ([]string, error) {
+	indexKeys := []string{}
+	if tokenLock.Recipient != "" {
+		indexKey, err := ctx.GetStub().CreateCompositeKey(tokenLockByRecipientObjectType, []string{tokenLock.Recipient, contractId})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create index key of token lock %s: %v", contractId, err)
+		}
+		indexKeys = append(indexKeys, indexKey)
+	}
+	indexKey, err := ctx.GetStub().CreateCompositeKey(tokenLockByTypeObjectType, []string{tokenLock.TokenAssetType, contractId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create index key of token lock %s: %v", contractId, err)
+	}
+	return append(indexKeys, indexKey), nil
+}
+
+// readIndexedTokenLocks returns the records of the locks listed under attribute (a recipient or a token asset type)
+// in the index of given object type, by contractId
+func readIndexedTokenLocks(ctx contractapi.TransactionContextInterface, objectType string, attribute string) (map[string]*TokenLock, error) {
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(objectType, []string{attribute})
+	if err != nil {
+		return nil, err
+	}
+	defer resultsIterator.Close()
+
+	tokenLocks := map[string]*TokenLock{}
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		_, attributes, err := ctx.GetStub().SplitCompositeKey(queryResponse.Key)
+		if err != nil {
+			return nil, err
+		}
+		if len(attributes) != 2 {
+			return nil, fmt.Errorf("invalid index key %s of object type %s", queryResponse.Key, objectType)
+		}
+		contractId := attributes[1]
+		tokenLock, err := readTokenLock(ctx, contractId)
+		if err != nil {
+			return nil, err
+		}
+		if tokenLock == nil {
+			return nil, fmt.Errorf("token lock %s listed in the index %s is missing", contractId, objectType)
+		}
+		tokenLocks[contractId] = tokenLock
+	}
+	return tokenLocks, nil
+}
+
+// readPendingClaims returns the units locked for the owner to claim, by contractId
+func readPendingClaims(ctx contractapi.TransactionContextInterface, owner string) (map[string]*LockedTokenAssets, error) {
+	pendingClaims := map[string]*LockedTokenAssets{}
+	if owner == "" {
+		return pendingClaims, nil
+	}
+	tokenLocks, err := readIndexedTokenLocks(ctx, tokenLockByRecipientObjectType, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token locks from world state: %v", err)
+	}
+	for contractId, tokenLock := range tokenLocks {
+		pendingClaims[contractId] = &LockedTokenAssets{TokenAssetType: tokenLock.TokenAssetType, NumUnits: tokenLock.NumUnits}
+	}
+	return pendingClaims, nil
+}
+
+// readLockedTokenAssetsTotal returns the units of given token asset type locked across all wallets
+func readLockedTokenAssetsTotal(ctx contractapi.TransactionContextInterface, tokenAssetType string) (uint64, error) {
+	tokenLocks, err := readIndexedTokenLocks(ctx, tokenLockByTypeObjectType, tokenAssetType)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read locked units of token asset type %s from world state: %v", tokenAssetType, err)
+	}
+	total := uint64(0)
+	for _, tokenLock := range tokenLocks {
+		total += tokenLock.NumUnits
+	}
+	return total, nil
 }
 
 func getTokenAssetTypeId(tokenAssetType string) string {
@@ -306,3 +688,6 @@ func getTokenAssetTypeId(tokenAssetType string) string {
 func getWalletId(owner string) string {
 	return "W_" + owner
 }
+func getTokenLockId(contractId string) string {
+	return "TL_" + contractId
+}
@@ -8,6 +8,8 @@ import (
 	"bytes"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
 	mspProtobuf "github.com/hyperledger/fabric-protos-go/msp"
 	sa "github.com/hyperledger-labs/weaver-dlt-interoperability/samples/fabric/simpleasset"
 	"github.com/stretchr/testify/require"
@@ -265,6 +267,120 @@ func TestTokenAssetsExist(t *testing.T) {
 	require.Equal(t, res, false)
 }
 
+func TestGetTokenBalances(t *testing.T) {
+	transactionContext, chaincodeStub, iterator, simpleToken := prepMockStubwithIterator()
+
+	wallet := &sa.TokenWallet{
+		WalletMap: map[string]uint64{"token1": 5, "token2": 1},
+		LockedMap: map[string]*sa.LockedTokenAssets{
+			"contract1": &sa.LockedTokenAssets{TokenAssetType: "token1", NumUnits: 3},
+			"contract2": &sa.LockedTokenAssets{TokenAssetType: "token1", NumUnits: 2},
+		},
+	}
+	walletBytes, err := json.Marshal(wallet)
+	require.NoError(t, err)
+	tokenAssetTypeBytes, err := json.Marshal(&sa.TokenAssetType{Issuer: "CentralBank", Value: 1})
+	require.NoError(t, err)
+
+	// Successful GetTokenBalances case
+	chaincodeStub.GetStateReturnsOnCall(0, tokenAssetTypeBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, walletBytes, nil)
+	balances, err := simpleToken.GetTokenBalances(transactionContext, "token1", "")
+	require.NoError(t, err)
+	require.Equal(t, &sa.TokenBalances{TokenAssetType: "token1", Available: 5, Locked: 5, PendingClaim: 0, Total: 10}, balances)
+
+	// token asset type does not exist
+	chaincodeStub.GetStateReturnsOnCall(2, nil, nil)
+	_, err = simpleToken.GetTokenBalances(transactionContext, "token4", "")
+	require.EqualError(t, err, "the token asset type token4 does not exist")
+
+	// Successful GetMyTokenBalances case, with the balances of each token asset type in the wallet, and the units
+	// pending claim read from the records of the locks listed for the caller in the index of recipients
+	pendingLockBytes, err := json.Marshal(&sa.TokenLock{Locker: "Alice", Recipient: getTestTxCreatorECertBase64(), TokenAssetType: "token3", NumUnits: 4})
+	require.NoError(t, err)
+	pendingLockIndexKey, _ := chaincodeStub.CreateCompositeKey("TokenLockByRecipient", []string{getTestTxCreatorECertBase64(), "contract3"})
+	iterator.HasNextReturnsOnCall(0, true)
+	iterator.HasNextReturnsOnCall(1, false)
+	iterator.NextReturnsOnCall(0, &queryresult.KV{Key: pendingLockIndexKey, Value: []byte{0x00}}, nil)
+	chaincodeStub.GetStateByPartialCompositeKeyReturns(iterator, nil)
+	chaincodeStub.GetCreatorReturns([]byte(getCreator()), nil)
+	chaincodeStub.GetStateReturnsOnCall(3, walletBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(4, pendingLockBytes, nil)
+	allBalances, err := simpleToken.GetMyTokenBalances(transactionContext)
+	require.NoError(t, err)
+	objectType, attributes := chaincodeStub.GetStateByPartialCompositeKeyArgsForCall(0)
+	require.Equal(t, "TokenLockByRecipient", objectType)
+	require.Equal(t, []string{getTestTxCreatorECertBase64()}, attributes)
+	require.Equal(t, 0, chaincodeStub.GetStateByRangeCallCount())
+	require.Equal(t, []*sa.TokenBalances{
+		&sa.TokenBalances{TokenAssetType: "token1", Available: 5, Locked: 5, PendingClaim: 0, Total: 10},
+		&sa.TokenBalances{TokenAssetType: "token2", Available: 1, Locked: 0, PendingClaim: 0, Total: 1},
+		&sa.TokenBalances{TokenAssetType: "token3", Available: 0, Locked: 0, PendingClaim: 4, Total: 0},
+	}, allBalances)
+
+	// Owner doesn't have a wallet
+	chaincodeStub.GetStateReturnsOnCall(5, nil, nil)
+	_, err = simpleToken.GetMyTokenBalances(transactionContext)
+	require.EqualError(t, err, "owner does not have a wallet")
+
+	chaincodeStub.GetStateByPartialCompositeKeyReturns(nil, fmt.Errorf("failed retrieving token locks"))
+	_, err = simpleToken.GetMyTokenBalances(transactionContext)
+	require.EqualError(t, err, "failed to read token locks from world state: failed retrieving token locks")
+}
+
+func TestCheckLockedTokenAssets(t *testing.T) {
+	transactionContext, chaincodeStub, iterator, simpleToken := prepMockStubwithIterator()
+
+	// the units locked in the wallets are summed over the records of the locks listed for the token asset type in
+	// the index of token asset types
+	lockBytes := func(tokenAssetType string, numUnits uint64) []byte {
+		bytes, _ := json.Marshal(&sa.TokenLock{Locker: "Alice", Recipient: "Bob", TokenAssetType: tokenAssetType, NumUnits: numUnits})
+		return bytes
+	}
+	indexKey := func(contractId string) string {
+		key, _ := chaincodeStub.CreateCompositeKey("TokenLockByType", []string{"token1", contractId})
+		return key
+	}
+	iterator.HasNextReturnsOnCall(0, true)
+	iterator.HasNextReturnsOnCall(1, true)
+	iterator.HasNextReturnsOnCall(2, false)
+	iterator.NextReturnsOnCall(0, &queryresult.KV{Key: indexKey("contract1"), Value: []byte{0x00}}, nil)
+	iterator.NextReturnsOnCall(1, &queryresult.KV{Key: indexKey("contract3"), Value: []byte{0x00}}, nil)
+	chaincodeStub.GetStateByPartialCompositeKeyReturns(iterator, nil)
+	chaincodeStub.GetStateReturnsOnCall(0, lockBytes("token1", 6), nil)
+	chaincodeStub.GetStateReturnsOnCall(1, lockBytes("token1", 4), nil)
+
+	// the units locked in the wallets match those held in locks
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte("10")))
+	consistent, err := simpleToken.CheckLockedTokenAssets(transactionContext, "token1")
+	require.NoError(t, err)
+	require.True(t, consistent)
+	objectType, attributes := chaincodeStub.GetStateByPartialCompositeKeyArgsForCall(0)
+	require.Equal(t, "TokenLockByType", objectType)
+	require.Equal(t, []string{"token1"}, attributes)
+	require.Equal(t, "TL_contract1", chaincodeStub.GetStateArgsForCall(0))
+	require.Equal(t, "TL_contract3", chaincodeStub.GetStateArgsForCall(1))
+	require.Equal(t, 0, chaincodeStub.GetStateByRangeCallCount())
+
+	// the units locked in the wallets differ from those held in locks
+	iterator.HasNextReturnsOnCall(3, false)
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte("7")))
+	consistent, err = simpleToken.CheckLockedTokenAssets(transactionContext, "token1")
+	require.NoError(t, err)
+	require.False(t, consistent)
+
+	// no units locked in the wallets
+	iterator.HasNextReturnsOnCall(4, false)
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte("0")))
+	consistent, err = simpleToken.CheckLockedTokenAssets(transactionContext, "token1")
+	require.NoError(t, err)
+	require.True(t, consistent)
+
+	chaincodeStub.GetStateByPartialCompositeKeyReturns(nil, fmt.Errorf("Failed to read state"))
+	_, err = simpleToken.CheckLockedTokenAssets(transactionContext, "token1")
+	require.EqualError(t, err, "failed to read locked units of token asset type token1 from world state: Failed to read state")
+}
+
 // function that supplies value that is to be returned by ctx.GetStub().GetCreator()
 func getCreator() string {
 	serializedIdentity := &mspProtobuf.SerializedIdentity{}
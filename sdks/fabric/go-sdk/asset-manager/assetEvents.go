@@ -0,0 +1,80 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package assetmanager
+
+import (
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/gateway"
+)
+
+// AssetLockEventName is the name of the event the interop chaincode sets, when invoked directly, with all the lock
+// lifecycle notifications (lock, claim, unlock, extend) of a transaction
+const AssetLockEventName = "AssetLockEvents"
+
+// AssetLockEventListener is called with the lock lifecycle notifications of every transaction committed
+type AssetLockEventListener func(envelope *common.AssetLockEventEnvelope)
+
+// DecodeAssetLockEventEnvelope decodes the payload of an AssetLockEvents chaincode event
+func DecodeAssetLockEventEnvelope(payload []byte) (*common.AssetLockEventEnvelope, error) {
+	if len(payload) == 0 {
+		return nil, logThenErrorf("empty asset lock event payload")
+	}
+	envelope := &common.AssetLockEventEnvelope{}
+	err := proto.Unmarshal(payload, envelope)
+	if err != nil {
+		return nil, logThenErrorf("failed to unmarshal asset lock event: %+v", err)
+	}
+
+	return envelope, nil
+}
+
+// RegisterAssetLockEventListener registers listener for the lock lifecycle events of the interop chaincode behind
+// contract; the returned function stops the listener and unregisters it from the contract
+func RegisterAssetLockEventListener(contract *gateway.Contract, listener AssetLockEventListener) (func(), error) {
+	if contract == nil {
+		return nil, logThenErrorf("contract handle not supplied")
+	}
+	if listener == nil {
+		return nil, logThenErrorf("listener not supplied")
+	}
+
+	reg, notifier, err := contract.RegisterEvent(AssetLockEventName)
+	if err != nil {
+		return nil, logThenErrorf("failed to register contract event %s: %+v", AssetLockEventName, err)
+	}
+	done := make(chan struct{})
+	go dispatchAssetLockEvents(notifier, listener, done)
+
+	return func() {
+		close(done)
+		contract.Unregister(reg)
+	}, nil
+}
+
+// function to pass the envelope of every event received on notifier to listener, until notifier is closed or done is signalled
+func dispatchAssetLockEvents(notifier <-chan *fab.CCEvent, listener AssetLockEventListener, done <-chan struct{}) {
+	for {
+		select {
+		case ccEvent, ok := <-notifier:
+			if !ok {
+				return
+			}
+			envelope, err := DecodeAssetLockEventEnvelope(ccEvent.Payload)
+			if err != nil {
+				log.Errorf("dropping %s event of transaction %s: %+v", ccEvent.EventName, ccEvent.TxID, err)
+				continue
+			}
+			listener(envelope)
+		case <-done:
+			return
+		}
+	}
+}
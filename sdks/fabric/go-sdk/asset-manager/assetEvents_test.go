@@ -0,0 +1,60 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package assetmanager
+
+import (
+	"testing"
+
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDecodeAssetLockEventEnvelope(t *testing.T) {
+
+	_, err := DecodeAssetLockEventEnvelope(nil)
+	require.EqualError(t, err, "empty asset lock event payload")
+
+	envelope := &common.AssetLockEventEnvelope{
+		TxId: "tx-id",
+		Events: []*common.AssetLockEvent{
+			{Action: common.AssetLockEvent_LOCK, ContractId: "contract-id-1", Type: "bond", Id: "A001"},
+			{Action: common.AssetLockEvent_CLAIM, ContractId: "contract-id-2", Type: "cbdc", NumUnits: 20},
+		},
+	}
+	envelopeBytes, _ := proto.Marshal(envelope)
+	decodedEnvelope, err := DecodeAssetLockEventEnvelope(envelopeBytes)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(envelope, decodedEnvelope))
+}
+
+func TestDispatchAssetLockEvents(t *testing.T) {
+
+	_, err := RegisterAssetLockEventListener(nil, func(*common.AssetLockEventEnvelope) {})
+	require.EqualError(t, err, "contract handle not supplied")
+
+	envelope := &common.AssetLockEventEnvelope{
+		TxId:   "tx-id",
+		Events: []*common.AssetLockEvent{{Action: common.AssetLockEvent_UNLOCK, ContractId: "contract-id"}},
+	}
+	envelopeBytes, _ := proto.Marshal(envelope)
+
+	notifier := make(chan *fab.CCEvent, 2)
+	notifier <- &fab.CCEvent{EventName: AssetLockEventName, TxID: "bad-tx-id", Payload: []byte{0xff}}
+	notifier <- &fab.CCEvent{EventName: AssetLockEventName, TxID: "tx-id", Payload: envelopeBytes}
+	close(notifier)
+
+	// the undecodable event is dropped, and the listener returns once the notifier is closed
+	received := []*common.AssetLockEventEnvelope{}
+	dispatchAssetLockEvents(notifier, func(envelope *common.AssetLockEventEnvelope) {
+		received = append(received, envelope)
+	}, make(chan struct{}))
+	require.Len(t, received, 1)
+	require.Equal(t, "tx-id", received[0].TxId)
+	require.Equal(t, common.AssetLockEvent_UNLOCK, received[0].Events[0].Action)
+}
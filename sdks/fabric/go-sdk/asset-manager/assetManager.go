@@ -7,14 +7,21 @@ SPDX-License-Identifier: Apache-2.0
 package assetmanager
 
 import (
+	"crypto/ecdsa"
+	"crypto/rand"
 	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/sha3"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/gateway"
@@ -79,11 +86,12 @@ func createFungibleAssetExchangeAgreementSerializedBase64(assetType string, numU
 }
 
 // Create an asset lock structure
-func createAssetLockInfoSerializedBase64(hashBase64 string, expiryTimeSecs uint64) (string, error) {
+func createAssetLockInfoSerializedBase64(hashBase64 string, expiryTimeSecs uint64, timeSpec common.AssetLockHTLC_TimeSpec, hashMechanism common.HashMechanism) (string, error) {
 	lockInfoHTLC := &common.AssetLockHTLC{
 		HashBase64:     []byte(hashBase64),
 		ExpiryTimeSecs: expiryTimeSecs,
-		TimeSpec:       common.AssetLockHTLC_EPOCH,
+		TimeSpec:       timeSpec,
+		HashMechanism:  hashMechanism,
 	}
 	lockInfoHTLCBytes, err := proto.Marshal(lockInfoHTLC)
 	if err != nil {
@@ -102,10 +110,11 @@ func createAssetLockInfoSerializedBase64(hashBase64 string, expiryTimeSecs uint6
 	return base64.StdEncoding.EncodeToString(lockInfoBytes), nil
 }
 
-// Create an asset claim structure
-func createAssetClaimInfoSerializedBase64(hashPreimageBase64 string) (string, error) {
+// Create an asset claim structure (numUnits being the units to claim from a fungible asset lock; all of them if 0)
+func createAssetClaimInfoSerializedBase64(hashPreimageBase64 string, numUnits uint64) (string, error) {
 	claimInfoHTLC := &common.AssetClaimHTLC{
 		HashPreimageBase64: []byte(hashPreimageBase64),
+		NumUnits:           numUnits,
 	}
 	claimInfoHTLCBytes, err := proto.Marshal(claimInfoHTLC)
 	if err != nil {
@@ -134,8 +143,127 @@ func GenerateSHA256HashInBase64Form(hashPreimage string) string {
 	return shaHashBase64
 }
 
+// function to generate a hash in base64 format for a given preimage, using the supplied hash mechanism
+func GenerateHashInBase64Form(hashPreimage string, hashMechanism common.HashMechanism) (string, error) {
+	var hash []byte
+	switch hashMechanism {
+	case common.HashMechanism_SHA256:
+		shaHash := sha256.Sum256([]byte(hashPreimage))
+		hash = shaHash[:]
+	case common.HashMechanism_SHA512:
+		shaHash := sha512.Sum512([]byte(hashPreimage))
+		hash = shaHash[:]
+	case common.HashMechanism_KECCAK256:
+		hasher := sha3.NewLegacyKeccak256()
+		hasher.Write([]byte(hashPreimage))
+		hash = hasher.Sum(nil)
+	case common.HashMechanism_DOUBLE_SHA256:
+		shaHash := sha256.Sum256([]byte(hashPreimage))
+		doubleShaHash := sha256.Sum256(shaHash[:])
+		hash = doubleShaHash[:]
+	default:
+		return "", logThenErrorf("hash mechanism %s is not supported", hashMechanism.String())
+	}
+
+	return base64.StdEncoding.EncodeToString(hash), nil
+}
+
+// function to generate a random hash preimage (hex encoded) of numBytes bytes along with its hash in base64 format,
+// using the supplied hash mechanism
+func GenerateHashPreimageAndHashInBase64Form(numBytes int, hashMechanism common.HashMechanism) (string, string, error) {
+	if numBytes <= 0 {
+		return "", "", logThenErrorf("preimage length must be a positive number")
+	}
+	randomBytes := make([]byte, numBytes)
+	_, err := rand.Read(randomBytes)
+	if err != nil {
+		return "", "", logThenErrorf("unable to generate a random preimage: %+v", err)
+	}
+	hashPreimage := hex.EncodeToString(randomBytes)
+	hashBase64, err := GenerateHashInBase64Form(hashPreimage, hashMechanism)
+	if err != nil {
+		return "", "", err
+	}
+
+	return hashPreimage, hashBase64, nil
+}
+
+// HTLCOption configures optional behaviour of CreateHTLC and CreateFungibleHTLC
+type HTLCOption func(*htlcOptions)
+
+type htlcOptions struct {
+	timeSpec        common.AssetLockHTLC_TimeSpec
+	expiryTimeSecs  *uint64
+	hashMechanism   common.HashMechanism
+	contractId      string
+	contractIdNonce string
+}
+
+// WithLockDuration treats the expiryTimeSecs argument as a lock duration in seconds rather than an absolute epoch time.
+// The chaincode computes the absolute expiry time from the transaction timestamp; if expiryTimeSecs is not nil,
+// it is set to that computed expiry time once the lock has been created. If that time cannot be fetched, the lock
+// still exists, so its contractId is returned along with the error.
+func WithLockDuration(expiryTimeSecs *uint64) HTLCOption {
+	return func(opts *htlcOptions) {
+		opts.timeSpec = common.AssetLockHTLC_DURATION
+		opts.expiryTimeSecs = expiryTimeSecs
+	}
+}
+
+// WithHashMechanism sets the hash function used to generate hashBase64 (SHA256 if not supplied);
+// the hash preimage supplied to claim the asset is verified against the hash using the same function
+func WithHashMechanism(hashMechanism common.HashMechanism) HTLCOption {
+	return func(opts *htlcOptions) {
+		opts.hashMechanism = hashMechanism
+	}
+}
+
+func getHTLCOptions(opts []HTLCOption) *htlcOptions {
+	htlcOpts := &htlcOptions{
+		timeSpec:      common.AssetLockHTLC_EPOCH,
+		hashMechanism: common.HashMechanism_SHA256,
+	}
+	for _, opt := range opts {
+		opt(htlcOpts)
+	}
+	return htlcOpts
+}
+
+// function to check the lock expiry time (or duration) supplied for an HTLC
+func validateHTLCExpiry(htlcOpts *htlcOptions, expiryTimeSecs uint64) error {
+	if htlcOpts.timeSpec == common.AssetLockHTLC_DURATION {
+		if expiryTimeSecs == 0 {
+			return logThenErrorf("lock duration must be greater than zero")
+		}
+		return nil
+	}
+	currentTimeSecs := uint64(time.Now().Unix())
+	if expiryTimeSecs <= currentTimeSecs {
+		return logThenErrorf("supplied expirty time in the past")
+	}
+	return nil
+}
+
+// function to fetch the absolute expiry time of the lock created with a duration, if the caller asked for it; the lock
+// has been created by then, so callers return its contractId along with any error
+func fetchHTLCExpiryTime(gci GatewayContractInterface, contract *gateway.Contract, htlcOpts *htlcOptions, ccFunc string, contractId string) error {
+	if htlcOpts.expiryTimeSecs == nil {
+		return nil
+	}
+	result, err := gci.EvaluateTransaction(contract, ccFunc, contractId)
+	if err != nil {
+		return logThenErrorf("error in contract.EvaluateTransaction %s: %+v", ccFunc, err.Error())
+	}
+	expiryTimeSecs, err := strconv.ParseUint(string(result), 10, 64)
+	if err != nil {
+		return logThenErrorf("invalid expiry time returned by %s: %+v", ccFunc, err.Error())
+	}
+	*htlcOpts.expiryTimeSecs = expiryTimeSecs
+	return nil
+}
+
 func CreateHTLC(gci GatewayContractInterface, contract *gateway.Contract, assetType string, assetId string, recipientECertBase64 string,
-	hashBase64 string, expiryTimeSecs uint64) (string, error) {
+	hashBase64 string, expiryTimeSecs uint64, opts ...HTLCOption) (string, error) {
 	if contract == nil {
 		return "", logThenErrorf("contract handle not supplied")
 	}
@@ -151,16 +279,18 @@ func CreateHTLC(gci GatewayContractInterface, contract *gateway.Contract, assetT
 	if hashBase64 == "" {
 		return "", logThenErrorf("hashBase64 is not supplied")
 	}
-	currentTimeSecs := uint64(time.Now().Unix())
-	if expiryTimeSecs <= currentTimeSecs {
-		return "", logThenErrorf("supplied expirty time in the past")
+	htlcOpts := getHTLCOptions(opts)
+	err := validateHTLCExpiry(htlcOpts, expiryTimeSecs)
+	if err != nil {
+		return "", err
 	}
 
-	assetExchangeAgreementStr, err := createAssetExchangeAgreementSerializedBase64(assetType, assetId, recipientECertBase64, "")
+	assetExchangeAgreementStr, err := createLockAssetExchangeAgreementSerializedBase64(&common.AssetExchangeAgreement{Type: assetType, Id: assetId,
+		Recipient: recipientECertBase64}, htlcOpts)
 	if err != nil {
 		return "", logThenErrorf(err.Error())
 	}
-	lockInfoStr, err := createAssetLockInfoSerializedBase64(hashBase64, expiryTimeSecs)
+	lockInfoStr, err := createAssetLockInfoSerializedBase64(hashBase64, expiryTimeSecs, htlcOpts.timeSpec, htlcOpts.hashMechanism)
 	if err != nil {
 		return "", logThenErrorf(err.Error())
 	}
@@ -170,12 +300,16 @@ func CreateHTLC(gci GatewayContractInterface, contract *gateway.Contract, assetT
 	if err != nil {
 		return "", logThenErrorf("error in contract.SubmitTransaction LockAsset: %+v", err.Error())
 	}
+	err = fetchHTLCExpiryTime(gci, contract, htlcOpts, "GetAssetTimeToReleaseUsingContractId", string(result))
+	if err != nil {
+		return string(result), err
+	}
 
 	return string(result), nil
 }
 
 func CreateFungibleHTLC(gci GatewayContractInterface, contract *gateway.Contract, assetType string, numUnits uint64, recipientECertBase64 string,
-	hashBase64 string, expiryTimeSecs uint64) (string, error) {
+	hashBase64 string, expiryTimeSecs uint64, opts ...HTLCOption) (string, error) {
 	if contract == nil {
 		return "", logThenErrorf("contract handle not supplied")
 	}
@@ -191,16 +325,18 @@ func CreateFungibleHTLC(gci GatewayContractInterface, contract *gateway.Contract
 	if hashBase64 == "" {
 		return "", logThenErrorf("hashBase64 is not supplied")
 	}
-	currentTimeSecs := uint64(time.Now().Unix())
-	if expiryTimeSecs <= currentTimeSecs {
-		return "", logThenErrorf("supplied expirty time in the past")
+	htlcOpts := getHTLCOptions(opts)
+	err := validateHTLCExpiry(htlcOpts, expiryTimeSecs)
+	if err != nil {
+		return "", err
 	}
 
-	assetExchangeAgreementStr, err := createFungibleAssetExchangeAgreementSerializedBase64(assetType, numUnits, recipientECertBase64, "")
+	assetExchangeAgreementStr, err := createLockFungibleAssetExchangeAgreementSerializedBase64(&common.FungibleAssetExchangeAgreement{Type: assetType,
+		NumUnits: numUnits, Recipient: recipientECertBase64}, htlcOpts)
 	if err != nil {
 		return "", logThenErrorf(err.Error())
 	}
-	lockInfoStr, err := createAssetLockInfoSerializedBase64(hashBase64, expiryTimeSecs)
+	lockInfoStr, err := createAssetLockInfoSerializedBase64(hashBase64, expiryTimeSecs, htlcOpts.timeSpec, htlcOpts.hashMechanism)
 	if err != nil {
 		return "", logThenErrorf(err.Error())
 	}
@@ -210,6 +346,10 @@ func CreateFungibleHTLC(gci GatewayContractInterface, contract *gateway.Contract
 	if err != nil {
 		return "", logThenErrorf("error in contract.SubmitTransaction LockFungibleAsset: %+v", err.Error())
 	}
+	err = fetchHTLCExpiryTime(gci, contract, htlcOpts, "GetFungibleAssetTimeToReleaseUsingContractId", string(result))
+	if err != nil {
+		return string(result), err
+	}
 
 	return string(result), nil
 }
@@ -299,7 +439,7 @@ func ClaimAssetInHTLC(gci GatewayContractInterface, contract *gateway.Contract,
 		return "", logThenErrorf("hashPreimageBase64 is not supplied")
 	}
 
-	claimInfoStr, err := createAssetClaimInfoSerializedBase64(hashPreimageBase64)
+	claimInfoStr, err := createAssetClaimInfoSerializedBase64(hashPreimageBase64, 0)
 	if err != nil {
 		return "", logThenErrorf(err.Error())
 	}
@@ -319,6 +459,12 @@ func ClaimAssetInHTLC(gci GatewayContractInterface, contract *gateway.Contract,
 }
 
 func ClaimFungibleAssetInHTLC(gci GatewayContractInterface, contract *gateway.Contract, contractId string, hashPreimageBase64 string) (string, error) {
+	return ClaimFungibleAssetUnitsInHTLC(gci, contract, contractId, hashPreimageBase64, 0)
+}
+
+// ClaimFungibleAssetUnitsInHTLC claims numUnits of the units locked in the HTLC associated with contractId (all of them
+// if numUnits is 0); the remaining units stay locked, to be claimed later or reclaimed by the locker after expiry
+func ClaimFungibleAssetUnitsInHTLC(gci GatewayContractInterface, contract *gateway.Contract, contractId string, hashPreimageBase64 string, numUnits uint64) (string, error) {
 	if contract == nil {
 		return "", logThenErrorf("contract handle not supplied")
 	}
@@ -329,7 +475,7 @@ func ClaimFungibleAssetInHTLC(gci GatewayContractInterface, contract *gateway.Co
 		return "", logThenErrorf("hashPreimageBase64 is not supplied")
 	}
 
-	claimInfoStr, err := createAssetClaimInfoSerializedBase64(hashPreimageBase64)
+	claimInfoStr, err := createAssetClaimInfoSerializedBase64(hashPreimageBase64, numUnits)
 	if err != nil {
 		return "", logThenErrorf(err.Error())
 	}
@@ -354,7 +500,7 @@ func ClaimAssetInHTLCusingContractId(gci GatewayContractInterface, contract *gat
 		return "", logThenErrorf("hashPreimageBase64 is not supplied")
 	}
 
-	claimInfoStr, err := createAssetClaimInfoSerializedBase64(hashPreimageBase64)
+	claimInfoStr, err := createAssetClaimInfoSerializedBase64(hashPreimageBase64, 0)
 	if err != nil {
 		return "", logThenErrorf(err.Error())
 	}
@@ -368,6 +514,37 @@ func ClaimAssetInHTLCusingContractId(gci GatewayContractInterface, contract *gat
 	return string(result), nil
 }
 
+// ClaimAssetsInHTLCusingContractIds claims atomically the HTLCs (fungible and non-fungible) associated with contractIds,
+// which are all locked with the hash of the given preimage; no asset is claimed if any of the claims fails
+func ClaimAssetsInHTLCusingContractIds(gci GatewayContractInterface, contract *gateway.Contract, contractIds []string, hashPreimageBase64 string) (string, error) {
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if len(contractIds) == 0 {
+		return "", logThenErrorf("contractIds not supplied")
+	}
+	if hashPreimageBase64 == "" {
+		return "", logThenErrorf("hashPreimageBase64 is not supplied")
+	}
+
+	contractIdsJSON, err := json.Marshal(contractIds)
+	if err != nil {
+		return "", logThenErrorf("failed to marshal contractIds: %+v", err)
+	}
+	claimInfoStr, err := createAssetClaimInfoSerializedBase64(hashPreimageBase64, 0)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	// Normal invoke function
+	result, err := gci.SubmitTransaction(contract, "ClaimAssetsUsingContractIds", string(contractIdsJSON), claimInfoStr)
+	if err != nil {
+		return "", logThenErrorf("error in contract.SubmitTransaction ClaimAssetsUsingContractIds: %+v", err.Error())
+	}
+
+	return string(result), nil
+}
+
 func ReclaimAssetInHTLC(gci GatewayContractInterface, contract *gateway.Contract, assetType string, assetId string, recipientECertBase64 string) (string, error) {
 	if contract == nil {
 		return "", logThenErrorf("contract handle not supplied")
@@ -429,3 +606,759 @@ func ReclaimAssetInHTLCusingContractId(gci GatewayContractInterface, contract *g
 
 	return string(result), nil
 }
+
+// GetHTLCHashPreImage fetches the hash preimage (in base64 form) revealed on the ledger when the HTLC associated with
+// contractId was claimed, so that the counterparty can use it to claim the other leg of the exchange
+func GetHTLCHashPreImage(gci GatewayContractInterface, contract *gateway.Contract, contractId string) (string, error) {
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if contractId == "" {
+		return "", logThenErrorf("contractId not supplied")
+	}
+
+	// Normal invoke function
+	result, err := gci.EvaluateTransaction(contract, "GetHTLCHashPreImage", contractId)
+	if err != nil {
+		return "", logThenErrorf("error in contract.EvaluateTransaction GetHTLCHashPreImage: %+v", err.Error())
+	}
+
+	return string(result), nil
+}
+
+// Create an escrow lock structure
+func createAssetLockEscrowInfoSerializedBase64(arbiterECertBase64 string, expiryTimeSecs uint64, timeSpec common.AssetLockHTLC_TimeSpec) (string, error) {
+	lockInfoEscrow := &common.AssetLockEscrow{
+		Arbiter:        arbiterECertBase64,
+		ExpiryTimeSecs: expiryTimeSecs,
+		TimeSpec:       timeSpec,
+	}
+	lockInfoEscrowBytes, err := proto.Marshal(lockInfoEscrow)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	lockInfo := &common.AssetLock{
+		LockMechanism: common.LockMechanism_ESCROW,
+		LockInfo:      lockInfoEscrowBytes,
+	}
+	lockInfoBytes, err := proto.Marshal(lockInfo)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	return base64.StdEncoding.EncodeToString(lockInfoBytes), nil
+}
+
+// Create an escrow claim structure
+func createAssetClaimEscrowInfoSerializedBase64(arbiterSignature []byte) (string, error) {
+	claimInfoEscrow := &common.AssetClaimEscrow{
+		ArbiterSignature: arbiterSignature,
+	}
+	claimInfoEscrowBytes, err := proto.Marshal(claimInfoEscrow)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	claimInfo := &common.AssetClaim{
+		LockMechanism: common.LockMechanism_ESCROW,
+		ClaimInfo:     claimInfoEscrowBytes,
+	}
+	claimInfoBytes, err := proto.Marshal(claimInfo)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	return base64.StdEncoding.EncodeToString(claimInfoBytes), nil
+}
+
+// GetEscrowReleaseMessage returns the message the arbiter of an escrow lock signs to release the asset locked
+// under contractId (with the absolute lock expiry time expiryTimeSecs) to its recipient
+func GetEscrowReleaseMessage(contractId string, expiryTimeSecs uint64) string {
+	return fmt.Sprintf("EscrowRelease:%s:%d", contractId, expiryTimeSecs)
+}
+
+// SignEscrowRelease produces the arbiter's signature releasing the asset locked in escrow under contractId;
+// the signature is handed over to the recipient, who submits it to claim the asset
+func SignEscrowRelease(arbiterPrivateKey *ecdsa.PrivateKey, contractId string, expiryTimeSecs uint64) ([]byte, error) {
+	if arbiterPrivateKey == nil {
+		return nil, logThenErrorf("arbiter private key not supplied")
+	}
+	if contractId == "" {
+		return nil, logThenErrorf("contractId not supplied")
+	}
+	hashed := sha256.Sum256([]byte(GetEscrowReleaseMessage(contractId, expiryTimeSecs)))
+	signature, err := ecdsa.SignASN1(rand.Reader, arbiterPrivateKey, hashed[:])
+	if err != nil {
+		return nil, logThenErrorf("error in signing the escrow release: %+v", err.Error())
+	}
+
+	return signature, nil
+}
+
+// CreateEscrowLock locks an asset for recipientECertBase64 until the arbiter (arbiterECertBase64) releases it or the
+// lock expires; only the WithLockDuration option applies to an escrow lock
+func CreateEscrowLock(gci GatewayContractInterface, contract *gateway.Contract, assetType string, assetId string, recipientECertBase64 string,
+	arbiterECertBase64 string, expiryTimeSecs uint64, opts ...HTLCOption) (string, error) {
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if assetType == "" {
+		return "", logThenErrorf("asset type not supplied")
+	}
+	if assetId == "" {
+		return "", logThenErrorf("asset id not supplied")
+	}
+	if recipientECertBase64 == "" {
+		return "", logThenErrorf("recipientECertBase64 id not supplied")
+	}
+	if arbiterECertBase64 == "" {
+		return "", logThenErrorf("arbiterECertBase64 is not supplied")
+	}
+	htlcOpts := getHTLCOptions(opts)
+	err := validateHTLCExpiry(htlcOpts, expiryTimeSecs)
+	if err != nil {
+		return "", err
+	}
+
+	assetExchangeAgreementStr, err := createAssetExchangeAgreementSerializedBase64(assetType, assetId, recipientECertBase64, "")
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	lockInfoStr, err := createAssetLockEscrowInfoSerializedBase64(arbiterECertBase64, expiryTimeSecs, htlcOpts.timeSpec)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	// Normal invoke function
+	result, err := gci.SubmitTransaction(contract, "LockAsset", assetExchangeAgreementStr, lockInfoStr)
+	if err != nil {
+		return "", logThenErrorf("error in contract.SubmitTransaction LockAsset: %+v", err.Error())
+	}
+	err = fetchHTLCExpiryTime(gci, contract, htlcOpts, "GetAssetTimeToReleaseUsingContractId", string(result))
+	if err != nil {
+		return string(result), err
+	}
+
+	return string(result), nil
+}
+
+// CreateFungibleEscrowLock locks numUnits of a fungible asset for recipientECertBase64 until the arbiter
+// (arbiterECertBase64) releases them or the lock expires; only the WithLockDuration option applies to an escrow lock
+func CreateFungibleEscrowLock(gci GatewayContractInterface, contract *gateway.Contract, assetType string, numUnits uint64, recipientECertBase64 string,
+	arbiterECertBase64 string, expiryTimeSecs uint64, opts ...HTLCOption) (string, error) {
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if assetType == "" {
+		return "", logThenErrorf("asset type not supplied")
+	}
+	if numUnits <= 0 {
+		return "", logThenErrorf("asset count must be a positive number")
+	}
+	if recipientECertBase64 == "" {
+		return "", logThenErrorf("recipientECertBase64 id not supplied")
+	}
+	if arbiterECertBase64 == "" {
+		return "", logThenErrorf("arbiterECertBase64 is not supplied")
+	}
+	htlcOpts := getHTLCOptions(opts)
+	err := validateHTLCExpiry(htlcOpts, expiryTimeSecs)
+	if err != nil {
+		return "", err
+	}
+
+	assetExchangeAgreementStr, err := createFungibleAssetExchangeAgreementSerializedBase64(assetType, numUnits, recipientECertBase64, "")
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	lockInfoStr, err := createAssetLockEscrowInfoSerializedBase64(arbiterECertBase64, expiryTimeSecs, htlcOpts.timeSpec)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	// Normal invoke function
+	result, err := gci.SubmitTransaction(contract, "LockFungibleAsset", assetExchangeAgreementStr, lockInfoStr)
+	if err != nil {
+		return "", logThenErrorf("error in contract.SubmitTransaction LockFungibleAsset: %+v", err.Error())
+	}
+	err = fetchHTLCExpiryTime(gci, contract, htlcOpts, "GetFungibleAssetTimeToReleaseUsingContractId", string(result))
+	if err != nil {
+		return string(result), err
+	}
+
+	return string(result), nil
+}
+
+func ClaimAssetInEscrow(gci GatewayContractInterface, contract *gateway.Contract, assetType string, assetId string, lockerECertBase64 string, arbiterSignature []byte) (string, error) {
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if assetType == "" {
+		return "", logThenErrorf("asset type not supplied")
+	}
+	if assetId == "" {
+		return "", logThenErrorf("asset id not supplied")
+	}
+	if lockerECertBase64 == "" {
+		return "", logThenErrorf("lockerECertBase64 id not supplied")
+	}
+	if len(arbiterSignature) == 0 {
+		return "", logThenErrorf("arbiterSignature is not supplied")
+	}
+
+	claimInfoStr, err := createAssetClaimEscrowInfoSerializedBase64(arbiterSignature)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	assetExchangeAgreementStr, err := createAssetExchangeAgreementSerializedBase64(assetType, assetId, "", lockerECertBase64)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	// Normal invoke function
+	result, err := gci.SubmitTransaction(contract, "ClaimAsset", assetExchangeAgreementStr, claimInfoStr)
+	if err != nil {
+		return "", logThenErrorf("error in contract.SubmitTransaction ClaimAsset: %+v", err.Error())
+	}
+
+	return string(result), nil
+}
+
+func ClaimFungibleAssetInEscrow(gci GatewayContractInterface, contract *gateway.Contract, contractId string, arbiterSignature []byte) (string, error) {
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if contractId == "" {
+		return "", logThenErrorf("contractId not supplied")
+	}
+	if len(arbiterSignature) == 0 {
+		return "", logThenErrorf("arbiterSignature is not supplied")
+	}
+
+	claimInfoStr, err := createAssetClaimEscrowInfoSerializedBase64(arbiterSignature)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	// Normal invoke function
+	result, err := gci.SubmitTransaction(contract, "ClaimFungibleAsset", contractId, claimInfoStr)
+	if err != nil {
+		return "", logThenErrorf("error in contract.SubmitTransaction ClaimFungibleAsset: %+v", err.Error())
+	}
+
+	return string(result), nil
+}
+
+func ClaimAssetInEscrowUsingContractId(gci GatewayContractInterface, contract *gateway.Contract, contractId string, arbiterSignature []byte) (string, error) {
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if contractId == "" {
+		return "", logThenErrorf("contractId not supplied")
+	}
+	if len(arbiterSignature) == 0 {
+		return "", logThenErrorf("arbiterSignature is not supplied")
+	}
+
+	claimInfoStr, err := createAssetClaimEscrowInfoSerializedBase64(arbiterSignature)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	// Normal invoke function
+	result, err := gci.SubmitTransaction(contract, "ClaimAssetUsingContractId", contractId, claimInfoStr)
+	if err != nil {
+		return "", logThenErrorf("error in contract.SubmitTransaction ClaimAssetUsingContractId: %+v", err.Error())
+	}
+
+	return string(result), nil
+}
+
+// Create an asset bundle exchange agreement structure
+func createAssetBundleExchangeAgreementSerializedBase64(assets []*common.AssetBundleItem, recipientECertBase64 string, lockerECertBase64 string) (string, error) {
+	bundleAgreement := &common.AssetBundleExchangeAgreement{
+		Assets:    assets,
+		Recipient: recipientECertBase64,
+		Locker:    lockerECertBase64,
+	}
+	bundleAgreementBytes, err := proto.Marshal(bundleAgreement)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	return base64.StdEncoding.EncodeToString(bundleAgreementBytes), nil
+}
+
+// CreateAssetBundleHTLC locks all the assets in the bundle (non-fungible assets identified by Id, fungible assets by NumUnits)
+// under a single HTLC, so that they are claimed or reclaimed together using the returned contractId
+func CreateAssetBundleHTLC(gci GatewayContractInterface, contract *gateway.Contract, assets []*common.AssetBundleItem, recipientECertBase64 string,
+	hashBase64 string, expiryTimeSecs uint64, opts ...HTLCOption) (string, error) {
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if len(assets) == 0 {
+		return "", logThenErrorf("asset bundle not supplied")
+	}
+	for _, asset := range assets {
+		if asset.Type == "" {
+			return "", logThenErrorf("asset type not supplied")
+		}
+		if (asset.Id == "") == (asset.NumUnits == 0) {
+			return "", logThenErrorf("exactly one of asset id and asset count must be supplied for asset type %s", asset.Type)
+		}
+	}
+	if recipientECertBase64 == "" {
+		return "", logThenErrorf("recipientECertBase64 id not supplied")
+	}
+	if hashBase64 == "" {
+		return "", logThenErrorf("hashBase64 is not supplied")
+	}
+	htlcOpts := getHTLCOptions(opts)
+	err := validateHTLCExpiry(htlcOpts, expiryTimeSecs)
+	if err != nil {
+		return "", err
+	}
+
+	bundleAgreementStr, err := createAssetBundleExchangeAgreementSerializedBase64(assets, recipientECertBase64, "")
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	lockInfoStr, err := createAssetLockInfoSerializedBase64(hashBase64, expiryTimeSecs, htlcOpts.timeSpec, htlcOpts.hashMechanism)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	// Normal invoke function
+	result, err := gci.SubmitTransaction(contract, "LockAssetBundle", bundleAgreementStr, lockInfoStr)
+	if err != nil {
+		return "", logThenErrorf("error in contract.SubmitTransaction LockAssetBundle: %+v", err.Error())
+	}
+	err = fetchHTLCExpiryTime(gci, contract, htlcOpts, "GetAssetBundleTimeToReleaseUsingContractId", string(result))
+	if err != nil {
+		return string(result), err
+	}
+
+	return string(result), nil
+}
+
+func IsAssetBundleLockedInHTLC(gci GatewayContractInterface, contract *gateway.Contract, contractId string) (string, error) {
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if contractId == "" {
+		return "", logThenErrorf("contractId not supplied")
+	}
+
+	// Normal invoke function
+	result, err := gci.EvaluateTransaction(contract, "IsAssetBundleLocked", contractId)
+	if err != nil {
+		return "", logThenErrorf("error in contract.EvaluateTransaction IsAssetBundleLocked: %+v", err.Error())
+	}
+
+	return string(result), nil
+}
+
+func ClaimAssetBundleInHTLC(gci GatewayContractInterface, contract *gateway.Contract, contractId string, hashPreimageBase64 string) (string, error) {
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if contractId == "" {
+		return "", logThenErrorf("contractId not supplied")
+	}
+	if hashPreimageBase64 == "" {
+		return "", logThenErrorf("hashPreimageBase64 is not supplied")
+	}
+
+	claimInfoStr, err := createAssetClaimInfoSerializedBase64(hashPreimageBase64, 0)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	// Normal invoke function
+	result, err := gci.SubmitTransaction(contract, "ClaimAssetBundle", contractId, claimInfoStr)
+	if err != nil {
+		return "", logThenErrorf("error in contract.SubmitTransaction ClaimAssetBundle: %+v", err.Error())
+	}
+
+	return string(result), nil
+}
+
+func ReclaimAssetBundleInHTLC(gci GatewayContractInterface, contract *gateway.Contract, contractId string) (string, error) {
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if contractId == "" {
+		return "", logThenErrorf("contractId not supplied")
+	}
+
+	// Normal invoke function
+	result, err := gci.SubmitTransaction(contract, "UnlockAssetBundle", contractId)
+	if err != nil {
+		return "", logThenErrorf("error in contract.SubmitTransaction UnlockAssetBundle: %+v", err.Error())
+	}
+
+	return string(result), nil
+}
+
+// GetLockExtensionMessage returns the message the recipient of a lock signs to consent to pushing out the expiry time
+// of the lock associated with contractId from expiryTimeSecs (the current absolute expiry time) to newExpiryTimeSecs
+func GetLockExtensionMessage(contractId string, expiryTimeSecs uint64, newExpiryTimeSecs uint64) string {
+	return fmt.Sprintf("LockExtension:%s:%d:%d", contractId, expiryTimeSecs, newExpiryTimeSecs)
+}
+
+// SignLockExtension produces the recipient's consent to the extension of the lock associated with contractId;
+// the signature is handed over to the locker, who submits it along with the new expiry time
+func SignLockExtension(recipientPrivateKey *ecdsa.PrivateKey, contractId string, expiryTimeSecs uint64, newExpiryTimeSecs uint64) ([]byte, error) {
+	if recipientPrivateKey == nil {
+		return nil, logThenErrorf("recipient private key not supplied")
+	}
+	if contractId == "" {
+		return nil, logThenErrorf("contractId not supplied")
+	}
+	hashed := sha256.Sum256([]byte(GetLockExtensionMessage(contractId, expiryTimeSecs, newExpiryTimeSecs)))
+	signature, err := ecdsa.SignASN1(rand.Reader, recipientPrivateKey, hashed[:])
+	if err != nil {
+		return nil, logThenErrorf("error in signing the lock extension: %+v", err.Error())
+	}
+
+	return signature, nil
+}
+
+// ExtendLockExpiry is invoked by the locker to push out the expiry time of the asset (or fungible asset) lock associated
+// with contractId to newExpiryTimeSecs, using the recipient's signature obtained through SignLockExtension
+func ExtendLockExpiry(gci GatewayContractInterface, contract *gateway.Contract, contractId string, newExpiryTimeSecs uint64, recipientSignature []byte) (string, error) {
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if contractId == "" {
+		return "", logThenErrorf("contractId not supplied")
+	}
+	if newExpiryTimeSecs <= uint64(time.Now().Unix()) {
+		return "", logThenErrorf("supplied expirty time in the past")
+	}
+	if len(recipientSignature) == 0 {
+		return "", logThenErrorf("recipientSignature is not supplied")
+	}
+
+	extensionInfo := &common.AssetLockExtension{
+		ContractId:         contractId,
+		NewExpiryTimeSecs:  newExpiryTimeSecs,
+		RecipientSignature: recipientSignature,
+	}
+	extensionInfoBytes, err := proto.Marshal(extensionInfo)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	// Normal invoke function
+	result, err := gci.SubmitTransaction(contract, "ExtendLockExpiry", contractId, base64.StdEncoding.EncodeToString(extensionInfoBytes))
+	if err != nil {
+		return "", logThenErrorf("error in contract.SubmitTransaction ExtendLockExpiry: %+v", err.Error())
+	}
+
+	return string(result), nil
+}
+
+// GetLockDelegationMessage returns the message the owner of an asset signs to authorize delegateECertBase64 to lock
+// the asset with the given (serialized and base64-encoded) asset exchange agreement and lock information
+func GetLockDelegationMessage(delegateECertBase64 string, assetAgreementBase64 string, lockInfoBase64 string) string {
+	return fmt.Sprintf("LockDelegation:%s:%s:%s", delegateECertBase64, assetAgreementBase64, lockInfoBase64)
+}
+
+// function to sign a lock delegation over the serialized agreement and lock information with the owner's key
+func signLockDelegation(ownerPrivateKey *ecdsa.PrivateKey, delegateECertBase64 string, assetAgreementStr string, lockInfoStr string) (*common.AssetLockDelegation, error) {
+	hashed := sha256.Sum256([]byte(GetLockDelegationMessage(delegateECertBase64, assetAgreementStr, lockInfoStr)))
+	signature, err := ecdsa.SignASN1(rand.Reader, ownerPrivateKey, hashed[:])
+	if err != nil {
+		return nil, logThenErrorf("error in signing the lock delegation: %+v", err.Error())
+	}
+
+	return &common.AssetLockDelegation{
+		Delegate:       delegateECertBase64,
+		OwnerSignature: signature,
+	}, nil
+}
+
+// SignHTLCDelegation produces the authorization of the owner (ownerECertBase64) for delegateECertBase64 to lock the
+// asset in an HTLC with the supplied terms; the delegation is handed over to the delegate, who submits it through
+// CreateHTLCAsDelegate with the same terms and options
+func SignHTLCDelegation(ownerPrivateKey *ecdsa.PrivateKey, delegateECertBase64 string, assetType string, assetId string, recipientECertBase64 string,
+	ownerECertBase64 string, hashBase64 string, expiryTimeSecs uint64, opts ...HTLCOption) (*common.AssetLockDelegation, error) {
+	if ownerPrivateKey == nil {
+		return nil, logThenErrorf("owner private key not supplied")
+	}
+	if delegateECertBase64 == "" {
+		return nil, logThenErrorf("delegateECertBase64 not supplied")
+	}
+	if ownerECertBase64 == "" {
+		return nil, logThenErrorf("ownerECertBase64 not supplied")
+	}
+	htlcOpts := getHTLCOptions(opts)
+
+	assetExchangeAgreementStr, err := createAssetExchangeAgreementSerializedBase64(assetType, assetId, recipientECertBase64, ownerECertBase64)
+	if err != nil {
+		return nil, logThenErrorf(err.Error())
+	}
+	lockInfoStr, err := createAssetLockInfoSerializedBase64(hashBase64, expiryTimeSecs, htlcOpts.timeSpec, htlcOpts.hashMechanism)
+	if err != nil {
+		return nil, logThenErrorf(err.Error())
+	}
+
+	return signLockDelegation(ownerPrivateKey, delegateECertBase64, assetExchangeAgreementStr, lockInfoStr)
+}
+
+// SignFungibleHTLCDelegation produces the authorization of the owner (ownerECertBase64) for delegateECertBase64 to lock
+// numUnits of the fungible asset in an HTLC with the supplied terms; the delegation is handed over to the delegate,
+// who submits it through CreateFungibleHTLCAsDelegate with the same terms and options
+func SignFungibleHTLCDelegation(ownerPrivateKey *ecdsa.PrivateKey, delegateECertBase64 string, assetType string, numUnits uint64, recipientECertBase64 string,
+	ownerECertBase64 string, hashBase64 string, expiryTimeSecs uint64, opts ...HTLCOption) (*common.AssetLockDelegation, error) {
+	if ownerPrivateKey == nil {
+		return nil, logThenErrorf("owner private key not supplied")
+	}
+	if delegateECertBase64 == "" {
+		return nil, logThenErrorf("delegateECertBase64 not supplied")
+	}
+	if ownerECertBase64 == "" {
+		return nil, logThenErrorf("ownerECertBase64 not supplied")
+	}
+	htlcOpts := getHTLCOptions(opts)
+
+	assetExchangeAgreementStr, err := createFungibleAssetExchangeAgreementSerializedBase64(assetType, numUnits, recipientECertBase64, ownerECertBase64)
+	if err != nil {
+		return nil, logThenErrorf(err.Error())
+	}
+	lockInfoStr, err := createAssetLockInfoSerializedBase64(hashBase64, expiryTimeSecs, htlcOpts.timeSpec, htlcOpts.hashMechanism)
+	if err != nil {
+		return nil, logThenErrorf(err.Error())
+	}
+
+	return signLockDelegation(ownerPrivateKey, delegateECertBase64, assetExchangeAgreementStr, lockInfoStr)
+}
+
+// function to serialize a lock delegation, checking that it carries the owner's signature
+func createLockDelegationSerializedBase64(delegation *common.AssetLockDelegation) (string, error) {
+	if delegation == nil || len(delegation.OwnerSignature) == 0 {
+		return "", logThenErrorf("lock delegation is not supplied")
+	}
+	delegationBytes, err := proto.Marshal(delegation)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	return base64.StdEncoding.EncodeToString(delegationBytes), nil
+}
+
+// CreateHTLCAsDelegate is invoked by a delegate to lock an asset in an HTLC on behalf of its owner (ownerECertBase64),
+// using the delegation obtained through SignHTLCDelegation; the owner is recorded as the locker
+func CreateHTLCAsDelegate(gci GatewayContractInterface, contract *gateway.Contract, assetType string, assetId string, recipientECertBase64 string,
+	ownerECertBase64 string, hashBase64 string, expiryTimeSecs uint64, delegation *common.AssetLockDelegation, opts ...HTLCOption) (string, error) {
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if assetType == "" {
+		return "", logThenErrorf("asset type not supplied")
+	}
+	if assetId == "" {
+		return "", logThenErrorf("asset id not supplied")
+	}
+	if recipientECertBase64 == "" {
+		return "", logThenErrorf("recipientECertBase64 id not supplied")
+	}
+	if ownerECertBase64 == "" {
+		return "", logThenErrorf("ownerECertBase64 not supplied")
+	}
+	if hashBase64 == "" {
+		return "", logThenErrorf("hashBase64 is not supplied")
+	}
+	htlcOpts := getHTLCOptions(opts)
+	err := validateHTLCExpiry(htlcOpts, expiryTimeSecs)
+	if err != nil {
+		return "", err
+	}
+	delegationStr, err := createLockDelegationSerializedBase64(delegation)
+	if err != nil {
+		return "", err
+	}
+
+	assetExchangeAgreementStr, err := createAssetExchangeAgreementSerializedBase64(assetType, assetId, recipientECertBase64, ownerECertBase64)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	lockInfoStr, err := createAssetLockInfoSerializedBase64(hashBase64, expiryTimeSecs, htlcOpts.timeSpec, htlcOpts.hashMechanism)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	// Normal invoke function
+	result, err := gci.SubmitTransaction(contract, "LockAssetAsDelegate", assetExchangeAgreementStr, lockInfoStr, delegationStr)
+	if err != nil {
+		return "", logThenErrorf("error in contract.SubmitTransaction LockAssetAsDelegate: %+v", err.Error())
+	}
+	err = fetchHTLCExpiryTime(gci, contract, htlcOpts, "GetAssetTimeToReleaseUsingContractId", string(result))
+	if err != nil {
+		return string(result), err
+	}
+
+	return string(result), nil
+}
+
+// CreateFungibleHTLCAsDelegate is invoked by a delegate to lock numUnits of a fungible asset in an HTLC on behalf of
+// their owner (ownerECertBase64), using the delegation obtained through SignFungibleHTLCDelegation; the owner is
+// recorded as the locker
+func CreateFungibleHTLCAsDelegate(gci GatewayContractInterface, contract *gateway.Contract, assetType string, numUnits uint64, recipientECertBase64 string,
+	ownerECertBase64 string, hashBase64 string, expiryTimeSecs uint64, delegation *common.AssetLockDelegation, opts ...HTLCOption) (string, error) {
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if assetType == "" {
+		return "", logThenErrorf("asset type not supplied")
+	}
+	if numUnits <= 0 {
+		return "", logThenErrorf("asset count must be a positive number")
+	}
+	if recipientECertBase64 == "" {
+		return "", logThenErrorf("recipientECertBase64 id not supplied")
+	}
+	if ownerECertBase64 == "" {
+		return "", logThenErrorf("ownerECertBase64 not supplied")
+	}
+	if hashBase64 == "" {
+		return "", logThenErrorf("hashBase64 is not supplied")
+	}
+	htlcOpts := getHTLCOptions(opts)
+	err := validateHTLCExpiry(htlcOpts, expiryTimeSecs)
+	if err != nil {
+		return "", err
+	}
+	delegationStr, err := createLockDelegationSerializedBase64(delegation)
+	if err != nil {
+		return "", err
+	}
+
+	assetExchangeAgreementStr, err := createFungibleAssetExchangeAgreementSerializedBase64(assetType, numUnits, recipientECertBase64, ownerECertBase64)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	lockInfoStr, err := createAssetLockInfoSerializedBase64(hashBase64, expiryTimeSecs, htlcOpts.timeSpec, htlcOpts.hashMechanism)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	// Normal invoke function
+	result, err := gci.SubmitTransaction(contract, "LockFungibleAssetAsDelegate", assetExchangeAgreementStr, lockInfoStr, delegationStr)
+	if err != nil {
+		return "", logThenErrorf("error in contract.SubmitTransaction LockFungibleAssetAsDelegate: %+v", err.Error())
+	}
+	err = fetchHTLCExpiryTime(gci, contract, htlcOpts, "GetFungibleAssetTimeToReleaseUsingContractId", string(result))
+	if err != nil {
+		return string(result), err
+	}
+
+	return string(result), nil
+}
+
+// LockedAssetsPage is one page of a paginated lock listing; each element of LockedAssets is the JSON summary of a lock,
+// and Bookmark is passed to the next query to fetch the following page (it is empty once the listing is exhausted)
+type LockedAssetsPage struct {
+	LockedAssets        []string `json:"lockedAssets"`
+	Bookmark            string   `json:"bookmark"`
+	FetchedRecordsCount int32    `json:"fetchedRecordsCount"`
+}
+
+// function to evaluate a paginated lock listing function, passing args ahead of the page size and bookmark
+func getLockedAssetsPage(gci GatewayContractInterface, contract *gateway.Contract, ccFunc string, args []string, pageSize int32, bookmark string) (*LockedAssetsPage, error) {
+	if contract == nil {
+		return nil, logThenErrorf("contract handle not supplied")
+	}
+	if pageSize <= 0 {
+		return nil, logThenErrorf("invalid page size %d", pageSize)
+	}
+
+	args = append(args, strconv.FormatInt(int64(pageSize), 10), bookmark)
+	result, err := gci.EvaluateTransaction(contract, ccFunc, args...)
+	if err != nil {
+		return nil, logThenErrorf("error in contract.EvaluateTransaction %s: %+v", ccFunc, err.Error())
+	}
+	page := &LockedAssetsPage{}
+	err = json.Unmarshal(result, page)
+	if err != nil {
+		return nil, logThenErrorf("failed to unmarshal page of locked assets: %+v", err)
+	}
+
+	return page, nil
+}
+
+// GetAllLockedAssetsWithPagination fetches one page of the locks (fungible and non-fungible) held by the locker for the
+// recipient; an empty locker or recipient stands for the caller, and '*' for an arbitrary locker or recipient
+func GetAllLockedAssetsWithPagination(gci GatewayContractInterface, contract *gateway.Contract, recipientECertBase64 string, lockerECertBase64 string, pageSize int32, bookmark string) (*LockedAssetsPage, error) {
+	return getLockedAssetsPage(gci, contract, "GetAllLockedAssetsWithPagination", []string{recipientECertBase64, lockerECertBase64}, pageSize, bookmark)
+}
+
+// GetAllNonFungibleLockedAssetsWithPagination fetches one page of the non-fungible asset locks held by the locker for the recipient
+func GetAllNonFungibleLockedAssetsWithPagination(gci GatewayContractInterface, contract *gateway.Contract, recipientECertBase64 string, lockerECertBase64 string, pageSize int32, bookmark string) (*LockedAssetsPage, error) {
+	return getLockedAssetsPage(gci, contract, "GetAllNonFungibleLockedAssetsWithPagination", []string{recipientECertBase64, lockerECertBase64}, pageSize, bookmark)
+}
+
+// GetAllFungibleLockedAssetsWithPagination fetches one page of the fungible asset locks held by the locker for the recipient
+func GetAllFungibleLockedAssetsWithPagination(gci GatewayContractInterface, contract *gateway.Contract, recipientECertBase64 string, lockerECertBase64 string, pageSize int32, bookmark string) (*LockedAssetsPage, error) {
+	return getLockedAssetsPage(gci, contract, "GetAllFungibleLockedAssetsWithPagination", []string{recipientECertBase64, lockerECertBase64}, pageSize, bookmark)
+}
+
+// GetAllAssetsLockedUntilWithPagination fetches one page of the locks, held by or for the caller, that expire at or before lockExpiryTimeSecs
+func GetAllAssetsLockedUntilWithPagination(gci GatewayContractInterface, contract *gateway.Contract, lockExpiryTimeSecs uint64, pageSize int32, bookmark string) (*LockedAssetsPage, error) {
+	if lockExpiryTimeSecs == 0 {
+		return nil, logThenErrorf("invalid expiry time")
+	}
+	return getLockedAssetsPage(gci, contract, "GetAllAssetsLockedUntilWithPagination", []string{strconv.FormatUint(lockExpiryTimeSecs, 10)}, pageSize, bookmark)
+}
+
+// function to submit a batch unlock function and decode the outcome of the release of each lock, which is returned
+// as a serialized AssetBatchUnlockOutcomes in base64 form
+func reclaimExpiredAssets(gci GatewayContractInterface, contract *gateway.Contract, ccFunc string, args ...string) ([]*common.AssetUnlockOutcome, error) {
+	result, err := gci.SubmitTransaction(contract, ccFunc, args...)
+	if err != nil {
+		return nil, logThenErrorf("error in contract.SubmitTransaction %s: %+v", ccFunc, err.Error())
+	}
+	outcomesBytes, err := base64.StdEncoding.DecodeString(string(result))
+	if err != nil {
+		return nil, logThenErrorf("failed to decode unlock outcomes: %+v", err)
+	}
+	outcomes := &common.AssetBatchUnlockOutcomes{}
+	err = proto.Unmarshal(outcomesBytes, outcomes)
+	if err != nil {
+		return nil, logThenErrorf("failed to unmarshal unlock outcomes: %+v", err)
+	}
+
+	return outcomes.Outcomes, nil
+}
+
+// ReclaimExpiredAssetsUsingContractIds releases, in one transaction, the expired locks (fungible, non-fungible and
+// asset bundles) associated with contractIds, and returns the outcome of the release of each lock
+func ReclaimExpiredAssetsUsingContractIds(gci GatewayContractInterface, contract *gateway.Contract, contractIds []string) ([]*common.AssetUnlockOutcome, error) {
+	if contract == nil {
+		return nil, logThenErrorf("contract handle not supplied")
+	}
+	if len(contractIds) == 0 {
+		return nil, logThenErrorf("contractIds not supplied")
+	}
+
+	contractIdsJSON, err := json.Marshal(contractIds)
+	if err != nil {
+		return nil, logThenErrorf("failed to marshal contractIds: %+v", err)
+	}
+	return reclaimExpiredAssets(gci, contract, "UnlockExpiredAssetsUsingContractIds", string(contractIdsJSON))
+}
+
+// ReclaimExpiredAssetsOfLocker releases, in one transaction, all the expired locks held by the locker (the caller, if
+// lockerECertBase64 is empty) that expire at or before expiryCutoffSecs, and returns the outcome of the release of each lock
+func ReclaimExpiredAssetsOfLocker(gci GatewayContractInterface, contract *gateway.Contract, lockerECertBase64 string, expiryCutoffSecs uint64) ([]*common.AssetUnlockOutcome, error) {
+	if contract == nil {
+		return nil, logThenErrorf("contract handle not supplied")
+	}
+	if expiryCutoffSecs == 0 {
+		return nil, logThenErrorf("expiry cutoff time not supplied")
+	}
+
+	return reclaimExpiredAssets(gci, contract, "UnlockExpiredAssetsOfLocker", lockerECertBase64, strconv.FormatUint(expiryCutoffSecs, 10))
+}
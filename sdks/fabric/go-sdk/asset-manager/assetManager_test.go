@@ -7,12 +7,20 @@ SPDX-License-Identifier: Apache-2.0
 package assetmanager
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"errors"
+	"strconv"
 	"testing"
 	"time"
 
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
 	"github.com/hyperledger/fabric-sdk-go/pkg/gateway"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 var submitTransactionMock func() ([]byte, error)
@@ -91,6 +99,51 @@ func TestCreateHTLC(t *testing.T) {
 	}
 	require.Equal(t, contractId, "contract-id")
 
+	keccakHashBase64, err := GenerateHashInBase64Form("hashPreimage", common.HashMechanism_KECCAK256)
+	require.NoError(t, err)
+	contractId, err = CreateHTLC(gci, contract, assetType, assetId, recipientECertBase64, keccakHashBase64, expiryTimeSecs, WithHashMechanism(common.HashMechanism_KECCAK256))
+	if err != nil {
+		t.Error("failed with error: ", err.Error())
+	}
+	require.Equal(t, contractId, "contract-id")
+
+	lockDurationSecs := uint64(10)
+	expectedError = "lock duration must be greater than zero"
+	_, err = CreateHTLC(gci, contract, assetType, assetId, recipientECertBase64, hashBase64, 0, WithLockDuration(nil))
+	if err == nil {
+		t.Error("expected to fail with error " + expectedError + " but didn't")
+	}
+	require.EqualError(t, err, expectedError)
+
+	contractId, err = CreateHTLC(gci, contract, assetType, assetId, recipientECertBase64, hashBase64, lockDurationSecs, WithLockDuration(nil))
+	if err != nil {
+		t.Error("failed with error: ", err.Error())
+	}
+	require.Equal(t, contractId, "contract-id")
+
+	var lockExpiryTimeSecs uint64
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte(strconv.FormatUint(expiryTimeSecs, 10)), nil
+	}
+	contractId, err = CreateHTLC(gci, contract, assetType, assetId, recipientECertBase64, hashBase64, lockDurationSecs, WithLockDuration(&lockExpiryTimeSecs))
+	if err != nil {
+		t.Error("failed with error: ", err.Error())
+	}
+	require.Equal(t, contractId, "contract-id")
+	require.Equal(t, expiryTimeSecs, lockExpiryTimeSecs)
+
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte(""), errors.New("failed evaluation")
+	}
+	expectedError = "error in contract.EvaluateTransaction GetAssetTimeToReleaseUsingContractId: failed evaluation"
+	contractId, err = CreateHTLC(gci, contract, assetType, assetId, recipientECertBase64, hashBase64, lockDurationSecs, WithLockDuration(&lockExpiryTimeSecs))
+	if err == nil {
+		t.Error("expected to fail with error " + expectedError + " but didn't")
+	}
+	require.EqualError(t, err, expectedError)
+	// the lock was created, so its contractId is still returned
+	require.Equal(t, contractId, "contract-id")
+
 	submitTransactionMock = func() ([]byte, error) {
 		return []byte(""), errors.New("failed submission")
 	}
@@ -165,6 +218,43 @@ func TestCreateFungibleHTLC(t *testing.T) {
 	}
 	require.Equal(t, contractId, "contract-id")
 
+	lockDurationSecs := uint64(10)
+	expectedError = "lock duration must be greater than zero"
+	_, err = CreateFungibleHTLC(gci, contract, assetType, numUnits, recipientECertBase64, hashBase64, 0, WithLockDuration(nil))
+	if err == nil {
+		t.Error("expected to fail with error " + expectedError + " but didn't")
+	}
+	require.EqualError(t, err, expectedError)
+
+	contractId, err = CreateFungibleHTLC(gci, contract, assetType, numUnits, recipientECertBase64, hashBase64, lockDurationSecs, WithLockDuration(nil))
+	if err != nil {
+		t.Error("failed with error: ", err.Error())
+	}
+	require.Equal(t, contractId, "contract-id")
+
+	var lockExpiryTimeSecs uint64
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte(strconv.FormatUint(expiryTimeSecs, 10)), nil
+	}
+	contractId, err = CreateFungibleHTLC(gci, contract, assetType, numUnits, recipientECertBase64, hashBase64, lockDurationSecs, WithLockDuration(&lockExpiryTimeSecs))
+	if err != nil {
+		t.Error("failed with error: ", err.Error())
+	}
+	require.Equal(t, contractId, "contract-id")
+	require.Equal(t, expiryTimeSecs, lockExpiryTimeSecs)
+
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte(""), errors.New("failed evaluation")
+	}
+	expectedError = "error in contract.EvaluateTransaction GetFungibleAssetTimeToReleaseUsingContractId: failed evaluation"
+	contractId, err = CreateFungibleHTLC(gci, contract, assetType, numUnits, recipientECertBase64, hashBase64, lockDurationSecs, WithLockDuration(&lockExpiryTimeSecs))
+	if err == nil {
+		t.Error("expected to fail with error " + expectedError + " but didn't")
+	}
+	require.EqualError(t, err, expectedError)
+	// the lock was created, so its contractId is still returned
+	require.Equal(t, contractId, "contract-id")
+
 	submitTransactionMock = func() ([]byte, error) {
 		return []byte(""), errors.New("failed submission")
 	}
@@ -437,6 +527,40 @@ func TestClaimFungibleAssetInHTLC(t *testing.T) {
 	require.EqualError(t, err, expectedError)
 }
 
+func TestClaimFungibleAssetUnitsInHTLC(t *testing.T) {
+
+	gci := fabricGatewayContractMock{}
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte("true"), nil
+	}
+
+	contract := &gateway.Contract{}
+	contractId := "contract-id"
+	hashPreimageBase64 := "hashPreimageBase64"
+
+	expectedError := "hashPreimageBase64 is not supplied"
+	_, err := ClaimFungibleAssetUnitsInHTLC(gci, contract, contractId, "", 30)
+	require.EqualError(t, err, expectedError)
+
+	isClaimed, err := ClaimFungibleAssetUnitsInHTLC(gci, contract, contractId, hashPreimageBase64, 30)
+	require.NoError(t, err)
+	require.Equal(t, "true", isClaimed)
+
+	// the claim carries the number of units to be claimed
+	claimInfoStr, err := createAssetClaimInfoSerializedBase64(hashPreimageBase64, 30)
+	require.NoError(t, err)
+	claimInfoBytes, err := base64.StdEncoding.DecodeString(claimInfoStr)
+	require.NoError(t, err)
+	claimInfo := &common.AssetClaim{}
+	err = proto.Unmarshal(claimInfoBytes, claimInfo)
+	require.NoError(t, err)
+	claimInfoHTLC := &common.AssetClaimHTLC{}
+	err = proto.Unmarshal(claimInfo.ClaimInfo, claimInfoHTLC)
+	require.NoError(t, err)
+	require.Equal(t, []byte(hashPreimageBase64), claimInfoHTLC.HashPreimageBase64)
+	require.Equal(t, uint64(30), claimInfoHTLC.NumUnits)
+}
+
 func TestClaimAssetInHTLCusingContractId(t *testing.T) {
 
 	gci := fabricGatewayContractMock{}
@@ -624,3 +748,556 @@ func TestReclaimAssetInHTLCusingContractId(t *testing.T) {
 	}
 	require.EqualError(t, err, expectedError)
 }
+
+func TestGenerateHashInBase64Form(t *testing.T) {
+	hashPreimage := "abcd"
+
+	hashBase64, err := GenerateHashInBase64Form(hashPreimage, common.HashMechanism_SHA256)
+	require.NoError(t, err)
+	require.Equal(t, GenerateSHA256HashInBase64Form(hashPreimage), hashBase64)
+
+	hashBase64, err = GenerateHashInBase64Form(hashPreimage, common.HashMechanism_SHA512)
+	require.NoError(t, err)
+	require.Equal(t, "2AIvIGCtbv0perc9zFNVybIUBUsNF3ahNqZp0mp9OxT3OqDQ6/8Z7jMzaPAWS2QZqW2knj5IF1Pn6Wtxa9zLbw==", hashBase64)
+
+	hashBase64, err = GenerateHashInBase64Form(hashPreimage, common.HashMechanism_DOUBLE_SHA256)
+	require.NoError(t, err)
+	require.Equal(t, "fpwVjs2Rn6Q5p6IUyfxYuFwxd/sWE72uQe5pUGDhG8Y=", hashBase64)
+
+	hashBase64, err = GenerateHashInBase64Form("", common.HashMechanism_KECCAK256)
+	require.NoError(t, err)
+	require.Equal(t, "xdJGAYb3IzySfn2y3McDwOUAtlPKgic7e/rYBF2FpHA=", hashBase64)
+
+	expectedError := "hash mechanism 10 is not supported"
+	_, err = GenerateHashInBase64Form(hashPreimage, common.HashMechanism(10))
+	require.EqualError(t, err, expectedError)
+}
+
+func TestGenerateHashPreimageAndHashInBase64Form(t *testing.T) {
+	expectedError := "preimage length must be a positive number"
+	_, _, err := GenerateHashPreimageAndHashInBase64Form(0, common.HashMechanism_SHA256)
+	require.EqualError(t, err, expectedError)
+
+	hashPreimage, hashBase64, err := GenerateHashPreimageAndHashInBase64Form(32, common.HashMechanism_KECCAK256)
+	require.NoError(t, err)
+	require.Len(t, hashPreimage, 64)
+	expectedHashBase64, _ := GenerateHashInBase64Form(hashPreimage, common.HashMechanism_KECCAK256)
+	require.Equal(t, expectedHashBase64, hashBase64)
+
+	otherHashPreimage, _, err := GenerateHashPreimageAndHashInBase64Form(32, common.HashMechanism_KECCAK256)
+	require.NoError(t, err)
+	require.NotEqual(t, hashPreimage, otherHashPreimage)
+
+	expectedError = "hash mechanism 10 is not supported"
+	_, _, err = GenerateHashPreimageAndHashInBase64Form(32, common.HashMechanism(10))
+	require.EqualError(t, err, expectedError)
+}
+
+func TestCreateAssetLockInfoSerializedBase64(t *testing.T) {
+	hashBase64 := GenerateSHA256HashInBase64Form("hashPreimage")
+	lockInfoStr, err := createAssetLockInfoSerializedBase64(hashBase64, 60, common.AssetLockHTLC_DURATION, common.HashMechanism_SHA512)
+	require.NoError(t, err)
+
+	lockInfoBytes, err := base64.StdEncoding.DecodeString(lockInfoStr)
+	require.NoError(t, err)
+	lockInfo := &common.AssetLock{}
+	err = proto.Unmarshal(lockInfoBytes, lockInfo)
+	require.NoError(t, err)
+	require.Equal(t, common.LockMechanism_HTLC, lockInfo.LockMechanism)
+	lockInfoHTLC := &common.AssetLockHTLC{}
+	err = proto.Unmarshal(lockInfo.LockInfo, lockInfoHTLC)
+	require.NoError(t, err)
+	require.Equal(t, []byte(hashBase64), lockInfoHTLC.HashBase64)
+	require.Equal(t, uint64(60), lockInfoHTLC.ExpiryTimeSecs)
+	require.Equal(t, common.AssetLockHTLC_DURATION, lockInfoHTLC.TimeSpec)
+	require.Equal(t, common.HashMechanism_SHA512, lockInfoHTLC.HashMechanism)
+}
+
+func TestGetHTLCHashPreImage(t *testing.T) {
+
+	gci := fabricGatewayContractMock{}
+	hashPreimageBase64 := base64.StdEncoding.EncodeToString([]byte("hashPreimage"))
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte(hashPreimageBase64), nil
+	}
+
+	contract := &gateway.Contract{}
+	contractId := "contract-id"
+
+	expectedError := "contract handle not supplied"
+	_, err := GetHTLCHashPreImage(gci, nil, contractId)
+	if err == nil {
+		t.Error("expected to fail with error " + expectedError + " but didn't")
+	}
+	require.EqualError(t, err, expectedError)
+
+	expectedError = "contractId not supplied"
+	_, err = GetHTLCHashPreImage(gci, contract, "")
+	if err == nil {
+		t.Error("expected to fail with error " + expectedError + " but didn't")
+	}
+	require.EqualError(t, err, expectedError)
+
+	revealedPreimageBase64, err := GetHTLCHashPreImage(gci, contract, contractId)
+	if err != nil {
+		t.Error("failed with error: ", err.Error())
+	}
+	require.Equal(t, revealedPreimageBase64, hashPreimageBase64)
+
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte(""), errors.New("failed evaluation")
+	}
+	expectedError = "error in contract.EvaluateTransaction GetHTLCHashPreImage: failed evaluation"
+	_, err = GetHTLCHashPreImage(gci, contract, contractId)
+	if err == nil {
+		t.Error("expected to fail with error " + expectedError + " but didn't")
+	}
+	require.EqualError(t, err, expectedError)
+}
+
+func TestSignEscrowRelease(t *testing.T) {
+
+	arbiterKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	contractId := "contract-id"
+	expiryTimeSecs := uint64(time.Now().Unix()) + 300
+
+	expectedError := "arbiter private key not supplied"
+	_, err = SignEscrowRelease(nil, contractId, expiryTimeSecs)
+	require.EqualError(t, err, expectedError)
+
+	expectedError = "contractId not supplied"
+	_, err = SignEscrowRelease(arbiterKey, "", expiryTimeSecs)
+	require.EqualError(t, err, expectedError)
+
+	require.Equal(t, "EscrowRelease:contract-id:"+strconv.FormatUint(expiryTimeSecs, 10), GetEscrowReleaseMessage(contractId, expiryTimeSecs))
+	signature, err := SignEscrowRelease(arbiterKey, contractId, expiryTimeSecs)
+	require.NoError(t, err)
+	hashed := sha256.Sum256([]byte(GetEscrowReleaseMessage(contractId, expiryTimeSecs)))
+	require.True(t, ecdsa.VerifyASN1(&arbiterKey.PublicKey, hashed[:], signature))
+	// the signature is bound to the contract and its expiry time
+	hashed = sha256.Sum256([]byte(GetEscrowReleaseMessage(contractId, expiryTimeSecs+1)))
+	require.False(t, ecdsa.VerifyASN1(&arbiterKey.PublicKey, hashed[:], signature))
+}
+
+func TestCreateEscrowLock(t *testing.T) {
+
+	gci := fabricGatewayContractMock{}
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte("contract-id"), nil
+	}
+
+	contract := &gateway.Contract{}
+	assetType := "bond"
+	assetId := "A001"
+	recipientECertBase64 := "recipientECertBase64"
+	arbiterECertBase64 := "arbiterECertBase64"
+	expiryTimeSecs := uint64(time.Now().Unix()) + 300
+
+	expectedError := "arbiterECertBase64 is not supplied"
+	_, err := CreateEscrowLock(gci, contract, assetType, assetId, recipientECertBase64, "", expiryTimeSecs)
+	require.EqualError(t, err, expectedError)
+	_, err = CreateFungibleEscrowLock(gci, contract, "cbdc", 10, recipientECertBase64, "", expiryTimeSecs)
+	require.EqualError(t, err, expectedError)
+
+	expectedError = "supplied expirty time in the past"
+	_, err = CreateEscrowLock(gci, contract, assetType, assetId, recipientECertBase64, arbiterECertBase64, uint64(time.Now().Unix())-1)
+	require.EqualError(t, err, expectedError)
+
+	contractId, err := CreateEscrowLock(gci, contract, assetType, assetId, recipientECertBase64, arbiterECertBase64, expiryTimeSecs)
+	require.NoError(t, err)
+	require.Equal(t, "contract-id", contractId)
+
+	// Test success with a lock duration, fetching the absolute expiry time computed by the chaincode
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte(strconv.FormatUint(expiryTimeSecs, 10)), nil
+	}
+	var computedExpiryTimeSecs uint64
+	contractId, err = CreateFungibleEscrowLock(gci, contract, "cbdc", 10, recipientECertBase64, arbiterECertBase64, 300, WithLockDuration(&computedExpiryTimeSecs))
+	require.NoError(t, err)
+	require.Equal(t, "contract-id", contractId)
+	require.Equal(t, expiryTimeSecs, computedExpiryTimeSecs)
+
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte(""), errors.New("failed submission")
+	}
+	expectedError = "error in contract.SubmitTransaction LockAsset: failed submission"
+	_, err = CreateEscrowLock(gci, contract, assetType, assetId, recipientECertBase64, arbiterECertBase64, expiryTimeSecs)
+	require.EqualError(t, err, expectedError)
+}
+
+func TestCreateAssetLockEscrowInfoSerializedBase64(t *testing.T) {
+
+	lockInfoStr, err := createAssetLockEscrowInfoSerializedBase64("arbiterECertBase64", 300, common.AssetLockHTLC_DURATION)
+	require.NoError(t, err)
+	lockInfoBytes, err := base64.StdEncoding.DecodeString(lockInfoStr)
+	require.NoError(t, err)
+	lockInfo := &common.AssetLock{}
+	err = proto.Unmarshal(lockInfoBytes, lockInfo)
+	require.NoError(t, err)
+	require.Equal(t, common.LockMechanism_ESCROW, lockInfo.LockMechanism)
+	lockInfoEscrow := &common.AssetLockEscrow{}
+	err = proto.Unmarshal(lockInfo.LockInfo, lockInfoEscrow)
+	require.NoError(t, err)
+	require.Equal(t, "arbiterECertBase64", lockInfoEscrow.Arbiter)
+	require.Equal(t, uint64(300), lockInfoEscrow.ExpiryTimeSecs)
+	require.Equal(t, common.AssetLockHTLC_DURATION, lockInfoEscrow.TimeSpec)
+}
+
+func TestClaimAssetInEscrow(t *testing.T) {
+
+	gci := fabricGatewayContractMock{}
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte(""), nil
+	}
+
+	contract := &gateway.Contract{}
+	contractId := "contract-id"
+	arbiterSignature := []byte("arbiter-signature")
+
+	expectedError := "arbiterSignature is not supplied"
+	_, err := ClaimAssetInEscrow(gci, contract, "bond", "A001", "lockerECertBase64", nil)
+	require.EqualError(t, err, expectedError)
+	_, err = ClaimFungibleAssetInEscrow(gci, contract, contractId, nil)
+	require.EqualError(t, err, expectedError)
+	_, err = ClaimAssetInEscrowUsingContractId(gci, contract, contractId, nil)
+	require.EqualError(t, err, expectedError)
+
+	expectedError = "contractId not supplied"
+	_, err = ClaimFungibleAssetInEscrow(gci, contract, "", arbiterSignature)
+	require.EqualError(t, err, expectedError)
+	_, err = ClaimAssetInEscrowUsingContractId(gci, contract, "", arbiterSignature)
+	require.EqualError(t, err, expectedError)
+
+	_, err = ClaimAssetInEscrow(gci, contract, "bond", "A001", "lockerECertBase64", arbiterSignature)
+	require.NoError(t, err)
+	_, err = ClaimFungibleAssetInEscrow(gci, contract, contractId, arbiterSignature)
+	require.NoError(t, err)
+	_, err = ClaimAssetInEscrowUsingContractId(gci, contract, contractId, arbiterSignature)
+	require.NoError(t, err)
+
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte(""), errors.New("failed submission")
+	}
+	expectedError = "error in contract.SubmitTransaction ClaimFungibleAsset: failed submission"
+	_, err = ClaimFungibleAssetInEscrow(gci, contract, contractId, arbiterSignature)
+	require.EqualError(t, err, expectedError)
+}
+
+func TestCreateAssetBundleHTLC(t *testing.T) {
+
+	gci := fabricGatewayContractMock{}
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte("bundle-contract-id"), nil
+	}
+
+	contract := &gateway.Contract{}
+	assets := []*common.AssetBundleItem{
+		{Type: "bond", Id: "A001"},
+		{Type: "cbdc", NumUnits: 50},
+	}
+	recipientECertBase64 := "recipientECertBase64"
+	hashBase64 := GenerateSHA256HashInBase64Form("abcd")
+	expiryTimeSecs := uint64(time.Now().Unix()) + 300
+
+	expectedError := "asset bundle not supplied"
+	_, err := CreateAssetBundleHTLC(gci, contract, nil, recipientECertBase64, hashBase64, expiryTimeSecs)
+	require.EqualError(t, err, expectedError)
+
+	expectedError = "exactly one of asset id and asset count must be supplied for asset type bond"
+	_, err = CreateAssetBundleHTLC(gci, contract, []*common.AssetBundleItem{{Type: "bond", Id: "A001", NumUnits: 1}}, recipientECertBase64, hashBase64, expiryTimeSecs)
+	require.EqualError(t, err, expectedError)
+	_, err = CreateAssetBundleHTLC(gci, contract, []*common.AssetBundleItem{{Type: "bond"}}, recipientECertBase64, hashBase64, expiryTimeSecs)
+	require.EqualError(t, err, expectedError)
+
+	contractId, err := CreateAssetBundleHTLC(gci, contract, assets, recipientECertBase64, hashBase64, expiryTimeSecs)
+	require.NoError(t, err)
+	require.Equal(t, "bundle-contract-id", contractId)
+
+	// Test success with a lock duration, fetching the absolute expiry time computed by the chaincode
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte(strconv.FormatUint(expiryTimeSecs, 10)), nil
+	}
+	var computedExpiryTimeSecs uint64
+	contractId, err = CreateAssetBundleHTLC(gci, contract, assets, recipientECertBase64, hashBase64, 300, WithLockDuration(&computedExpiryTimeSecs))
+	require.NoError(t, err)
+	require.Equal(t, "bundle-contract-id", contractId)
+	require.Equal(t, expiryTimeSecs, computedExpiryTimeSecs)
+
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte(""), errors.New("failed submission")
+	}
+	expectedError = "error in contract.SubmitTransaction LockAssetBundle: failed submission"
+	_, err = CreateAssetBundleHTLC(gci, contract, assets, recipientECertBase64, hashBase64, expiryTimeSecs)
+	require.EqualError(t, err, expectedError)
+}
+
+func TestClaimAndReclaimAssetBundleInHTLC(t *testing.T) {
+
+	gci := fabricGatewayContractMock{}
+	contract := &gateway.Contract{}
+	contractId := "bundle-contract-id"
+	hashPreimageBase64 := base64.StdEncoding.EncodeToString([]byte("abcd"))
+
+	expectedError := "hashPreimageBase64 is not supplied"
+	_, err := ClaimAssetBundleInHTLC(gci, contract, contractId, "")
+	require.EqualError(t, err, expectedError)
+	expectedError = "contractId not supplied"
+	_, err = ReclaimAssetBundleInHTLC(gci, contract, "")
+	require.EqualError(t, err, expectedError)
+	_, err = IsAssetBundleLockedInHTLC(gci, contract, "")
+	require.EqualError(t, err, expectedError)
+
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte(""), nil
+	}
+	_, err = ClaimAssetBundleInHTLC(gci, contract, contractId, hashPreimageBase64)
+	require.NoError(t, err)
+	_, err = ReclaimAssetBundleInHTLC(gci, contract, contractId)
+	require.NoError(t, err)
+
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte("true"), nil
+	}
+	isLocked, err := IsAssetBundleLockedInHTLC(gci, contract, contractId)
+	require.NoError(t, err)
+	require.Equal(t, "true", isLocked)
+
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte(""), errors.New("failed submission")
+	}
+	expectedError = "error in contract.SubmitTransaction ClaimAssetBundle: failed submission"
+	_, err = ClaimAssetBundleInHTLC(gci, contract, contractId, hashPreimageBase64)
+	require.EqualError(t, err, expectedError)
+	expectedError = "error in contract.SubmitTransaction UnlockAssetBundle: failed submission"
+	_, err = ReclaimAssetBundleInHTLC(gci, contract, contractId)
+	require.EqualError(t, err, expectedError)
+}
+
+func TestExtendLockExpiry(t *testing.T) {
+
+	gci := fabricGatewayContractMock{}
+	contract := &gateway.Contract{}
+	recipientKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	contractId := "contract-id"
+	expiryTimeSecs := uint64(time.Now().Unix()) + 300
+	newExpiryTimeSecs := expiryTimeSecs + 300
+
+	expectedError := "recipient private key not supplied"
+	_, err = SignLockExtension(nil, contractId, expiryTimeSecs, newExpiryTimeSecs)
+	require.EqualError(t, err, expectedError)
+
+	require.Equal(t, "LockExtension:contract-id:"+strconv.FormatUint(expiryTimeSecs, 10)+":"+strconv.FormatUint(newExpiryTimeSecs, 10),
+		GetLockExtensionMessage(contractId, expiryTimeSecs, newExpiryTimeSecs))
+	signature, err := SignLockExtension(recipientKey, contractId, expiryTimeSecs, newExpiryTimeSecs)
+	require.NoError(t, err)
+	hashed := sha256.Sum256([]byte(GetLockExtensionMessage(contractId, expiryTimeSecs, newExpiryTimeSecs)))
+	require.True(t, ecdsa.VerifyASN1(&recipientKey.PublicKey, hashed[:], signature))
+
+	expectedError = "supplied expirty time in the past"
+	_, err = ExtendLockExpiry(gci, contract, contractId, uint64(time.Now().Unix())-1, signature)
+	require.EqualError(t, err, expectedError)
+	expectedError = "recipientSignature is not supplied"
+	_, err = ExtendLockExpiry(gci, contract, contractId, newExpiryTimeSecs, nil)
+	require.EqualError(t, err, expectedError)
+
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte("true"), nil
+	}
+	result, err := ExtendLockExpiry(gci, contract, contractId, newExpiryTimeSecs, signature)
+	require.NoError(t, err)
+	require.Equal(t, "true", result)
+
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte(""), errors.New("failed submission")
+	}
+	expectedError = "error in contract.SubmitTransaction ExtendLockExpiry: failed submission"
+	_, err = ExtendLockExpiry(gci, contract, contractId, newExpiryTimeSecs, signature)
+	require.EqualError(t, err, expectedError)
+}
+
+func TestCreateHTLCAsDelegate(t *testing.T) {
+
+	gci := fabricGatewayContractMock{}
+	contract := &gateway.Contract{}
+	ownerKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	assetType := "bond"
+	assetId := "A001"
+	numUnits := uint64(10)
+	recipientECertBase64 := "recipient-ecert"
+	ownerECertBase64 := "owner-ecert"
+	delegateECertBase64 := "delegate-ecert"
+	hashBase64 := "ivHErp1x4bJDKuRo6L5bApO/DdoyD/dG0mAZrzLZEIs="
+	expiryTimeSecs := uint64(time.Now().Unix()) + 300
+
+	expectedError := "owner private key not supplied"
+	_, err = SignHTLCDelegation(nil, delegateECertBase64, assetType, assetId, recipientECertBase64, ownerECertBase64, hashBase64, expiryTimeSecs)
+	require.EqualError(t, err, expectedError)
+	expectedError = "delegateECertBase64 not supplied"
+	_, err = SignFungibleHTLCDelegation(ownerKey, "", assetType, numUnits, recipientECertBase64, ownerECertBase64, hashBase64, expiryTimeSecs)
+	require.EqualError(t, err, expectedError)
+
+	// the owner signs over the same serialized agreement and lock information that the delegate submits
+	delegation, err := SignHTLCDelegation(ownerKey, delegateECertBase64, assetType, assetId, recipientECertBase64, ownerECertBase64, hashBase64, expiryTimeSecs)
+	require.NoError(t, err)
+	require.Equal(t, delegateECertBase64, delegation.Delegate)
+	assetExchangeAgreementStr, err := createAssetExchangeAgreementSerializedBase64(assetType, assetId, recipientECertBase64, ownerECertBase64)
+	require.NoError(t, err)
+	lockInfoStr, err := createAssetLockInfoSerializedBase64(hashBase64, expiryTimeSecs, common.AssetLockHTLC_EPOCH, common.HashMechanism_SHA256)
+	require.NoError(t, err)
+	hashed := sha256.Sum256([]byte(GetLockDelegationMessage(delegateECertBase64, assetExchangeAgreementStr, lockInfoStr)))
+	require.True(t, ecdsa.VerifyASN1(&ownerKey.PublicKey, hashed[:], delegation.OwnerSignature))
+
+	expectedError = "contract handle not supplied"
+	_, err = CreateHTLCAsDelegate(gci, nil, assetType, assetId, recipientECertBase64, ownerECertBase64, hashBase64, expiryTimeSecs, delegation)
+	require.EqualError(t, err, expectedError)
+	expectedError = "ownerECertBase64 not supplied"
+	_, err = CreateHTLCAsDelegate(gci, contract, assetType, assetId, recipientECertBase64, "", hashBase64, expiryTimeSecs, delegation)
+	require.EqualError(t, err, expectedError)
+	expectedError = "lock delegation is not supplied"
+	_, err = CreateHTLCAsDelegate(gci, contract, assetType, assetId, recipientECertBase64, ownerECertBase64, hashBase64, expiryTimeSecs, nil)
+	require.EqualError(t, err, expectedError)
+
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte("contract-id-1"), nil
+	}
+	result, err := CreateHTLCAsDelegate(gci, contract, assetType, assetId, recipientECertBase64, ownerECertBase64, hashBase64, expiryTimeSecs, delegation)
+	require.NoError(t, err)
+	require.Equal(t, "contract-id-1", result)
+
+	fungibleDelegation, err := SignFungibleHTLCDelegation(ownerKey, delegateECertBase64, assetType, numUnits, recipientECertBase64, ownerECertBase64, hashBase64, expiryTimeSecs)
+	require.NoError(t, err)
+	result, err = CreateFungibleHTLCAsDelegate(gci, contract, assetType, numUnits, recipientECertBase64, ownerECertBase64, hashBase64, expiryTimeSecs, fungibleDelegation)
+	require.NoError(t, err)
+	require.Equal(t, "contract-id-1", result)
+
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte(""), errors.New("failed submission")
+	}
+	expectedError = "error in contract.SubmitTransaction LockFungibleAssetAsDelegate: failed submission"
+	_, err = CreateFungibleHTLCAsDelegate(gci, contract, assetType, numUnits, recipientECertBase64, ownerECertBase64, hashBase64, expiryTimeSecs, fungibleDelegation)
+	require.EqualError(t, err, expectedError)
+}
+
+func TestGetAllLockedAssetsWithPagination(t *testing.T) {
+
+	gci := fabricGatewayContractMock{}
+	contract := &gateway.Contract{}
+
+	expectedError := "contract handle not supplied"
+	_, err := GetAllLockedAssetsWithPagination(gci, nil, "*", "", 10, "")
+	require.EqualError(t, err, expectedError)
+	expectedError = "invalid page size 0"
+	_, err = GetAllFungibleLockedAssetsWithPagination(gci, contract, "*", "", 0, "")
+	require.EqualError(t, err, expectedError)
+	expectedError = "invalid expiry time"
+	_, err = GetAllAssetsLockedUntilWithPagination(gci, contract, 0, 10, "")
+	require.EqualError(t, err, expectedError)
+
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte(`{"lockedAssets":["{\"contractId\":\"contract-id\"}"],"bookmark":"next-page","fetchedRecordsCount":2}`), nil
+	}
+	page, err := GetAllNonFungibleLockedAssetsWithPagination(gci, contract, "*", "", 2, "")
+	require.NoError(t, err)
+	require.Equal(t, &LockedAssetsPage{LockedAssets: []string{`{"contractId":"contract-id"}`}, Bookmark: "next-page", FetchedRecordsCount: 2}, page)
+	page, err = GetAllAssetsLockedUntilWithPagination(gci, contract, uint64(time.Now().Unix()), 2, "next-page")
+	require.NoError(t, err)
+	require.Equal(t, "next-page", page.Bookmark)
+
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte("not a page"), nil
+	}
+	_, err = GetAllLockedAssetsWithPagination(gci, contract, "*", "", 2, "")
+	require.Error(t, err)
+
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte(""), errors.New("failed evaluation")
+	}
+	expectedError = "error in contract.EvaluateTransaction GetAllLockedAssetsWithPagination: failed evaluation"
+	_, err = GetAllLockedAssetsWithPagination(gci, contract, "*", "", 2, "")
+	require.EqualError(t, err, expectedError)
+}
+
+func TestReclaimExpiredAssets(t *testing.T) {
+
+	gci := fabricGatewayContractMock{}
+	contract := &gateway.Contract{}
+	cutoffSecs := uint64(time.Now().Unix())
+
+	expectedError := "contract handle not supplied"
+	_, err := ReclaimExpiredAssetsUsingContractIds(gci, nil, []string{"contract-id"})
+	require.EqualError(t, err, expectedError)
+	expectedError = "contractIds not supplied"
+	_, err = ReclaimExpiredAssetsUsingContractIds(gci, contract, nil)
+	require.EqualError(t, err, expectedError)
+	expectedError = "expiry cutoff time not supplied"
+	_, err = ReclaimExpiredAssetsOfLocker(gci, contract, "", 0)
+	require.EqualError(t, err, expectedError)
+
+	batchOutcomes := &common.AssetBatchUnlockOutcomes{Outcomes: []*common.AssetUnlockOutcome{
+		{ContractId: "contract-id-1", Unlocked: true},
+		{ContractId: "contract-id-2", Error: "expiry time is not yet elapsed"},
+	}}
+	batchOutcomesBytes, _ := proto.Marshal(batchOutcomes)
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte(base64.StdEncoding.EncodeToString(batchOutcomesBytes)), nil
+	}
+	outcomes, err := ReclaimExpiredAssetsUsingContractIds(gci, contract, []string{"contract-id-1", "contract-id-2"})
+	require.NoError(t, err)
+	require.Len(t, outcomes, 2)
+	require.Equal(t, "contract-id-1", outcomes[0].ContractId)
+	require.True(t, outcomes[0].Unlocked)
+	require.False(t, outcomes[1].Unlocked)
+	require.Equal(t, "expiry time is not yet elapsed", outcomes[1].Error)
+	outcomes, err = ReclaimExpiredAssetsOfLocker(gci, contract, "", cutoffSecs)
+	require.NoError(t, err)
+	require.Len(t, outcomes, 2)
+
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte(`[{"contractId":"contract-id-1","unlocked":true}]`), nil
+	}
+	_, err = ReclaimExpiredAssetsOfLocker(gci, contract, "", cutoffSecs)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to decode unlock outcomes")
+
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte(""), errors.New("failed submission")
+	}
+	expectedError = "error in contract.SubmitTransaction UnlockExpiredAssetsOfLocker: failed submission"
+	_, err = ReclaimExpiredAssetsOfLocker(gci, contract, "", cutoffSecs)
+	require.EqualError(t, err, expectedError)
+}
+
+func TestClaimAssetsInHTLCusingContractIds(t *testing.T) {
+
+	gci := fabricGatewayContractMock{}
+	contract := &gateway.Contract{}
+	contractIds := []string{"contract-id-1", "contract-id-2"}
+	hashPreimageBase64 := base64.StdEncoding.EncodeToString([]byte("hashPreimage"))
+
+	expectedError := "contract handle not supplied"
+	_, err := ClaimAssetsInHTLCusingContractIds(gci, nil, contractIds, hashPreimageBase64)
+	require.EqualError(t, err, expectedError)
+	expectedError = "contractIds not supplied"
+	_, err = ClaimAssetsInHTLCusingContractIds(gci, contract, []string{}, hashPreimageBase64)
+	require.EqualError(t, err, expectedError)
+	expectedError = "hashPreimageBase64 is not supplied"
+	_, err = ClaimAssetsInHTLCusingContractIds(gci, contract, contractIds, "")
+	require.EqualError(t, err, expectedError)
+
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte("true"), nil
+	}
+	result, err := ClaimAssetsInHTLCusingContractIds(gci, contract, contractIds, hashPreimageBase64)
+	require.NoError(t, err)
+	require.Equal(t, "true", result)
+
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte(""), errors.New("failed submission")
+	}
+	expectedError = "error in contract.SubmitTransaction ClaimAssetsUsingContractIds: failed submission"
+	_, err = ClaimAssetsInHTLCusingContractIds(gci, contract, contractIds, hashPreimageBase64)
+	require.EqualError(t, err, expectedError)
+}
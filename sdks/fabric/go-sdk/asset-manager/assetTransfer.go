@@ -0,0 +1,173 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package assetmanager
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger/fabric-sdk-go/pkg/gateway"
+	"google.golang.org/protobuf/proto"
+)
+
+// GetAssetPledgeViewAddress returns the address of the view, on the network networkId (reached through the relay at
+// relayEndpoint), of the pledge with pledgeId recorded by the application contract contractName on channel; the view
+// is to be fetched through the relays and passed to ClaimRemoteAsset
+func GetAssetPledgeViewAddress(relayEndpoint string, networkId string, channel string, contractName string, pledgeId string) string {
+	return fmt.Sprintf("%s/%s/%s:%s:GetAssetPledgeStatus:%s", relayEndpoint, networkId, channel, contractName, pledgeId)
+}
+
+// GetAssetClaimStatusViewAddress returns the address of the view, on the network networkId (reached through the relay
+// at relayEndpoint), of the status of the claim of the asset pledged on remoteNetworkId with pledgeId; the view is to be
+// fetched through the relays and passed to ReclaimAsset
+func GetAssetClaimStatusViewAddress(relayEndpoint string, networkId string, channel string, contractName string, pledgeId string,
+	remoteNetworkId string) string {
+	return fmt.Sprintf("%s/%s/%s:%s:GetAssetClaimStatus:%s:%s", relayEndpoint, networkId, channel, contractName, pledgeId,
+		remoteNetworkId)
+}
+
+// PledgeAsset pledges an asset, described by assetDetails in the form defined by the application contract, for its
+// transfer to the recipient on the network remoteNetworkId; the asset can be claimed there until expiryTimeSecs, after
+// which it can be reclaimed if it was not claimed. The pledge id is returned.
+func PledgeAsset(gci GatewayContractInterface, contract *gateway.Contract, assetType string, assetDetails []byte, remoteNetworkId string,
+	recipientECertBase64 string, expiryTimeSecs uint64) (string, error) {
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if assetType == "" {
+		return "", logThenErrorf("asset type not supplied")
+	}
+	if len(assetDetails) == 0 {
+		return "", logThenErrorf("asset details not supplied")
+	}
+	if remoteNetworkId == "" {
+		return "", logThenErrorf("remote network id not supplied")
+	}
+	if recipientECertBase64 == "" {
+		return "", logThenErrorf("recipientECertBase64 id not supplied")
+	}
+
+	assetPledge := &common.AssetPledge{
+		AssetType:       assetType,
+		AssetDetails:    assetDetails,
+		RemoteNetworkID: remoteNetworkId,
+		Recipient:       recipientECertBase64,
+		ExpiryTimeSecs:  expiryTimeSecs,
+	}
+	assetPledgeBytes, err := proto.Marshal(assetPledge)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	pledgeId, err := gci.SubmitTransaction(contract, "PledgeAsset", base64.StdEncoding.EncodeToString(assetPledgeBytes))
+	if err != nil {
+		return "", logThenErrorf("error in contract.SubmitTransaction PledgeAsset: %+v", err.Error())
+	}
+
+	return string(pledgeId), nil
+}
+
+// function to decode a serialized message (in base64 form) returned by a query
+func decodeAssetTransferQueryResult(result []byte, message proto.Message) error {
+	messageBytes, err := base64.StdEncoding.DecodeString(string(result))
+	if err != nil {
+		return logThenErrorf("failed to decode query result: %+v", err)
+	}
+	err = proto.Unmarshal(messageBytes, message)
+	if err != nil {
+		return logThenErrorf("failed to unmarshal query result: %+v", err)
+	}
+	return nil
+}
+
+// GetAssetPledgeStatus returns the pledge with pledgeId, which is empty if no asset is pledged with pledgeId
+func GetAssetPledgeStatus(gci GatewayContractInterface, contract *gateway.Contract, pledgeId string) (*common.AssetPledge, error) {
+	if contract == nil {
+		return nil, logThenErrorf("contract handle not supplied")
+	}
+	if pledgeId == "" {
+		return nil, logThenErrorf("pledgeId not supplied")
+	}
+
+	result, err := gci.EvaluateTransaction(contract, "GetAssetPledgeStatus", pledgeId)
+	if err != nil {
+		return nil, logThenErrorf("error in contract.EvaluateTransaction GetAssetPledgeStatus: %+v", err.Error())
+	}
+	assetPledge := &common.AssetPledge{}
+	err = decodeAssetTransferQueryResult(result, assetPledge)
+	return assetPledge, err
+}
+
+// ClaimRemoteAsset claims the asset pledged on the network remoteNetworkId with pledgeId, using the view of the pledge
+// fetched from pledgeViewAddress (see GetAssetPledgeViewAddress)
+func ClaimRemoteAsset(gci GatewayContractInterface, contract *gateway.Contract, pledgeId string, remoteNetworkId string,
+	pledgeViewAddress string, pledgeViewB64 string) (string, error) {
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if pledgeId == "" {
+		return "", logThenErrorf("pledgeId not supplied")
+	}
+	if remoteNetworkId == "" {
+		return "", logThenErrorf("remote network id not supplied")
+	}
+	if pledgeViewAddress == "" || pledgeViewB64 == "" {
+		return "", logThenErrorf("view of the asset pledge not supplied")
+	}
+
+	result, err := gci.SubmitTransaction(contract, "ClaimRemoteAsset", pledgeId, remoteNetworkId, pledgeViewAddress, pledgeViewB64)
+	if err != nil {
+		return "", logThenErrorf("error in contract.SubmitTransaction ClaimRemoteAsset: %+v", err.Error())
+	}
+
+	return string(result), nil
+}
+
+// GetAssetClaimStatus returns the status of the claim of the asset pledged on the network remoteNetworkId with pledgeId
+func GetAssetClaimStatus(gci GatewayContractInterface, contract *gateway.Contract, pledgeId string,
+	remoteNetworkId string) (*common.AssetClaimStatus, error) {
+	if contract == nil {
+		return nil, logThenErrorf("contract handle not supplied")
+	}
+	if pledgeId == "" {
+		return nil, logThenErrorf("pledgeId not supplied")
+	}
+	if remoteNetworkId == "" {
+		return nil, logThenErrorf("remote network id not supplied")
+	}
+
+	result, err := gci.EvaluateTransaction(contract, "GetAssetClaimStatus", pledgeId, remoteNetworkId)
+	if err != nil {
+		return nil, logThenErrorf("error in contract.EvaluateTransaction GetAssetClaimStatus: %+v", err.Error())
+	}
+	claimStatus := &common.AssetClaimStatus{}
+	err = decodeAssetTransferQueryResult(result, claimStatus)
+	return claimStatus, err
+}
+
+// ReclaimAsset reclaims the asset pledged with pledgeId after the pledge has expired without the asset being claimed,
+// using the view of the claim status fetched from claimStatusViewAddress (see GetAssetClaimStatusViewAddress)
+func ReclaimAsset(gci GatewayContractInterface, contract *gateway.Contract, pledgeId string, claimStatusViewAddress string,
+	claimStatusViewB64 string) (string, error) {
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if pledgeId == "" {
+		return "", logThenErrorf("pledgeId not supplied")
+	}
+	if claimStatusViewAddress == "" || claimStatusViewB64 == "" {
+		return "", logThenErrorf("view of the asset claim status not supplied")
+	}
+
+	result, err := gci.SubmitTransaction(contract, "ReclaimAsset", pledgeId, claimStatusViewAddress, claimStatusViewB64)
+	if err != nil {
+		return "", logThenErrorf("error in contract.SubmitTransaction ReclaimAsset: %+v", err.Error())
+	}
+
+	return string(result), nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package assetmanager
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger/fabric-sdk-go/pkg/gateway"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestAssetTransferViewAddresses(t *testing.T) {
+	require.Equal(t, "relay-network1:9080/network1/mychannel:simpleasset:GetAssetPledgeStatus:pledge-id",
+		GetAssetPledgeViewAddress("relay-network1:9080", "network1", "mychannel", "simpleasset", "pledge-id"))
+	require.Equal(t, "relay-network2:9083/network2/mychannel:simpleasset:GetAssetClaimStatus:pledge-id:network1",
+		GetAssetClaimStatusViewAddress("relay-network2:9083", "network2", "mychannel", "simpleasset", "pledge-id", "network1"))
+}
+
+func TestPledgeAsset(t *testing.T) {
+	gci := fabricGatewayContractMock{}
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte("pledge-id"), nil
+	}
+
+	contract := &gateway.Contract{}
+	assetDetails := []byte(`{"id":"A001"}`)
+	expiryTimeSecs := uint64(time.Now().Unix()) + 300
+
+	_, err := PledgeAsset(gci, nil, "bond", assetDetails, "network2", "recipientECertBase64", expiryTimeSecs)
+	require.EqualError(t, err, "contract handle not supplied")
+	_, err = PledgeAsset(gci, contract, "bond", nil, "network2", "recipientECertBase64", expiryTimeSecs)
+	require.EqualError(t, err, "asset details not supplied")
+	_, err = PledgeAsset(gci, contract, "bond", assetDetails, "", "recipientECertBase64", expiryTimeSecs)
+	require.EqualError(t, err, "remote network id not supplied")
+
+	pledgeId, err := PledgeAsset(gci, contract, "bond", assetDetails, "network2", "recipientECertBase64", expiryTimeSecs)
+	require.NoError(t, err)
+	require.Equal(t, "pledge-id", pledgeId)
+
+	submitTransactionMock = func() ([]byte, error) {
+		return nil, errors.New("pledge expiry time is not after the transaction time")
+	}
+	_, err = PledgeAsset(gci, contract, "bond", assetDetails, "network2", "recipientECertBase64", expiryTimeSecs)
+	require.EqualError(t, err, "error in contract.SubmitTransaction PledgeAsset: pledge expiry time is not after the transaction time")
+}
+
+func TestClaimAndReclaimRemoteAsset(t *testing.T) {
+	gci := fabricGatewayContractMock{}
+	contract := &gateway.Contract{}
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte("true"), nil
+	}
+
+	_, err := ClaimRemoteAsset(gci, contract, "pledge-id", "network1", "", "view")
+	require.EqualError(t, err, "view of the asset pledge not supplied")
+	result, err := ClaimRemoteAsset(gci, contract, "pledge-id", "network1", "view-address", "view")
+	require.NoError(t, err)
+	require.Equal(t, "true", result)
+
+	_, err = ReclaimAsset(gci, contract, "", "view-address", "view")
+	require.EqualError(t, err, "pledgeId not supplied")
+	result, err = ReclaimAsset(gci, contract, "pledge-id", "view-address", "view")
+	require.NoError(t, err)
+	require.Equal(t, "true", result)
+}
+
+func TestGetAssetPledgeAndClaimStatus(t *testing.T) {
+	gci := fabricGatewayContractMock{}
+	contract := &gateway.Contract{}
+
+	assetPledge := &common.AssetPledge{AssetType: "bond", AssetDetails: []byte("A001"), LocalNetworkID: "network1",
+		RemoteNetworkID: "network2", Recipient: "recipientECertBase64", ExpiryTimeSecs: 1000}
+	assetPledgeBytes, _ := proto.Marshal(assetPledge)
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte(base64.StdEncoding.EncodeToString(assetPledgeBytes)), nil
+	}
+	fetchedAssetPledge, err := GetAssetPledgeStatus(gci, contract, "pledge-id")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(assetPledge, fetchedAssetPledge))
+
+	claimStatus := &common.AssetClaimStatus{LocalNetworkID: "network2", RemoteNetworkID: "network1", ExpiryTimeSecs: 1000, ExpirationStatus: true,
+		StatusTimeSecs: 1000}
+	claimStatusBytes, _ := proto.Marshal(claimStatus)
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte(base64.StdEncoding.EncodeToString(claimStatusBytes)), nil
+	}
+	fetchedClaimStatus, err := GetAssetClaimStatus(gci, contract, "pledge-id", "network1")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(claimStatus, fetchedClaimStatus))
+
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte("not-base64"), nil
+	}
+	_, err = GetAssetClaimStatus(gci, contract, "pledge-id", "network1")
+	require.Error(t, err)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package assetmanager
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger/fabric-sdk-go/pkg/gateway"
+	"google.golang.org/protobuf/proto"
+)
+
+// WithContractId sets the contractId of the lock created by CreateHTLC, CreateFungibleHTLC or CreateMultiRecipientHTLC,
+// so that it is known before the lock is submitted; the contractId needs to be unique on the ledger
+func WithContractId(contractId string) HTLCOption {
+	return func(opts *htlcOptions) {
+		opts.contractId = contractId
+	}
+}
+
+// WithContractIdNonce sets a nonce from which the contractId of the lock created by CreateHTLC, CreateFungibleHTLC or
+// CreateMultiRecipientHTLC is derived (see GenerateContractIdOfNonce); the locker cannot use the same nonce twice
+func WithContractIdNonce(contractIdNonce string) HTLCOption {
+	return func(opts *htlcOptions) {
+		opts.contractIdNonce = contractIdNonce
+	}
+}
+
+// GenerateContractIdOfNonce returns the contractId of a lock created by lockerECertBase64 with the WithContractIdNonce option
+func GenerateContractIdOfNonce(lockerECertBase64 string, contractIdNonce string) string {
+	return GenerateSHA256HashInBase64Form(fmt.Sprintf("ContractIdNonce:%s:%s", lockerECertBase64, contractIdNonce))
+}
+
+// Create the asset exchange agreement structure of a new lock, with the contractId (or contractId nonce) chosen through the options
+func createLockAssetExchangeAgreementSerializedBase64(assetAgreement *common.AssetExchangeAgreement, htlcOpts *htlcOptions) (string, error) {
+	assetAgreement.ContractId = htlcOpts.contractId
+	assetAgreement.ContractIdNonce = htlcOpts.contractIdNonce
+	assetAgreementBytes, err := proto.Marshal(assetAgreement)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	return base64.StdEncoding.EncodeToString(assetAgreementBytes), nil
+}
+
+// Create the fungible asset exchange agreement structure of a new lock, with the contractId (or contractId nonce) chosen through the options
+func createLockFungibleAssetExchangeAgreementSerializedBase64(assetAgreement *common.FungibleAssetExchangeAgreement, htlcOpts *htlcOptions) (string, error) {
+	assetAgreement.ContractId = htlcOpts.contractId
+	assetAgreement.ContractIdNonce = htlcOpts.contractIdNonce
+	assetAgreementBytes, err := proto.Marshal(assetAgreement)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	return base64.StdEncoding.EncodeToString(assetAgreementBytes), nil
+}
+
+// GetContractIdsOfTxId returns the contractIds of the asset locks created by the transaction txId
+func GetContractIdsOfTxId(gci GatewayContractInterface, contract *gateway.Contract, txId string) ([]string, error) {
+	if contract == nil {
+		return nil, logThenErrorf("contract handle not supplied")
+	}
+	if txId == "" {
+		return nil, logThenErrorf("txId not supplied")
+	}
+
+	result, err := gci.EvaluateTransaction(contract, "GetContractIdsOfTxId", txId)
+	if err != nil {
+		return nil, logThenErrorf("error in contract.EvaluateTransaction GetContractIdsOfTxId: %+v", err.Error())
+	}
+	contractIds := []string{}
+	err = json.Unmarshal(result, &contractIds)
+	if err != nil {
+		return nil, logThenErrorf("failed to unmarshal the contractIds: %+v", err)
+	}
+
+	return contractIds, nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package assetmanager
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger/fabric-sdk-go/pkg/gateway"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCreateHTLCWithContractId(t *testing.T) {
+	gci := fabricGatewayContractMock{}
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte("leg-1"), nil
+	}
+
+	contract := &gateway.Contract{}
+	hashBase64 := GenerateSHA256HashInBase64Form("hashPreimage")
+	expiryTimeSecs := uint64(time.Now().Unix()) + 300
+
+	contractId, err := CreateHTLC(gci, contract, "bond", "A001", "bobECertBase64", hashBase64, expiryTimeSecs, WithContractId("leg-1"))
+	require.NoError(t, err)
+	require.Equal(t, "leg-1", contractId)
+
+	// the contractId and nonce chosen through the options are carried in the agreement
+	htlcOpts := getHTLCOptions([]HTLCOption{WithContractIdNonce("nonce-1")})
+	assetAgreementStr, err := createLockFungibleAssetExchangeAgreementSerializedBase64(&common.FungibleAssetExchangeAgreement{Type: "cbdc",
+		NumUnits: 10, Recipient: "bobECertBase64"}, htlcOpts)
+	require.NoError(t, err)
+	assetAgreementBytes, err := base64.StdEncoding.DecodeString(assetAgreementStr)
+	require.NoError(t, err)
+	assetAgreement := &common.FungibleAssetExchangeAgreement{}
+	require.NoError(t, proto.Unmarshal(assetAgreementBytes, assetAgreement))
+	require.Equal(t, "", assetAgreement.ContractId)
+	require.Equal(t, "nonce-1", assetAgreement.ContractIdNonce)
+
+	require.Equal(t, GenerateSHA256HashInBase64Form("ContractIdNonce:aliceECertBase64:nonce-1"), GenerateContractIdOfNonce("aliceECertBase64", "nonce-1"))
+
+	submitTransactionMock = func() ([]byte, error) {
+		return nil, errors.New("error in contractId validation: contractId leg-1 is already in use")
+	}
+	_, err = CreateFungibleHTLC(gci, contract, "cbdc", 10, "bobECertBase64", hashBase64, expiryTimeSecs, WithContractId("leg-1"))
+	require.EqualError(t, err, "error in contract.SubmitTransaction LockFungibleAsset: error in contractId validation: contractId leg-1 is already in use")
+}
+
+func TestGetContractIdsOfTxId(t *testing.T) {
+	gci := fabricGatewayContractMock{}
+	contract := &gateway.Contract{}
+
+	_, err := GetContractIdsOfTxId(gci, contract, "")
+	require.EqualError(t, err, "txId not supplied")
+
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte(`["leg-1","leg-2"]`), nil
+	}
+	contractIds, err := GetContractIdsOfTxId(gci, contract, "tx1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"leg-1", "leg-2"}, contractIds)
+
+	evaluateTransactionMock = func() ([]byte, error) {
+		return nil, errors.New("empty transaction id")
+	}
+	_, err = GetContractIdsOfTxId(gci, contract, "tx1")
+	require.EqualError(t, err, "error in contract.EvaluateTransaction GetContractIdsOfTxId: empty transaction id")
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package assetmanager
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger/fabric-sdk-go/pkg/gateway"
+	"google.golang.org/protobuf/proto"
+)
+
+// CreateMultiRecipientHTLC locks an asset for several recipients (recipientECertsBase64): any one of them can claim it
+// if recipientThreshold is 0 or 1, while a claim needs the approval of recipientThreshold of them otherwise
+func CreateMultiRecipientHTLC(gci GatewayContractInterface, contract *gateway.Contract, assetType string, assetId string, recipientECertsBase64 []string,
+	recipientThreshold uint32, hashBase64 string, expiryTimeSecs uint64, opts ...HTLCOption) (string, error) {
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if assetType == "" {
+		return "", logThenErrorf("asset type not supplied")
+	}
+	if assetId == "" {
+		return "", logThenErrorf("asset id not supplied")
+	}
+	if len(recipientECertsBase64) == 0 {
+		return "", logThenErrorf("recipientECertsBase64 not supplied")
+	}
+	if int(recipientThreshold) > len(recipientECertsBase64) {
+		return "", logThenErrorf("recipient threshold %d exceeds the number of recipients %d", recipientThreshold, len(recipientECertsBase64))
+	}
+	if hashBase64 == "" {
+		return "", logThenErrorf("hashBase64 is not supplied")
+	}
+	htlcOpts := getHTLCOptions(opts)
+	err := validateHTLCExpiry(htlcOpts, expiryTimeSecs)
+	if err != nil {
+		return "", err
+	}
+
+	assetExchangeAgreementStr, err := createLockAssetExchangeAgreementSerializedBase64(&common.AssetExchangeAgreement{Type: assetType, Id: assetId,
+		Recipients: recipientECertsBase64, RecipientThreshold: recipientThreshold}, htlcOpts)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	lockInfoStr, err := createAssetLockInfoSerializedBase64(hashBase64, expiryTimeSecs, htlcOpts.timeSpec, htlcOpts.hashMechanism)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	result, err := gci.SubmitTransaction(contract, "LockAsset", assetExchangeAgreementStr, lockInfoStr)
+	if err != nil {
+		return "", logThenErrorf("error in contract.SubmitTransaction LockAsset: %+v", err.Error())
+	}
+	err = fetchHTLCExpiryTime(gci, contract, htlcOpts, "GetAssetTimeToReleaseUsingContractId", string(result))
+	if err != nil {
+		return string(result), err
+	}
+
+	return string(result), nil
+}
+
+// GetClaimApprovalMessage returns the message a recipient of the lock associated with contractId, with the hash
+// hashBase64 and the expiry time expiryTimeSecs, signs to approve its claim by another recipient (claimantECertBase64);
+// the hash and the expiry time bind the approval to that lock, so that it is not valid for a later lock under the
+// same contractId, nor once the expiry of the lock has been extended
+func GetClaimApprovalMessage(contractId string, hashBase64 string, expiryTimeSecs uint64, claimantECertBase64 string) string {
+	return fmt.Sprintf("ClaimApproval:%s:%s:%d:%s", contractId, hashBase64, expiryTimeSecs, claimantECertBase64)
+}
+
+// SignClaimApproval produces the approval, by the recipient recipientECertBase64, of the claim of the lock associated
+// with contractId (with the hash hashBase64 and the expiry time expiryTimeSecs) by claimantECertBase64; the approval is
+// handed over to the claimant, who submits it with the claim
+func SignClaimApproval(recipientPrivateKey *ecdsa.PrivateKey, recipientECertBase64 string, contractId string, hashBase64 string,
+	expiryTimeSecs uint64, claimantECertBase64 string) (*common.RecipientApproval, error) {
+	if recipientPrivateKey == nil {
+		return nil, logThenErrorf("recipient private key not supplied")
+	}
+	if recipientECertBase64 == "" {
+		return nil, logThenErrorf("recipientECertBase64 not supplied")
+	}
+	if contractId == "" {
+		return nil, logThenErrorf("contractId not supplied")
+	}
+	if hashBase64 == "" {
+		return nil, logThenErrorf("hashBase64 is not supplied")
+	}
+	hashed := sha256.Sum256([]byte(GetClaimApprovalMessage(contractId, hashBase64, expiryTimeSecs, claimantECertBase64)))
+	signature, err := ecdsa.SignASN1(rand.Reader, recipientPrivateKey, hashed[:])
+	if err != nil {
+		return nil, logThenErrorf("error in signing the claim approval: %+v", err.Error())
+	}
+
+	return &common.RecipientApproval{Recipient: recipientECertBase64, Signature: signature}, nil
+}
+
+// ClaimMultiRecipientAssetInHTLCusingContractId claims the asset locked for several recipients under contractId, along
+// with the approvals (obtained through SignClaimApproval) of as many other recipients as the lock requires
+func ClaimMultiRecipientAssetInHTLCusingContractId(gci GatewayContractInterface, contract *gateway.Contract, contractId string, hashPreimageBase64 string,
+	approvals []*common.RecipientApproval) (string, error) {
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if contractId == "" {
+		return "", logThenErrorf("contractId not supplied")
+	}
+	if hashPreimageBase64 == "" {
+		return "", logThenErrorf("hashPreimageBase64 is not supplied")
+	}
+
+	claimInfoHTLCBytes, err := proto.Marshal(&common.AssetClaimHTLC{HashPreimageBase64: []byte(hashPreimageBase64)})
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+	claimInfo := &common.AssetClaim{
+		LockMechanism:      common.LockMechanism_HTLC,
+		ClaimInfo:          claimInfoHTLCBytes,
+		RecipientApprovals: approvals,
+	}
+	claimInfoBytes, err := proto.Marshal(claimInfo)
+	if err != nil {
+		return "", logThenErrorf(err.Error())
+	}
+
+	result, err := gci.SubmitTransaction(contract, "ClaimAssetUsingContractId", contractId, base64.StdEncoding.EncodeToString(claimInfoBytes))
+	if err != nil {
+		return "", logThenErrorf("error in contract.SubmitTransaction ClaimAssetUsingContractId: %+v", err.Error())
+	}
+
+	return string(result), nil
+}
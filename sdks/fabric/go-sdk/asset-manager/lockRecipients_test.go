@@ -0,0 +1,81 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package assetmanager
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/gateway"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateMultiRecipientHTLC(t *testing.T) {
+	gci := fabricGatewayContractMock{}
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte("contract-id"), nil
+	}
+
+	contract := &gateway.Contract{}
+	recipients := []string{"bobECertBase64", "charlieECertBase64", "daveECertBase64"}
+	hashBase64 := GenerateSHA256HashInBase64Form("hashPreimage")
+	expiryTimeSecs := uint64(time.Now().Unix()) + 300
+
+	_, err := CreateMultiRecipientHTLC(gci, contract, "bond", "A001", nil, 0, hashBase64, expiryTimeSecs)
+	require.EqualError(t, err, "recipientECertsBase64 not supplied")
+	_, err = CreateMultiRecipientHTLC(gci, contract, "bond", "A001", recipients, 4, hashBase64, expiryTimeSecs)
+	require.EqualError(t, err, "recipient threshold 4 exceeds the number of recipients 3")
+
+	contractId, err := CreateMultiRecipientHTLC(gci, contract, "bond", "A001", recipients, 2, hashBase64, expiryTimeSecs)
+	require.NoError(t, err)
+	require.Equal(t, "contract-id", contractId)
+
+	submitTransactionMock = func() ([]byte, error) {
+		return nil, errors.New("recipient bobECertBase64 is listed more than once")
+	}
+	_, err = CreateMultiRecipientHTLC(gci, contract, "bond", "A001", []string{"bobECertBase64", "bobECertBase64"}, 0, hashBase64, expiryTimeSecs)
+	require.EqualError(t, err, "error in contract.SubmitTransaction LockAsset: recipient bobECertBase64 is listed more than once")
+}
+
+func TestSignClaimApproval(t *testing.T) {
+	recipientKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, err = SignClaimApproval(nil, "bobECertBase64", "contract-id", "hashBase64", 1000, "charlieECertBase64")
+	require.EqualError(t, err, "recipient private key not supplied")
+	_, err = SignClaimApproval(recipientKey, "bobECertBase64", "", "hashBase64", 1000, "charlieECertBase64")
+	require.EqualError(t, err, "contractId not supplied")
+	_, err = SignClaimApproval(recipientKey, "bobECertBase64", "contract-id", "", 1000, "charlieECertBase64")
+	require.EqualError(t, err, "hashBase64 is not supplied")
+
+	approval, err := SignClaimApproval(recipientKey, "bobECertBase64", "contract-id", "hashBase64", 1000, "charlieECertBase64")
+	require.NoError(t, err)
+	require.Equal(t, "bobECertBase64", approval.Recipient)
+	hashed := sha256.Sum256([]byte("ClaimApproval:contract-id:hashBase64:1000:charlieECertBase64"))
+	require.True(t, ecdsa.VerifyASN1(&recipientKey.PublicKey, hashed[:], approval.Signature))
+
+	gci := fabricGatewayContractMock{}
+	contract := &gateway.Contract{}
+	submitTransactionMock = func() ([]byte, error) {
+		return []byte(""), nil
+	}
+	_, err = ClaimMultiRecipientAssetInHTLCusingContractId(gci, contract, "contract-id", "", nil)
+	require.EqualError(t, err, "hashPreimageBase64 is not supplied")
+	_, err = ClaimMultiRecipientAssetInHTLCusingContractId(gci, contract, "contract-id", "hashPreimageBase64", nil)
+	require.NoError(t, err)
+
+	submitTransactionMock = func() ([]byte, error) {
+		return nil, errors.New("claim is approved by 1 recipients while the lock requires 2")
+	}
+	_, err = ClaimMultiRecipientAssetInHTLCusingContractId(gci, contract, "contract-id", "hashPreimageBase64", nil)
+	require.EqualError(t, err, "error in contract.SubmitTransaction ClaimAssetUsingContractId: claim is approved by 1 recipients while the lock requires 2")
+}
@@ -4,11 +4,15 @@ go 1.15
 
 require (
 	github.com/golang/mock v1.6.0
-	github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go v0.0.0-20210712215013-866f5840a1a9
+	github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go v1.3.0
+	github.com/hyperledger/fabric-protos-go v0.0.0-20210720123151-f0dc3e2a0871
 	github.com/hyperledger/fabric-sdk-go v1.0.0-rc1
 	github.com/sirupsen/logrus v1.3.0
 	github.com/stretchr/testify v1.5.1
-	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9 // indirect
+	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
 	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
 	google.golang.org/protobuf v1.27.1
 )
+
+// fabric-sdk-go v1.0.0-rc1 does not build against the later fabric protos required by the protos in this repository
+replace github.com/hyperledger/fabric-protos-go => github.com/hyperledger/fabric-protos-go v0.0.0-20210528200356-82833ecdac31
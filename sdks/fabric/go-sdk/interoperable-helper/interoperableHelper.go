@@ -0,0 +1,202 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interoperablehelper
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/common"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/corda"
+	"github.com/hyperledger-labs/weaver-dlt-interoperability/common/protos-go/fabric"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// helper functions to log and return errors
+func logThenErrorf(format string, args ...interface{}) error {
+	errorMsg := fmt.Sprintf(format, args...)
+	log.Error(errorMsg)
+	return errors.New(errorMsg)
+}
+
+// GetSignedQueryMessage returns the message signed by the requestor of a query: the address, nonce and timestamp (in
+// epoch seconds, in decimal form), followed by the field "confidential" for a confidential query, each prefixed by its
+// length in bytes and ':', so that no characters can be moved from one field to the next without invalidating the
+// signature. A query with neither timestamp nor confidentiality flag is signed over its address followed by its nonce.
+func GetSignedQueryMessage(address string, nonce string, timestampSecs uint64, confidential bool) string {
+	if timestampSecs == 0 && !confidential {
+		return address + nonce
+	}
+	fields := []string{address, nonce, strconv.FormatUint(timestampSecs, 10)}
+	if confidential {
+		fields = append(fields, "confidential")
+	}
+	message := ""
+	for _, field := range fields {
+		message += strconv.Itoa(len(field)) + ":" + field
+	}
+	return message
+}
+
+// SignQuery returns the requestor signature (base64 encoded) of a query, with the private key matching the certificate
+// sent along with the query
+func SignQuery(privateKey *ecdsa.PrivateKey, address string, nonce string, timestampSecs uint64, confidential bool) (string, error) {
+	if privateKey == nil {
+		return "", logThenErrorf("private key not supplied")
+	}
+	hashed := sha256.Sum256([]byte(GetSignedQueryMessage(address, nonce, timestampSecs, confidential)))
+	signature, err := ecdsa.SignASN1(rand.Reader, privateKey, hashed[:])
+	if err != nil {
+		return "", logThenErrorf("failed to sign query: %+v", err)
+	}
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// DecryptConfidentialPayload decrypts the encrypted payload of a confidential view with the private key of the
+// requestor. The ciphertext is the uncompressed ephemeral public key followed by the AES-256-GCM sealed plaintext (with
+// a zero nonce), keyed with the X9.63 KDF (SHA-256) of the ECDH shared secret, as produced by the interop chaincode.
+func DecryptConfidentialPayload(privateKey *ecdsa.PrivateKey, encryptedPayload []byte) ([]byte, error) {
+	if privateKey == nil {
+		return nil, logThenErrorf("private key not supplied")
+	}
+	curve := privateKey.Curve
+	coordinateLength := (curve.Params().BitSize + 7) / 8
+	ephemeralPublicKeyLength := 1 + 2*coordinateLength
+	if len(encryptedPayload) < ephemeralPublicKeyLength {
+		return nil, logThenErrorf("encrypted payload is too short")
+	}
+	ephemeralPublicKey := encryptedPayload[:ephemeralPublicKeyLength]
+	ephemeralX, ephemeralY := elliptic.Unmarshal(curve, ephemeralPublicKey)
+	if ephemeralX == nil {
+		return nil, logThenErrorf("invalid ephemeral public key in encrypted payload")
+	}
+	sharedX, _ := curve.ScalarMult(ephemeralX, ephemeralY, privateKey.D.Bytes())
+	sharedSecret := make([]byte, coordinateLength)
+	sharedX.FillBytes(sharedSecret)
+	kdf := sha256.New()
+	kdf.Write(sharedSecret)
+	kdf.Write([]byte{0x00, 0x00, 0x00, 0x01})
+	kdf.Write(ephemeralPublicKey)
+	block, err := aes.NewCipher(kdf.Sum(nil))
+	if err != nil {
+		return nil, logThenErrorf("failed to create cipher: %+v", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, logThenErrorf("failed to create cipher: %+v", err)
+	}
+	plaintext, err := aead.Open(nil, make([]byte, aead.NonceSize()), encryptedPayload[ephemeralPublicKeyLength:], nil)
+	if err != nil {
+		return nil, logThenErrorf("failed to decrypt payload: %+v", err)
+	}
+	return plaintext, nil
+}
+
+// GetInteropPayloadFromView extracts the interop payload embedded in a view
+func GetInteropPayloadFromView(view *common.View) (*common.InteropPayload, error) {
+	if view == nil || view.Meta == nil {
+		return nil, logThenErrorf("view not supplied")
+	}
+	var payloadBytes []byte
+	switch view.Meta.Protocol {
+	case common.Meta_FABRIC:
+		var fabricView fabric.FabricView
+		err := proto.Unmarshal(view.Data, &fabricView)
+		if err != nil {
+			return nil, logThenErrorf("failed to unmarshal fabric view: %+v", err)
+		}
+		payloadBytes = fabricView.GetResponse().GetPayload()
+	case common.Meta_CORDA:
+		var cordaView corda.ViewData
+		err := proto.Unmarshal(view.Data, &cordaView)
+		if err != nil {
+			return nil, logThenErrorf("failed to unmarshal corda view: %+v", err)
+		}
+		payloadBytes = cordaView.Payload
+	default:
+		return nil, logThenErrorf("unsupported DLT type: %s", view.Meta.Protocol)
+	}
+	interopPayload := &common.InteropPayload{}
+	err := proto.Unmarshal(payloadBytes, interopPayload)
+	if err != nil {
+		return nil, logThenErrorf("failed to unmarshal interop payload: %+v", err)
+	}
+	return interopPayload, nil
+}
+
+// GetViewContents decrypts the contents of a confidential view with the private key of the requestor, and checks them
+// against the hash in the view. The contents (a serialized ConfidentialPayloadContents) are returned base64 encoded, to
+// be passed to the interop chaincode along with the view (WriteExternalStateWithViewContents or
+// ParseAndValidateConfidentialView); an empty string is returned for a view that is not confidential.
+func GetViewContents(privateKey *ecdsa.PrivateKey, view *common.View) (string, error) {
+	interopPayload, err := GetInteropPayloadFromView(view)
+	if err != nil {
+		return "", err
+	}
+	if !interopPayload.Confidential {
+		return "", nil
+	}
+	var confidentialPayload common.ConfidentialPayload
+	err = proto.Unmarshal(interopPayload.Payload, &confidentialPayload)
+	if err != nil {
+		return "", logThenErrorf("failed to unmarshal confidential payload: %+v", err)
+	}
+	if confidentialPayload.HashType != common.ConfidentialPayload_HMAC {
+		return "", logThenErrorf("unsupported confidential payload hash type: %s", confidentialPayload.HashType)
+	}
+	contentsBytes, err := DecryptConfidentialPayload(privateKey, confidentialPayload.EncryptedPayload)
+	if err != nil {
+		return "", err
+	}
+	var contents common.ConfidentialPayloadContents
+	err = proto.Unmarshal(contentsBytes, &contents)
+	if err != nil {
+		return "", logThenErrorf("failed to unmarshal confidential payload contents: %+v", err)
+	}
+	mac := hmac.New(sha256.New, contents.Random)
+	mac.Write(contents.Payload)
+	if !hmac.Equal(mac.Sum(nil), confidentialPayload.Hash) {
+		return "", logThenErrorf("view contents do not match the hash in the confidential payload")
+	}
+	return base64.StdEncoding.EncodeToString(contentsBytes), nil
+}
+
+// GetResponseDataFromView extracts the remote query response embedded in a view; the response in a confidential view
+// is decrypted with the private key of the requestor
+func GetResponseDataFromView(privateKey *ecdsa.PrivateKey, view *common.View) ([]byte, error) {
+	interopPayload, err := GetInteropPayloadFromView(view)
+	if err != nil {
+		return nil, err
+	}
+	if !interopPayload.Confidential {
+		return interopPayload.Payload, nil
+	}
+	b64Contents, err := GetViewContents(privateKey, view)
+	if err != nil {
+		return nil, err
+	}
+	contentsBytes, err := base64.StdEncoding.DecodeString(b64Contents)
+	if err != nil {
+		return nil, logThenErrorf("failed to decode view contents: %+v", err)
+	}
+	var contents common.ConfidentialPayloadContents
+	err = proto.Unmarshal(contentsBytes, &contents)
+	if err != nil {
+		return nil, logThenErrorf("failed to unmarshal confidential payload contents: %+v", err)
+	}
+	return contents.Payload, nil
+}
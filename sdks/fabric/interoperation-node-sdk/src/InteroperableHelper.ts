@@ -157,24 +157,109 @@ const verifyRemoteProposalResponse = async (proposalResponseBase64, isEncrypted,
 };
 
 /**
- * Extracts actual remote query response embedded in view structure.
+ * Extracts the interop payload embedded in view structure.
  * Argument is a View protobuf ('statePb.View')
  **/
-const getResponseDataFromView = (view) => {
+const getInteropPayloadFromView = (view) => {
     if (view.getMeta().getProtocol() == statePb.Meta.Protocol.FABRIC) {
         const fabricView = fabricViewPb.FabricView.deserializeBinary(view.getData());
-        const interopPayload = interopPayloadPb.InteropPayload.deserializeBinary(Uint8Array.from(Buffer.from(fabricView.getResponse().getPayload())));
-        return Buffer.from(interopPayload.getPayload()).toString();
+        return interopPayloadPb.InteropPayload.deserializeBinary(Uint8Array.from(Buffer.from(fabricView.getResponse().getPayload())));
     } else if (view.getMeta().getProtocol() == statePb.Meta.Protocol.CORDA) {
         const cordaView = cordaViewPb.ViewData.deserializeBinary(view.getData());
-        const interopPayload = interopPayloadPb.InteropPayload.deserializeBinary(Uint8Array.from(Buffer.from(cordaView.getPayload())));
-        return Buffer.from(interopPayload.getPayload()).toString();
+        return interopPayloadPb.InteropPayload.deserializeBinary(Uint8Array.from(Buffer.from(cordaView.getPayload())));
     } else {
         const protocolType = view.getMeta().getProtocol();
         throw new Error(`Unsupported DLT type: ${protocolType}`);
     }
 }
 
+// Names of the curves supported for ECIES decryption, as known to 'crypto.createECDH', by their 'jsrsasign' names
+const ecdhCurveNames = {
+    secp256r1: "prime256v1",
+    secp384r1: "secp384r1",
+    secp521r1: "secp521r1",
+};
+
+/**
+ * Decrypts the encrypted payload of a confidential view with the private key (PKCS8 PEM) of the requestor.
+ * The ciphertext is the uncompressed ephemeral public key followed by the AES-256-GCM sealed plaintext (with a zero nonce
+ * and a 16-byte tag), keyed with the X9.63 KDF (SHA-256) of the ECDH shared secret, as produced by the interop chaincode.
+ **/
+const eciesDecryptConfidentialPayload = (encryptedPayload: Uint8Array, privateKeyPEM: string): Buffer => {
+    const privKey = keyutil.getKeyFromPlainPrivatePKCS8PEM(privateKeyPEM);
+    //@ts-ignore curveName and prvKeyHex are set on the ECDSA keys of 'jsrsasign'
+    const { curveName, prvKeyHex } = privKey;
+    if (!ecdhCurveNames[curveName]) {
+        throw new Error(`Unsupported curve for decryption of a confidential payload: ${curveName}`);
+    }
+    const ecdh = crypto.createECDH(ecdhCurveNames[curveName]);
+    ecdh.setPrivateKey(Buffer.from(prvKeyHex, "hex"));
+    const ciphertext = Buffer.from(encryptedPayload);
+    const ephemeralPublicKeyLength = ecdh.getPublicKey().length;
+    const tagLength = 16;
+    if (ciphertext.length < ephemeralPublicKeyLength + tagLength) {
+        throw new Error("Encrypted payload is too short");
+    }
+    const ephemeralPublicKey = ciphertext.slice(0, ephemeralPublicKeyLength);
+    const sharedSecret = ecdh.computeSecret(ephemeralPublicKey);
+    const key = crypto
+        .createHash("sha256")
+        .update(sharedSecret)
+        .update(Buffer.from([0, 0, 0, 1]))
+        .update(ephemeralPublicKey)
+        .digest();
+    const decipher = crypto.createDecipheriv("aes-256-gcm", key, Buffer.alloc(12));
+    decipher.setAuthTag(ciphertext.slice(ciphertext.length - tagLength));
+    return Buffer.concat([
+        decipher.update(ciphertext.slice(ephemeralPublicKeyLength, ciphertext.length - tagLength)),
+        decipher.final(),
+    ]);
+};
+
+/**
+ * Decrypts the contents of a confidential view with the private key (PKCS8 PEM) of the requestor, and checks them
+ * against the hash in the view. Returns the contents (a serialized 'ConfidentialPayloadContents') in base64 format, to
+ * be passed to the interop chaincode along with the view, or an empty string for a view that is not confidential.
+ * Argument is a View protobuf ('statePb.View')
+ **/
+const getViewContentsBase64 = (view, privateKeyPEM: string): string => {
+    const interopPayload = getInteropPayloadFromView(view);
+    if (!interopPayload.getConfidential()) {
+        return "";
+    }
+    const confidentialPayload = interopPayloadPb.ConfidentialPayload.deserializeBinary(interopPayload.getPayload_asU8());
+    const contentsBytes = eciesDecryptConfidentialPayload(confidentialPayload.getEncryptedPayload_asU8(), privateKeyPEM);
+    const contents = interopPayloadPb.ConfidentialPayloadContents.deserializeBinary(Uint8Array.from(contentsBytes));
+    if (confidentialPayload.getHashType() !== interopPayloadPb.ConfidentialPayload.HashType.HMAC) {
+        throw new Error(`Unsupported confidential payload hash type: ${confidentialPayload.getHashType()}`);
+    }
+    const hash = crypto.createHmac("sha256", Buffer.from(contents.getRandom_asU8())).update(contents.getPayload_asU8()).digest();
+    const expectedHash = Buffer.from(confidentialPayload.getHash_asU8());
+    if (hash.length !== expectedHash.length || !crypto.timingSafeEqual(hash, expectedHash)) {
+        throw new Error("View contents do not match the hash in the confidential payload");
+    }
+    return contentsBytes.toString("base64");
+};
+
+/**
+ * Extracts actual remote query response embedded in view structure.
+ * The response in a confidential view is decrypted with the private key (PKCS8 PEM) of the requestor.
+ * Argument is a View protobuf ('statePb.View')
+ **/
+const getResponseDataFromView = (view, privateKeyPEM?: string) => {
+    const interopPayload = getInteropPayloadFromView(view);
+    if (!interopPayload.getConfidential()) {
+        return Buffer.from(interopPayload.getPayload()).toString();
+    }
+    if (!privateKeyPEM) {
+        throw new Error("Private key of the requestor required to read a confidential view");
+    }
+    const contents = interopPayloadPb.ConfidentialPayloadContents.deserializeBinary(
+        Uint8Array.from(Buffer.from(getViewContentsBase64(view, privateKeyPEM), "base64")),
+    );
+    return Buffer.from(contents.getPayload()).toString();
+}
+
 /**
  * Extracts endorsements and the signing authorities backing those endorsements from a Fabric view.
  * Argument is a View protobuf ('statePb.View')
@@ -259,6 +344,19 @@ const signMessage = (message, privateKey) => {
     return sign.sign(privateKey);
 };
 
+/**
+ * Get the message signed by the requestor of a query: the address, nonce and timestamp (in epoch seconds, in decimal
+ * form), followed by the field "confidential" for a confidential query, each prefixed by its length in bytes and ':',
+ * so that the fields cannot be told apart in any other way
+ **/
+const getSignedQueryMessage = (address: string, nonce: string, timestamp: number, confidential: boolean = false): string => {
+    const fields = [address, nonce, timestamp.toString()];
+    if (confidential) {
+        fields.push("confidential");
+    }
+    return fields.map((field) => `${Buffer.byteLength(field)}:${field}`).join("");
+};
+
 const validPatternString = (pattern: string): boolean => {
     // count number of stars in pattern
     const numStars = (pattern.match(/\*/g) || []).length;
@@ -390,7 +488,8 @@ const interopFlow = async (
         throw new Error(`Number of argument indices ${interopArgIndices.length} does not match number of view addresses ${interopJSONs.length}`);
     }
     // Step 1: Iterate through the view addresses, and send remote requests and get views in response for each
-    let views = [], viewsSerializedBase64 = [], computedAddresses = [];
+    // The contents of confidential views are decrypted, to be passed along with the views
+    let views = [], viewsSerializedBase64 = [], viewsContentsBase64 = [], computedAddresses = [];
     for(let i = 0 ; i < interopJSONs.length ; i++) {
         const [requestResponse, requestResponseError] = await helpers.handlePromise(
             getRemoteView(
@@ -407,8 +506,14 @@ const interopFlow = async (
         }
         views.push(requestResponse.view);
         viewsSerializedBase64.push(Buffer.from(requestResponse.view.serializeBinary()).toString("base64"));
+        viewsContentsBase64.push(
+            interopJSONs[i].Confidential ? getViewContentsBase64(requestResponse.view, keyCert.key.toBytes()) : "",
+        );
         computedAddresses.push(requestResponse.address);
     }
+    if (!interopJSONs.some((interopJSON) => interopJSON.Confidential)) {
+        viewsContentsBase64 = [];
+    }
     // Return here if caller just wants the views and doesn't want to invoke a local chaincode
     if (returnWithoutLocalInvocation) {
         const ccArgs = getCCArgsForProofVerification(
@@ -416,6 +521,7 @@ const interopFlow = async (
             interopArgIndices,
             computedAddresses,
             viewsSerializedBase64,
+            viewsContentsBase64,
         );
         return { views, result: ccArgs };
     }
@@ -426,18 +532,22 @@ const interopFlow = async (
         interopArgIndices,
         computedAddresses,
         viewsSerializedBase64,
+        viewsContentsBase64,
     );
     return { views, result };
 };
 
 /**
  * Prepare arguments for WriteExternalState chaincode transaction to verify a view and write data to ledger.
+ * The decrypted contents of confidential views, if any, are passed in viewsContentsBase64 (with an entry, empty for a
+ * view that is not confidential, for each view), and are then the last argument, of WriteExternalStateWithViewContents.
  **/
 const getCCArgsForProofVerification = (
     invokeObject: Query,
     interopArgIndices: Array<number>,
     viewAddresses: Array<string>,
     viewsSerializedBase64: Array<string>,
+    viewsContentsBase64: Array<string> = [],
 ): Array<any> => {
     const {
         ccArgs: localCCArgs,
@@ -454,12 +564,15 @@ const getCCArgsForProofVerification = (
         JSON.stringify(viewAddresses),
         JSON.stringify(viewsSerializedBase64),
     ];
+    if (viewsContentsBase64.length > 0) {
+        ccArgs.push(JSON.stringify(viewsContentsBase64));
+    }
     return ccArgs;
 };
 
 /**
  * Submit local chaincode transaction to verify a view and write data to ledger.
- * - Prepare arguments and call WriteExternalState.
+ * - Prepare arguments and call WriteExternalState (WriteExternalStateWithViewContents if view contents are supplied).
  **/
 const submitTransactionWithRemoteViews = async (
     interopContract: Contract,
@@ -467,15 +580,18 @@ const submitTransactionWithRemoteViews = async (
     interopArgIndices: Array<number>,
     viewAddresses: Array<string>,
     viewsSerializedBase64: Array<string>,
+    viewsContentsBase64: Array<string> = [],
 ): Promise<any> => {
     const ccArgs = getCCArgsForProofVerification(
         invokeObject,
         interopArgIndices,
         viewAddresses,
         viewsSerializedBase64,
+        viewsContentsBase64,
     );
+    const ccFunc = viewsContentsBase64.length > 0 ? "WriteExternalStateWithViewContents" : "WriteExternalState";
     const [result, submitError] = await helpers.handlePromise(
-        interopContract.submitTransaction("WriteExternalState", ...ccArgs),
+        interopContract.submitTransaction(ccFunc, ...ccArgs),
     );
     if (submitError) {
         throw new Error(`submitTransaction Error: ${submitError}`);
@@ -506,6 +622,7 @@ const getRemoteView = async (
         RemoteEndpoint,
         NetworkID: RemoteNetworkID,
         Sign,
+        Confidential,
         ccArgs: args,
     } = interopJSON;
     // Step 1
@@ -525,6 +642,7 @@ const getRemoteView = async (
     }
     const relay = new Relay(localRelayEndpoint);
     const uuidValue = uuidv4();
+    const timestamp = Math.floor(Date.now() / 1000);
     // Step 3
     // TODO fix types here so can return proper view
     const [relayResponse, relayResponseError] = await helpers.handlePromise(
@@ -533,10 +651,17 @@ const getRemoteView = async (
             policyCriteria,
             networkID,
             keyCert.cert,
-            Sign ? signMessage(computedAddress + uuidValue, keyCert.key.toBytes()).toString("base64") : "",
+            Sign
+                ? signMessage(
+                      getSignedQueryMessage(computedAddress, uuidValue, timestamp, !!Confidential),
+                      keyCert.key.toBytes(),
+                  ).toString("base64")
+                : "",
             uuidValue,
             // Org is empty as the name is in the certs for
             org,
+            timestamp,
+            !!Confidential,
         ),
     );
     if (relayResponseError) {
@@ -622,12 +747,15 @@ export {
     verifyRemoteProposalResponse,
     verifyDecryptedRemoteProposalResponse,
     getResponseDataFromView,
+    getViewContentsBase64,
+    eciesDecryptConfidentialPayload,
     getEndorsementsAndSignatoriesFromFabricView,
     getEndorsementsAndSignatoriesFromCordaView,
     getResponsePayloadFromFabricView,
     getSignatoryOrgMSPFromFabricEndorsementBase64,
     decodeView,
     signMessage,
+    getSignedQueryMessage,
     invokeHandler,
     interopFlow,
     getCCArgsForProofVerification,
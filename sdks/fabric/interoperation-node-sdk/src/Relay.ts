@@ -48,6 +48,8 @@ class Relay {
 
     /**
      * SendRequest to send a request to a remote network using gRPC and the relay.
+     * The timestamp (in epoch seconds) is the time at which the requestor signed the request, if the signature covers one.
+     * A confidential request gets a view whose payload is encrypted for the requestor, and needs a signature covering the flag.
      * @returns {string} The ID of the request
      */
     async SendRequest(
@@ -58,6 +60,8 @@ class Relay {
         signature: string,
         nonce: string,
         org: string,
+        timestamp?: number,
+        confidential?: boolean,
     ): Promise<string> {
         try {
             const networkClient = new networksGrpcPb.NetworkClient(
@@ -79,6 +83,8 @@ class Relay {
             query.setRequestingRelay("");
             query.setRequestingNetwork(requestingNetwork);
             query.setRequestingOrg(org || "");
+            query.setTimestamp(timestamp || 0);
+            query.setConfidential(!!confidential);
             if (typeof requestState === "function") {
                 const [resp, error] = await helpers.handlePromise(requestState(query));
                 if (error) {
@@ -105,10 +111,12 @@ class Relay {
         signature: string,
         nonce: string,
         org: string,
+        timestamp?: number,
+        confidential?: boolean,
     ): Promise<any> {
         try {
             const [requestID, error] = await helpers.handlePromise(
-                this.SendRequest(address, policy, requestingNetwork, certificate, signature, nonce, org),
+                this.SendRequest(address, policy, requestingNetwork, certificate, signature, nonce, org, timestamp, confidential),
             );
             if (error) {
                 throw new Error(`Request state error: ${error}`);
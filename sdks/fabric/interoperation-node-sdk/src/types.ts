@@ -26,6 +26,7 @@ export type InteropJSON = {
     RemoteEndpoint?: string;
     NetworkID?: string;
     Sign: boolean;
+    Confidential?: boolean;
     ccArgs?: string[];
 };
 
@@ -28,6 +28,8 @@ const {
     getKeyAndCertForRemoteRequestbyUserName,
     getPolicyCriteriaForAddress,
     getSignatoryNodeFromCertificate,
+    getSignedQueryMessage,
+    eciesDecryptConfidentialPayload,
     invokeHandler,
 } = require("../src/InteroperableHelper");
 const { deserializeRemoteProposalResponseBase64, serializeRemoteProposalResponse } = require("../src/decoders");
@@ -116,6 +118,45 @@ describe("InteroperableHelper", () => {
         });
     });
 
+    describe("signed query message", () => {
+        it("prefix each field of the query with its length", () => {
+            const address = "localhost:9080/network1/mychannel:interop:Read:a";
+            expect(getSignedQueryMessage(address, "nonce-1", 1700000000)).to.equal(
+                `48:${address}7:nonce-110:1700000000`,
+            );
+            expect(getSignedQueryMessage(address, "nonce-1", 1700000000)).to.not.equal(
+                getSignedQueryMessage(address, "nonce-", 11700000000),
+            );
+        });
+        it("cover the confidentiality flag in a separate field", () => {
+            const address = "localhost:9080/network1/mychannel:interop:Read:a";
+            expect(getSignedQueryMessage(address, "nonce-1", 0, true)).to.equal(
+                `48:${address}7:nonce-11:012:confidential`,
+            );
+            expect(getSignedQueryMessage(address, "nonce-1", 0, true)).to.not.equal(
+                getSignedQueryMessage(address, "nonce-1confidential", 0),
+            );
+        });
+    });
+
+    describe("decrypt confidential payload", () => {
+        // encrypted by the interop chaincode for the public key matching data/privKey.pem
+        const encryptedPayloadBase64 =
+            "BLgdIh6DhP9jgyaWlY9GY25n2NBEgW8EiAtHHKI0vrsxp0dzIMsQUAJXDIvPza1xYaa0USi+a4cvMDnGLAglbFGpnZWG3eYTRmei7A+qkC2m/bMpW4smnbBQs8+dTx9NfVwU99wNevxDSYcXGjKHuHblkm2tHcSkKpA=";
+        const contentsBase64 = "CgUxNy4xMhIgHZvwYp1pS2KhQFG6AnVstjIHsbEuKTWS5ftoSltFH/w=";
+        it("decrypt payload using private key", () => {
+            const privKeyPEM = fs.readFileSync(`${__dirname}/data/privKey.pem`).toString();
+            const contents = eciesDecryptConfidentialPayload(Buffer.from(encryptedPayloadBase64, "base64"), privKeyPEM);
+            expect(contents.toString("base64")).to.equal(contentsBase64);
+        });
+        it("fail to decrypt a tampered payload", () => {
+            const privKeyPEM = fs.readFileSync(`${__dirname}/data/privKey.pem`).toString();
+            const tamperedPayload = Buffer.from(encryptedPayloadBase64, "base64");
+            tamperedPayload[tamperedPayload.length - 1] ^= 1;
+            expect(() => eciesDecryptConfidentialPayload(tamperedPayload, privKeyPEM)).to.throw();
+        });
+    });
+
     describe("decrypt remote proposal response", () => {
         it("decrypt proposal response using private key", () => {
             const samplePropJSON = JSON.parse(fs.readFileSync(`${__dirname}/data/prop.json`).toString());
@@ -11,6 +11,11 @@ if [ -d "${CHAINCODE_PATH}/${CC_NAME}" ]; then
     echo "Deleting existing ${CC_NAME} chaincode folder to copy the latest..."
     rm -rf ${CHAINCODE_PATH}/${CC_NAME}
 fi
+# Go chaincodes may build against modules in this repository through relative replace directives, which do not
+# resolve once the chaincode is copied, so vendor their dependencies first
+if [ -f "${APP_CC_PATH}/go.mod" ]; then
+    (cd ${APP_CC_PATH} && go mod vendor)
+fi
 cp -r ${APP_CC_PATH} ${CHAINCODE_PATH}/
 
 echo "Done."
@@ -14,6 +14,9 @@ if [ -d "interop"]; then
     echo "Deleting previously built interop cc folder"
     rm -rf interop
 fi
+# the interop cc builds against the protos in this repository through a relative replace directive, which does not
+# resolve once the chaincode is copied, so vendor its dependencies first
+(cd ${INTEROP_CC_PATH}/contracts/interop && go mod vendor)
 cp -r ${INTEROP_CC_PATH} .
 
 cd fabric-interop-cc